@@ -0,0 +1,405 @@
+// Package cbor contains functions to encode dgo values as CBOR (RFC 7049) and to decode CBOR into dgo values.
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/vf"
+)
+
+// CBOR major types
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+)
+
+const (
+	simpleFalse     = 0xf4
+	simpleTrue      = 0xf5
+	simpleNull      = 0xf6
+	simpleUndefined = 0xf7
+	simpleFloat16   = 0xf9
+	simpleFloat32   = 0xfa
+	simpleFloat64   = 0xfb
+	breakCode       = 0xff
+	indefinite      = 31
+)
+
+// maxDepth is the maximum nesting depth of arrays, maps, and tags that the decoder accepts
+const maxDepth = 10000
+
+var errUnexpectedEnd = errors.New(`unexpected end of CBOR data`)
+
+// Marshal returns the CBOR encoding of the given value. Nil, Boolean, Integer, Float, String, Binary, Array, and
+// Map values can be encoded. An error is returned if the value graph contains a value of any other kind.
+func Marshal(v dgo.Value) ([]byte, error) {
+	b := &bytes.Buffer{}
+	if err := encode(b, v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func encode(b *bytes.Buffer, v dgo.Value) (err error) {
+	switch v := v.(type) {
+	case dgo.Boolean:
+		if v.GoBool() {
+			b.WriteByte(simpleTrue)
+		} else {
+			b.WriteByte(simpleFalse)
+		}
+	case dgo.Integer:
+		i := v.GoInt()
+		if i < 0 {
+			writeHead(b, majorNegative, uint64(-(i + 1)))
+		} else {
+			writeHead(b, majorUnsigned, uint64(i))
+		}
+	case dgo.Float:
+		b.WriteByte(simpleFloat64)
+		var bs [8]byte
+		binary.BigEndian.PutUint64(bs[:], math.Float64bits(v.GoFloat()))
+		b.Write(bs[:])
+	case dgo.String:
+		s := v.GoString()
+		writeHead(b, majorText, uint64(len(s)))
+		b.WriteString(s)
+	case dgo.Binary:
+		bs := v.GoBytes()
+		writeHead(b, majorBytes, uint64(len(bs)))
+		b.Write(bs)
+	case dgo.Array:
+		writeHead(b, majorArray, uint64(v.Len()))
+		v.All(func(e dgo.Value) bool {
+			err = encode(b, e)
+			return err == nil
+		})
+	case dgo.Map:
+		writeHead(b, majorMap, uint64(v.Len()))
+		v.All(func(e dgo.MapEntry) bool {
+			if err = encode(b, e.Key()); err == nil {
+				err = encode(b, e.Value())
+			}
+			return err == nil
+		})
+	default:
+		if v == vf.Nil {
+			b.WriteByte(simpleNull)
+		} else {
+			err = fmt.Errorf(`unable to encode a value of type %s as CBOR`, v.Type())
+		}
+	}
+	return
+}
+
+// writeHead writes the initial byte of a data item together with its argument, using the shortest possible
+// encoding of the argument
+func writeHead(b *bytes.Buffer, major byte, arg uint64) {
+	major <<= 5
+	var bs [8]byte
+	switch {
+	case arg < 24:
+		b.WriteByte(major | byte(arg))
+	case arg <= math.MaxUint8:
+		b.WriteByte(major | 24)
+		b.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		b.WriteByte(major | 25)
+		binary.BigEndian.PutUint16(bs[:], uint16(arg))
+		b.Write(bs[:2])
+	case arg <= math.MaxUint32:
+		b.WriteByte(major | 26)
+		binary.BigEndian.PutUint32(bs[:], uint32(arg))
+		b.Write(bs[:4])
+	default:
+		b.WriteByte(major | 27)
+		binary.BigEndian.PutUint64(bs[:], arg)
+		b.Write(bs[:])
+	}
+}
+
+// Unmarshal decodes the given CBOR data and returns the resulting frozen value. Maps become Map values, arrays
+// become Array values, text strings become String values, integers become Integer values, floats become Float
+// values, byte strings become Binary values, and null and undefined become Nil. Tags are ignored, i.e. a tagged
+// data item is decoded as if it was not tagged. An error is returned if the data is malformed, if it contains
+// an integer that cannot be represented as an int64, or if it contains more than one data item.
+func Unmarshal(data []byte) (dgo.Value, error) {
+	d := &decoder{data: data}
+	v, err := d.decode()
+	if err == nil && d.pos < len(data) {
+		err = fmt.Errorf(`unexpected data after CBOR data item at position %d`, d.pos)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// decode decodes the next data item while keeping track of the nesting depth
+func (d *decoder) decode() (dgo.Value, error) {
+	if d.depth >= maxDepth {
+		return nil, fmt.Errorf(`CBOR data exceeds the maximum nesting depth of %d at position %d`, maxDepth, d.pos)
+	}
+	d.depth++
+	v, err := d.decodeItem()
+	d.depth--
+	return v, err
+}
+
+func (d *decoder) decodeItem() (dgo.Value, error) {
+	if d.pos >= len(d.data) {
+		return nil, errUnexpectedEnd
+	}
+	ib := d.data[d.pos]
+	if ib == breakCode {
+		return nil, fmt.Errorf(`unexpected break code at position %d`, d.pos)
+	}
+	d.pos++
+	major := ib >> 5
+	info := ib & 0x1f
+	if major == majorSimple {
+		return d.decodeSimple(ib)
+	}
+
+	if info == indefinite {
+		return d.decodeIndefinite(major)
+	}
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf(`CBOR integer %d cannot be represented as an int64`, arg)
+		}
+		return vf.Integer(int64(arg)), nil
+	case majorNegative:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf(`CBOR integer -1-%d cannot be represented as an int64`, arg)
+		}
+		return vf.Integer(-1 - int64(arg)), nil
+	case majorBytes:
+		bs, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return vf.Binary(bs, true), nil
+	case majorText:
+		bs, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return vf.String(string(bs)), nil
+	case majorArray:
+		if arg > uint64(len(d.data)-d.pos) {
+			// Each element occupies at least one byte
+			return nil, errUnexpectedEnd
+		}
+		es := make([]interface{}, arg)
+		for i := range es {
+			if es[i], err = d.decode(); err != nil {
+				return nil, err
+			}
+		}
+		return vf.Values(es...), nil
+	case majorMap:
+		if arg > uint64(len(d.data)-d.pos)/2 {
+			// Each entry occupies at least two bytes
+			return nil, errUnexpectedEnd
+		}
+		m := vf.MapWithCapacity(int(arg))
+		for i := uint64(0); i < arg; i++ {
+			if err = d.decodeEntry(m); err != nil {
+				return nil, err
+			}
+		}
+		m.Freeze()
+		return m, nil
+	default: // majorTag
+		return d.decode()
+	}
+}
+
+func (d *decoder) decodeEntry(m dgo.Map) error {
+	k, err := d.decode()
+	if err == nil {
+		var v dgo.Value
+		if v, err = d.decode(); err == nil {
+			m.Put(k, v)
+		}
+	}
+	return err
+}
+
+func (d *decoder) decodeSimple(ib byte) (dgo.Value, error) {
+	switch ib {
+	case simpleFalse:
+		return vf.False, nil
+	case simpleTrue:
+		return vf.True, nil
+	case simpleNull, simpleUndefined:
+		return vf.Nil, nil
+	case simpleFloat16:
+		bs, err := d.bytes(2)
+		if err != nil {
+			return nil, err
+		}
+		return vf.Float(halfToFloat(binary.BigEndian.Uint16(bs))), nil
+	case simpleFloat32:
+		bs, err := d.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return vf.Float(float64(math.Float32frombits(binary.BigEndian.Uint32(bs)))), nil
+	case simpleFloat64:
+		bs, err := d.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return vf.Float(math.Float64frombits(binary.BigEndian.Uint64(bs))), nil
+	}
+	return nil, fmt.Errorf(`unsupported CBOR simple value 0x%x at position %d`, ib, d.pos-1)
+}
+
+// decodeIndefinite decodes an indefinite length byte string, text string, array, or map. The initial byte has
+// already been consumed.
+func (d *decoder) decodeIndefinite(major byte) (dgo.Value, error) {
+	switch major {
+	case majorBytes, majorText:
+		var bs []byte
+		for !d.atBreak() {
+			c, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if major == majorBytes {
+				cb, ok := c.(dgo.Binary)
+				if !ok {
+					return nil, errors.New(`indefinite length byte string contains a chunk that is not a byte string`)
+				}
+				bs = append(bs, cb.GoBytes()...)
+			} else {
+				cs, ok := c.(dgo.String)
+				if !ok {
+					return nil, errors.New(`indefinite length text string contains a chunk that is not a text string`)
+				}
+				bs = append(bs, cs.GoString()...)
+			}
+		}
+		if major == majorBytes {
+			return vf.Binary(bs, true), nil
+		}
+		return vf.String(string(bs)), nil
+	case majorArray:
+		a := vf.MutableValues()
+		for !d.atBreak() {
+			e, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			a.Add(e)
+		}
+		a.Freeze()
+		return a, nil
+	case majorMap:
+		m := vf.MutableMap()
+		for !d.atBreak() {
+			if err := d.decodeEntry(m); err != nil {
+				return nil, err
+			}
+		}
+		m.Freeze()
+		return m, nil
+	}
+	return nil, fmt.Errorf(`illegal indefinite length for CBOR major type %d at position %d`, major, d.pos-1)
+}
+
+// atBreak returns true and consumes the break code if it is found at the current position
+func (d *decoder) atBreak() bool {
+	if d.pos < len(d.data) && d.data[d.pos] == breakCode {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+// argument returns the argument of a data item with the given additional information
+func (d *decoder) argument(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+	var n uint64
+	switch info {
+	case 24:
+		n = 1
+	case 25:
+		n = 2
+	case 26:
+		n = 4
+	case 27:
+		n = 8
+	default:
+		return 0, fmt.Errorf(`illegal CBOR additional information %d at position %d`, info, d.pos-1)
+	}
+	bs, err := d.bytes(n)
+	if err != nil {
+		return 0, err
+	}
+	var arg uint64
+	for _, b := range bs {
+		arg = arg<<8 | uint64(b)
+	}
+	return arg, nil
+}
+
+// bytes consumes and returns the next n bytes
+func (d *decoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errUnexpectedEnd
+	}
+	s := d.pos
+	d.pos += int(n)
+	return d.data[s:d.pos], nil
+}
+
+// halfToFloat converts an IEEE 754 half precision float to a float64
+func halfToFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
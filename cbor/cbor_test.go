@@ -0,0 +1,158 @@
+package cbor_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/lyraproj/dgo/cbor"
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	bs, err := hex.DecodeString(s)
+	require.Nil(t, err)
+	return bs
+}
+
+// Examples from RFC 7049 Appendix A
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		hex   string
+	}{
+		{0, `00`},
+		{1, `01`},
+		{10, `0a`},
+		{23, `17`},
+		{24, `1818`},
+		{100, `1864`},
+		{1000, `1903e8`},
+		{1000000, `1a000f4240`},
+		{1000000000000, `1b000000e8d4a51000`},
+		{int64(math.MaxInt64), `1b7fffffffffffffff`},
+		{-1, `20`},
+		{-10, `29`},
+		{-100, `3863`},
+		{-1000, `3903e7`},
+		{int64(math.MinInt64), `3b7fffffffffffffff`},
+		{1.1, `fb3ff199999999999a`},
+		{false, `f4`},
+		{true, `f5`},
+		{nil, `f6`},
+		{``, `60`},
+		{`a`, `6161`},
+		{`IETF`, `6449455446`},
+		{"ü", `62c3bc`},
+		{[]byte{1, 2, 3, 4}, `4401020304`},
+		{vf.Values(), `80`},
+		{vf.Values(1, 2, 3), `83010203`},
+		{vf.Values(1, vf.Values(2, 3), vf.Values(4, 5)), `8301820203820405`},
+		{vf.Map(), `a0`},
+		{vf.Map(1, 2, 3, 4), `a201020304`},
+		{vf.Map(`a`, 1, `b`, vf.Values(2, 3)), `a26161016162820203`},
+	}
+	for _, tt := range tests {
+		v := vf.Value(tt.value)
+		bs, err := cbor.Marshal(v)
+		require.Nil(t, err)
+		require.Equal(t, tt.hex, hex.EncodeToString(bs))
+
+		rv, err := cbor.Unmarshal(bs)
+		require.Nil(t, err)
+		require.Equal(t, v, rv)
+	}
+}
+
+func TestMarshal_unsupported(t *testing.T) {
+	_, err := cbor.Marshal(vf.Values(1, vf.Value(func() {})))
+	require.NotNil(t, err)
+	require.Match(t, `unable to encode a value of type func\(\) as CBOR`, err.Error())
+
+	_, err = cbor.Marshal(vf.Map(`a`, vf.Values(vf.Value(func() {}))))
+	require.NotNil(t, err)
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		hex   string
+		value interface{}
+	}{
+		{`f90000`, 0.0},
+		{`f93c00`, 1.0},
+		{`f93e00`, 1.5},
+		{`f97bff`, 65504.0},
+		{`f90001`, 5.960464477539063e-8},
+		{`f9c400`, -4.0},
+		{`fa47c35000`, 100000.0},
+		{`f7`, nil},
+		{`c074323031332d30332d32315432303a30343a30305a`, `2013-03-21T20:04:00Z`},
+		{`5f42010243030405ff`, []byte{1, 2, 3, 4, 5}},
+		{`7f657374726561646d696e67ff`, `streaming`},
+		{`9fff`, vf.Values()},
+		{`9f018202039f0405ffff`, vf.Values(1, vf.Values(2, 3), vf.Values(4, 5))},
+		{`bf61610161629f0203ffff`, vf.Map(`a`, 1, `b`, vf.Values(2, 3))},
+	}
+	for _, tt := range tests {
+		v, err := cbor.Unmarshal(decodeHex(t, tt.hex))
+		require.Nil(t, err)
+		require.Equal(t, vf.Value(tt.value), v)
+		if f, ok := v.(dgo.Freezable); ok {
+			require.True(t, f.Frozen())
+		}
+	}
+
+	v, err := cbor.Unmarshal(decodeHex(t, `f97c00`))
+	require.Nil(t, err)
+	require.True(t, math.IsInf(v.(dgo.Float).GoFloat(), 1))
+	v, err = cbor.Unmarshal(decodeHex(t, `f97e00`))
+	require.Nil(t, err)
+	require.True(t, math.IsNaN(v.(dgo.Float).GoFloat()))
+}
+
+func TestUnmarshal_errors(t *testing.T) {
+	tests := []struct {
+		hex string
+		err string
+	}{
+		{``, `unexpected end of CBOR data`},
+		{`18`, `unexpected end of CBOR data`},
+		{`62c3`, `unexpected end of CBOR data`},
+		{`83010203ff`, `unexpected data after CBOR data item at position 4`},
+		{`ff`, `unexpected break code at position 0`},
+		{`1c`, `illegal CBOR additional information 28 at position 0`},
+		{`1f`, `illegal indefinite length for CBOR major type 0 at position 0`},
+		{`f0`, `unsupported CBOR simple value 0xf0 at position 0`},
+		{`1bffffffffffffffff`, `CBOR integer 18446744073709551615 cannot be represented as an int64`},
+		{`3bffffffffffffffff`, `CBOR integer -1-18446744073709551615 cannot be represented as an int64`},
+		{`9b7fffffffffffffff`, `unexpected end of CBOR data`},
+		{`bb7fffffffffffffff`, `unexpected end of CBOR data`},
+		{`5f01ff`, `indefinite length byte string contains a chunk that is not a byte string`},
+		{`7f01ff`, `indefinite length text string contains a chunk that is not a text string`},
+		{`9f01`, `unexpected end of CBOR data`},
+		{`a161`, `unexpected end of CBOR data`},
+		{`fa0000`, `unexpected end of CBOR data`},
+	}
+	for _, tt := range tests {
+		_, err := cbor.Unmarshal(decodeHex(t, tt.hex))
+		require.NotNil(t, err)
+		require.Equal(t, tt.err, err.Error())
+	}
+}
+
+func TestUnmarshal_maxDepth(t *testing.T) {
+	_, err := cbor.Unmarshal(bytes.Repeat([]byte{0xc1}, 20000000))
+	require.NotNil(t, err)
+	require.Equal(t, `CBOR data exceeds the maximum nesting depth of 10000 at position 10000`, err.Error())
+
+	_, err = cbor.Unmarshal(append(bytes.Repeat([]byte{0x81}, 10000), 0x01))
+	require.NotNil(t, err)
+
+	v, err := cbor.Unmarshal(append(bytes.Repeat([]byte{0x81}, 9999), 0x01))
+	require.Nil(t, err)
+	require.Equal(t, 1, v.(dgo.Array).Len())
+}
@@ -0,0 +1,21 @@
+// Package codegen contains functions that help generating Go source code that uses dgo.
+package codegen
+
+import (
+	"strconv"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/internal"
+	"github.com/lyraproj/dgo/stringer"
+)
+
+// TagKey is the key used for dgo annotations in Go struct field tags. The tags are read by tf.StructMapFromReflected.
+const TagKey = internal.StructTagKey
+
+// StructFieldTag returns a Go struct field tag that annotates the field with the given type, e.g. `dgo:"0..100"`
+// for an integer range or `dgo:"/^[a-z]+$/"` for a pattern. The type is written using the dgo type expression
+// syntax, so the value obtained using reflect.StructTag.Get(TagKey) can be parsed back into an equal type, and
+// tf.StructMapFromReflected uses it as the type of the field.
+func StructFieldTag(t dgo.Type) string {
+	return TagKey + `:` + strconv.Quote(stringer.TypeString(t))
+}
@@ -0,0 +1,55 @@
+package codegen_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/lyraproj/dgo/codegen"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestStructFieldTag(t *testing.T) {
+	require.Equal(t, `dgo:"0..100"`, codegen.StructFieldTag(tf.Integer(0, 100, true)))
+	require.Equal(t, `dgo:"/^[a-z]+$/"`, codegen.StructFieldTag(tf.Pattern(regexp.MustCompile(`^[a-z]+$`))))
+	require.Equal(t, `dgo:"map[string]int"`, codegen.StructFieldTag(tf.Map(typ.String, typ.Integer)))
+	require.Equal(t, `dgo:"\"a\"|\"b\""`, codegen.StructFieldTag(tf.Enum(`a`, `b`)))
+}
+
+func TestStructFieldTag_roundTrip(t *testing.T) {
+	for _, tp := range []string{
+		`0..100`,
+		`string[1,10]`,
+		`/^[a-z]+$/`,
+		`"a"|"b"`,
+		`[]string`,
+		`map[string]{name:string[1],type:dgo,required?:bool}`,
+		`{string,...int}`,
+	} {
+		expected := tf.ParseType(tp)
+		tag := reflect.StructTag(codegen.StructFieldTag(expected))
+		require.Equal(t, expected, tf.ParseType(tag.Get(codegen.TagKey)))
+	}
+}
+
+func TestStructFieldTag_reflectedStruct(t *testing.T) {
+	port := tf.Integer(1, 65535, true)
+	name := tf.Pattern(regexp.MustCompile(`^[a-z]+$`))
+	st := reflect.StructOf([]reflect.StructField{
+		{Name: `Port`, Type: reflect.TypeOf(0), Tag: reflect.StructTag(codegen.StructFieldTag(port))},
+		{Name: `Name`, Type: reflect.TypeOf(``), Tag: reflect.StructTag(codegen.StructFieldTag(name))},
+	})
+	tp := tf.StructMapFromReflected(st)
+	require.Equal(t, port, tp.Get(`Port`).Value())
+	require.Equal(t, name, tp.Get(`Name`).Value())
+
+	sv := reflect.New(st).Elem()
+	sv.Field(0).SetInt(80)
+	sv.Field(1).SetString(`web`)
+	require.Instance(t, tp, vf.Map(sv.Interface()))
+	sv.Field(0).SetInt(0)
+	require.NotInstance(t, tp, vf.Map(sv.Interface()))
+}
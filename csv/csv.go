@@ -0,0 +1,212 @@
+// Package csv contains functions to read CSV data into dgo values using a tuple type that describes the columns
+// and to write dgo values as CSV data.
+package csv
+
+import (
+	gocsv "encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/vf"
+)
+
+type (
+	// Options controls some aspects of reading and writing CSV data.
+	Options struct {
+		// Comma is the field delimiter. It defaults to ',' when it is zero
+		Comma rune
+
+		// Header means that the first row is a header that is excluded from the result when reading.
+		Header bool
+	}
+
+	// ValidationError is the error returned when a row or a field that is read doesn't conform to the schema.
+	ValidationError struct {
+		// Row is the 1 based number of the row in the read data, including a header row if present.
+		Row int
+
+		// Column is the 1 based number of the column of the failing field or 0 when the error concerns the
+		// whole row.
+		Column int
+
+		// Value is the raw field value or, when the error concerns the whole row, the number of fields
+		// in the row.
+		Value string
+
+		// Expected is the type of the column or, when the error concerns the whole row, the schema.
+		Expected dgo.Type
+	}
+)
+
+// DefaultOptions returns the default options. The returned value is a private copy that can be modified by
+// the caller before it is passed to ReadWithOptions or WriteWithOptions.
+func DefaultOptions() *Options {
+	return &Options{Comma: ','}
+}
+
+// comma returns the field delimiter of the options, which is ',' unless another delimiter has been set
+func (o *Options) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+func (e *ValidationError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf(`row %d: a row with %s fields cannot be assigned to a variable of type %s`,
+			e.Row, e.Value, e.Expected)
+	}
+	return fmt.Sprintf(`row %d, column %d: the value %s cannot be assigned to a variable of type %s`,
+		e.Row, e.Column, strconv.Quote(e.Value), e.Expected)
+}
+
+// Unwrap returns dgo.ErrAssignment
+func (e *ValidationError) Unwrap() error {
+	return dgo.ErrAssignment
+}
+
+// Read reads CSV data from the given reader using the default options and returns an Array with one Array
+// for each row. See ReadWithOptions for details.
+func Read(r io.Reader, schema dgo.TupleType) (dgo.Array, error) {
+	return ReadWithOptions(r, schema, nil)
+}
+
+// ReadWithOptions reads CSV data from the given reader and returns a frozen Array with one Array for each row.
+// Each row is validated against the given schema. The type of a column determines how its fields are converted
+// from strings. A field is converted to the first of an integer, a float, a boolean, nil (only for empty fields),
+// and a string that is an instance of the column type. A *ValidationError is returned when no such value exists
+// or when the converted row is not an instance of the schema.
+func ReadWithOptions(r io.Reader, schema dgo.TupleType, options *Options) (dgo.Array, error) {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	cr := gocsv.NewReader(r)
+	cr.Comma = options.comma()
+	cr.FieldsPerRecord = -1
+
+	var rows []interface{}
+	for rowNum := 1; ; rowNum++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rowNum == 1 && options.Header {
+			continue
+		}
+		row, err := convertRow(rowNum, record, schema)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return vf.Values(rows...), nil
+}
+
+func convertRow(rowNum int, record []string, schema dgo.TupleType) (dgo.Array, error) {
+	row := make([]interface{}, len(record))
+	for i, field := range record {
+		ct := columnType(schema, i)
+		if ct == nil {
+			// Field is outside of the schema. Leave it as a string and let the schema validation fail.
+			row[i] = field
+			continue
+		}
+		v := convertField(field, ct)
+		if v == nil {
+			return nil, &ValidationError{Row: rowNum, Column: i + 1, Value: field, Expected: ct}
+		}
+		row[i] = v
+	}
+	a := vf.Values(row...)
+	if !schema.Instance(a) {
+		return nil, &ValidationError{Row: rowNum, Value: strconv.Itoa(len(record)), Expected: schema}
+	}
+	return a, nil
+}
+
+// columnType returns the type of the column at the given index or nil if the schema has no such column
+func columnType(schema dgo.TupleType, i int) dgo.Type {
+	n := schema.Len()
+	if schema.Variadic() && i >= n-1 {
+		return schema.Element(n - 1)
+	}
+	if i < n {
+		return schema.Element(i)
+	}
+	return nil
+}
+
+// convertField returns the first conversion of the given field that is an instance of the given type or nil
+// if no such conversion exists
+func convertField(field string, t dgo.Type) dgo.Value {
+	if i, err := strconv.ParseInt(field, 10, 64); err == nil {
+		if v := vf.Integer(i); t.Instance(v) {
+			return v
+		}
+	}
+	if f, err := strconv.ParseFloat(field, 64); err == nil {
+		if v := vf.Float(f); t.Instance(v) {
+			return v
+		}
+	}
+	if b, err := strconv.ParseBool(field); err == nil {
+		if v := vf.Boolean(b); t.Instance(v) {
+			return v
+		}
+	}
+	if field == `` && t.Instance(vf.Nil) {
+		return vf.Nil
+	}
+	if v := vf.String(field); t.Instance(v) {
+		return v
+	}
+	return nil
+}
+
+// Write writes the given data as CSV to the given writer using the default options. See WriteWithOptions for
+// details.
+func Write(w io.Writer, data dgo.Array) error {
+	return WriteWithOptions(w, data, nil)
+}
+
+// WriteWithOptions writes the given data as CSV to the given writer. Each element of the data must be an Array
+// that represents a row. Strings are written verbatim, nil is written as an empty field, and all other values are
+// written using their String() representation.
+func WriteWithOptions(w io.Writer, data dgo.Array, options *Options) error {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	cw := gocsv.NewWriter(w)
+	cw.Comma = options.comma()
+	for i, n := 0, data.Len(); i < n; i++ {
+		row, ok := data.Get(i).(dgo.Array)
+		if !ok {
+			return fmt.Errorf(`row %d: the value %s is not an array`, i+1, data.Get(i))
+		}
+		record := make([]string, row.Len())
+		for j := range record {
+			record[j] = fieldString(row.Get(j))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func fieldString(v dgo.Value) string {
+	switch v := v.(type) {
+	case dgo.String:
+		return v.GoString()
+	case dgo.Nil:
+		return ``
+	}
+	return v.String()
+}
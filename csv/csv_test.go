@@ -0,0 +1,126 @@
+package csv_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lyraproj/dgo/csv"
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+type badWriter int
+
+func (b badWriter) Write(p []byte) (n int, err error) {
+	return 0, errors.New(`bang`)
+}
+
+func ExampleWrite() {
+	_ = csv.Write(os.Stdout, vf.Values(vf.Values(`name`, `age`), vf.Values(`Bob`, 42), vf.Values(`Eve, Jr.`, nil)))
+	// Output:
+	// name,age
+	// Bob,42
+	// "Eve, Jr.",
+}
+
+func TestRead(t *testing.T) {
+	schema := tf.Tuple(typ.String, typ.Integer, typ.Float, typ.Boolean)
+	a, err := csv.Read(strings.NewReader("Bob,42,1.5,true\n\"Eve, Jr.\",7,2,false\n"), schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(
+		vf.Values(`Bob`, 42, 1.5, true),
+		vf.Values(`Eve, Jr.`, 7, 2.0, false)), a)
+	require.True(t, a.Frozen())
+}
+
+func TestReadWithOptions_header(t *testing.T) {
+	schema := tf.Tuple(typ.String, tf.AnyOf(typ.Integer, typ.Nil))
+	o := csv.DefaultOptions()
+	o.Header = true
+	o.Comma = ';'
+	a, err := csv.ReadWithOptions(strings.NewReader("name;age\nBob;42\nEve;\n"), schema, o)
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(vf.Values(`Bob`, 42), vf.Values(`Eve`, nil)), a)
+}
+
+func TestReadWithOptions_defaultComma(t *testing.T) {
+	schema := tf.Tuple(typ.String, typ.Integer)
+	a, err := csv.ReadWithOptions(strings.NewReader("name,age\nBob,42\n"), schema, &csv.Options{Header: true})
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(vf.Values(`Bob`, 42)), a)
+
+	b := bytes.Buffer{}
+	require.Nil(t, csv.WriteWithOptions(&b, a, &csv.Options{}))
+	require.Equal(t, "Bob,42\n", b.String())
+}
+
+func TestRead_typeDrivenConversion(t *testing.T) {
+	schema := tf.Tuple(typ.String, typ.Any, tf.Pattern(regexp.MustCompile(`^\d+$`)))
+	a, err := csv.Read(strings.NewReader("42,42,42\n"), schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(vf.Values(`42`, 42, `42`)), a)
+}
+
+func TestRead_variadic(t *testing.T) {
+	schema := tf.VariadicTuple(typ.String, typ.Integer)
+	a, err := csv.Read(strings.NewReader("a,1,2,3\nb\n"), schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(vf.Values(`a`, 1, 2, 3), vf.Values(`b`)), a)
+}
+
+func TestRead_validationError(t *testing.T) {
+	schema := tf.Tuple(typ.String, typ.Integer)
+	_, err := csv.Read(strings.NewReader("Bob,42\nEve,old\n"), schema)
+	ve, ok := err.(*csv.ValidationError)
+	require.True(t, ok)
+	require.Equal(t, 2, ve.Row)
+	require.Equal(t, 2, ve.Column)
+	require.Equal(t, `old`, ve.Value)
+	require.Equal(t, typ.Integer, ve.Expected)
+	require.Equal(t, `row 2, column 2: the value "old" cannot be assigned to a variable of type int`, err.Error())
+	require.True(t, errors.Is(err, dgo.ErrAssignment))
+
+	_, err = csv.Read(strings.NewReader("Bob,42,x\n"), schema)
+	ve, ok = err.(*csv.ValidationError)
+	require.True(t, ok)
+	require.Equal(t, 0, ve.Column)
+	require.Equal(t, `row 1: a row with 3 fields cannot be assigned to a variable of type {string,int}`, err.Error())
+
+	_, err = csv.Read(strings.NewReader("Bob\n"), schema)
+	require.Equal(t, `row 1: a row with 1 fields cannot be assigned to a variable of type {string,int}`, err.Error())
+}
+
+func TestRead_syntaxError(t *testing.T) {
+	_, err := csv.Read(strings.NewReader("\"Bob,42\n"), tf.Tuple(typ.String, typ.Integer))
+	require.NotNil(t, err)
+}
+
+func TestWriteWithOptions(t *testing.T) {
+	b := bytes.Buffer{}
+	o := csv.DefaultOptions()
+	o.Comma = '\t'
+	require.Nil(t, csv.WriteWithOptions(&b, vf.Values(vf.Values(`a`, 1.5, true)), o))
+	require.Equal(t, "a\t1.5\ttrue\n", b.String())
+}
+
+func TestWrite_errors(t *testing.T) {
+	b := bytes.Buffer{}
+	require.Equal(t, `row 1: the value 1 is not an array`, csv.Write(&b, vf.Values(1)).Error())
+	require.NotNil(t, csv.Write(badWriter(0), vf.Values(vf.Values(1))))
+}
+
+func TestWrite_roundTrip(t *testing.T) {
+	data := vf.Values(vf.Values(`Bob`, 42), vf.Values("multi\nline", -1))
+	b := bytes.Buffer{}
+	require.Nil(t, csv.Write(&b, data))
+	a, err := csv.Read(&b, tf.Tuple(typ.String, typ.Integer))
+	require.Nil(t, err)
+	require.Equal(t, data, a)
+}
@@ -0,0 +1,20 @@
+package dgo
+
+// Annotated is implemented by values that carry annotations, i.e. information that documents the value without
+// affecting its semantics, such as a description, a deprecation notice, or examples.
+type Annotated interface {
+	// Annotations returns a frozen and possibly empty Map with the annotations of this value
+	Annotations() Map
+}
+
+const (
+	// AnnotationDescription is the annotation key for a human readable description
+	AnnotationDescription = `description`
+
+	// AnnotationDeprecated is the annotation key for a deprecation notice. The value is either true or a string
+	// that explains what to use instead.
+	AnnotationDeprecated = `deprecated`
+
+	// AnnotationExamples is the annotation key for an Array of example values
+	AnnotationExamples = `examples`
+)
@@ -0,0 +1,23 @@
+package dgo
+
+// Arena allocates values that are reused after the arena is reset. It is intended for batch processing where
+// a large number of short lived values are created and discarded for each batch.
+//
+// Values obtained from an Arena must not be used after a call to Reset since their memory may then be handed
+// out again by the arena. An Arena is not safe for concurrent use.
+type Arena interface {
+	// Integer returns an Integer that represents the given value
+	Integer(v int64) Integer
+
+	// String returns a String that represents the given string
+	String(s string) String
+
+	// Array returns an empty mutable Array with room for size elements
+	Array(size int) Array
+
+	// Map returns an empty mutable Map with room for size associations
+	Map(size int) Map
+
+	// Reset makes all values that were obtained from this Arena available for reuse
+	Reset()
+}
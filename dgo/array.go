@@ -65,10 +65,29 @@ type (
 		// Any returns true if the predicate returns true for any value of this Array.
 		Any(predicate Predicate) bool
 
+		// Annotate returns a new Array with the same elements as this Array that will panic when an attempt is made
+		// to add or set an element that isn't an instance of the given element type. The method panics if an element
+		// of this Array isn't an instance of the element type. The frozen status of this array is inherited by the
+		// new Array.
+		Annotate(elementType Type) Array
+
 		// AppendToSlice appends all values of this array to the given slice and returns the
 		// result of the append.
 		AppendToSlice([]Value) []Value
 
+		// CartesianProduct returns a new Array with the Cartesian product of the elements of this Array. Each
+		// element must be an Array. Each element of the result is an Array that contains one element from each
+		// element of this Array, e.g. the product of [[a,b],[c,d]] is [[a,c],[a,d],[b,c],[b,d]]. When an element
+		// constrains its elements, the new Array constrains its elements to a tuple type of the element types. The
+		// frozen status of this array is inherited by the new Array and its elements.
+		CartesianProduct() Array
+
+		// Chunks returns a new Array of successive chunks of this Array, each holding size elements except the last
+		// one which may hold fewer. When this Array is frozen, the new Array is frozen and each chunk is a frozen
+		// view that shares storage with this Array. Otherwise, each chunk is a mutable copy. The method panics if
+		// size is less than 1.
+		Chunks(size int) Array
+
 		// ContainsAll returns true if this Array contains all elements of the given Iterable
 		ContainsAll(other Iterable) bool
 
@@ -83,6 +102,12 @@ type (
 		// overflow panic.
 		Copy(frozen bool) Array
 
+		// CryptoShuffle returns a new Array with the elements of this Array in a random order determined by a
+		// Fisher-Yates shuffle that uses the cryptographically secure random number generator in crypto/rand. An
+		// error is returned if the random number generator fails. The returned Array is frozen if this Array is
+		// frozen.
+		CryptoShuffle() (Array, error)
+
 		// Find calls the Mapper function for each value of this Array. The first call that returns
 		// a non nil value will terminate the iteration. The value of the last call is returned.
 		Find(Mapper) interface{}
@@ -95,14 +120,60 @@ type (
 		// for every element that is an array, extract its elements into the new array.
 		Flatten() Array
 
+		// ForEachChunk calls the given function with successive chunks of this Array, each holding size elements
+		// except the last one which may hold fewer. The chunks are created one at a time. When this Array is frozen,
+		// each chunk is a frozen view that shares storage with this Array. Otherwise, each chunk is a mutable copy.
+		// The method panics if size is less than 1.
+		ForEachChunk(size int, actor func(chunk Array))
+
 		// Get returns the value at the given position. A negative position or a position
 		// that is greater or equal to the length of the array will result in a panic.
 		Get(position int) Value
 
+		// GetArray returns the Array at the given position and true, or nil and false if the position is out of
+		// bounds or if the element at the position isn't an Array.
+		GetArray(position int) (Array, bool)
+
+		// GetBool returns the Go bool of the Boolean at the given position and true, or false and false if the
+		// position is out of bounds or if the element at the position isn't a Boolean.
+		GetBool(position int) (bool, bool)
+
+		// GetFloat returns the Go float64 of the Float at the given position and true, or zero and false if the
+		// position is out of bounds or if the element at the position isn't a Float.
+		GetFloat(position int) (float64, bool)
+
+		// GetInt returns the Go int64 of the Integer at the given position and true, or zero and false if the
+		// position is out of bounds or if the element at the position isn't an Integer.
+		GetInt(position int) (int64, bool)
+
+		// GetMap returns the Map at the given position and true, or nil and false if the position is out of
+		// bounds or if the element at the position isn't a Map.
+		GetMap(position int) (Map, bool)
+
+		// GetString returns the Go string of the String at the given position and true, or an empty string and
+		// false if the position is out of bounds or if the element at the position isn't a String.
+		GetString(position int) (string, bool)
+
 		// GoSlice returns the internal slice or, in case the Array is frozen, a copy
 		// of the internal slice.
 		GoSlice() []Value
 
+		// GroupBy returns a new Map where each key that the given keyMapper returns for an element of this Array is
+		// associated with an Array of the elements that produced that key. The keys and the elements in each Array
+		// retain the order of this Array. The frozen status of this array is inherited by the new Map and its Arrays.
+		GroupBy(keyMapper Mapper) Map
+
+		// GroupConsecutive returns a new Array of Arrays where each run of consecutive elements for which the
+		// predicate returns true is grouped together. Each element for which the predicate returns false is placed
+		// in a group of its own unless dropOthers is true, in which case it is excluded. The order of the elements
+		// is retained. The frozen status of this array is inherited by the new Array and its groups.
+		GroupConsecutive(predicate Predicate, dropOthers bool) Array
+
+		// IndexBy returns a new Map where each element of this Array is associated with the key that the given
+		// keyMapper returns for the element. When more than one element produce the same key, the last element
+		// wins. The frozen status of this array is inherited by the new Map.
+		IndexBy(keyMapper Mapper) Map
+
 		// IndexOf returns the index of the given value in this Array. The index is determined
 		// by calling the Equals method on each element until a matching element is found. The
 		// method returns -1 to indicate not found.
@@ -112,6 +183,11 @@ type (
 		// one step forward. The method panics if the receiver is frozen.
 		Insert(pos int, val interface{})
 
+		// InsertSorted inserts the given value into this Array, which must be sorted in natural order, so that the
+		// order is retained. The value is inserted after all values that are equal to it. The position of the inserted
+		// value is returned. The method panics if the receiver is frozen.
+		InsertSorted(val interface{}) int
+
 		// InterfaceSlice returns the values held by the Array as a slice. The slice will
 		// contain dgo.Value instances. The method is intended for cases where an array
 		// must be expanded into a variadic function argument.
@@ -124,6 +200,20 @@ type (
 		// One returns true if the predicate returns true for exactly one value of this Array.
 		One(predicate Predicate) bool
 
+		// PEach calls the given actor once for each value of this Array using at most n goroutines. The calls are
+		// made in no particular order and PEach returns when all calls have finished. If the actor panics, the
+		// remaining values are skipped and the panic is repeated by PEach. The method panics if n is less than 1.
+		PEach(n int, actor Consumer)
+
+		// PMap is like Map but calls the mapper using at most n goroutines. The values of the new Array are in the
+		// same order as the values that they were mapped from. If the mapper panics, the remaining values are
+		// skipped and the panic is repeated by PMap. The method panics if n is less than 1.
+		PMap(n int, mapper Mapper) Array
+
+		// Partition returns two new Arrays. The first contains the values for which the predicate returned true and
+		// the second contains the other values. The frozen status of this array is inherited by both Arrays.
+		Partition(predicate Predicate) (Array, Array)
+
 		// Pop removes and returns the last element of the array together with a boolean indicating
 		// if the pop was possible (i.e. if the array had any elements)
 		Pop() (Value, bool)
@@ -135,6 +225,10 @@ type (
 		// Reduce returns the last computed memo. For an empty array, this will be the initial memo.
 		Reduce(memo interface{}, reductor func(memo Value, elem Value) interface{}) Value
 
+		// ReduceUntil is like Reduce but the reductor also returns a boolean. When that boolean is true, the
+		// iteration stops and the memo returned by that call is returned.
+		ReduceUntil(memo interface{}, reductor func(memo Value, elem Value) (Value, bool)) Value
+
 		// Reject returns a new Array where all values for which the predicate returned true
 		// has been removed.
 		Reject(predicate Predicate) Array
@@ -151,10 +245,29 @@ type (
 		// SameValues returns true if this Array is the same size as the given Iterable and contains all of its values
 		SameValues(other Iterable) bool
 
+		// Sample returns a new Array with n elements that are randomly selected from this Array without
+		// replacement using the given random number source. The method panics if n is negative or greater than the
+		// size of this Array. The returned Array is frozen if this Array is frozen.
+		Sample(n int, rnd interface{ Intn(int) int }) Array
+
+		// SearchSorted uses binary search to find the position of the given value in this Array, which must be sorted
+		// in natural order. The position of the first value that is not less than the given value is returned, or
+		// the length of this Array if no such value exists. The value is present if the returned position is less
+		// than the length of this Array and the value at that position equals the given value.
+		SearchSorted(val interface{}) int
+
 		// Select returns a new Array where only values for which the predicate returned true
 		// are included.
 		Select(predicate Predicate) Array
 
+		// Seq returns a lazy Seq over the values of this Array. The values are read when the Seq is iterated.
+		Seq() Seq
+
+		// SetEquals returns true if this Array and the given Iterable contain the same values the same number of
+		// times, regardless of order, i.e. if they are equal when seen as multisets. Unlike SameValues, it runs in
+		// O(n log n) time.
+		SetEquals(other Iterable) bool
+
 		// Set replaces the given value at the given position and returns the old value for the position.
 		// The method panics if the receiver is frozen
 		Set(pos int, val interface{}) Value
@@ -162,24 +275,65 @@ type (
 		// Slice returns a slice of this array, starting at position start and ending at position end-1
 		Slice(start, end int) Array
 
-		// Sort returns a new Array with all elements sorted using their natural order. The method
-		// will panic unless all elements implement the Comparable interface
+		// SlidingWindow returns a new Array of windows over this Array. Each window holds size consecutive elements
+		// and each window starts step elements after the previous one. Only complete windows are included, so the
+		// new Array is empty when this Array holds fewer than size elements. The windows share storage with this
+		// Array in the same way as the chunks returned by Chunks. The method panics if size or step is less than 1.
+		SlidingWindow(size, step int) Array
+
+		// Sort returns a new Array with all elements sorted using their natural order. The sort is stable, i.e.
+		// elements that are equal in the natural order retain their relative order. Elements that are not comparable
+		// to each other are ordered by their type identifier.
 		Sort() Array
 
+		// SortBy returns a new Array with all elements sorted using the given compare function. The function must
+		// return a negative number when a is less than b, zero when they are equal, and a positive number otherwise.
+		// The sort is stable. A frozen Array returns a frozen result and a mutable Array returns a mutable copy.
+		SortBy(compare func(a, b Value) int) Array
+
+		// SortByKey is like SortStableBy but sorts the elements in descending order of their keys when descending
+		// is true. Elements with equal keys retain their relative order in both directions.
+		SortByKey(keyMapper Mapper, descending bool) Array
+
+		// SortStableBy returns a new Array with all elements sorted by the natural order of the keys that the
+		// given keyMapper returns for them. The keyMapper is called once for each element. Elements with equal keys
+		// retain their relative order.
+		SortStableBy(keyMapper Mapper) Array
+
+		// SortUnstableBy is like SortStableBy but makes no guarantee about the relative order of elements with
+		// equal keys. It is faster than SortStableBy for large arrays.
+		SortUnstableBy(keyMapper Mapper) Array
+
 		// ToMap returns this Array as a Map. The first and second elements of the array becomes the first key and
 		// value association of the Map, the third and fourth element becomes the second association, and so on. The
 		// association will have a Nil value if the Array has an uneven number of elements. The frozen status of this
 		// array is inherited by the new Map.
 		ToMap() Map
 
+		// Tee returns n arrays with the same elements as this Array. The arrays of a frozen Array share the
+		// same backing slice. The arrays of a mutable Array are independent mutable copies. The method
+		// panics if n is negative.
+		Tee(n int) []Array
+
 		// ToMapFromEntries assumes that all elements of this Array are either Arrays with two elements or MapEntries.
 		// If it is the former, MapEntries are created using the two elements as the key and value. A new Map is created
 		// that will contained all the MapEntries. The frozen status of this array is inherited by the new Map.
 		ToMapFromEntries() (Map, bool)
 
+		// ToSortedMap is like ToMap but the associations of the new Map are ordered by the natural order of their
+		// keys. Later associations will replace earlier associations that have an equal key. The frozen status of
+		// this array is inherited by the new Map.
+		ToSortedMap() Map
+
 		// Unique returns a new Array where all duplicate values have been removed
 		Unique() Array
 
+		// Unzip is the inverse of Zip. It returns two new Arrays with the first and the second element of each element
+		// of this Array. Each element must be an Array with exactly two elements. When the elements of this Array are
+		// constrained by a tuple type with two elements, the new Arrays are constrained by those element types. The
+		// frozen status of this array is inherited by the new Arrays.
+		Unzip() (Array, Array)
+
 		// With appends the given value to a copy of this Array and returns the result.
 		With(value interface{}) Array
 
@@ -188,6 +342,17 @@ type (
 
 		// WithValues appends the given values to a copy of this array and returns the resulting Array
 		WithValues(values ...interface{}) Array
+
+		// Zip returns a new Array where each element is a two element Array holding the element of this Array and
+		// the element at the same position in the given Array. The method panics if the two Arrays differ in size.
+		// When either Array constrains its elements, the new Array constrains its elements to a tuple type of the
+		// two element types. The new Array and its elements are frozen if both Arrays are frozen.
+		Zip(other Array) Array
+
+		// ZipToMap returns a new Map where each element of the given keys is associated with the element at the
+		// same position in this Array. The method panics if the keys and this Array differ in size. The new Map
+		// is frozen if both this Array and the keys are frozen.
+		ZipToMap(keys Array) Map
 	}
 
 	// Arguments is a special form of an Array that enables differentiation between one argument that is an Array and
@@ -0,0 +1,73 @@
+package dgo
+
+import "errors"
+
+var (
+	// ErrFrozen is wrapped by all errors that are caused by an attempt to modify a frozen value. It can be
+	// detected using errors.Is(err, ErrFrozen)
+	ErrFrozen = errors.New(`frozen`)
+
+	// ErrAssignment is wrapped by all errors that are caused by an attempt to assign a value to a variable whose
+	// type doesn't permit it. It can be detected using errors.Is(err, ErrAssignment)
+	ErrAssignment = errors.New(`assignment`)
+
+	// ErrSize is wrapped by all errors that are caused by an attempt to resize a value beyond the size
+	// constraint of its type. It can be detected using errors.Is(err, ErrSize)
+	ErrSize = errors.New(`size`)
+)
+
+type (
+	// AssignmentError is the error that represents an assignment type constraint mismatch. It wraps
+	// ErrAssignment.
+	AssignmentError interface {
+		Value
+		error
+
+		// Expected returns the type of the variable
+		Expected() Type
+
+		// Actual returns the type of the value that was assigned
+		Actual() Type
+
+		// Unwrap returns ErrAssignment
+		Unwrap() error
+	}
+
+	// ValidationError is an AssignmentError that also describes the parts of the value that are in violation of
+	// the expected type.
+	ValidationError interface {
+		AssignmentError
+
+		// Reasons returns a description of each violation, e.g. "field 'name': missing" or "allOf operand 1:
+		// integer max: expected <=10, got 12"
+		Reasons() []string
+	}
+
+	// PathError is an error that occurred at a specific location within a nested value. It wraps the error that
+	// describes the violation.
+	PathError interface {
+		error
+
+		// Path returns the location of the violation as a JSON Pointer (RFC 6901), e.g. "/servers/0/port". The
+		// empty string denotes the value itself.
+		Path() string
+
+		// Unwrap returns the error that describes the violation
+		Unwrap() error
+	}
+
+	// SizeError is the error that represents a size constraint mismatch. It wraps ErrSize.
+	SizeError interface {
+		Value
+		error
+
+		// SizedType returns the type whose size constraint was violated
+		SizedType() Type
+
+		// AttemptedSize returns the size that violated the constraint
+		AttemptedSize() int
+
+		// Unwrap returns ErrSize
+		Unwrap() error
+	}
+)
@@ -1,5 +1,7 @@
 package dgo
 
+import "time"
+
 type (
 	// Doer is performs some task
 	Doer func()
@@ -13,6 +15,10 @@ type (
 	// Producer is a function that produces a value
 	Producer func() Value
 
+	// TypeCheckTracer is called with the type, the value or type that was checked against it, and the time that
+	// the check took.
+	TypeCheckTracer func(t Type, v Value, elapsed time.Duration)
+
 	// Function is implemented by data types that can be called such as named or
 	// unnamed functions and methods.
 	Function interface {
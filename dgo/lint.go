@@ -0,0 +1,16 @@
+package dgo
+
+// LintWarning describes a type that is valid but suspicious, e.g. an AllOf type that can never be satisfied.
+type LintWarning interface {
+	// Code returns a short identifier for the kind of warning, e.g. "disjoint-all-of"
+	Code() string
+
+	// Message returns a description of the problem
+	Message() string
+
+	// Suggestion returns a description of how the problem can be resolved
+	Suggestion() string
+
+	// String returns the code, the message, and the suggestion as one string
+	String() string
+}
@@ -0,0 +1,17 @@
+package dgo
+
+// LRUMap is a Map with a fixed capacity that evicts its least recently used entry when a new key is added and the
+// capacity has been reached. Get, and the typed getters that depend on it, promotes the accessed entry to most
+// recently used. Iteration starts with the least recently used entry.
+//
+// All keys and values are constrained by the key and value types that were given when the map was created.
+type LRUMap interface {
+	Map
+
+	// Capacity returns the maximum number of entries in this map
+	Capacity() int
+
+	// OnEvict registers a function that is called with the key and value of each entry that is evicted from this
+	// map. A previously registered function is replaced. Entries that are explicitly removed are not reported.
+	OnEvict(func(key, value Value))
+}
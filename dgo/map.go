@@ -1,6 +1,14 @@
 package dgo
 
 type (
+	// MergeStrategy determines how the values of keys that are present in both Maps are combined when two Maps
+	// are merged
+	MergeStrategy int
+
+	// DuplicatePolicy determines how a value that is associated with more than one key is handled when the keys and
+	// values of a Map are swapped
+	DuplicatePolicy int
+
 	// MapEntry is a key-value association in a Map
 	MapEntry interface {
 		Value
@@ -14,8 +22,13 @@ type (
 	// StructMapEntry describes a MapEntry
 	StructMapEntry interface {
 		MapEntry
+		Annotated
 
 		Required() bool
+
+		// Default returns the value that is used when the entry is missing in a Map, or nil if the entry has no
+		// default value. An entry that has a default value is never required.
+		Default() Value
 	}
 
 	// EntryActor performs some task on behalf of a caller
@@ -27,6 +40,9 @@ type (
 	// EntryPredicate returns true of false based on the given entry
 	EntryPredicate func(entry MapEntry) bool
 
+	// EntryTransformer produces a new key and a new value from an entry
+	EntryTransformer func(entry MapEntry) (key, value interface{})
+
 	// Keyed is the simples possible interface for a key store.
 	Keyed interface {
 		// Get returns the value for the given key. The method will return nil when the key is not found. A
@@ -36,7 +52,8 @@ type (
 
 	// Map represents an ordered set of key-value associations. The Map preserves the order by which the entries
 	// were added. Associations retain their order even if their value change. When creating a Map from a go map
-	// the associations will be sorted based on the natural order of the keys.
+	// the associations will be sorted based on the natural order of the keys. A SortedMap is ordered by key and an
+	// LRUMap by recency of use instead, see their respective documentation.
 	Map interface {
 		Iterable
 		Keyed
@@ -61,6 +78,11 @@ type (
 		// AnyValue returns true if the predicate returns true for any value of this Map.
 		AnyValue(actor Predicate) bool
 
+		// ComputeIfAbsent returns the value associated with the given key. If no such value exists, the computer is
+		// called with the key and the value that it returns is associated with the key and returned. The method will
+		// panic if the map is immutable.
+		ComputeIfAbsent(key interface{}, computer func(key Value) Value) Value
+
 		// ContainsKey returns true if the map contains the give key
 		ContainsKey(key interface{}) bool
 
@@ -75,18 +97,72 @@ type (
 		// overflow panic.
 		Copy(frozen bool) Map
 
-		// EachEntry calls the given actor with each entry of this Map
+		// DeepMerge returns a Map where all associations from this and the given Map are merged recursively. When
+		// both maps have a value for the same key and both values are maps, then those maps are deep merged. When
+		// both values are arrays and concatArrays is true, the result is the concatenation of the arrays. In all
+		// other cases, the value of the given map has priority. The result is frozen if both maps are frozen.
+		DeepMerge(associations Map, concatArrays bool) Map
+
+		// EachEntry calls the given actor with each entry of this Map. The entries are visited in the order by
+		// which their keys were first added. Changing the value of an existing association does not affect the
+		// order. A removed key that is added again is visited last. SortedMap and LRUMap define their own order.
 		EachEntry(actor EntryActor)
 
-		// EachKey calls the given actor with each key of this Map
+		// EachKey calls the given actor with each key of this Map in the same order as EachEntry
 		EachKey(actor Consumer)
 
-		// EachValue calls the given actor with each value of this Map
+		// EachValue calls the given actor with each value of this Map in the same order as EachEntry
 		EachValue(actor Consumer)
 
+		// Entries returns a frozen snapshot of all the entries of this map in the same order as EachEntry. The
+		// returned Array is not affected by subsequent modifications of this map.
+		Entries() Array
+
 		// Find returns the first entry for which the entry predicate returns true
 		Find(predicate EntryPredicate) MapEntry
 
+		// GetAndDelete removes the association for the given key and returns the value that was associated with it
+		// together with true. If no such association exists, nil and false is returned. The method will panic if the
+		// map is immutable.
+		GetAndDelete(key interface{}) (Value, bool)
+
+		// GetArray returns the Array that is associated with the given key and true, or nil and false if no value is
+		// associated with the key or if the value isn't an Array.
+		GetArray(key interface{}) (Array, bool)
+
+		// GetBool returns the Go bool of the Boolean that is associated with the given key and true, or false and false
+		// if no value is associated with the key or if the value isn't a Boolean.
+		GetBool(key interface{}) (bool, bool)
+
+		// GetFloat returns the Go float64 of the Float that is associated with the given key and true, or zero and
+		// false if no value is associated with the key or if the value isn't a Float.
+		GetFloat(key interface{}) (float64, bool)
+
+		// GetInt returns the Go int64 of the Integer that is associated with the given key and true, or zero and false
+		// if no value is associated with the key or if the value isn't an Integer.
+		GetInt(key interface{}) (int64, bool)
+
+		// GetMap returns the Map that is associated with the given key and true, or nil and false if no value is
+		// associated with the key or if the value isn't a Map.
+		GetMap(key interface{}) (Map, bool)
+
+		// GetOrElse returns the value that is associated with the given key or the given defaultValue if no value is
+		// associated with the key. An associated Nil value is returned as is.
+		GetOrElse(key interface{}, defaultValue Value) Value
+
+		// GetOrElseGet returns the value that is associated with the given key or the value returned by the given
+		// computer if no value is associated with the key. The computer is not called when the key is present.
+		GetOrElseGet(key interface{}, computer func() Value) Value
+
+		// GetString returns the Go string of the String that is associated with the given key and true, or an empty
+		// string and false if no value is associated with the key or if the value isn't a String.
+		GetString(key interface{}) (string, bool)
+
+		// Invert returns a new map where the keys and values of this map have been swapped. The given policy
+		// determines what happens when a value is associated with more than one key. The frozen status of this map
+		// is inherited by the new map.
+		Invert(policy DuplicatePolicy) Map
+
 		// Keys returns frozen snapshot of all the keys of this map
 		Keys() Array
 
@@ -94,14 +170,61 @@ type (
 		// given mapper function.
 		Map(mapper EntryMapper) Map
 
+		// MapKeys returns a new map with the same values where each key has been replaced using the given mapper
+		// function. When the mapper returns equal keys for more than one entry, the last entry wins. The method
+		// panics if target is not nil and the new map is not an instance of target. The frozen status of this map
+		// is inherited by the new map.
+		MapKeys(mapper EntryMapper, target MapType) Map
+
+		// MapValues is like Map but the method panics if target is not nil and the new map is not an instance of
+		// target.
+		MapValues(mapper EntryMapper, target MapType) Map
+
 		// Merge returns a Map where all associations from this and the given Map are merged. The associations of the
 		// given map have priority.
 		Merge(associations Map) Map
 
+		// MergeWith returns a Map where all associations from this and the given Map are merged using the given
+		// strategy. MergeShallow is equivalent to Merge. The other strategies are equivalent to DeepMerge except
+		// for how they treat a key where both values are arrays.
+		MergeWith(associations Map, strategy MergeStrategy) Map
+
+		// MustGetArray returns the Array that is associated with the given key. The method panics if no value is
+		// associated with the key or if the value isn't an Array.
+		MustGetArray(key interface{}) Array
+
+		// MustGetBool returns the Go bool of the Boolean that is associated with the given key. The method panics if no
+		// value is associated with the key or if the value isn't a Boolean.
+		MustGetBool(key interface{}) bool
+
+		// MustGetFloat returns the Go float64 of the Float that is associated with the given key. The method panics if
+		// no value is associated with the key or if the value isn't a Float.
+		MustGetFloat(key interface{}) float64
+
+		// MustGetInt returns the Go int64 of the Integer that is associated with the given key. The method panics if no
+		// value is associated with the key or if the value isn't an Integer.
+		MustGetInt(key interface{}) int64
+
+		// MustGetMap returns the Map that is associated with the given key. The method panics if no value is associated
+		// with the key or if the value isn't a Map.
+		MustGetMap(key interface{}) Map
+
+		// MustGetString returns the Go string of the String that is associated with the given key. The method panics if
+		// no value is associated with the key or if the value isn't a String.
+		MustGetString(key interface{}) string
+
+		// None returns true if the predicate returns false for all entries of this Map.
+		None(predicate EntryPredicate) bool
+
 		// Put adds an association between the given key and value. The old value for the key or nil is returned. The
 		// method will panic if the map is immutable
 		Put(key, value interface{}) Value
 
+		// PutIfAbsent adds an association between the given key and value unless the key is already associated with a
+		// value. The existing value and false is returned when the key was present. Otherwise the given value and
+		// true is returned. The method will panic if the map is immutable
+		PutIfAbsent(key, value interface{}) (Value, bool)
+
 		// PutAll adds all associations from the given Map, overwriting any that has the same key. It will panic if the
 		// map is immutable.
 		PutAll(associations Map)
@@ -114,15 +237,48 @@ type (
 		// panic if the map is immutable.
 		RemoveAll(keys Array)
 
+		// RenameKeys returns a new Map where each key that is found in the given mapping has been replaced by the
+		// value that the mapping associates with that key. Keys that aren't found in the mapping are retained. The
+		// method panics if two keys end up being equal after the rename. The frozen status of this Map is
+		// inherited by the new Map.
+		RenameKeys(mapping Map) Map
+
 		// StringKeys returns true if this map's key type is assignable to String (i.e. if all keys are strings)
 		StringKeys() bool
 
+		// ToFlatSlice returns a Go slice with the keys and values of this map in alternating order, e.g. for use
+		// with variadic functions that take key, value, key, value arguments. The elements are the dgo.Value keys and
+		// values of this map.
+		ToFlatSlice() []interface{}
+
+		// ToStructSlice returns a Go slice with one element for each entry of this map. The element is the value that
+		// the given mapper returns for the entry.
+		ToStructSlice(mapper EntryMapper) []interface{}
+
+		// Transform returns a new Map that is an instance of the given schema. Values that aren't instances of the
+		// type of their entry are converted using the New function of that type and missing entries that have a
+		// default value are added. An error listing all violations is returned when a value cannot be converted,
+		// when a required entry is missing, or when this map contains entries that aren't allowed by the schema. The
+		// returned map is frozen if this map is frozen.
+		Transform(schema StructMapType) (Map, error)
+
+		// TransformEntries returns a new map with the keys and values that the given transformer returns for the
+		// entries of this map. When the transformer returns equal keys for more than one entry, the last entry wins.
+		// The method panics if target is not nil and the new map is not an instance of target. The frozen status of
+		// this map is inherited by the new map.
+		TransformEntries(transformer EntryTransformer, target MapType) Map
+
 		// Values returns snapshot of all the values of this map.
 		Values() Array
 
 		// With creates a copy of this Map containing an association between the given key and value.
 		With(key, value interface{}) Map
 
+		// WithDefaults returns a Map where each entry of the given schema that has a default value and is missing in
+		// this Map has been added with that value. This Map is returned when no such entry is missing. The frozen
+		// status of this map is inherited by the new map.
+		WithDefaults(schema StructMapType) Map
+
 		// Without returns a Map that is guaranteed to have no value associated with the given key.
 		Without(key interface{}) Map
 
@@ -160,7 +316,15 @@ type (
 		// Each iterates over each entry of the StructMapType
 		Each(actor func(StructMapEntry))
 
-		// Get returns the StructMapEntry that is identified with the given key
+		// Extend returns a new StructMapType that inherits all entries of this type and adds the entries of the
+		// given type. An entry of the given type replaces an inherited entry that has the same key. The method
+		// panics if the value type of such an entry isn't assignable to the inherited value type or if the entry
+		// makes a required entry optional. The given overrides replace or add entries without those checks. The
+		// new type allows additional entries only when both types do.
+		Extend(other StructMapType, overrides ...StructMapEntry) StructMapType
+
+		// Get returns the StructMapEntry that is identified with the given key. When no such entry exists, the first
+		// entry with a pattern key that matches the given key is returned.
 		Get(key interface{}) StructMapEntry
 
 		// Len returns the number of StructEntrys in this StructMapType
@@ -182,4 +346,55 @@ type (
 		// Indenter.
 		ValidateVerbose(value interface{}, out Indenter) bool
 	}
+
+	// ConditionalStructType represents a Map that is an instance of one of several StructMapTypes. The value that
+	// the Map associates with the discriminator key selects the StructMapType that the Map must be an instance of.
+	ConditionalStructType interface {
+		Type
+
+		// Case returns the StructMapType that is selected by the given discriminator value, or nil if no such
+		// StructMapType exists
+		Case(discriminatorValue interface{}) StructMapType
+
+		// Cases returns the StructMapTypes of this type in the order they were given
+		Cases() Array
+
+		// Discriminator returns the key whose value selects the StructMapType
+		Discriminator() Value
+
+		// Validate checks that the given value represents a Map which is an instance of this type and returns a
+		// possibly empty slice of errors explaining why that's not the case. An error listing the valid
+		// discriminator values is generated when the discriminator is missing or has an unknown value. Otherwise,
+		// the errors of the selected StructMapType are returned.
+		//
+		// The keyLabel argument has the same meaning as for StructMapType.Validate.
+		Validate(keyLabel func(key Value) string, value interface{}) []error
+	}
+)
+
+const (
+	// MergeShallow lets the values of the given Map replace the values of the receiver
+	MergeShallow = MergeStrategy(iota)
+
+	// MergeDeep merges Map values recursively and lets all other values of the given Map replace the values of
+	// the receiver, including arrays
+	MergeDeep
+
+	// MergeDeepConcat merges Map values recursively and concatenates Array values
+	MergeDeepConcat
+
+	// MergeDeepUnique merges Map values recursively and concatenates Array values while dropping duplicates
+	MergeDeepUnique
+)
+
+const (
+	// DuplicatePanic causes a panic when a value is associated with more than one key
+	DuplicatePanic = DuplicatePolicy(iota)
+
+	// DuplicateKeepFirst lets the first key that is associated with a value win
+	DuplicateKeepFirst
+
+	// DuplicateCollect makes each value of the new map an Array that contains all keys that were associated with
+	// that value, in the order they were found
+	DuplicateCollect
 )
@@ -0,0 +1,35 @@
+package dgo
+
+type (
+	// MultiMap is a Map that can associate several values with each key. The values for a key are kept in an Array
+	// in the order that they were added. Methods that iterate over the entries, such as Each, EachEntry, and Values,
+	// will see each key once and that Array as its value.
+	//
+	// Get, and the typed getters that depend on it, returns the first value that is associated with the given key.
+	// Put replaces all values associated with the key with the given value.
+	MultiMap interface {
+		Map
+
+		// Add appends the given value to the values that are associated with the given key. The method will panic
+		// if the map is immutable.
+		Add(key, value interface{})
+
+		// AddAll appends all values of the given Array to the values that are associated with the given key. The
+		// method will panic if the map is immutable.
+		AddAll(key interface{}, values Array)
+
+		// GetAll returns a frozen snapshot of all values associated with the given key. An empty Array is returned
+		// when no value is associated with the key.
+		GetAll(key interface{}) Array
+	}
+
+	// MultiMapType is the type of a MultiMap. The KeyType constrains the keys and the ElementType constrains each
+	// value that is associated with a key. The ValueType is the Array type of the values that are associated with
+	// one key.
+	MultiMapType interface {
+		MapType
+
+		// ElementType returns the type of each value that is associated with a key
+		ElementType() Type
+	}
+)
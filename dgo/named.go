@@ -17,6 +17,7 @@ type (
 	// NamedTypeExtension defines the extensions that a NamedType brings to a Type.
 	NamedTypeExtension interface {
 		Factory
+		Annotated
 
 		// AssignableType returns a reflect.Type that is either an interface that instances
 		// of this type must implement, or an actual implementation. The default AssignableChecker
@@ -2,6 +2,9 @@ package dgo
 
 import (
 	"fmt"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
 	"regexp"
 	"time"
@@ -67,6 +70,102 @@ type (
 		GoInt() int64
 	}
 
+	// BigInt value is a *big.Int that implements the Value interface. It is used for integers that don't fit into an
+	// int64.
+	BigInt interface {
+		Value
+		Number
+		Comparable
+		ReflectedValue
+
+		// GoBigInt returns a copy of the Go native representation of this value
+		GoBigInt() *big.Int
+	}
+
+	// Decimal value is an arbitrary precision fixed point decimal number. Its value is Unscaled() * 10^-Scale().
+	Decimal interface {
+		Value
+		Number
+		Comparable
+		ReflectedValue
+
+		// Precision returns the number of digits in the unscaled value
+		Precision() int
+
+		// Rat returns the value of this decimal as a new *big.Rat
+		Rat() *big.Rat
+
+		// Scale returns the number of digits after the decimal point
+		Scale() int
+
+		// Unscaled returns a copy of the unscaled value of this decimal
+		Unscaled() *big.Int
+	}
+
+	// URI value is a *url.URL that implements the Value interface
+	URI interface {
+		Value
+		ReflectedValue
+
+		// GoURL returns a copy of the Go native representation of this value
+		GoURL() *url.URL
+	}
+
+	// SemVer value is a semantic version as defined by https://semver.org. Versions are compared using the semantic
+	// versioning precedence rules.
+	SemVer interface {
+		Value
+		Comparable
+		ReflectedValue
+
+		// Build returns the build metadata or an empty string
+		Build() string
+
+		// Major returns the major version number
+		Major() int
+
+		// Minor returns the minor version number
+		Minor() int
+
+		// Patch returns the patch version number
+		Patch() int
+
+		// Prerelease returns the prerelease identifiers joined with a period or an empty string
+		Prerelease() string
+	}
+
+	// SemVerRange value is a set of semantic versions described by a range expression such as "^1.2.0" or
+	// ">=1.2.0 <2.0.0 || 3.x"
+	SemVerRange interface {
+		Value
+		ReflectedValue
+
+		// Includes returns true if the given version is included in this range
+		Includes(v SemVer) bool
+	}
+
+	// IP value is an IPv4 or IPv6 address
+	IP interface {
+		Value
+		Comparable
+		ReflectedValue
+
+		// GoIP returns a copy of the Go native representation of this value
+		GoIP() net.IP
+	}
+
+	// IPNet value is an IPv4 or IPv6 network
+	IPNet interface {
+		Value
+		ReflectedValue
+
+		// Contains returns true if the given address is contained in this network
+		Contains(ip IP) bool
+
+		// GoIPNet returns a copy of the Go native representation of this value
+		GoIPNet() *net.IPNet
+	}
+
 	// Float value is a float64 that implements the Value interface
 	Float interface {
 		Value
@@ -111,14 +210,36 @@ type (
 		SecondsWithFraction() float64
 	}
 
+	// Duration value is a time.Duration that implements the Value interface
+	Duration interface {
+		Value
+		ReflectedValue
+		Comparable
+
+		// GoDuration returns the Go native representation of this value
+		GoDuration() time.Duration
+	}
+
 	// Boolean value
 	Boolean interface {
 		Value
 		Comparable
 		ReflectedValue
 
+		// And returns the result of a logical AND between this value and the given value
+		And(other Boolean) Boolean
+
 		// GoBool returns the Go native representation of this value
 		GoBool() bool
+
+		// Not returns the logical negation of this value
+		Not() Boolean
+
+		// Or returns the result of a logical OR between this value and the given value
+		Or(other Boolean) Boolean
+
+		// Xor returns the result of a logical exclusive OR between this value and the given value
+		Xor(other Boolean) Boolean
 	}
 	// Native is a wrapper of a runtime value such as a chan or a pointer for which there is no proper immutable Value
 	// representation
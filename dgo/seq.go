@@ -0,0 +1,29 @@
+package dgo
+
+// Seq is a lazy sequence of values. The methods that return a Seq only describe an operation. No values are produced
+// and no intermediate Arrays are allocated until Each, First, or Into is called. The values are read from the source
+// at that time, so a Seq that is iterated twice reflects changes made to a mutable source in between.
+type Seq interface {
+	// Drop returns a Seq that skips the first n values of this Seq. The method panics if n is negative.
+	Drop(n int) Seq
+
+	// Each calls the given actor once for each value of this Seq
+	Each(actor Consumer)
+
+	// Filter returns a Seq with the values of this Seq for which the given predicate returns true
+	Filter(predicate Predicate) Seq
+
+	// First returns the first value of this Seq, or nil if the Seq is empty. Only the values needed to find the
+	// first value are produced.
+	First() Value
+
+	// Into adds all values of this Seq to the given Array and returns that Array. It panics if the Array is frozen.
+	Into(array Array) Array
+
+	// Map returns a Seq with the values that the given mapper returns for the values of this Seq
+	Map(mapper Mapper) Seq
+
+	// Take returns a Seq with at most the first n values of this Seq. No values beyond the first n are produced
+	// from the source. The method panics if n is negative.
+	Take(n int) Seq
+}
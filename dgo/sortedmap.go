@@ -0,0 +1,25 @@
+package dgo
+
+// SortedMap is a Map that keeps its entries ordered by key. The order is given by a comparator function or, when no
+// comparator is given, by the natural order of the keys. Iteration starts with the entry that has the least key.
+type SortedMap interface {
+	Map
+
+	// Ceiling returns the entry with the least key that is greater than or equal to the given key, or nil if no such
+	// entry exists
+	Ceiling(key interface{}) MapEntry
+
+	// First returns the entry with the least key, or nil if the map is empty
+	First() MapEntry
+
+	// Floor returns the entry with the greatest key that is less than or equal to the given key, or nil if no such
+	// entry exists
+	Floor(key interface{}) MapEntry
+
+	// Last returns the entry with the greatest key, or nil if the map is empty
+	Last() MapEntry
+
+	// Range calls the given actor with each entry whose key is greater than or equal to from and less than to, in
+	// key order. A nil from or to means that the range is unbounded in that direction.
+	Range(from, to interface{}, actor EntryActor)
+}
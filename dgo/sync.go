@@ -0,0 +1,71 @@
+package dgo
+
+type (
+	// SyncMap is a mutable Map that is safe for concurrent use. All operations are serialized by a read-write lock.
+	// A SyncMap is not a Value. Use Snapshot to obtain a frozen Map with its current entries. The map stores frozen
+	// copies of the values that are given to it so that the values that it returns can be used without the lock.
+	// Get takes the write lock when the map was created from an LRUMap since it then promotes the accessed entry.
+	SyncMap interface {
+		// CompareAndSet associates the given value with the given key if the current value of the key equals
+		// expected, and returns true. A nil expected value means that the key must be absent. The map is not
+		// changed and false is returned when the current value differs.
+		CompareAndSet(key, expected, value interface{}) bool
+
+		// Get returns the value that is associated with the given key, or nil if no such value exists
+		Get(key interface{}) Value
+
+		// GetOrCompute returns the value that is associated with the given key. If no such value exists, the given
+		// computer is called with the key and the value that it returns is associated with the key and returned. The
+		// lock is held while the computer runs, so the computer must not use this map.
+		GetOrCompute(key interface{}, computer func(key Value) Value) Value
+
+		// Len returns the number of entries in this map
+		Len() int
+
+		// Put associates the given value with the given key and returns the value that was previously associated
+		// with the key, or nil if no such value existed
+		Put(key, value interface{}) Value
+
+		// Remove removes the association for the given key and returns the value that was associated with it, or
+		// nil if no such value existed
+		Remove(key interface{}) Value
+
+		// Snapshot returns a frozen copy of the entries of this map
+		Snapshot() Map
+
+		// Update calls the given function with the underlying mutable Map while holding the write lock so that
+		// several changes are made atomically. The function must not retain the Map or use it after it returns.
+		// Values that the function stores are replaced with frozen copies when it returns.
+		Update(updater func(m Map))
+	}
+
+	// SyncArray is a mutable Array that is safe for concurrent use. All operations are serialized by a read-write
+	// lock. A SyncArray is not a Value. Use Snapshot to obtain a frozen Array with its current elements. The array
+	// stores frozen copies of the values that are given to it so that the values that it returns can be used
+	// without the lock.
+	SyncArray interface {
+		// Add adds the given value to the end of this array
+		Add(value interface{})
+
+		// CompareAndSet replaces the element at the given position with the given value if that element equals
+		// expected, and returns true. The array is not changed and false is returned when the element differs.
+		CompareAndSet(pos int, expected, value interface{}) bool
+
+		// Get returns the element at the given position
+		Get(pos int) Value
+
+		// Len returns the number of elements in this array
+		Len() int
+
+		// Set replaces the element at the given position with the given value and returns the replaced element
+		Set(pos int, value interface{}) Value
+
+		// Snapshot returns a frozen copy of the elements of this array
+		Snapshot() Array
+
+		// Update calls the given function with the underlying mutable Array while holding the write lock so that
+		// several changes are made atomically. The function must not retain the Array or use it after it returns.
+		// Values that the function stores are replaced with frozen copies when it returns.
+		Update(updater func(a Array))
+	}
+)
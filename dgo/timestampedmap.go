@@ -0,0 +1,13 @@
+package dgo
+
+import "time"
+
+// TimestampedMap is a Map that records the time of each Put. It is intended as a debugging aid, e.g. to find out in
+// what order concurrent goroutines updated a map.
+type TimestampedMap interface {
+	Map
+
+	// TimestampOf returns the time when a value was last associated with the given key. The second return value is
+	// false when no value is associated with the key.
+	TimestampOf(key interface{}) (time.Time, bool)
+}
@@ -23,6 +23,31 @@ type (
 
 		// ReflectType returns the reflect.Type that corresponds to the receiver
 		ReflectType() reflect.Type
+
+		// IsExact returns true if the receiver is an ExactType, i.e. a type that represents exactly one value
+		IsExact() bool
+
+		// IsArray returns true if the receiver is an ArrayType. Tuple types and exact array types are array types
+		IsArray() bool
+
+		// IsMap returns true if the receiver is a MapType. Struct types and exact map types are map types
+		IsMap() bool
+
+		// IsString returns true if the instances of the receiver are strings, e.g. if it is an exact, sized,
+		// pattern, or case insensitive string type
+		IsString() bool
+
+		// IsInteger returns true if the receiver is an IntegerType
+		IsInteger() bool
+
+		// IsFloat returns true if the receiver is a FloatType
+		IsFloat() bool
+
+		// IsBoolean returns true if the receiver is a BooleanType
+		IsBoolean() bool
+
+		// IsNil returns true if the receiver is the type of the Nil value
+		IsNil() bool
 	}
 
 	// Meta is the description of a Type.
@@ -89,6 +114,78 @@ type (
 
 		// IsInstance returns true if the Go native value is represented by this type
 		IsInstance(tm time.Time) bool
+
+		// Max returns the inclusive upper bound of this type. A zero time means that there is no upper bound.
+		Max() time.Time
+
+		// Min returns the inclusive lower bound of this type. A zero time means that there is no lower bound.
+		Min() time.Time
+	}
+
+	// DurationType matches duration values that are within an inclusive range
+	DurationType interface {
+		Type
+
+		// IsInstance returns true if the Go native value is represented by this type
+		IsInstance(d time.Duration) bool
+
+		// Max returns the inclusive upper bound of this type
+		Max() time.Duration
+
+		// Min returns the inclusive lower bound of this type
+		Min() time.Duration
+	}
+
+	// DecimalType matches decimals that have at most Precision digits of which at most Scale digits are after the
+	// decimal point. The Precision is zero when the number of digits is unconstrained.
+	DecimalType interface {
+		Type
+
+		// Precision returns the maximum number of digits or zero when unconstrained
+		Precision() int
+
+		// Scale returns the maximum number of digits after the decimal point
+		Scale() int
+	}
+
+	// URIType matches URIs that have a specific scheme and/or host. Both are compared case insensitively.
+	URIType interface {
+		Type
+
+		// Host returns the required host name in lower case or an empty string when unconstrained
+		Host() string
+
+		// Scheme returns the required scheme in lower case or an empty string when unconstrained
+		Scheme() string
+	}
+
+	// SemVerType matches semantic versions that are included in a range
+	SemVerType interface {
+		Type
+
+		// Range returns the range of versions that this type matches
+		Range() SemVerRange
+	}
+
+	// IPType matches IP addresses of a specific version that are contained in a network
+	IPType interface {
+		Type
+
+		// Network returns the network that contains all addresses matched by this type or nil if there is no such
+		// constraint
+		Network() IPNet
+
+		// Version returns 4 or 6 when the type matches only IPv4 or IPv6 addresses, and zero otherwise
+		Version() int
+	}
+
+	// CIDRType matches IP networks that are subnets of, or equal to, a network
+	CIDRType interface {
+		Type
+
+		// Network returns the network that contains all networks matched by this type or nil if there is no such
+		// constraint
+		Network() IPNet
 	}
 
 	// SizedType is implemented by types that may have a size constraint
@@ -134,6 +231,15 @@ type (
 		Resolve(AliasAdder)
 	}
 
+	// ResolveError is the error that is raised when the resolution of an AliasContainer is abandoned
+	// because the nesting of containers exceeded the depth limit.
+	ResolveError interface {
+		error
+
+		// Depth returns the depth of container nesting at the point where the resolution was abandoned
+		Depth() int
+	}
+
 	// Alias is a named reference of another type which can be resolved using an AliasAdder
 	Alias interface {
 		Type
@@ -176,6 +282,16 @@ type (
 		Generic() Type
 	}
 
+	// DescribableType is implemented by types that can describe themselves in natural language. All types
+	// provided by dgo implement this interface.
+	DescribableType interface {
+		Type
+
+		// Describe returns a natural language description of this type that is suitable for messages
+		// intended for end users, e.g. "an integer between 0 and 100"
+		Describe() string
+	}
+
 	// ExactType is implemented by types that match exactly one value
 	ExactType interface {
 		Type
@@ -97,6 +97,63 @@ const (
 	// TiTuple is the type identifier for the Tuple type
 	TiTuple
 
+	// TiMultiMap is the type identifier for the MultiMap type
+	TiMultiMap
+
+	// TiBase64String is the type identifier for the type of base64 encoded strings
+	TiBase64String
+
+	// TiHexString is the type identifier for the type of hex encoded strings
+	TiHexString
+
+	// TiTimeRange is the type identifier for the Time range type
+	TiTimeRange
+
+	// TiDuration is the type identifier for the Duration type
+	TiDuration
+
+	// TiDurationRange is the type identifier for the Duration range type
+	TiDurationRange
+
+	// TiBigInt is the type identifier for the BigInt type
+	TiBigInt
+
+	// TiDecimal is the type identifier for the Decimal type
+	TiDecimal
+
+	// TiDecimalSized is the type identifier for the precision and scale constrained Decimal type
+	TiDecimalSized
+
+	// TiURI is the type identifier for the URI type
+	TiURI
+
+	// TiURIRestricted is the type identifier for the scheme and host restricted URI type
+	TiURIRestricted
+
+	// TiSemVer is the type identifier for the SemVer type
+	TiSemVer
+
+	// TiSemVerConstraint is the type identifier for the SemVer type that is constrained by a range
+	TiSemVerConstraint
+
+	// TiSemVerRange is the type identifier for the SemVerRange type
+	TiSemVerRange
+
+	// TiIPAddr is the type identifier for the IP address type
+	TiIPAddr
+
+	// TiIPv4 is the type identifier for the IPv4 address type
+	TiIPv4
+
+	// TiIPv6 is the type identifier for the IPv6 address type
+	TiIPv6
+
+	// TiCIDR is the type identifier for the IP network type
+	TiCIDR
+
+	// TiConditionalStruct is the type identifier for the ConditionalStruct type
+	TiConditionalStruct
+
 	// exactStart denotes the index of where the range of exact types start. All
 	// exact types must be added below this entry
 	exactStart
@@ -142,52 +199,103 @@ const (
 
 	// TiTimeExact is the type identifier for the exact Time type
 	TiTimeExact
+
+	// TiDurationExact is the type identifier for the exact Duration type
+	TiDurationExact
+
+	// TiBigIntExact is the type identifier for the exact BigInt type
+	TiBigIntExact
+
+	// TiDecimalExact is the type identifier for the exact Decimal type
+	TiDecimalExact
+
+	// TiURIExact is the type identifier for the exact URI type
+	TiURIExact
+
+	// TiSemVerExact is the type identifier for the exact SemVer type
+	TiSemVerExact
+
+	// TiSemVerRangeExact is the type identifier for the exact SemVerRange type
+	TiSemVerRangeExact
+
+	// TiIPAddrExact is the type identifier for the exact IP address type
+	TiIPAddrExact
+
+	// TiCIDRExact is the type identifier for the exact IP network type
+	TiCIDRExact
 )
 
 var tiLabels = map[TypeIdentifier]string{
-	TiAlias:         `alias`,
-	TiNil:           `nil`,
-	TiAny:           `any`,
-	TiMeta:          `type`,
-	TiBoolean:       `bool`,
-	TiBooleanExact:  `bool`,
-	TiInteger:       `int`,
-	TiIntegerExact:  `int`,
-	TiIntegerRange:  `int range`,
-	TiFloat:         `float`,
-	TiFloatExact:    `float`,
-	TiFloatRange:    `float range`,
-	TiBinary:        `binary`,
-	TiBinaryExact:   `binary`,
-	TiString:        `string`,
-	TiStringExact:   `string`,
-	TiStringSized:   `string`,
-	TiStringPattern: `pattern`,
-	TiCiString:      `string`,
-	TiRegexp:        `regexp`,
-	TiRegexpExact:   `regexp`,
-	TiTime:          `time`,
-	TiTimeExact:     `time`,
-	TiNative:        `native`,
-	TiArray:         `slice`,
-	TiArrayExact:    `slice`,
-	TiTuple:         `tuple`,
-	TiMap:           `map`,
-	TiMapExact:      `map`,
-	TiMapEntryExact: `map entry`,
-	TiStruct:        `struct`,
-	TiNot:           `not`,
-	TiAllOf:         `all of`,
-	TiAllOfValue:    `all of`,
-	TiAnyOf:         `any of`,
-	TiOneOf:         `one of`,
-	TiError:         `error`,
-	TiErrorExact:    `error`,
-	TiDgoString:     `dgo`,
-	TiSensitive:     `sensitive`,
-	TiFunction:      `function`,
-	TiFunctionExact: `function`,
-	TiNamed:         `named`,
+	TiAlias:             `alias`,
+	TiNil:               `nil`,
+	TiAny:               `any`,
+	TiMeta:              `type`,
+	TiBoolean:           `bool`,
+	TiBooleanExact:      `bool`,
+	TiInteger:           `int`,
+	TiIntegerExact:      `int`,
+	TiIntegerRange:      `int range`,
+	TiFloat:             `float`,
+	TiFloatExact:        `float`,
+	TiFloatRange:        `float range`,
+	TiBinary:            `binary`,
+	TiBinaryExact:       `binary`,
+	TiString:            `string`,
+	TiStringExact:       `string`,
+	TiStringSized:       `string`,
+	TiStringPattern:     `pattern`,
+	TiCiString:          `string`,
+	TiRegexp:            `regexp`,
+	TiRegexpExact:       `regexp`,
+	TiTime:              `time`,
+	TiTimeExact:         `time`,
+	TiTimeRange:         `time`,
+	TiDuration:          `duration`,
+	TiDurationExact:     `duration`,
+	TiDurationRange:     `duration`,
+	TiBigInt:            `bigint`,
+	TiBigIntExact:       `bigint`,
+	TiDecimal:           `decimal`,
+	TiDecimalExact:      `decimal`,
+	TiDecimalSized:      `decimal`,
+	TiURI:               `uri`,
+	TiURIExact:          `uri`,
+	TiURIRestricted:     `uri`,
+	TiSemVer:            `semver`,
+	TiSemVerExact:       `semver`,
+	TiSemVerConstraint:  `semver`,
+	TiSemVerRange:       `semverrange`,
+	TiSemVerRangeExact:  `semverrange`,
+	TiIPAddr:            `ipaddr`,
+	TiIPAddrExact:       `ipaddr`,
+	TiIPv4:              `ipv4`,
+	TiIPv6:              `ipv6`,
+	TiCIDR:              `cidr`,
+	TiCIDRExact:         `cidr`,
+	TiNative:            `native`,
+	TiArray:             `slice`,
+	TiArrayExact:        `slice`,
+	TiTuple:             `tuple`,
+	TiMap:               `map`,
+	TiMapExact:          `map`,
+	TiMapEntryExact:     `map entry`,
+	TiMultiMap:          `multimap`,
+	TiStruct:            `struct`,
+	TiConditionalStruct: `conditional`,
+	TiNot:               `not`,
+	TiAllOf:             `all of`,
+	TiAllOfValue:        `all of`,
+	TiAnyOf:             `any of`,
+	TiOneOf:             `one of`,
+	TiError:             `error`,
+	TiErrorExact:        `error`,
+	TiDgoString:         `dgo`,
+	TiBase64String:      `base64`,
+	TiHexString:         `hex`,
+	TiSensitive:         `sensitive`,
+	TiFunction:          `function`,
+	TiFunctionExact:     `function`,
+	TiNamed:             `named`,
 }
 
 func (ti TypeIdentifier) String() string {
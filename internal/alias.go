@@ -3,8 +3,10 @@ package internal
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
 )
 
 type (
@@ -20,6 +22,11 @@ type (
 	aliasAdder struct {
 		namedTypes hashMap
 		backingMap dgo.AliasMap
+		resolving  []dgo.Value
+	}
+
+	resolveError struct {
+		depth int
 	}
 
 	dType        = dgo.Type // To avoid collision with method named Type
@@ -62,6 +69,21 @@ func DefaultAliases() dgo.AliasMap {
 	return defaultAliases
 }
 
+// DefaultResolveDepthLimit is the default limit for the nesting of containers during alias resolution
+const DefaultResolveDepthLimit = 100
+
+var resolveDepthLimit int64 = DefaultResolveDepthLimit
+
+// SetResolveDepthLimit sets the limit for the nesting of containers that is permitted during alias
+// resolution and returns the previous limit. Exceeding the limit will result in a panic with a
+// dgo.ResolveError.
+func SetResolveDepthLimit(limit int) int {
+	if limit < 1 {
+		panic(fmt.Errorf(`illegal resolve depth limit %d`, limit))
+	}
+	return int(atomic.SwapInt64(&resolveDepthLimit, int64(limit)))
+}
+
 // ResetDefaultAliases will reset the AliasMap returned by the DefaultAliases() method to the BuiltInAliases()
 // and thereby throw away any changes made to the DefaultAliases map.
 //
@@ -206,8 +228,11 @@ func (a *aliasAdder) GetType(n dgo.String) dgo.Type {
 	return a.backingMap.GetType(n)
 }
 
-func (a *aliasAdder) Replace(t dgo.Value) dgo.Value {
-	switch t := t.(type) {
+// Replace replaces the given value if it is an alias or a deferred call. If the value is an AliasContainer
+// then it is resolved in place unless it is already being resolved, which is the case when a container
+// contains itself. A dgo.ResolveError is raised if the nesting of containers exceeds the resolve depth limit.
+func (a *aliasAdder) Replace(v dgo.Value) dgo.Value {
+	switch t := v.(type) {
 	case *deferredCall:
 		return New(t.dType, t.args)
 	case dgo.Alias:
@@ -216,7 +241,24 @@ func (a *aliasAdder) Replace(t dgo.Value) dgo.Value {
 		}
 		panic(fmt.Errorf(`reference to unresolved type '%s'`, t.Reference()))
 	case dgo.AliasContainer:
+		if util.RecursionHit(a.resolving, v) {
+			break
+		}
+		depth := len(a.resolving)
+		if int64(depth) >= atomic.LoadInt64(&resolveDepthLimit) {
+			panic(&resolveError{depth: depth})
+		}
+		a.resolving = append(a.resolving, v)
+		defer func() { a.resolving = a.resolving[:depth] }()
 		t.Resolve(a)
 	}
-	return t
+	return v
+}
+
+func (e *resolveError) Depth() int {
+	return e.depth
+}
+
+func (e *resolveError) Error() string {
+	return fmt.Sprintf(`alias resolution exceeded the depth limit %d`, e.depth)
 }
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/lyraproj/dgo/internal"
 	"github.com/lyraproj/dgo/parser"
 
 	"github.com/lyraproj/dgo/dgo"
@@ -60,3 +61,75 @@ func TestAddAliases(t *testing.T) {
 	require.Equal(t, aliases.GetType(vf.String(`pnr`)), tf.String(10, 12))
 	require.Nil(t, bi.GetType(vf.String(`pnr`)))
 }
+
+func TestAliasAdder_Replace_cycle(t *testing.T) {
+	a := vf.MutableValues(1)
+	a.Add(a)
+	tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		require.Same(t, a, aa.Replace(a))
+	})
+	require.Same(t, a, a.Get(1))
+}
+
+func TestAliasAdder_Replace_depthLimit(t *testing.T) {
+	nested := func(depth int) dgo.Value {
+		v := vf.MutableValues(1)
+		for i := 1; i < depth; i++ {
+			v = vf.MutableValues(v)
+		}
+		return v
+	}
+
+	tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		aa.Replace(nested(100))
+		require.Panic(t, func() { aa.Replace(nested(101)) }, `exceeded the depth limit 100`)
+	})
+
+	old := tf.SetResolveDepthLimit(200)
+	defer tf.SetResolveDepthLimit(old)
+	require.Equal(t, internal.DefaultResolveDepthLimit, old)
+	tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		aa.Replace(nested(101))
+	})
+	require.Panic(t, func() { tf.SetResolveDepthLimit(0) }, `illegal resolve depth limit 0`)
+}
+
+func TestSetResolveDepthLimit_concurrent(t *testing.T) {
+	old := tf.SetResolveDepthLimit(internal.DefaultResolveDepthLimit)
+	defer tf.SetResolveDepthLimit(old)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				tf.SetResolveDepthLimit(internal.DefaultResolveDepthLimit + n)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) { aa.Replace(vf.MutableValues(vf.MutableValues(1))) })
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResolveError(t *testing.T) {
+	var err dgo.ResolveError
+	tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		defer func() {
+			var ok bool
+			err, ok = recover().(dgo.ResolveError)
+			require.True(t, ok)
+		}()
+		v := vf.MutableValues(1)
+		for i := 0; i < 100; i++ {
+			v = vf.MutableValues(v)
+		}
+		aa.Replace(v)
+	})
+	require.Equal(t, 100, err.Depth())
+}
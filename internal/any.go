@@ -18,6 +18,10 @@ func (t anyType) Assignable(other dgo.Type) bool {
 	return true
 }
 
+func (t anyType) Describe() string {
+	return Describe(t)
+}
+
 func (t anyType) Equals(other interface{}) bool {
 	return t == other
 }
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"sync"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type arena struct {
+	strings []*hstring
+	arrays  []*array
+	maps    []*hashMap
+}
+
+var (
+	stringPool = sync.Pool{New: func() interface{} { return &hstring{} }}
+	arrayPool  = sync.Pool{New: func() interface{} { return &array{} }}
+	mapPool    = sync.Pool{New: func() interface{} { return &hashMap{} }}
+)
+
+// NewArena returns a new dgo.Arena. Values that are released by the arena when it is reset are retained in pools
+// that are shared by all arenas.
+func NewArena() dgo.Arena {
+	return &arena{}
+}
+
+// Integer returns the Integer for the given value. Integers are immutable and small integers are cached so they
+// don't need to be pooled.
+func (a *arena) Integer(v int64) dgo.Integer {
+	return Integer(v)
+}
+
+func (a *arena) String(s string) dgo.String {
+	hs := stringPool.Get().(*hstring)
+	hs.s = s
+	a.strings = append(a.strings, hs)
+	return hs
+}
+
+func (a *arena) Array(size int) dgo.Array {
+	av := arrayPool.Get().(*array)
+	if cap(av.slice) < size {
+		av.slice = make([]dgo.Value, 0, size)
+	}
+	a.arrays = append(a.arrays, av)
+	return av
+}
+
+func (a *arena) Map(size int) dgo.Map {
+	m := mapPool.Get().(*hashMap)
+	if size <= 0 {
+		size = initialCapacity
+	}
+	if ts := tableSizeFor(int(float64(size) / loadFactor)); len(m.table) < ts {
+		m.table = make([]*hashNode, ts)
+	}
+	a.maps = append(a.maps, m)
+	return m
+}
+
+func (a *arena) Reset() {
+	for i, hs := range a.strings {
+		*hs = hstring{}
+		stringPool.Put(hs)
+		a.strings[i] = nil
+	}
+	a.strings = a.strings[:0]
+
+	for i, av := range a.arrays {
+		s := av.slice
+		if av.sharedCopy() != nil {
+			// the slice belongs to a frozen copy
+			s = nil
+		}
+		for j := range s {
+			s[j] = nil
+		}
+		*av = array{slice: s[:0]}
+		arrayPool.Put(av)
+		a.arrays[i] = nil
+	}
+	a.arrays = a.arrays[:0]
+
+	for i, m := range a.maps {
+		t := m.table
+		if m.sharedCopy() != nil {
+			// the table belongs to a frozen copy
+			t = nil
+		}
+		for j := range t {
+			t[j] = nil
+		}
+		*m = hashMap{table: t}
+		mapPool.Put(m)
+		a.maps[i] = nil
+	}
+	a.maps = a.maps[:0]
+}
@@ -0,0 +1,62 @@
+package internal_test
+
+import (
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestArena(t *testing.T) {
+	a := vf.NewArena()
+	require.Equal(t, vf.Integer(42), a.Integer(42))
+
+	s := a.String(`hello`)
+	require.Equal(t, `hello`, s.GoString())
+	require.Equal(t, vf.String(`hello`), s)
+	require.Equal(t, vf.String(`hello`).HashCode(), s.HashCode())
+
+	arr := a.Array(3)
+	require.False(t, arr.Frozen())
+	require.Equal(t, 0, arr.Len())
+	arr.AddValues(1, s, 3)
+	require.Equal(t, vf.Values(1, `hello`, 3), arr)
+
+	m := a.Map(2)
+	require.False(t, m.Frozen())
+	require.Equal(t, 0, m.Len())
+	m.Put(s, arr)
+	m.Put(`b`, 2)
+	require.Equal(t, vf.Map(`hello`, vf.Values(1, `hello`, 3), `b`, 2), m)
+}
+
+func TestArena_Reset(t *testing.T) {
+	a := vf.NewArena()
+	for i := 0; i < 3; i++ {
+		s := a.String(`x`)
+		arr := a.Array(2)
+		arr.AddValues(1, 2, 3)
+		arr.Freeze()
+		m := a.Map(0)
+		for j := 0; j < 20; j++ {
+			m.Put(j, s)
+		}
+		m.Freeze()
+		a.Reset()
+	}
+
+	arr := a.Array(1)
+	require.False(t, arr.Frozen())
+	require.Equal(t, 0, arr.Len())
+	arr.Add(1)
+	require.Equal(t, vf.Values(1), arr)
+
+	m := a.Map(1)
+	require.False(t, m.Frozen())
+	require.Equal(t, 0, m.Len())
+	require.Nil(t, m.Get(1))
+	m.Put(1, `one`)
+	require.Equal(t, vf.Map(1, `one`), m)
+	require.Equal(t, `y`, a.String(`y`).GoString())
+
+}
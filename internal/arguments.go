@@ -15,8 +15,10 @@ func Arguments(values []interface{}) dgo.Arguments {
 
 // ArgumentsFromArray returns an Arguments instance backed by the given array
 func ArgumentsFromArray(values dgo.Array) dgo.Arguments {
-	a := values.(*array)
-	return &arguments{*a}
+	if a, ok := values.(*array); ok {
+		return &arguments{array{slice: a.slice, elementType: a.elementType, frozen: a.frozen}}
+	}
+	return &arguments{array{slice: values.GoSlice(), elementType: elementTypeOf(values), frozen: values.Frozen()}}
 }
 
 func (a *arguments) AssertSize(funcName string, min, max int) {
@@ -1,11 +1,15 @@
 package internal
 
 import (
+	crand "crypto/rand"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/lyraproj/dgo/dgo"
 	"github.com/lyraproj/dgo/util"
@@ -13,8 +17,13 @@ import (
 
 type (
 	array struct {
-		slice  []dgo.Value
-		frozen bool
+		slice       []dgo.Value
+		elementType dgo.Type
+		frozen      bool
+
+		// shared holds the frozen copy (an *array) of this array that shares its slice. The slice is copied by detach
+		// before it is modified. The copy is published atomically since Copy(true) may be called concurrently.
+		shared atomic.Value
 	}
 
 	// defaultArrayType is the unconstrained array type
@@ -134,6 +143,10 @@ func (t defaultArrayType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t defaultArrayType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultArrayType) ElementType() dgo.Type {
 	return DefaultAnyType
 }
@@ -197,6 +210,10 @@ func (t *sizedArrayType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type
 	return CheckAssignableTo(guard, other, t)
 }
 
+func (t *sizedArrayType) Describe() string {
+	return Describe(t)
+}
+
 func (t *sizedArrayType) ElementType() dgo.Type {
 	return t.elementType
 }
@@ -235,9 +252,9 @@ func (t *sizedArrayType) Instance(value interface{}) bool {
 }
 
 func (t *sizedArrayType) DeepInstance(guard dgo.RecursionGuard, value interface{}) bool {
-	if ov, ok := value.(*array); ok {
-		l := len(ov.slice)
-		return t.min <= l && l <= t.max && allInstance(guard, t.elementType, ov.slice)
+	if ov, ok := value.(dgo.Array); ok {
+		l := ov.Len()
+		return t.min <= l && l <= t.max && allInstance(guard, t.elementType, sliceOf(ov))
 	}
 	return false
 }
@@ -305,6 +322,9 @@ func (t *exactArrayType) ElementTypes() dgo.Array {
 }
 
 func (t *exactArrayType) Generic() dgo.Type {
+	if et := t.value.elementType; et != nil {
+		return &sizedArrayType{elementType: et, min: 0, max: math.MaxInt64}
+	}
 	return &sizedArrayType{elementType: Generic(t.ElementType()), min: 0, max: math.MaxInt64}
 }
 
@@ -475,6 +495,10 @@ func tupleAssignable(guard dgo.RecursionGuard, t dgo.TupleType, other dgo.Type)
 	return CheckAssignableTo(guard, other, t)
 }
 
+func (t *tupleType) Describe() string {
+	return Describe(t)
+}
+
 func (t *tupleType) Element(index int) dgo.Type {
 	return t.types[index].(dgo.Type)
 }
@@ -562,12 +586,12 @@ func (t *tupleType) DeepInstance(guard dgo.RecursionGuard, value interface{}) bo
 }
 
 func tupleInstance(guard dgo.RecursionGuard, t dgo.TupleType, value interface{}) bool {
-	ov, ok := value.(*array)
+	ov, ok := value.(dgo.Array)
 	if !ok {
 		return false
 	}
 
-	s := ov.slice
+	s := sliceOf(ov)
 	n := len(s)
 	if t.Variadic() {
 		if t.Min() > n {
@@ -706,15 +730,24 @@ func arrayFromIterator(size int, each func(dgo.Consumer)) *array {
 }
 
 func sliceFromIterable(ir dgo.Iterable) []dgo.Value {
-	es := make([]dgo.Value, ir.Len())
-	i := 0
+	n := ir.Len()
+	if n < 0 {
+		// Size is unknown
+		n = 0
+	}
+	es := make([]dgo.Value, 0, n)
 	ir.Each(func(e dgo.Value) {
-		es[i] = e
-		i++
+		es = append(es, e)
 	})
 	return es
 }
 
+// IterableToArray returns a new Array that contains the values of the given Iterable in the order that they are
+// produced by its Each method. The Array is frozen if the Iterable is frozen.
+func IterableToArray(ir dgo.Iterable) dgo.Array {
+	return &array{slice: sliceFromIterable(ir), frozen: ir.Frozen()}
+}
+
 // ArrayFromReflected creates a new array that contains a copy of the given reflected slice
 func ArrayFromReflected(vr reflect.Value, frozen bool) dgo.Value {
 	if vr.IsNil() {
@@ -808,7 +841,7 @@ func valueSlice(values []interface{}, frozen bool) []dgo.Value {
 func Integers(values []int) dgo.Array {
 	cp := make([]dgo.Value, len(values))
 	for i := range values {
-		cp[i] = intVal(values[i])
+		cp[i] = Integer(int64(values[i]))
 	}
 	return &array{slice: cp, frozen: true}
 }
@@ -831,18 +864,26 @@ func (v *array) Add(vi interface{}) {
 	if v.frozen {
 		panic(frozenArray(`Add`))
 	}
-	v.slice = append(v.slice, Value(vi))
+	v.detach()
+	e := Value(vi)
+	v.assertElement(e)
+	v.slice = append(v.slice, e)
 }
 
 func (v *array) AddAll(values dgo.Iterable) {
 	if v.frozen {
 		panic(frozenArray(`AddAll`))
 	}
+	v.detach()
 	a := v.slice
 	if ar, ok := values.(*array); ok {
+		v.assertElements(ar.slice)
 		a = ar.AppendToSlice(a)
 	} else {
-		values.Each(func(e dgo.Value) { a = append(a, e) })
+		values.Each(func(e dgo.Value) {
+			v.assertElement(e)
+			a = append(a, e)
+		})
 	}
 	v.slice = a
 }
@@ -851,7 +892,39 @@ func (v *array) AddValues(values ...interface{}) {
 	if v.frozen {
 		panic(frozenArray(`AddValues`))
 	}
-	v.slice = append(v.slice, valueSlice(values, false)...)
+	v.detach()
+	vs := valueSlice(values, false)
+	v.assertElements(vs)
+	v.slice = append(v.slice, vs...)
+}
+
+func (v *array) Annotate(elementType dgo.Type) dgo.Array {
+	a := v.slice
+	for i := range a {
+		if !elementType.Instance(a[i]) {
+			panic(IllegalAssignment(elementType, a[i]))
+		}
+	}
+	if !v.frozen {
+		a = util.SliceCopy(a)
+	}
+	return &array{slice: a, elementType: elementType, frozen: v.frozen}
+}
+
+// assertElement panics unless the given value is an instance of the element type of the receiver
+func (v *array) assertElement(e dgo.Value) {
+	if v.elementType != nil && !v.elementType.Instance(e) {
+		panic(IllegalAssignment(v.elementType, e))
+	}
+}
+
+// assertElements panics unless all given values are instances of the element type of the receiver
+func (v *array) assertElements(es []dgo.Value) {
+	if v.elementType != nil {
+		for i := range es {
+			v.assertElement(es[i])
+		}
+	}
 }
 
 func (v *array) All(predicate dgo.Predicate) bool {
@@ -898,6 +971,9 @@ func (v *array) CompareTo(other interface{}) (int, bool) {
 }
 
 func (v *array) deepCompare(seen []dgo.Value, other deepCompare) (int, bool) {
+	if ca, ok := other.(*circularArray); ok {
+		other = ca.logical()
+	}
 	ov, ok := other.(*array)
 	if !ok {
 		return 0, false
@@ -936,6 +1012,18 @@ func (v *array) Copy(frozen bool) dgo.Array {
 	if frozen && v.frozen {
 		return v
 	}
+	if frozen {
+		if s := v.sharedCopy(); s != nil {
+			return s
+		}
+		if v.elementsFrozen() {
+			// Concurrent callers may each store a copy. They are equivalent so the last one stored is retained.
+			l := len(v.slice)
+			s := &array{slice: v.slice[:l:l], elementType: v.elementType, frozen: true}
+			v.shared.Store(s)
+			return s
+		}
+	}
 	cp := util.SliceCopy(v.slice)
 	if frozen {
 		for i := range cp {
@@ -950,7 +1038,56 @@ func (v *array) Copy(frozen bool) dgo.Array {
 			}
 		}
 	}
-	return &array{slice: cp, frozen: frozen}
+	return &array{slice: cp, elementType: v.elementType, frozen: frozen}
+}
+
+func (v *array) CryptoShuffle() (dgo.Array, error) {
+	vs := util.SliceCopy(v.slice)
+	for i := len(vs) - 1; i > 0; i-- {
+		n, err := crand.Int(crand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		j := int(n.Int64())
+		vs[i], vs[j] = vs[j], vs[i]
+	}
+	return &array{slice: vs, elementType: v.elementType, frozen: v.frozen}, nil
+}
+
+func (v *array) CartesianProduct() dgo.Array {
+	s := v.slice
+	factors := make([]dgo.Array, len(s))
+	ts := make([]dgo.Type, len(s))
+	n := 1
+	for i := range s {
+		f, ok := s[i].(dgo.Array)
+		if !ok {
+			panic(IllegalAssignment(DefaultArrayType, s[i]))
+		}
+		factors[i] = f
+		ts[i] = elementTypeOf(f)
+		n *= f.Len()
+	}
+	pt := tupleTypeOf(ts)
+
+	// Each product is produced by incrementing the indexes like an odometer, rightmost index first
+	ps := make([]dgo.Value, n)
+	ix := make([]int, len(factors))
+	for pi := range ps {
+		p := make([]dgo.Value, len(factors))
+		for i, f := range factors {
+			p[i] = f.Get(ix[i])
+		}
+		ps[pi] = &array{slice: p, frozen: v.frozen}
+		for i := len(ix) - 1; i >= 0; i-- {
+			ix[i]++
+			if ix[i] < factors[i].Len() {
+				break
+			}
+			ix[i] = 0
+		}
+	}
+	return &array{slice: ps, elementType: pt, frozen: v.frozen}
 }
 
 func (v *array) ContainsAll(other dgo.Iterable) bool {
@@ -996,6 +1133,21 @@ func (v *array) deepContainsAll(seen []dgo.Value, other dgo.Iterable) bool {
 	return true
 }
 
+// detach gives this array a slice of its own when its slice is shared with a frozen copy. It must be called before
+// the slice is modified.
+func (v *array) detach() {
+	if v.sharedCopy() != nil {
+		v.shared.Store((*array)(nil))
+		v.slice = util.SliceCopy(v.slice)
+	}
+}
+
+// sharedCopy returns the frozen copy that shares the slice of this array or nil when no such copy exists
+func (v *array) sharedCopy() *array {
+	s, _ := v.shared.Load().(*array)
+	return s
+}
+
 func (v *array) Each(actor dgo.Consumer) {
 	a := v.slice
 	for i := range a {
@@ -1015,8 +1167,11 @@ func (v *array) Equals(other interface{}) bool {
 }
 
 func (v *array) deepEqual(seen []dgo.Value, other deepEqual) bool {
-	if ov, ok := other.(*array); ok {
+	switch ov := other.(type) {
+	case *array:
 		return sliceEquals(seen, v.slice, ov.slice)
+	case *circularArray:
+		return sliceEquals(seen, v.slice, ov.values())
 	}
 	return false
 }
@@ -1068,6 +1223,17 @@ func (v *array) Freeze() {
 	}
 }
 
+// elementsFrozen returns true when all elements of this array are frozen or immutable
+func (v *array) elementsFrozen() bool {
+	a := v.slice
+	for i := range a {
+		if f, ok := a[i].(dgo.Freezable); ok && !f.Frozen() {
+			return false
+		}
+	}
+	return true
+}
+
 func (v *array) Frozen() bool {
 	return v.frozen
 }
@@ -1084,9 +1250,57 @@ func (v *array) GoSlice() []dgo.Value {
 	if v.frozen {
 		return util.SliceCopy(v.slice)
 	}
+	// The caller may modify the returned slice so it must not be shared with a frozen copy
+	v.detach()
 	return v.slice
 }
 
+func (v *array) GroupBy(keyMapper dgo.Mapper) dgo.Map {
+	a := v.slice
+	m := MapWithCapacity(0).(*hashMap)
+	for i := range a {
+		e := a[i]
+		k := Value(keyMapper(e))
+		if g, ok := m.Get(k).(*array); ok {
+			g.slice = append(g.slice, e)
+		} else {
+			m.Put(k, &array{slice: []dgo.Value{e}})
+		}
+	}
+	if v.frozen {
+		for e := m.first; e != nil; e = e.next {
+			e.value.(*array).frozen = true
+		}
+		m.frozen = true
+	}
+	return m
+}
+
+func (v *array) GroupConsecutive(predicate dgo.Predicate, dropOthers bool) dgo.Array {
+	groups := make([]dgo.Value, 0)
+	var run []dgo.Value
+	flush := func() {
+		if run != nil {
+			groups = append(groups, &array{slice: run, frozen: v.frozen})
+			run = nil
+		}
+	}
+	a := v.slice
+	for i := range a {
+		e := a[i]
+		if predicate(e) {
+			run = append(run, e)
+			continue
+		}
+		flush()
+		if !dropOthers {
+			groups = append(groups, &array{slice: []dgo.Value{e}, frozen: v.frozen})
+		}
+	}
+	flush()
+	return &array{slice: groups, frozen: v.frozen}
+}
+
 func (v *array) HashCode() int {
 	return v.deepHashCode(nil)
 }
@@ -1100,10 +1314,73 @@ func (v *array) deepHashCode(seen []dgo.Value) int {
 	return h
 }
 
+func (v *array) Chunks(size int) dgo.Array {
+	cs := make([]dgo.Value, 0)
+	v.ForEachChunk(size, func(c dgo.Array) { cs = append(cs, c) })
+	return &array{slice: cs, frozen: v.frozen}
+}
+
+func (v *array) ForEachChunk(size int, actor func(chunk dgo.Array)) {
+	if size < 1 {
+		panic(fmt.Errorf(`illegal chunk size %d`, size))
+	}
+	l := len(v.slice)
+	for i := 0; i < l; i += size {
+		j := i + size
+		if j > l {
+			j = l
+		}
+		actor(v.Slice(i, j))
+	}
+}
+
 func (v *array) Get(index int) dgo.Value {
 	return v.slice[index]
 }
 
+// at returns the element at the given position or nil if the position is out of bounds
+func (v *array) at(pos int) dgo.Value {
+	if pos >= 0 && pos < len(v.slice) {
+		return v.slice[pos]
+	}
+	return nil
+}
+
+func (v *array) GetArray(pos int) (dgo.Array, bool) {
+	return asArray(v.at(pos))
+}
+
+func (v *array) GetBool(pos int) (bool, bool) {
+	return asGoBool(v.at(pos))
+}
+
+func (v *array) GetFloat(pos int) (float64, bool) {
+	return asGoFloat(v.at(pos))
+}
+
+func (v *array) GetInt(pos int) (int64, bool) {
+	return asGoInt(v.at(pos))
+}
+
+func (v *array) GetMap(pos int) (dgo.Map, bool) {
+	return asMap(v.at(pos))
+}
+
+func (v *array) GetString(pos int) (string, bool) {
+	return asGoString(v.at(pos))
+}
+
+func (v *array) IndexBy(keyMapper dgo.Mapper) dgo.Map {
+	a := v.slice
+	m := MapWithCapacity(len(a)).(*hashMap)
+	for i := range a {
+		e := a[i]
+		m.Put(keyMapper(e), e)
+	}
+	m.frozen = v.frozen
+	return m
+}
+
 func (v *array) IndexOf(vi interface{}) int {
 	val := Value(vi)
 	a := v.slice
@@ -1119,7 +1396,21 @@ func (v *array) Insert(pos int, vi interface{}) {
 	if v.frozen {
 		panic(frozenArray(`Insert`))
 	}
-	v.slice = append(v.slice[:pos], append([]dgo.Value{Value(vi)}, v.slice[pos:]...)...)
+	v.detach()
+	e := Value(vi)
+	v.assertElement(e)
+	v.slice = append(v.slice[:pos], append([]dgo.Value{e}, v.slice[pos:]...)...)
+}
+
+func (v *array) InsertSorted(vi interface{}) int {
+	if v.frozen {
+		panic(frozenArray(`InsertSorted`))
+	}
+	e := Value(vi)
+	a := v.slice
+	pos := sort.Search(len(a), func(i int) bool { return naturalLess(e, a[i]) })
+	v.Insert(pos, e)
+	return pos
 }
 
 // InterfaceSlice returns the values held by the Array as a slice. The slice will
@@ -1146,6 +1437,116 @@ func (v *array) Map(mapper dgo.Mapper) dgo.Array {
 	return &array{slice: vs, frozen: v.frozen}
 }
 
+func (v *array) PEach(n int, actor dgo.Consumer) {
+	a := v.slice
+	parallel(n, len(a), func(i int) { actor(a[i]) })
+}
+
+func (v *array) PMap(n int, mapper dgo.Mapper) dgo.Array {
+	a := v.slice
+	vs := make([]dgo.Value, len(a))
+	parallel(n, len(a), func(i int) { vs[i] = Value(mapper(a[i])) })
+	return &array{slice: vs, frozen: v.frozen}
+}
+
+func (v *array) Partition(predicate dgo.Predicate) (dgo.Array, dgo.Array) {
+	in := make([]dgo.Value, 0)
+	out := make([]dgo.Value, 0)
+	a := v.slice
+	for i := range a {
+		e := a[i]
+		if predicate(e) {
+			in = append(in, e)
+		} else {
+			out = append(out, e)
+		}
+	}
+	return &array{slice: in, frozen: v.frozen}, &array{slice: out, frozen: v.frozen}
+}
+
+// elementTypeOf returns the type that constrains the elements of the given array or nil if they are unconstrained
+func elementTypeOf(a dgo.Array) dgo.Type {
+	switch a := a.(type) {
+	case *array:
+		return a.elementType
+	case *circularArray:
+		return constraint(a.typ)
+	}
+	return nil
+}
+
+// sliceOf returns the elements of the given array in a slice that must not be modified
+func sliceOf(a dgo.Array) []dgo.Value {
+	switch a := a.(type) {
+	case *array:
+		return a.slice
+	case *arguments:
+		return a.slice
+	}
+	return a.GoSlice()
+}
+
+// constraint returns nil if the given type is the default any type. Otherwise it returns the type.
+func constraint(t dgo.Type) dgo.Type {
+	if t == DefaultAnyType {
+		return nil
+	}
+	return t
+}
+
+// tupleTypeOf returns a tuple type of the given element constraints where nil means any, or nil when all elements
+// are unconstrained
+func tupleTypeOf(ts []dgo.Type) dgo.Type {
+	tt := make([]interface{}, len(ts))
+	typed := false
+	for i, t := range ts {
+		if t == nil {
+			t = DefaultAnyType
+		} else {
+			typed = true
+		}
+		tt[i] = t
+	}
+	if !typed {
+		return nil
+	}
+	return newTupleType(tt, false)
+}
+
+// parallel calls f once for each index from 0 to l - 1 using at most n goroutines. When f panics, no further calls
+// are made and the first panic is repeated once all goroutines have finished.
+func parallel(n, l int, f func(int)) {
+	if n < 1 {
+		panic(fmt.Errorf(`illegal number of workers %d`, n))
+	}
+	if n > l {
+		n = l
+	}
+	next := int64(-1)
+	var failure interface{}
+	var once sync.Once
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for w := 0; w < n; w++ {
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { failure = r })
+					atomic.StoreInt64(&next, int64(l))
+				}
+			}()
+			for i := int(atomic.AddInt64(&next, 1)); i < l; i = int(atomic.AddInt64(&next, 1)) {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if failure != nil {
+		panic(failure)
+	}
+}
+
 func (v *array) One(predicate dgo.Predicate) bool {
 	a := v.slice
 	f := false
@@ -1169,6 +1570,19 @@ func (v *array) Reduce(mi interface{}, reductor func(memo dgo.Value, elem dgo.Va
 	return memo
 }
 
+func (v *array) ReduceUntil(mi interface{}, reductor func(memo dgo.Value, elem dgo.Value) (dgo.Value, bool)) dgo.Value {
+	memo := Value(mi)
+	a := v.slice
+	for i := range a {
+		m, done := reductor(memo, a[i])
+		memo = Value(m)
+		if done {
+			break
+		}
+	}
+	return memo
+}
+
 func (v *array) ReflectTo(value reflect.Value) {
 	vt := value.Type()
 	ptr := vt.Kind() == reflect.Ptr
@@ -1178,11 +1592,12 @@ func (v *array) ReflectTo(value reflect.Value) {
 	if vt.Kind() == reflect.Interface && vt.Name() == `` {
 		vt = v.Type().ReflectType()
 	}
-	a := v.slice
 	var s reflect.Value
 	if !v.frozen && vt.Elem() == reflectValueType {
-		s = reflect.ValueOf(a)
+		v.detach()
+		s = reflect.ValueOf(v.slice)
 	} else {
+		a := v.slice
 		l := len(a)
 		s = reflect.MakeSlice(vt, l, l)
 		for i := range a {
@@ -1215,6 +1630,7 @@ func (v *array) Remove(pos int) dgo.Value {
 	if v.frozen {
 		panic(frozenArray(`Remove`))
 	}
+	v.detach()
 	return v.removePos(pos)
 }
 
@@ -1222,10 +1638,12 @@ func (v *array) RemoveValue(value interface{}) bool {
 	if v.frozen {
 		panic(frozenArray(`RemoveValue`))
 	}
+	v.detach()
 	return v.removePos(v.IndexOf(value)) != nil
 }
 
 func (v *array) Resolve(ap dgo.AliasAdder) {
+	v.detach()
 	a := v.slice
 	for i := range a {
 		a[i] = ap.Replace(a[i])
@@ -1248,6 +1666,98 @@ func (v *array) SameValues(other dgo.Iterable) bool {
 	return len(v.slice) == other.Len() && v.ContainsAll(other)
 }
 
+func (v *array) SetEquals(other dgo.Iterable) bool {
+	if len(v.slice) != other.Len() {
+		return false
+	}
+	var vs []dgo.Value
+	if oa, ok := other.(*array); ok {
+		vs = oa.slice
+	} else {
+		vs = sliceFromIterable(other)
+	}
+	return bagEquals(v.slice, vs)
+}
+
+// hashedValue is a value together with its hash code
+type hashedValue struct {
+	h int
+	v dgo.Value
+}
+
+func sortedByHash(vs []dgo.Value) []hashedValue {
+	hs := make([]hashedValue, len(vs))
+	for i := range vs {
+		v := vs[i]
+		hs[i] = hashedValue{h: v.HashCode(), v: v}
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].h < hs[j].h })
+	return hs
+}
+
+// bagEquals returns true if the two slices contain the same values the same number of times. Both slices are sorted
+// by hash code and then compared one run of equal hash codes at a time, so only values with equal hash codes are
+// compared for equality.
+func bagEquals(a, b []dgo.Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ha := sortedByHash(a)
+	hb := sortedByHash(b)
+	for s := 0; s < len(ha); {
+		h := ha[s].h
+		e := s + 1
+		for e < len(ha) && ha[e].h == h {
+			e++
+		}
+		// the run in b must start and end at the same positions as the run in a
+		if hb[s].h != h || hb[e-1].h != h || e < len(hb) && hb[e].h == h || !runEquals(ha[s:e], hb[s:e]) {
+			return false
+		}
+		s = e
+	}
+	return true
+}
+
+// runEquals returns true if a and b, that have the same length, contain the same values the same number of times.
+func runEquals(a, b []hashedValue) bool {
+	used := make([]bool, len(b))
+	for i := range a {
+		f := false
+		for j := range b {
+			if !used[j] && a[i].v.Equals(b[j].v) {
+				used[j] = true
+				f = true
+				break
+			}
+		}
+		if !f {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *array) Sample(n int, rnd interface{ Intn(int) int }) dgo.Array {
+	l := len(v.slice)
+	if n < 0 || n > l {
+		panic(fmt.Errorf(`illegal sample size %d for an array of size %d`, n, l))
+	}
+	// partial Fisher-Yates shuffle of a copy where only the first n positions are shuffled
+	vs := util.SliceCopy(v.slice)
+	for i := 0; i < n; i++ {
+		j := i + rnd.Intn(l-i)
+		vs[i], vs[j] = vs[j], vs[i]
+	}
+	return &array{slice: vs[:n:n], frozen: v.frozen}
+}
+
+func (v *array) SearchSorted(vi interface{}) int {
+	e := Value(vi)
+	a := v.slice
+	return sort.Search(len(a), func(i int) bool { return !naturalLess(a[i], e) })
+}
+
 func (v *array) Select(predicate dgo.Predicate) dgo.Array {
 	vs := make([]dgo.Value, 0)
 	a := v.slice
@@ -1264,8 +1774,11 @@ func (v *array) Set(pos int, vi interface{}) dgo.Value {
 	if v.frozen {
 		panic(frozenArray(`Set`))
 	}
+	v.detach()
+	e := Value(vi)
+	v.assertElement(e)
 	old := v.slice[pos]
-	v.slice[pos] = Value(vi)
+	v.slice[pos] = e
 	return old
 }
 
@@ -1281,26 +1794,92 @@ func (v *array) Slice(i, j int) dgo.Array {
 	return &array{slice: ss, frozen: v.frozen}
 }
 
+func (v *array) SlidingWindow(size, step int) dgo.Array {
+	if size < 1 {
+		panic(fmt.Errorf(`illegal window size %d`, size))
+	}
+	if step < 1 {
+		panic(fmt.Errorf(`illegal window step %d`, step))
+	}
+	ws := make([]dgo.Value, 0)
+	for i, l := 0, len(v.slice); i+size <= l; i += step {
+		ws = append(ws, v.Slice(i, i+size))
+	}
+	return &array{slice: ws, frozen: v.frozen}
+}
+
 func (v *array) Sort() dgo.Array {
 	sa := v.slice
-	if len(sa) < 2 {
+	if len(sa) < 2 && v.frozen {
 		return v
 	}
 	sorted := util.SliceCopy(sa)
-	sort.SliceStable(sorted, func(i, j int) bool {
-		a := sorted[i]
-		b := sorted[j]
-		if ac, ok := a.(dgo.Comparable); ok {
-			var c int
-			if c, ok = ac.CompareTo(b); ok {
-				return c < 0
-			}
-		}
-		return a.Type().TypeIdentifier() < b.Type().TypeIdentifier()
-	})
+	sort.SliceStable(sorted, func(i, j int) bool { return naturalLess(sorted[i], sorted[j]) })
+	return &array{slice: sorted, frozen: v.frozen}
+}
+
+func (v *array) SortBy(compare func(a, b dgo.Value) int) dgo.Array {
+	sa := v.slice
+	if len(sa) < 2 && v.frozen {
+		return v
+	}
+	sorted := util.SliceCopy(sa)
+	sort.SliceStable(sorted, func(i, j int) bool { return compare(sorted[i], sorted[j]) < 0 })
+	return &array{slice: sorted, frozen: v.frozen}
+}
+
+func (v *array) SortByKey(keyMapper dgo.Mapper, descending bool) dgo.Array {
+	return v.sortBy(keyMapper, sort.SliceStable, descending)
+}
+
+func (v *array) SortStableBy(keyMapper dgo.Mapper) dgo.Array {
+	return v.sortBy(keyMapper, sort.SliceStable, false)
+}
+
+func (v *array) SortUnstableBy(keyMapper dgo.Mapper) dgo.Array {
+	return v.sortBy(keyMapper, sort.Slice, false)
+}
+
+// sortBy computes the key of each element once and then sorts the elements by the natural order of those keys
+// using the given sort function. The order is reversed when descending is true.
+func (v *array) sortBy(keyMapper dgo.Mapper, sorter func(interface{}, func(int, int) bool), descending bool) dgo.Array {
+	sa := v.slice
+	if len(sa) < 2 && v.frozen {
+		return v
+	}
+	type keyed struct {
+		key dgo.Value
+		val dgo.Value
+	}
+	ks := make([]keyed, len(sa))
+	for i := range sa {
+		e := sa[i]
+		ks[i] = keyed{key: Value(keyMapper(e)), val: e}
+	}
+	if descending {
+		sorter(ks, func(i, j int) bool { return naturalLess(ks[j].key, ks[i].key) })
+	} else {
+		sorter(ks, func(i, j int) bool { return naturalLess(ks[i].key, ks[j].key) })
+	}
+	sorted := make([]dgo.Value, len(ks))
+	for i := range ks {
+		sorted[i] = ks[i].val
+	}
 	return &array{slice: sorted, frozen: v.frozen}
 }
 
+// naturalLess returns true if a is less than b using their natural order. Values that are not
+// comparable are ordered by their TypeIdentifier.
+func naturalLess(a, b dgo.Value) bool {
+	if ac, ok := a.(dgo.Comparable); ok {
+		var c int
+		if c, ok = ac.CompareTo(b); ok {
+			return c < 0
+		}
+	}
+	return a.Type().TypeIdentifier() < b.Type().TypeIdentifier()
+}
+
 func (v *array) String() string {
 	return util.ToStringERP(v)
 }
@@ -1375,6 +1954,64 @@ func (v *array) ToMapFromEntries() (dgo.Map, bool) {
 	return m, true
 }
 
+func (v *array) Tee(n int) []dgo.Array {
+	if n < 0 {
+		panic(fmt.Errorf(`illegal tee count %d`, n))
+	}
+	as := make([]dgo.Array, n)
+	for i := range as {
+		if v.frozen {
+			as[i] = &array{slice: v.slice, elementType: v.elementType, frozen: true}
+		} else {
+			as[i] = v.Copy(false)
+		}
+	}
+	return as
+}
+
+func (v *array) ToSortedMap() dgo.Map {
+	ms := v.slice
+	top := len(ms)
+	es := make([]mapEntry, 0, (top+1)/2)
+	for i := 0; i < top; {
+		mk := ms[i]
+		i++
+		var mv dgo.Value = Nil
+		if i < top {
+			mv = ms[i]
+			i++
+		}
+		es = append(es, mapEntry{key: mk, value: mv})
+	}
+	sort.SliceStable(es, func(i, j int) bool { return naturalLess(es[i].key, es[j].key) })
+
+	tbl := make([]*hashNode, tableSizeFor(len(es)))
+	hl := len(tbl) - 1
+	m := &hashMap{table: tbl, frozen: v.frozen}
+
+nextEntry:
+	for i := range es {
+		e := es[i]
+		hk := hl & hash(e.key.HashCode())
+		for nd := tbl[hk]; nd != nil; nd = nd.hashNext {
+			if e.key.Equals(nd.key) {
+				nd.value = e.value
+				continue nextEntry
+			}
+		}
+		nd := &hashNode{mapEntry: e, hashNext: tbl[hk], prev: m.last}
+		if m.first == nil {
+			m.first = nd
+		} else {
+			m.last.next = nd
+		}
+		m.last = nd
+		tbl[hk] = nd
+		m.len++
+	}
+	return m
+}
+
 func (v *array) Type() dgo.Type {
 	ea := &exactArrayType{value: v}
 	ea.ExactType = ea
@@ -1411,10 +2048,32 @@ nextVal:
 	return &array{slice: u[:ui], frozen: v.frozen}
 }
 
+func (v *array) Unzip() (dgo.Array, dgo.Array) {
+	a := v.slice
+	firsts := make([]dgo.Value, len(a))
+	seconds := make([]dgo.Value, len(a))
+	for i := range a {
+		p, ok := a[i].(dgo.Array)
+		if !ok || p.Len() != 2 {
+			panic(IllegalAssignment(newTupleType([]interface{}{DefaultAnyType, DefaultAnyType}, false), a[i]))
+		}
+		firsts[i] = p.Get(0)
+		seconds[i] = p.Get(1)
+	}
+	var ft, st dgo.Type
+	if tt, ok := v.elementType.(dgo.TupleType); ok && !tt.Variadic() && tt.Len() == 2 {
+		ft = constraint(tt.Element(0))
+		st = constraint(tt.Element(1))
+	}
+	return &array{slice: firsts, elementType: ft, frozen: v.frozen},
+		&array{slice: seconds, elementType: st, frozen: v.frozen}
+}
+
 func (v *array) Pop() (dgo.Value, bool) {
 	if v.frozen {
 		panic(frozenArray(`Pop`))
 	}
+	v.detach()
 	p := len(v.slice) - 1
 	if p >= 0 {
 		return v.removePos(p), true
@@ -1423,7 +2082,9 @@ func (v *array) Pop() (dgo.Value, bool) {
 }
 
 func (v *array) With(vi interface{}) dgo.Array {
-	return &array{slice: append(v.slice, Value(vi)), frozen: v.frozen}
+	e := Value(vi)
+	v.assertElement(e)
+	return &array{slice: append(v.sliceForAppend(1), e), elementType: v.elementType, frozen: v.frozen}
 }
 
 func (v *array) WithAll(values dgo.Iterable) dgo.Array {
@@ -1443,7 +2104,44 @@ func (v *array) WithValues(values ...interface{}) dgo.Array {
 	if len(values) == 0 {
 		return v
 	}
-	return &array{slice: append(v.slice, valueSlice(values, v.frozen)...), frozen: v.frozen}
+	vs := valueSlice(values, v.frozen)
+	v.assertElements(vs)
+	return &array{slice: append(v.sliceForAppend(len(vs)), vs...), elementType: v.elementType, frozen: v.frozen}
+}
+
+// sliceForAppend returns a copy of the slice of the receiver that has room for n more elements. Appending directly to
+// the slice of the receiver isn't safe since its spare capacity may be shared with arrays that were created earlier.
+func (v *array) sliceForAppend(n int) []dgo.Value {
+	a := v.slice
+	c := make([]dgo.Value, len(a), len(a)+n)
+	copy(c, a)
+	return c
+}
+
+func (v *array) Zip(other dgo.Array) dgo.Array {
+	a := v.slice
+	if other.Len() != len(a) {
+		panic(fmt.Errorf(`the size %d of the given array is not equal to the size %d of this array`, other.Len(), len(a)))
+	}
+	frozen := v.frozen && other.Frozen()
+	ps := make([]dgo.Value, len(a))
+	other.EachWithIndex(func(e dgo.Value, i int) {
+		ps[i] = &array{slice: []dgo.Value{a[i], e}, frozen: frozen}
+	})
+	return &array{slice: ps, elementType: tupleTypeOf([]dgo.Type{v.elementType, elementTypeOf(other)}), frozen: frozen}
+}
+
+func (v *array) ZipToMap(keys dgo.Array) dgo.Map {
+	a := v.slice
+	if keys.Len() != len(a) {
+		panic(fmt.Errorf(`the number of keys %d is not equal to the number of values %d`, keys.Len(), len(a)))
+	}
+	m := MapWithCapacity(len(a)).(*hashMap)
+	keys.EachWithIndex(func(k dgo.Value, i int) {
+		m.Put(k, a[i])
+	})
+	m.frozen = v.frozen && keys.Frozen()
+	return m
 }
 
 // ReplaceNil performs an in-place replacement of nil interfaces with the NilValue
@@ -1469,7 +2167,7 @@ func allInstance(guard dgo.RecursionGuard, t dgo.Type, vs []dgo.Value) bool {
 }
 
 func frozenArray(f string) error {
-	return fmt.Errorf(`%s called on a frozen Array`, f)
+	return &frozenError{function: f, kind: `Array`}
 }
 
 func resolveSlice(ts []dgo.Value, ap dgo.AliasAdder) {
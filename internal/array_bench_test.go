@@ -133,3 +133,70 @@ func Test_ArrayEquality(t *testing.T) {
 		t.Error(`== on array isn't true for same object`)
 	}
 }
+
+// BenchmarkArray_FrozenCopy measures a frozen copy of an array with immutable elements. The copy shares the slice of
+// the array, so its cost does not depend on the number of elements.
+func BenchmarkArray_FrozenCopy(b *testing.B) {
+	a := ArrayWithCapacity(elemCount)
+	for i := 0; i < elemCount; i++ {
+		a.Add(intVal(i))
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		a.Copy(true)
+	}
+}
+
+// BenchmarkMap_FrozenCopy measures a frozen copy of a map with immutable values. The copy shares the table and the
+// nodes of the map, so its cost does not depend on the number of entries.
+func BenchmarkMap_FrozenCopy(b *testing.B) {
+	m := MapWithCapacity(elemCount)
+	for i := 0; i < elemCount; i++ {
+		m.Put(intVal(i), intVal(i))
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m.Copy(true)
+	}
+}
+
+func evenInt(v dgo.Value) bool {
+	return v.(intVal)%2 == 0
+}
+
+func doubleInt(v dgo.Value) interface{} {
+	return v.(intVal) * 2
+}
+
+// BenchmarkArray_SelectMap chains Select and Map, which allocates an intermediate array
+func BenchmarkArray_SelectMap(b *testing.B) {
+	s := make([]dgo.Value, elemCount)
+	for i := 0; i < elemCount; i++ {
+		s[i] = intVal(i)
+	}
+	a := &array{slice: s, frozen: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		a.Select(evenInt).Map(doubleInt)
+	}
+}
+
+// BenchmarkSeq_FilterMap performs the same operation as BenchmarkArray_SelectMap using a lazy Seq, which only
+// allocates the resulting array
+func BenchmarkSeq_FilterMap(b *testing.B) {
+	s := make([]dgo.Value, elemCount)
+	for i := 0; i < elemCount; i++ {
+		s[i] = intVal(i)
+	}
+	a := &array{slice: s, frozen: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		a.Seq().Filter(evenInt).Map(doubleInt).Into(ArrayWithCapacity(elemCount / 2))
+	}
+}
@@ -1,8 +1,12 @@
 package internal_test
 
 import (
+	"errors"
 	"math"
+	"math/rand"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/lyraproj/dgo/internal"
@@ -426,6 +430,66 @@ func TestArray_FrozenCopy(t *testing.T) {
 	require.Panic(t, func() { f.Set(0, 1) }, `Set .* frozen`)
 }
 
+func TestArray_FrozenCopy_shared(t *testing.T) {
+	a := vf.MutableValues(1, `two`, vf.Values(3))
+	f := a.Copy(true)
+	require.Same(t, f, a.Copy(true))
+	require.Same(t, f, a.FrozenCopy())
+
+	a.Set(0, 10)
+	a.Add(4)
+	require.Equal(t, vf.Values(1, `two`, vf.Values(3)), f)
+	require.Equal(t, vf.Values(10, `two`, vf.Values(3), 4), a)
+	require.NotSame(t, f, a.Copy(true))
+
+	f = a.Copy(true)
+	a.Remove(0)
+	a.Insert(0, 0)
+	require.Equal(t, vf.Values(10, `two`, vf.Values(3), 4), f)
+	require.Equal(t, vf.Values(0, `two`, vf.Values(3), 4), a)
+
+	m := vf.MutableValues(vf.MutableValues(1))
+	f = m.Copy(true)
+	m.Get(0).(dgo.Array).Add(2)
+	require.Equal(t, vf.Values(vf.Values(1)), f)
+}
+
+func TestArray_FrozenCopy_goSlice(t *testing.T) {
+	a := vf.MutableValues(3, 1, 2)
+	f := a.FrozenCopy()
+	a.GoSlice()[0] = vf.Integer(99)
+	require.Equal(t, vf.Values(3, 1, 2), f)
+	require.Equal(t, vf.Values(99, 1, 2), a)
+
+	f = a.FrozenCopy()
+	var s []dgo.Value
+	a.ReflectTo(reflect.ValueOf(&s).Elem())
+	s[0] = vf.Integer(3)
+	require.Equal(t, vf.Values(99, 1, 2), f)
+	require.Equal(t, vf.Values(3, 1, 2), a)
+}
+
+func TestArray_FrozenCopy_concurrent(t *testing.T) {
+	a := vf.MutableValues(1, `two`, 3)
+	cs := make([]dgo.Value, 8)
+	var wg sync.WaitGroup
+	for i := range cs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cs[i] = a.FrozenCopy()
+		}(i)
+	}
+	wg.Wait()
+	for i := range cs {
+		require.Equal(t, vf.Values(1, `two`, 3), cs[i])
+	}
+	a.Set(0, 10)
+	for i := range cs {
+		require.Equal(t, vf.Values(1, `two`, 3), cs[i])
+	}
+}
+
 func TestArray_selfReference(t *testing.T) {
 	internal.ResetDefaultAliases()
 	tp := tf.ParseType(`x=[](string|x)`).(dgo.ArrayType)
@@ -831,6 +895,27 @@ func TestArray_Reduce(t *testing.T) {
 	}))
 }
 
+func TestArray_ReduceUntil(t *testing.T) {
+	a := vf.Integers(1, 2, 3, 4)
+	var visited []int64
+	require.Equal(t, 6, a.ReduceUntil(0, func(memo, v dgo.Value) (dgo.Value, bool) {
+		visited = append(visited, v.(dgo.Integer).GoInt())
+		sum := memo.(dgo.Integer).GoInt() + v.(dgo.Integer).GoInt()
+		return vf.Integer(sum), sum > 5
+	}))
+	require.Equal(t, []int64{1, 2, 3}, visited)
+
+	require.Equal(t, 10, a.ReduceUntil(0, func(memo, v dgo.Value) (dgo.Value, bool) {
+		return vf.Integer(memo.(dgo.Integer).GoInt() + v.(dgo.Integer).GoInt()), false
+	}))
+	require.Equal(t, vf.Nil, a.ReduceUntil(0, func(memo, v dgo.Value) (dgo.Value, bool) {
+		return nil, true
+	}))
+	require.Equal(t, `x`, vf.Values().ReduceUntil(`x`, func(memo, v dgo.Value) (dgo.Value, bool) {
+		return v, true
+	}))
+}
+
 func TestArray_ReflectTo(t *testing.T) {
 	var s []string
 	a := vf.Strings(`a`, `b`)
@@ -929,6 +1014,75 @@ func TestArray_SameValues(t *testing.T) {
 	require.False(t, vf.Values(1, 2).SameValues(vf.Values(3, 2, 1)))
 }
 
+func TestArray_CryptoShuffle(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5, 6, 7, 8)
+	s, err := a.CryptoShuffle()
+	require.Nil(t, err)
+	require.True(t, s.Frozen())
+	require.True(t, a.SameValues(s))
+	require.Equal(t, vf.Values(1, 2, 3, 4, 5, 6, 7, 8), a)
+
+	m := vf.MutableValues(1, 2)
+	s, err = m.CryptoShuffle()
+	require.Nil(t, err)
+	require.False(t, s.Frozen())
+	s.Add(3)
+	require.Equal(t, vf.Values(1, 2), m)
+
+	s, err = vf.Values().CryptoShuffle()
+	require.Nil(t, err)
+	require.Equal(t, 0, s.Len())
+
+	s, err = vf.MutableValues(1, 2).Annotate(typ.Integer).CryptoShuffle()
+	require.Nil(t, err)
+	require.Panic(t, func() { s.Add(`x`) }, `cannot be assigned`)
+}
+
+func TestArray_SetEquals(t *testing.T) {
+	a := vf.Values(1, `a`, 1, vf.Values(2), vf.Map(`x`, 3))
+	require.True(t, a.SetEquals(vf.Values(vf.Map(`x`, 3), 1, vf.Values(2), `a`, 1)))
+	require.True(t, a.SetEquals(vf.MutableValues(1, 1, `a`, vf.MutableValues(2), vf.Map(`x`, 3))))
+	require.False(t, a.SetEquals(vf.Values(1, `a`, `a`, vf.Values(2), vf.Map(`x`, 3))))
+	require.False(t, a.SetEquals(vf.Values(1, `a`, 1, vf.Values(2))))
+	require.False(t, vf.Values(1, 1, 2).SetEquals(vf.Values(1, 2, 2)))
+	require.True(t, vf.Values().SetEquals(vf.Values()))
+
+	c := vf.CircularArray(2, nil)
+	c.AddValues(`b`, `a`)
+	require.True(t, vf.Values(`a`, `b`).SetEquals(c))
+	require.True(t, c.SetEquals(vf.Values(`a`, `b`)))
+
+	// values with equal hash codes
+	require.Equal(t, vf.Integer(1).HashCode(), vf.Float(1).HashCode())
+	require.True(t, vf.Values(1, 1.0, 1).SetEquals(vf.Values(1.0, 1, 1)))
+	require.False(t, vf.Values(1, 1.0, 1).SetEquals(vf.Values(1.0, 1, 1.0)))
+}
+
+func TestArray_Sample(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5, 6, 7, 8)
+	s := a.Sample(3, rand.New(rand.NewSource(1)))
+	require.Equal(t, 3, s.Len())
+	require.True(t, s.Frozen())
+	require.True(t, s.All(func(e dgo.Value) bool { return a.IndexOf(e) >= 0 }))
+	require.Equal(t, 3, s.Unique().Len())
+	require.Equal(t, vf.Values(1, 2, 3, 4, 5, 6, 7, 8), a)
+
+	// same seed gives the same sample
+	require.Equal(t, s, a.Sample(3, rand.New(rand.NewSource(1))))
+
+	require.True(t, a.SameValues(a.Sample(8, rand.New(rand.NewSource(2)))))
+	require.Equal(t, 0, a.Sample(0, rand.New(rand.NewSource(2))).Len())
+
+	m := vf.MutableValues(1, 2, 3)
+	s = m.Sample(2, rand.New(rand.NewSource(1)))
+	require.False(t, s.Frozen())
+	s.Add(4)
+	require.Equal(t, vf.Values(1, 2, 3), m)
+
+	require.Panic(t, func() { a.Sample(9, rand.New(rand.NewSource(1))) }, `illegal sample size 9 for an array of size 8`)
+	require.Panic(t, func() { a.Sample(-1, rand.New(rand.NewSource(1))) }, `illegal sample size -1`)
+}
+
 func TestArray_Select(t *testing.T) {
 	require.Equal(t, vf.Values(1, 2, 4, 5), vf.Values(1, 2, vf.Nil, 4, 5).Select(func(e dgo.Value) bool {
 		return e != vf.Nil
@@ -975,6 +1129,188 @@ func TestArray_Sort(t *testing.T) {
 	require.Equal(t, b, vf.Values(-3.14, 4.2, `hello`))
 }
 
+func TestArray_ForEachChunk(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5)
+	var chunks []dgo.Array
+	a.ForEachChunk(2, func(c dgo.Array) { chunks = append(chunks, c) })
+	require.Equal(t, []dgo.Array{vf.Values(1, 2), vf.Values(3, 4), vf.Values(5)}, chunks)
+	require.True(t, chunks[0].Frozen())
+
+	chunks = nil
+	a.ForEachChunk(5, func(c dgo.Array) { chunks = append(chunks, c) })
+	require.Same(t, a, chunks[0])
+
+	m := vf.MutableValues(1, 2, 3)
+	m.ForEachChunk(2, func(c dgo.Array) {
+		require.False(t, c.Frozen())
+		c.Set(0, 0)
+	})
+	require.Equal(t, vf.Values(1, 2, 3), m)
+
+	calls := 0
+	vf.Values().ForEachChunk(1, func(c dgo.Array) { calls++ })
+	require.Equal(t, 0, calls)
+	require.Panic(t, func() { a.ForEachChunk(0, func(c dgo.Array) {}) }, `illegal chunk size 0`)
+}
+
+func TestArray_Chunks(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5)
+	cs := a.Chunks(2)
+	require.Equal(t, vf.Values(vf.Values(1, 2), vf.Values(3, 4), vf.Values(5)), cs)
+	require.True(t, cs.Frozen())
+	require.Same(t, a, a.Chunks(5).Get(0))
+
+	m := vf.MutableValues(1, 2, 3)
+	cs = m.Chunks(2)
+	require.False(t, cs.Frozen())
+	cs.Get(0).(dgo.Array).Set(0, 0)
+	require.Equal(t, vf.Values(1, 2, 3), m)
+
+	require.Equal(t, vf.Values(), vf.Values().Chunks(3))
+	require.Panic(t, func() { a.Chunks(0) }, `illegal chunk size 0`)
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 2, 3, 4)
+	require.Equal(t, vf.Values(vf.Values(2, 3), vf.Values(4)), c.Chunks(2))
+}
+
+func TestArray_SlidingWindow(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5)
+	ws := a.SlidingWindow(3, 1)
+	require.Equal(t, vf.Values(vf.Values(1, 2, 3), vf.Values(2, 3, 4), vf.Values(3, 4, 5)), ws)
+	require.True(t, ws.Frozen())
+	require.Equal(t, vf.Values(vf.Values(1, 2), vf.Values(4, 5)), a.SlidingWindow(2, 3))
+	require.Equal(t, vf.Values(vf.Values(1), vf.Values(3), vf.Values(5)), a.SlidingWindow(1, 2))
+	require.Equal(t, vf.Values(), a.SlidingWindow(6, 1))
+
+	m := vf.MutableValues(1, 2, 3)
+	ws = m.SlidingWindow(2, 1)
+	require.False(t, ws.Frozen())
+	ws.Get(0).(dgo.Array).Set(1, 0)
+	require.Equal(t, vf.Values(1, 2, 3), m)
+	require.Equal(t, vf.Values(2, 3), ws.Get(1))
+
+	require.Panic(t, func() { a.SlidingWindow(0, 1) }, `illegal window size 0`)
+	require.Panic(t, func() { a.SlidingWindow(1, 0) }, `illegal window step 0`)
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 2, 3, 4)
+	require.Equal(t, vf.Values(vf.Values(2, 3), vf.Values(3, 4)), c.SlidingWindow(2, 1))
+}
+
+func TestArray_SortBy(t *testing.T) {
+	a := vf.Strings(`ccc`, `a`, `bb`, `dd`, `e`)
+	calls := 0
+	length := func(v dgo.Value) interface{} {
+		calls++
+		return len(v.String())
+	}
+	b := a.SortStableBy(length)
+	require.Equal(t, vf.Strings(`a`, `e`, `bb`, `dd`, `ccc`), b)
+	require.Equal(t, 5, calls)
+	require.True(t, b.Frozen())
+	require.Equal(t, vf.Strings(`ccc`, `a`, `bb`, `dd`, `e`), a)
+
+	b = a.SortUnstableBy(length)
+	require.Equal(t, vf.Values(1, 1, 2, 2, 3), b.Map(length))
+	require.Equal(t, `ccc`, b.Get(4))
+
+	m := vf.MutableValues(vf.Map(`n`, 2), vf.Map(`n`, 1))
+	b = m.SortStableBy(func(v dgo.Value) interface{} { return v.(dgo.Map).Get(`n`) })
+	require.Equal(t, vf.Values(vf.Map(`n`, 1), vf.Map(`n`, 2)), b)
+	require.False(t, b.Frozen())
+	b.Add(vf.Map(`n`, 3))
+	require.Equal(t, 2, m.Len())
+
+	a = vf.Strings(`the one and only`)
+	require.Same(t, a, a.SortStableBy(length))
+	require.Same(t, a, a.SortUnstableBy(length))
+}
+
+func TestArray_SortBy_compare(t *testing.T) {
+	a := vf.Values(3, 1, 2)
+	b := a.SortBy(func(x, y dgo.Value) int { return int(y.(dgo.Integer).GoInt() - x.(dgo.Integer).GoInt()) })
+	require.Equal(t, vf.Values(3, 2, 1), b)
+	require.True(t, b.Frozen())
+	require.Equal(t, vf.Values(3, 1, 2), a)
+
+	byLen := func(x, y dgo.Value) int { return len(x.String()) - len(y.String()) }
+	require.Equal(t, vf.Strings(`b`, `d`, `aa`, `cc`), vf.Strings(`aa`, `b`, `cc`, `d`).SortBy(byLen))
+
+	m := vf.MutableValues(1)
+	b = m.SortBy(byLen)
+	require.NotSame(t, m, b)
+	require.False(t, b.Frozen())
+	b.Add(2)
+	require.Equal(t, 1, m.Len())
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 3, 2, 4)
+	require.Equal(t, vf.Values(2, 3, 4), c.SortBy(func(x, y dgo.Value) int {
+		return int(x.(dgo.Integer).GoInt() - y.(dgo.Integer).GoInt())
+	}))
+}
+
+func TestArray_SortByKey(t *testing.T) {
+	length := func(v dgo.Value) interface{} { return len(v.String()) }
+	a := vf.Strings(`ccc`, `a`, `bb`, `dd`, `e`)
+	require.Equal(t, vf.Strings(`a`, `e`, `bb`, `dd`, `ccc`), a.SortByKey(length, false))
+	require.Equal(t, vf.Strings(`ccc`, `bb`, `dd`, `a`, `e`), a.SortByKey(length, true))
+	require.True(t, a.SortByKey(length, true).Frozen())
+
+	m := vf.MutableValues(`x`)
+	b := m.SortByKey(length, true)
+	require.NotSame(t, m, b)
+	require.False(t, b.Frozen())
+
+	c := vf.CircularArray(2, nil)
+	c.AddValues(`a`, `bb`, `c`)
+	require.Equal(t, vf.Strings(`bb`, `c`), c.SortByKey(length, true))
+}
+
+func TestArray_SearchSorted(t *testing.T) {
+	a := vf.Values(1, 3, 3, 5, 7)
+	require.Equal(t, 0, a.SearchSorted(0))
+	require.Equal(t, 0, a.SearchSorted(1))
+	require.Equal(t, 1, a.SearchSorted(3))
+	require.Equal(t, 3, a.SearchSorted(4))
+	require.Equal(t, 5, a.SearchSorted(8))
+	require.Equal(t, 0, vf.Values().SearchSorted(1))
+	require.Equal(t, 1, vf.Strings(`a`, `c`).SearchSorted(`b`))
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(9, 2, 4, 6)
+	require.Equal(t, 1, c.SearchSorted(4))
+	require.Equal(t, 3, c.SearchSorted(7))
+}
+
+func TestArray_InsertSorted(t *testing.T) {
+	a := vf.MutableValues()
+	for _, v := range []int{5, 1, 3, 3, 7, 0} {
+		a.InsertSorted(v)
+	}
+	require.Equal(t, vf.Values(0, 1, 3, 3, 5, 7), a)
+	require.Equal(t, 4, a.InsertSorted(3))
+	require.Equal(t, 7, a.InsertSorted(9))
+	require.Equal(t, vf.Values(0, 1, 3, 3, 3, 5, 7, 9), a)
+
+	f := a.Copy(true)
+	require.Panic(t, func() { f.InsertSorted(2) }, `InsertSorted .* frozen`)
+	c := vf.CircularArray(3, nil)
+	c.Freeze()
+	require.Panic(t, func() { c.InsertSorted(2) }, `InsertSorted .* frozen`)
+	require.Panic(t, func() { vf.MutableValues(1).Annotate(typ.Integer).InsertSorted(`x`) }, `cannot be assigned`)
+
+	c = vf.CircularArray(3, nil)
+	require.Equal(t, 0, c.InsertSorted(5))
+	require.Equal(t, 0, c.InsertSorted(2))
+	require.Equal(t, 2, c.InsertSorted(8))
+	require.Equal(t, 1, c.InsertSorted(6))
+	require.Equal(t, vf.Values(5, 6, 8), c)
+	require.Equal(t, -1, c.InsertSorted(1))
+	require.Equal(t, vf.Values(5, 6, 8), c)
+}
+
 func TestArray_ToMap(t *testing.T) {
 	a := vf.Strings(`a`, `b`, `c`, `d`)
 	b := a.ToMap()
@@ -1001,6 +1337,313 @@ func TestArray_ToMapFromEntries(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestArray_CartesianProduct(t *testing.T) {
+	a := vf.Values(vf.Values(`a`, `b`), vf.Values(`c`, `d`))
+	p := a.CartesianProduct()
+	require.Equal(t, vf.Values(
+		vf.Values(`a`, `c`), vf.Values(`a`, `d`), vf.Values(`b`, `c`), vf.Values(`b`, `d`)), p)
+	require.True(t, p.Frozen())
+	require.True(t, p.Get(0).(dgo.Array).Frozen())
+
+	p = vf.Values(vf.Values(1, 2), vf.Values(3), vf.Values(4, 5, 6)).CartesianProduct()
+	require.Equal(t, 6, p.Len())
+	require.Equal(t, vf.Values(2, 3, 4), p.Get(3))
+
+	m := vf.MutableValues(vf.Values(1, 2))
+	p = m.CartesianProduct()
+	require.Equal(t, vf.Values(vf.Values(1), vf.Values(2)), p)
+	require.False(t, p.Frozen())
+	require.False(t, p.Get(0).(dgo.Array).Frozen())
+
+	require.Equal(t, vf.Values(vf.Values()), vf.Values().CartesianProduct())
+	require.Equal(t, vf.Values(), vf.Values(vf.Values(1, 2), vf.Values()).CartesianProduct())
+	require.Panic(t, func() { vf.Values(vf.Values(1), 2).CartesianProduct() }, `the value 2 cannot be assigned`)
+}
+
+func TestArray_CartesianProduct_typed(t *testing.T) {
+	p := vf.MutableValues(vf.MutableValues(1, 2).Annotate(typ.Integer), vf.MutableValues(`a`)).CartesianProduct()
+	p.Add(vf.Values(3, true))
+	require.Panic(t, func() { p.Add(vf.Values(`a`, 1)) }, `cannot be assigned`)
+}
+
+func TestArray_Zip(t *testing.T) {
+	z := vf.Values(1, 2).Zip(vf.Values(`a`, `b`))
+	require.Equal(t, vf.Values(vf.Values(1, `a`), vf.Values(2, `b`)), z)
+	require.True(t, z.Frozen())
+	require.True(t, z.Get(0).(dgo.Array).Frozen())
+
+	z = vf.Values(1).Zip(vf.MutableValues(`a`))
+	require.False(t, z.Frozen())
+	z.Add(vf.Values(true))
+
+	z = vf.MutableValues(1, 2).Annotate(typ.Integer).Zip(vf.Values(`a`, `b`))
+	z.Add(vf.Values(3, true))
+	require.Panic(t, func() { z.Add(vf.Values(`c`, `d`)) }, `cannot be assigned`)
+
+	c := vf.CircularArray(2, typ.String)
+	c.AddValues(`a`, `b`, `c`)
+	z = c.Zip(vf.MutableValues(1, 2).Annotate(typ.Integer))
+	require.Equal(t, vf.Values(vf.Values(`b`, 1), vf.Values(`c`, 2)), z)
+	z.Add(vf.Values(`d`, 3))
+	require.Panic(t, func() { z.Add(vf.Values(`d`, `e`)) }, `cannot be assigned`)
+
+	require.Equal(t, vf.Values(), vf.Values().Zip(vf.Values()))
+	require.Panic(t, func() { vf.Values(1).Zip(vf.Values()) }, `the size 0 of the given array is not equal to the size 1`)
+}
+
+func TestArray_Unzip(t *testing.T) {
+	a, b := vf.Values(vf.Values(1, `a`), vf.Values(2, `b`)).Unzip()
+	require.Equal(t, vf.Values(1, 2), a)
+	require.Equal(t, vf.Values(`a`, `b`), b)
+	require.True(t, a.Frozen())
+	require.True(t, b.Frozen())
+
+	a, b = vf.MutableValues(1, 2).Annotate(typ.Integer).Zip(vf.MutableValues(`a`, `b`).Annotate(typ.String)).Unzip()
+	require.Equal(t, vf.Values(1, 2), a)
+	require.Equal(t, vf.Values(`a`, `b`), b)
+	require.False(t, a.Frozen())
+	a.Add(3)
+	require.Panic(t, func() { a.Add(`c`) }, `cannot be assigned`)
+	b.Add(`c`)
+	require.Panic(t, func() { b.Add(3) }, `cannot be assigned`)
+
+	a, _ = vf.MutableValues(1).Zip(vf.MutableValues(`a`)).Unzip()
+	a.Add(`x`)
+
+	c := vf.CircularArray(2, nil)
+	c.AddValues(vf.Values(1, 2), vf.Values(3, 4))
+	a, b = c.Unzip()
+	require.Equal(t, vf.Values(1, 3), a)
+	require.Equal(t, vf.Values(2, 4), b)
+
+	require.Panic(t, func() { vf.Values(vf.Values(1)).Unzip() }, `the value \{1\} cannot be assigned to a variable of type \{any,any\}`)
+	require.Panic(t, func() { vf.Values(1).Unzip() }, `the value 1 cannot be assigned`)
+}
+
+func TestArray_With_capacity(t *testing.T) {
+	a := vf.ArrayWithCapacity(3).Annotate(typ.Integer)
+	b := a.With(1)
+	c := a.With(2)
+	require.Equal(t, vf.Values(1), b)
+	require.Equal(t, vf.Values(2), c)
+	require.Equal(t, 0, a.Len())
+
+	d := b.WithValues(3, 4)
+	e := b.WithValues(5)
+	require.Equal(t, vf.Values(1, 3, 4), d)
+	require.Equal(t, vf.Values(1, 5), e)
+	require.Panic(t, func() { d.With(`x`) }, `cannot be assigned`)
+
+	// An array has no size constraint of its own. A sized type detects when With grows it beyond the max.
+	tp := tf.Array(typ.Integer, 0, 3)
+	require.Instance(t, tp, d)
+	require.NotInstance(t, tp, d.With(6))
+	require.Panic(t, func() { tp.(dgo.Factory).New(d.With(6)) }, `cannot be assigned`)
+}
+
+func TestArray_Annotate(t *testing.T) {
+	m := vf.MutableValues(1, 2)
+	a := m.Annotate(typ.Integer)
+	a.Add(3)
+	a.AddValues(4, 5)
+	a.AddAll(vf.Values(6))
+	a.Insert(0, 0)
+	a.Set(1, 7)
+	require.Equal(t, vf.Values(0, 7, 2, 3, 4, 5, 6), a)
+	require.Equal(t, vf.Values(1, 2), m)
+
+	require.Panic(t, func() { a.Add(`x`) }, `the string "x" cannot be assigned to a variable of type int`)
+	require.Panic(t, func() { a.AddValues(8, `x`) }, `cannot be assigned`)
+	require.Panic(t, func() { a.AddAll(vf.Values(`x`)) }, `cannot be assigned`)
+	ca := vf.CircularArray(1, nil)
+	ca.Add(`x`)
+	require.Panic(t, func() { a.AddAll(ca) }, `cannot be assigned`)
+	require.Panic(t, func() { a.Insert(0, `x`) }, `cannot be assigned`)
+	require.Panic(t, func() { a.Set(0, `x`) }, `cannot be assigned`)
+	require.Equal(t, vf.Values(0, 7, 2, 3, 4, 5, 6), a)
+
+	c := a.Copy(false)
+	require.Panic(t, func() { c.Add(`x`) }, `cannot be assigned`)
+
+	f := vf.Values(1, 2).Annotate(typ.Integer)
+	require.True(t, f.Frozen())
+	require.Equal(t, vf.Values(1, 2, 3), f.With(3))
+	require.Panic(t, func() { f.With(`x`) }, `cannot be assigned`)
+	require.Panic(t, func() { f.WithValues(`x`) }, `cannot be assigned`)
+	require.Panic(t, func() { f.WithAll(vf.Values(`x`)) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.Values(1, `x`).Annotate(typ.Integer) }, `the string "x" cannot be assigned`)
+}
+
+func TestArray_GroupConsecutive(t *testing.T) {
+	positive := func(e dgo.Value) bool { return e.(dgo.Integer).GoInt() > 0 }
+	a := vf.Values(1, 2, -1, -2, 3, 0, 4, 5, 6)
+	g := a.GroupConsecutive(positive, false)
+	require.Equal(t, vf.Values(
+		vf.Values(1, 2), vf.Values(-1), vf.Values(-2), vf.Values(3), vf.Values(0), vf.Values(4, 5, 6)), g)
+	require.True(t, g.Frozen())
+	require.True(t, g.Get(0).(dgo.Array).Frozen())
+
+	require.Equal(t, vf.Values(vf.Values(1, 2), vf.Values(3), vf.Values(4, 5, 6)), a.GroupConsecutive(positive, true))
+	require.Equal(t, vf.Values(), vf.Values(-1, -2).GroupConsecutive(positive, true))
+	require.Equal(t, vf.Values(), vf.Values().GroupConsecutive(positive, false))
+
+	g = vf.MutableValues(1, -1).GroupConsecutive(positive, false)
+	require.False(t, g.Frozen())
+	require.False(t, g.Get(0).(dgo.Array).Frozen())
+}
+
+// countdown is an Iterable that doesn't know its size
+type countdown int
+
+func (c countdown) Each(actor dgo.Consumer) {
+	for i := int(c); i > 0; i-- {
+		actor(vf.Integer(int64(i)))
+	}
+}
+
+func (c countdown) Equals(other interface{}) bool { return c == other }
+
+func (c countdown) Freeze() {}
+
+func (c countdown) Frozen() bool { return true }
+
+func (c countdown) FrozenCopy() dgo.Value { return c }
+
+func (c countdown) HashCode() int { return int(c) }
+
+func (c countdown) Len() int { return -1 }
+
+func (c countdown) String() string { return `countdown` }
+
+func (c countdown) ThawedCopy() dgo.Value { return c }
+
+func (c countdown) Type() dgo.Type { return typ.Any }
+
+func TestIterableToArray(t *testing.T) {
+	a := vf.IterableToArray(countdown(3))
+	require.Equal(t, vf.Values(3, 2, 1), a)
+	require.True(t, a.Frozen())
+	require.Equal(t, 0, vf.IterableToArray(countdown(0)).Len())
+
+	m := vf.MutableMap(`a`, 1)
+	a = vf.IterableToArray(m)
+	require.Equal(t, vf.Values(internal.NewMapEntry(`a`, 1)), a)
+	require.False(t, a.Frozen())
+
+	a = vf.IterableToArray(vf.Values(1, 2))
+	require.Equal(t, vf.Values(1, 2), a)
+}
+
+func TestArray_IndexBy(t *testing.T) {
+	a := vf.Values(
+		vf.Map(`id`, 1, `name`, `first`),
+		vf.Map(`id`, 2, `name`, `second`),
+		vf.Map(`id`, 1, `name`, `third`))
+	m := a.IndexBy(func(e dgo.Value) interface{} { return e.(dgo.Map).Get(`id`) })
+	require.Equal(t, vf.Map(
+		1, vf.Map(`id`, 1, `name`, `third`),
+		2, vf.Map(`id`, 2, `name`, `second`)), m)
+	require.True(t, m.Frozen())
+
+	m = vf.MutableValues(`a`, `bb`).IndexBy(func(e dgo.Value) interface{} { return len(e.(dgo.String).GoString()) })
+	require.Equal(t, vf.Map(1, `a`, 2, `bb`), m)
+	require.False(t, m.Frozen())
+}
+
+func TestArray_typedGetters(t *testing.T) {
+	a := vf.Values(`hello`, 42, 3.14, true, vf.Map(`a`, 1), vf.Values(1, 2))
+
+	s, ok := a.GetString(0)
+	require.True(t, ok)
+	require.Equal(t, `hello`, s)
+	s, ok = a.GetString(1)
+	require.False(t, ok)
+	require.Equal(t, ``, s)
+
+	i, ok := a.GetInt(1)
+	require.True(t, ok)
+	require.Equal(t, 42, i)
+	i, ok = a.GetInt(2)
+	require.False(t, ok)
+	require.Equal(t, 0, i)
+
+	f, ok := a.GetFloat(2)
+	require.True(t, ok)
+	require.Equal(t, 3.14, f)
+	_, ok = a.GetFloat(1)
+	require.False(t, ok)
+
+	b, ok := a.GetBool(3)
+	require.True(t, ok)
+	require.True(t, b)
+	_, ok = a.GetBool(0)
+	require.False(t, ok)
+
+	m, ok := a.GetMap(4)
+	require.True(t, ok)
+	require.Equal(t, vf.Map(`a`, 1), m)
+	m, ok = a.GetMap(5)
+	require.False(t, ok)
+	require.Nil(t, m)
+
+	sa, ok := a.GetArray(5)
+	require.True(t, ok)
+	require.Equal(t, vf.Values(1, 2), sa)
+	_, ok = a.GetArray(4)
+	require.False(t, ok)
+
+	_, ok = a.GetString(-1)
+	require.False(t, ok)
+	_, ok = a.GetString(6)
+	require.False(t, ok)
+}
+
+func TestArray_Tee(t *testing.T) {
+	a := vf.Values(1, vf.Values(2, 3))
+	ts := a.Tee(3)
+	require.Equal(t, 3, len(ts))
+	for _, c := range ts {
+		require.True(t, c.Frozen())
+		require.Equal(t, a, c)
+		require.Same(t, a.Get(1), c.Get(1))
+	}
+
+	m := vf.MutableValues(1, vf.MutableValues(2, 3))
+	ts = m.Tee(2)
+	ts[0].Add(4)
+	ts[1].Get(1).(dgo.Array).Add(5)
+	require.Equal(t, vf.Values(1, vf.Values(2, 3), 4), ts[0])
+	require.Equal(t, vf.Values(1, vf.Values(2, 3, 5)), ts[1])
+	require.Equal(t, vf.Values(1, vf.Values(2, 3)), m)
+
+	require.Equal(t, 0, len(a.Tee(0)))
+	require.Panic(t, func() { a.Tee(-1) }, `illegal tee count -1`)
+
+	for _, c := range vf.Values(1, 2).Annotate(typ.Integer).Tee(2) {
+		require.Panic(t, func() { c.With(`x`) }, `cannot be assigned`)
+	}
+	for _, c := range vf.MutableValues(1, 2).Annotate(typ.Integer).Tee(2) {
+		require.Panic(t, func() { c.Add(`x`) }, `cannot be assigned`)
+	}
+}
+
+func TestArray_ToSortedMap(t *testing.T) {
+	a := vf.Values(`c`, 3, `a`, 1, `b`, 2)
+	b := a.ToSortedMap()
+	require.True(t, b.Frozen())
+	require.Equal(t, vf.Strings(`a`, `b`, `c`), b.Keys())
+	require.Equal(t, vf.Integers(1, 2, 3), b.Values())
+
+	a = vf.MutableValues(`b`, 1, `a`, 2, `b`, 3, `c`)
+	b = a.ToSortedMap()
+	require.False(t, b.Frozen())
+	require.Equal(t, 3, b.Len())
+	require.Equal(t, vf.Strings(`a`, `b`, `c`), b.Keys())
+	require.Equal(t, vf.Values(2, 3, nil), b.Values())
+
+	require.Equal(t, 0, vf.Values().ToSortedMap().Len())
+}
+
 func TestArray_String(t *testing.T) {
 	require.Equal(t, `{1,"two",3.1,true,nil}`, vf.Values(1, "two", 3.1, true, nil).String())
 }
@@ -1030,3 +1673,103 @@ func TestArray_WithAll(t *testing.T) {
 	require.Equal(t, vf.Values(`a`, internal.NewMapEntry(`c`, `C`), internal.NewMapEntry(`d`, `D`)), c)
 	require.True(t, c.Frozen())
 }
+
+func TestArray_ZipToMap(t *testing.T) {
+	m := vf.Values(1, 2, 3).ZipToMap(vf.Values(`a`, `b`, `c`))
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2, `c`, 3), m)
+	require.True(t, m.Frozen())
+
+	m = vf.MutableValues(1, 2).ZipToMap(vf.Values(`a`, `b`))
+	require.False(t, m.Frozen())
+	m = vf.Values(1, 2).ZipToMap(vf.MutableValues(`a`, `b`))
+	require.False(t, m.Frozen())
+
+	require.Equal(t, vf.Map(`a`, 2), vf.Values(1, 2).ZipToMap(vf.Values(`a`, `a`)))
+	require.Equal(t, vf.Map(), vf.Values().ZipToMap(vf.Values()))
+	require.Panic(t, func() { vf.Values(1, 2).ZipToMap(vf.Values(`a`)) },
+		`the number of keys 1 is not equal to the number of values 2`)
+}
+
+func TestArray_PMap(t *testing.T) {
+	a := vf.MutableValues()
+	for i := 0; i < 1000; i++ {
+		a.Add(i)
+	}
+	a.Freeze()
+	m := a.PMap(4, func(v dgo.Value) interface{} { return v.(dgo.Integer).GoInt() * 2 })
+	require.True(t, m.Frozen())
+	require.Equal(t, a.Map(func(v dgo.Value) interface{} { return v.(dgo.Integer).GoInt() * 2 }), m)
+
+	require.Equal(t, vf.Values(), vf.Values().PMap(4, func(v dgo.Value) interface{} { return v }))
+	require.Equal(t, vf.Values(2, 3), vf.MutableValues(1, 2).PMap(8, func(v dgo.Value) interface{} {
+		return v.(dgo.Integer).GoInt() + 1
+	}))
+	require.Panic(t, func() { a.PMap(0, func(v dgo.Value) interface{} { return v }) }, `illegal number of workers 0`)
+	require.Panic(t, func() {
+		a.PMap(4, func(v dgo.Value) interface{} {
+			if v.Equals(500) {
+				panic(errors.New(`boom`))
+			}
+			return v
+		})
+	}, `boom`)
+}
+
+func TestArray_PEach(t *testing.T) {
+	a := vf.MutableValues()
+	for i := 1; i <= 1000; i++ {
+		a.Add(i)
+	}
+	sum := int64(0)
+	a.PEach(3, func(v dgo.Value) { atomic.AddInt64(&sum, v.(dgo.Integer).GoInt()) })
+	require.Equal(t, 500500, sum)
+	require.Panic(t, func() { a.PEach(-1, func(v dgo.Value) {}) }, `illegal number of workers -1`)
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 2, 3, 4)
+	sum = 0
+	c.PEach(2, func(v dgo.Value) { atomic.AddInt64(&sum, v.(dgo.Integer).GoInt()) })
+	require.Equal(t, 9, sum)
+	require.Equal(t, vf.Values(`2`, `3`, `4`), c.PMap(2, func(v dgo.Value) interface{} { return v.String() }))
+}
+
+func TestArray_GroupBy(t *testing.T) {
+	a := vf.Values(`apple`, `avocado`, `banana`, `blueberry`, `cherry`)
+	g := a.GroupBy(func(v dgo.Value) interface{} { return v.String()[:1] })
+	require.Equal(t, vf.Map(`a`, vf.Values(`apple`, `avocado`), `b`, vf.Values(`banana`, `blueberry`), `c`,
+		vf.Values(`cherry`)), g)
+	require.Equal(t, vf.Values(`a`, `b`, `c`), g.Keys())
+	require.True(t, g.Frozen())
+	require.True(t, g.Get(`a`).(dgo.Array).Frozen())
+
+	g = vf.MutableValues(1, 2, 3).GroupBy(func(v dgo.Value) interface{} { return v.(dgo.Integer).GoInt() % 2 })
+	require.Equal(t, vf.Map(1, vf.Values(1, 3), 0, vf.Values(2)), g)
+	require.False(t, g.Frozen())
+	g.Get(0).(dgo.Array).Add(4)
+	require.Equal(t, vf.Values(2, 4), g.Get(0))
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 2, 3, 4)
+	require.Equal(t, vf.Map(true, vf.Values(2, 4), false, vf.Values(3)),
+		c.GroupBy(func(v dgo.Value) interface{} { return v.(dgo.Integer).GoInt()%2 == 0 }))
+}
+
+func TestArray_Partition(t *testing.T) {
+	even := func(v dgo.Value) bool { return v.(dgo.Integer).GoInt()%2 == 0 }
+	in, out := vf.Values(1, 2, 3, 4, 5).Partition(even)
+	require.Equal(t, vf.Values(2, 4), in)
+	require.Equal(t, vf.Values(1, 3, 5), out)
+	require.True(t, in.Frozen())
+	require.True(t, out.Frozen())
+
+	in, out = vf.MutableValues(1, 3).Partition(even)
+	require.Equal(t, 0, in.Len())
+	require.Equal(t, vf.Values(1, 3), out)
+	require.False(t, in.Frozen())
+
+	c := vf.CircularArray(3, nil)
+	c.AddValues(1, 2, 3, 4)
+	in, out = c.Partition(even)
+	require.Equal(t, vf.Values(2, 4), in)
+	require.Equal(t, vf.Values(3), out)
+}
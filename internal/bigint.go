@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"reflect"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	// bigIntVal is a *big.Int that implements the dgo.BigInt interface. The wrapped value is never mutated.
+	bigIntVal struct {
+		v *big.Int
+	}
+
+	bigIntType int
+
+	exactBigIntType struct {
+		exactType
+		value *bigIntVal
+	}
+)
+
+// DefaultBigIntType is the unconstrained BigInt type. It matches all integers regardless of size.
+const DefaultBigIntType = bigIntType(0)
+
+var reflectBigIntType = reflect.TypeOf(&big.Int{})
+
+var minInt64 = big.NewInt(math.MinInt64)
+var maxInt64 = big.NewInt(math.MaxInt64)
+
+// BigInt returns the given *big.Int as a dgo.BigInt. The given value is copied.
+func BigInt(v *big.Int) dgo.BigInt {
+	return &bigIntVal{new(big.Int).Set(v)}
+}
+
+// BigIntFromString returns the given string as a dgo.BigInt. The string may have a base prefix such as "0x". The
+// function will panic if the given string cannot be parsed.
+func BigIntFromString(s string) dgo.BigInt {
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		panic(fmt.Errorf(`unable to parse '%s' as a big integer`, s))
+	}
+	return &bigIntVal{v}
+}
+
+// IntegerFromBig returns the given *big.Int as a dgo.Integer if it fits into an int64, and as a dgo.BigInt otherwise.
+func IntegerFromBig(v *big.Int) dgo.Value {
+	if v.IsInt64() {
+		return Integer(v.Int64())
+	}
+	return &bigIntVal{v}
+}
+
+// toBig returns the given value as a *big.Int and true, or nil and false if the value isn't an integer. The returned
+// value must not be mutated.
+func toBig(v interface{}) (*big.Int, bool) {
+	switch v := v.(type) {
+	case *bigIntVal:
+		return v.v, true
+	case *big.Int:
+		return v, true
+	}
+	if i, ok := ToInt(v); ok {
+		return big.NewInt(i), true
+	}
+	return nil, false
+}
+
+func integerOperands(name string, a, b interface{}) (*big.Int, *big.Int) {
+	ab, ok := toBig(a)
+	if !ok {
+		panic(illegalArgument(name, `integer`, []interface{}{a, b}, 0))
+	}
+	bb, ok := toBig(b)
+	if !ok {
+		panic(illegalArgument(name, `integer`, []interface{}{a, b}, 1))
+	}
+	return ab, bb
+}
+
+// AddIntegers returns the sum of the given integers. The result is a dgo.Integer when it fits into an int64 and a
+// dgo.BigInt otherwise.
+func AddIntegers(a, b interface{}) dgo.Value {
+	if ai, ok := ToInt(a); ok {
+		if bi, ok := ToInt(b); ok {
+			s := ai + bi
+			// Overflow occurred if both operands have a sign that differs from the sign of the sum
+			if (ai^s)&(bi^s) >= 0 {
+				return Integer(s)
+			}
+		}
+	}
+	ab, bb := integerOperands(`AddIntegers`, a, b)
+	return IntegerFromBig(new(big.Int).Add(ab, bb))
+}
+
+// SubtractIntegers returns the difference between the given integers. The result is a dgo.Integer when it fits into
+// an int64 and a dgo.BigInt otherwise.
+func SubtractIntegers(a, b interface{}) dgo.Value {
+	if ai, ok := ToInt(a); ok {
+		if bi, ok := ToInt(b); ok {
+			d := ai - bi
+			// Overflow occurred if the operands have different signs and the sign of the difference differs from a
+			if (ai^bi)&(ai^d) >= 0 {
+				return Integer(d)
+			}
+		}
+	}
+	ab, bb := integerOperands(`SubtractIntegers`, a, b)
+	return IntegerFromBig(new(big.Int).Sub(ab, bb))
+}
+
+// MultiplyIntegers returns the product of the given integers. The result is a dgo.Integer when it fits into an int64
+// and a dgo.BigInt otherwise.
+func MultiplyIntegers(a, b interface{}) dgo.Value {
+	if ai, ok := ToInt(a); ok {
+		if bi, ok := ToInt(b); ok && ai != math.MinInt64 && bi != math.MinInt64 {
+			hi, lo := bits.Mul64(absInt(ai), absInt(bi))
+			if hi == 0 && lo <= math.MaxInt64 {
+				p := int64(lo)
+				if (ai < 0) != (bi < 0) {
+					p = -p
+				}
+				return Integer(p)
+			}
+		}
+	}
+	ab, bb := integerOperands(`MultiplyIntegers`, a, b)
+	return IntegerFromBig(new(big.Int).Mul(ab, bb))
+}
+
+func absInt(i int64) uint64 {
+	if i < 0 {
+		return uint64(-i)
+	}
+	return uint64(i)
+}
+
+func (t bigIntType) Assignable(other dgo.Type) bool {
+	switch other.(type) {
+	case bigIntType, *exactBigIntType, defaultIntegerType, *exactIntegerType, *integerType:
+		return true
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t bigIntType) Describe() string {
+	return Describe(t)
+}
+
+func (t bigIntType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t bigIntType) HashCode() int {
+	return int(dgo.TiBigInt)
+}
+
+func (t bigIntType) Instance(value interface{}) bool {
+	_, ok := toBig(value)
+	return ok
+}
+
+func (t bigIntType) New(arg dgo.Value) dgo.Value {
+	return newBigInt(t, arg)
+}
+
+func (t bigIntType) ReflectType() reflect.Type {
+	return reflectBigIntType
+}
+
+func (t bigIntType) String() string {
+	return TypeString(t)
+}
+
+func (t bigIntType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t bigIntType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiBigInt
+}
+
+func (t *exactBigIntType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactBigIntType) Generic() dgo.Type {
+	return DefaultBigIntType
+}
+
+func (t *exactBigIntType) New(arg dgo.Value) dgo.Value {
+	return newBigInt(t, arg)
+}
+
+func (t *exactBigIntType) ReflectType() reflect.Type {
+	return reflectBigIntType
+}
+
+func (t *exactBigIntType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiBigIntExact
+}
+
+func newBigInt(t dgo.Type, arg dgo.Value) dgo.BigInt {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`bigint`, 1, 1)
+		arg = args.Get(0)
+	}
+	var bv dgo.BigInt
+	switch arg := arg.(type) {
+	case dgo.BigInt:
+		bv = arg
+	case dgo.Integer:
+		bv = &bigIntVal{big.NewInt(arg.GoInt())}
+	case dgo.Float:
+		v, _ := big.NewFloat(arg.GoFloat()).Int(nil)
+		bv = &bigIntVal{v}
+	case dgo.String:
+		bv = BigIntFromString(arg.GoString())
+	default:
+		panic(illegalArgument(`bigint`, `bigint|int|float|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(bv) {
+		panic(IllegalAssignment(t, bv))
+	}
+	return bv
+}
+
+func (v *bigIntVal) CompareTo(other interface{}) (int, bool) {
+	if ob, ok := toBig(other); ok {
+		return v.v.Cmp(ob), true
+	}
+	if of, ok := ToFloat(other); ok {
+		return new(big.Float).SetInt(v.v).Cmp(big.NewFloat(of)), true
+	}
+	if other == Nil || other == nil {
+		return 1, true
+	}
+	return 0, false
+}
+
+func (v *bigIntVal) Equals(other interface{}) bool {
+	switch ov := other.(type) {
+	case *bigIntVal:
+		return v.v.Cmp(ov.v) == 0
+	case *big.Int:
+		return v.v.Cmp(ov) == 0
+	}
+	return false
+}
+
+func (v *bigIntVal) GoBigInt() *big.Int {
+	return new(big.Int).Set(v.v)
+}
+
+func (v *bigIntVal) HashCode() int {
+	h := v.v.Sign()
+	for _, w := range v.v.Bits() {
+		h = h*31 + int(w)
+	}
+	return h
+}
+
+func (v *bigIntVal) ReflectTo(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !v.v.IsInt64() || value.OverflowInt(v.v.Int64()) {
+			panic(fmt.Errorf(`value %s overflows %s`, v.v, value.Type()))
+		}
+		value.SetInt(v.v.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !v.v.IsUint64() || value.OverflowUint(v.v.Uint64()) {
+			panic(fmt.Errorf(`value %s overflows %s`, v.v, value.Type()))
+		}
+		value.SetUint(v.v.Uint64())
+	case reflect.Struct:
+		value.Set(reflect.ValueOf(v.GoBigInt()).Elem())
+	default:
+		value.Set(reflect.ValueOf(v.GoBigInt()))
+	}
+}
+
+func (v *bigIntVal) String() string {
+	return v.v.String()
+}
+
+// ToFloat returns the float64 that is nearest to this value
+func (v *bigIntVal) ToFloat() float64 {
+	f, _ := new(big.Float).SetInt(v.v).Float64()
+	return f
+}
+
+// ToInt returns the low order bits of this value as an int64. The result is undefined if the value doesn't fit.
+func (v *bigIntVal) ToInt() int64 {
+	return v.v.Int64()
+}
+
+func (v *bigIntVal) Type() dgo.Type {
+	et := &exactBigIntType{value: v}
+	et.ExactType = et
+	return et
+}
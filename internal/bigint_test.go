@@ -0,0 +1,194 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+const hugeInt = `123456789012345678901234567890`
+
+func TestBigIntDefault(t *testing.T) {
+	tp := typ.BigInt
+	bi := vf.BigIntFromString(hugeInt)
+	require.Instance(t, tp, bi)
+	require.Instance(t, tp, bi.GoBigInt())
+	require.Instance(t, tp, 3)
+	require.Instance(t, tp, vf.Integer(3))
+	require.NotInstance(t, tp, 3.0)
+	require.NotInstance(t, tp, `3`)
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, bi.Type())
+	require.Assignable(t, tp, typ.Integer)
+	require.Assignable(t, tp, tf.Integer(0, 10, true))
+	require.Assignable(t, tp, vf.Integer(3).Type())
+	require.NotAssignable(t, tp, typ.Float)
+	require.NotAssignable(t, typ.Integer, tp)
+	require.NotAssignable(t, typ.Integer, bi.Type())
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.Integer)
+	require.Equal(t, tp.HashCode(), tp.HashCode())
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `bigint`, tp.String())
+	require.Same(t, tp, typ.Generic(bi.Type()))
+	require.True(t, reflect.ValueOf(big.NewInt(1)).Type().AssignableTo(tp.ReflectType()))
+}
+
+func TestBigIntExact(t *testing.T) {
+	bi := vf.BigIntFromString(hugeInt)
+	tp := bi.Type()
+	require.Instance(t, tp, bi)
+	require.Instance(t, tp, vf.BigIntFromString(hugeInt).GoBigInt())
+	require.NotInstance(t, tp, vf.BigIntFromString(`1`+hugeInt))
+	require.NotInstance(t, tp, 3)
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, typ.BigInt)
+	require.Equal(t, tp, vf.BigIntFromString(hugeInt).Type())
+	require.NotEqual(t, tp, vf.BigIntFromString(`1`+hugeInt).Type())
+	require.Equal(t, hugeInt, tp.String())
+	require.Equal(t, typ.BigInt.ReflectType(), tp.ReflectType())
+}
+
+func TestBigIntType_New(t *testing.T) {
+	bi := vf.BigIntFromString(hugeInt)
+	require.Same(t, bi, vf.New(typ.BigInt, bi))
+	require.Equal(t, bi, vf.New(typ.BigInt, vf.Arguments(vf.String(hugeInt))))
+	require.Equal(t, bi, vf.New(bi.Type(), vf.String(hugeInt)))
+	require.Equal(t, vf.BigInt(big.NewInt(42)), vf.New(typ.BigInt, vf.Integer(42)))
+	require.Equal(t, vf.BigInt(big.NewInt(42)), vf.New(typ.BigInt, vf.Float(42.7)))
+	require.Equal(t, vf.BigInt(big.NewInt(255)), vf.New(typ.BigInt, vf.String(`0xff`)))
+
+	require.Panic(t, func() { vf.New(bi.Type(), vf.Integer(42)) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.BigInt, vf.String(`x`)) }, `unable to parse 'x' as a big integer`)
+	require.Panic(t, func() { vf.New(typ.BigInt, vf.True) }, `illegal argument`)
+
+	require.Equal(t, 42, vf.New(typ.Integer, vf.BigInt(big.NewInt(42))))
+	require.Panic(t, func() { vf.New(typ.Integer, bi) }, `overflows int64`)
+	require.Equal(t, 1.2345678901234568e+29, vf.New(typ.Float, bi))
+}
+
+func TestBigInt(t *testing.T) {
+	b := new(big.Int)
+	b.SetString(hugeInt, 10)
+	bi := vf.BigInt(b)
+	b.SetInt64(1)
+	require.Equal(t, hugeInt, bi.String())
+	require.Equal(t, bi, vf.BigIntFromString(hugeInt))
+	require.Equal(t, bi, vf.Value(vf.BigIntFromString(hugeInt).GoBigInt()))
+	require.NotEqual(t, bi, vf.BigIntFromString(`1`+hugeInt))
+	require.NotEqual(t, vf.BigInt(big.NewInt(3)), 3)
+	require.Equal(t, bi.HashCode(), vf.BigIntFromString(hugeInt).HashCode())
+	require.NotEqual(t, bi.HashCode(), vf.BigIntFromString(`-`+hugeInt).HashCode())
+
+	g := bi.GoBigInt()
+	g.SetInt64(1)
+	require.Equal(t, hugeInt, bi.String())
+
+	require.Equal(t, 1.2345678901234568e+29, bi.ToFloat())
+	require.Equal(t, int64(42), vf.BigInt(big.NewInt(42)).ToInt())
+
+	c, ok := bi.CompareTo(vf.BigIntFromString(`1` + hugeInt))
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = bi.CompareTo(math.MaxInt64)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	c, ok = bi.CompareTo(1e40)
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = bi.CompareTo(vf.Nil)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	_, ok = bi.CompareTo(`x`)
+	require.False(t, ok)
+
+	c, ok = vf.Integer(3).CompareTo(bi)
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = vf.Integer(3).CompareTo(vf.BigIntFromString(`-` + hugeInt))
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+
+	require.Panic(t, func() { vf.BigIntFromString(`12x`) }, `unable to parse`)
+}
+
+func TestBigInt_jsonNumber(t *testing.T) {
+	require.Equal(t, vf.BigIntFromString(hugeInt), vf.Value(json.Number(hugeInt)))
+	require.Equal(t, 42, vf.Value(json.Number(`42`)))
+	require.Equal(t, 1e40, vf.Value(json.Number(`1e40`)))
+}
+
+func TestIntegerFromBig(t *testing.T) {
+	require.Equal(t, vf.Integer(42), vf.IntegerFromBig(big.NewInt(42)))
+	require.Equal(t, vf.BigIntFromString(hugeInt), vf.IntegerFromBig(vf.BigIntFromString(hugeInt).GoBigInt()))
+}
+
+func TestIntegerArithmetic(t *testing.T) {
+	require.Equal(t, vf.Integer(5), vf.AddIntegers(2, 3))
+	require.Equal(t, vf.Integer(-1), vf.SubtractIntegers(2, 3))
+	require.Equal(t, vf.Integer(-6), vf.MultiplyIntegers(-2, 3))
+	require.Equal(t, vf.Integer(math.MinInt64), vf.MultiplyIntegers(math.MinInt64, 1))
+
+	over := vf.BigIntFromString(`9223372036854775808`)
+	under := vf.BigIntFromString(`-9223372036854775809`)
+	require.Equal(t, over, vf.AddIntegers(int64(math.MaxInt64), 1))
+	require.Equal(t, under, vf.AddIntegers(int64(math.MinInt64), -1))
+	require.Equal(t, over, vf.SubtractIntegers(int64(math.MaxInt64), -1))
+	require.Equal(t, under, vf.SubtractIntegers(int64(math.MinInt64), 1))
+	require.Equal(t, vf.BigIntFromString(`85070591730234615847396907784232501249`),
+		vf.MultiplyIntegers(int64(math.MaxInt64), int64(math.MaxInt64)))
+	require.Equal(t, vf.BigIntFromString(`-18446744073709551616`), vf.MultiplyIntegers(int64(math.MinInt64), 2))
+
+	// Results that fit in an int64 are demoted
+	require.Equal(t, vf.Integer(math.MaxInt64), vf.SubtractIntegers(over, 1))
+	require.Equal(t, vf.Integer(0), vf.AddIntegers(over, vf.BigInt(new(big.Int).Neg(over.GoBigInt()))))
+	require.Equal(t, vf.Integer(0), vf.MultiplyIntegers(over, 0))
+
+	require.Panic(t, func() { vf.AddIntegers(`1`, 2) }, `illegal argument 1`)
+	require.Panic(t, func() { vf.SubtractIntegers(1, 2.0) }, `illegal argument 2`)
+	require.Panic(t, func() { vf.MultiplyIntegers(1, nil) }, `illegal argument 2`)
+}
+
+func TestBigInt_ReflectTo(t *testing.T) {
+	bi := vf.BigIntFromString(hugeInt)
+	var bp *big.Int
+	vf.ReflectTo(bi, reflect.ValueOf(&bp).Elem())
+	require.Equal(t, hugeInt, bp.String())
+
+	var bv big.Int
+	vf.ReflectTo(bi, reflect.ValueOf(&bv).Elem())
+	require.Equal(t, hugeInt, bv.String())
+
+	var mi interface{}
+	vf.ReflectTo(bi, reflect.ValueOf(&mi).Elem())
+	require.Equal(t, hugeInt, mi.(*big.Int).String())
+
+	var i int32
+	vf.ReflectTo(vf.BigInt(big.NewInt(42)), reflect.ValueOf(&i).Elem())
+	require.Equal(t, int32(42), i)
+	var u uint8
+	vf.ReflectTo(vf.BigInt(big.NewInt(42)), reflect.ValueOf(&u).Elem())
+	require.Equal(t, uint8(42), u)
+	require.Panic(t, func() { vf.ReflectTo(bi, reflect.ValueOf(&i).Elem()) }, `overflows int32`)
+	require.Panic(t, func() { vf.ReflectTo(vf.BigInt(big.NewInt(-1)), reflect.ValueOf(&u).Elem()) }, `overflows uint8`)
+
+	type withBig struct {
+		N *big.Int
+	}
+	s := withBig{N: big.NewInt(3)}
+	m := vf.Map(&s)
+	require.Equal(t, vf.BigInt(big.NewInt(3)), m.Get(`N`))
+	m.Put(`N`, bi)
+	require.Equal(t, hugeInt, s.N.String())
+	require.Same(t, typ.BigInt, tf.FromReflected(reflect.TypeOf(s.N)))
+}
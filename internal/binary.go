@@ -80,6 +80,10 @@ func (t *binaryType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *binaryType) Describe() string {
+	return Describe(t)
+}
+
 func (t *binaryType) Equals(other interface{}) bool {
 	if ob, ok := other.(*binaryType); ok {
 		return *t == *ob
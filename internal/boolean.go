@@ -39,6 +39,10 @@ func (t booleanType) Assignable(ot dgo.Type) bool {
 	return ok || CheckAssignableTo(nil, ot, t)
 }
 
+func (t booleanType) Describe() string {
+	return Describe(t)
+}
+
 func (t booleanType) Equals(v interface{}) bool {
 	return t == v
 }
@@ -106,6 +110,10 @@ func (t *exactBooleanType) TypeIdentifier() dgo.TypeIdentifier {
 	return dgo.TiBooleanExact
 }
 
+func (v boolean) And(other dgo.Boolean) dgo.Boolean {
+	return boolean(bool(v) && other.GoBool())
+}
+
 func (v boolean) CompareTo(other interface{}) (r int, ok bool) {
 	ok = true
 	switch ov := other.(type) {
@@ -147,6 +155,14 @@ func (v boolean) HashCode() int {
 	return 1237
 }
 
+func (v boolean) Not() dgo.Boolean {
+	return !v
+}
+
+func (v boolean) Or(other dgo.Boolean) dgo.Boolean {
+	return boolean(bool(v) || other.GoBool())
+}
+
 func (v boolean) ReflectTo(value reflect.Value) {
 	b := bool(v)
 	switch value.Kind() {
@@ -173,6 +189,10 @@ func (v boolean) Type() dgo.Type {
 	return FalseType
 }
 
+func (v boolean) Xor(other dgo.Boolean) dgo.Boolean {
+	return boolean(bool(v) != other.GoBool())
+}
+
 func init() {
 	et := &exactBooleanType{value: boolean(true)}
 	et.ExactType = et
@@ -161,3 +161,29 @@ func TestBoolean_String(t *testing.T) {
 	require.Equal(t, `true`, vf.True.String())
 	require.Equal(t, `false`, vf.False.String())
 }
+
+func TestBoolean_Not(t *testing.T) {
+	require.Equal(t, vf.False, vf.True.Not())
+	require.Equal(t, vf.True, vf.False.Not())
+}
+
+func TestBoolean_And(t *testing.T) {
+	require.Equal(t, vf.True, vf.True.And(vf.True))
+	require.Equal(t, vf.False, vf.True.And(vf.False))
+	require.Equal(t, vf.False, vf.False.And(vf.True))
+	require.Equal(t, vf.False, vf.False.And(vf.False))
+}
+
+func TestBoolean_Or(t *testing.T) {
+	require.Equal(t, vf.True, vf.True.Or(vf.True))
+	require.Equal(t, vf.True, vf.True.Or(vf.False))
+	require.Equal(t, vf.True, vf.False.Or(vf.True))
+	require.Equal(t, vf.False, vf.False.Or(vf.False))
+}
+
+func TestBoolean_Xor(t *testing.T) {
+	require.Equal(t, vf.False, vf.True.Xor(vf.True))
+	require.Equal(t, vf.True, vf.True.Xor(vf.False))
+	require.Equal(t, vf.True, vf.False.Xor(vf.True))
+	require.Equal(t, vf.False, vf.False.Xor(vf.False))
+}
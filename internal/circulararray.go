@@ -0,0 +1,556 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+// circularArray is an Array with a fixed capacity. When the capacity is reached, adding a new
+// element will overwrite the oldest element.
+type circularArray struct {
+	slice    []dgo.Value
+	head     int
+	capacity int
+	typ      dgo.Type
+	frozen   bool
+}
+
+// CircularArray creates a new mutable Array with a fixed capacity that will overwrite its oldest element when a
+// new element is added and the capacity has been reached. All elements added to the array must be instances of
+// the given type. A nil type means that elements can be of any type.
+func CircularArray(capacity int, typ dgo.Type) dgo.Array {
+	if capacity < 1 {
+		panic(errors.New(`the capacity of a circular array must be greater than zero`))
+	}
+	if typ == nil {
+		typ = DefaultAnyType
+	}
+	return &circularArray{slice: make([]dgo.Value, 0, capacity), capacity: capacity, typ: typ}
+}
+
+// values returns a new slice containing the elements of the receiver in logical order
+func (v *circularArray) values() []dgo.Value {
+	s := v.slice
+	vs := make([]dgo.Value, len(s))
+	n := copy(vs, s[v.head:])
+	copy(vs[n:], s[:v.head])
+	return vs
+}
+
+// logical returns an array that contains the elements of the receiver in logical order
+func (v *circularArray) logical() *array {
+	return &array{slice: v.values(), frozen: v.frozen}
+}
+
+// typedLogical returns an array that contains the elements of the receiver in logical order and is constrained by
+// the element type of the receiver
+func (v *circularArray) typedLogical() *array {
+	a := v.logical()
+	a.elementType = elementTypeOf(v)
+	return a
+}
+
+// reset replaces the contents of the receiver with the given values. Excess values at the beginning
+// of the given slice are dropped.
+func (v *circularArray) reset(vs []dgo.Value) {
+	if n := len(vs) - v.capacity; n > 0 {
+		vs = vs[n:]
+	}
+	s := make([]dgo.Value, len(vs), v.capacity)
+	copy(s, vs)
+	v.slice = s
+	v.head = 0
+}
+
+func (v *circularArray) assertMutable(f string) {
+	if v.frozen {
+		panic(frozenArray(f))
+	}
+}
+
+func (v *circularArray) add(e dgo.Value) {
+	if !v.typ.Instance(e) {
+		panic(IllegalAssignment(v.typ, e))
+	}
+	if len(v.slice) < v.capacity {
+		v.slice = append(v.slice, e)
+		return
+	}
+	v.slice[v.head] = e
+	v.head++
+	if v.head == v.capacity {
+		v.head = 0
+	}
+}
+
+func (v *circularArray) copyOf(frozen bool) *circularArray {
+	vs := v.values()
+	for i := range vs {
+		if f, ok := vs[i].(dgo.Freezable); ok {
+			if frozen {
+				vs[i] = f.FrozenCopy()
+			} else {
+				vs[i] = f.ThawedCopy()
+			}
+		}
+	}
+	c := &circularArray{capacity: v.capacity, typ: v.typ}
+	c.reset(vs)
+	c.frozen = frozen
+	return c
+}
+
+func (v *circularArray) Add(vi interface{}) {
+	v.assertMutable(`Add`)
+	v.add(Value(vi))
+}
+
+func (v *circularArray) AddAll(values dgo.Iterable) {
+	v.assertMutable(`AddAll`)
+	values.Each(v.add)
+}
+
+func (v *circularArray) AddValues(values ...interface{}) {
+	v.assertMutable(`AddValues`)
+	for i := range values {
+		v.add(Value(values[i]))
+	}
+}
+
+func (v *circularArray) All(predicate dgo.Predicate) bool {
+	return v.logical().All(predicate)
+}
+
+func (v *circularArray) Annotate(elementType dgo.Type) dgo.Array {
+	s := v.slice
+	for i := range s {
+		if !elementType.Instance(s[i]) {
+			panic(IllegalAssignment(elementType, s[i]))
+		}
+	}
+	c := &circularArray{capacity: v.capacity, typ: elementType}
+	c.reset(v.values())
+	c.frozen = v.frozen
+	return c
+}
+
+func (v *circularArray) Any(predicate dgo.Predicate) bool {
+	return v.logical().Any(predicate)
+}
+
+func (v *circularArray) AppendTo(w dgo.Indenter) {
+	v.logical().AppendTo(w)
+}
+
+func (v *circularArray) AppendToSlice(slice []dgo.Value) []dgo.Value {
+	return append(slice, v.values()...)
+}
+
+func (v *circularArray) CartesianProduct() dgo.Array {
+	return v.logical().CartesianProduct()
+}
+
+func (v *circularArray) CompareTo(other interface{}) (int, bool) {
+	return compare(nil, v, Value(other))
+}
+
+func (v *circularArray) deepCompare(seen []dgo.Value, other deepCompare) (int, bool) {
+	return v.logical().deepCompare(seen, other)
+}
+
+func (v *circularArray) ContainsAll(other dgo.Iterable) bool {
+	return v.logical().ContainsAll(other)
+}
+
+func (v *circularArray) Copy(frozen bool) dgo.Array {
+	if frozen && v.frozen {
+		return v
+	}
+	return v.copyOf(frozen)
+}
+
+func (v *circularArray) CryptoShuffle() (dgo.Array, error) {
+	return v.typedLogical().CryptoShuffle()
+}
+
+func (v *circularArray) Each(actor dgo.Consumer) {
+	v.logical().Each(actor)
+}
+
+func (v *circularArray) Chunks(size int) dgo.Array {
+	return v.logical().Chunks(size)
+}
+
+func (v *circularArray) ForEachChunk(size int, actor func(chunk dgo.Array)) {
+	v.logical().ForEachChunk(size, actor)
+}
+
+func (v *circularArray) EachWithIndex(actor dgo.DoWithIndex) {
+	v.logical().EachWithIndex(actor)
+}
+
+func (v *circularArray) Equals(other interface{}) bool {
+	return equals(nil, v, other)
+}
+
+func (v *circularArray) deepEqual(seen []dgo.Value, other deepEqual) bool {
+	return v.logical().deepEqual(seen, other)
+}
+
+func (v *circularArray) Find(finder dgo.Mapper) interface{} {
+	return v.logical().Find(finder)
+}
+
+func (v *circularArray) Flatten() dgo.Array {
+	return v.logical().Flatten()
+}
+
+func (v *circularArray) Freeze() {
+	if v.frozen {
+		return
+	}
+	v.frozen = true
+	s := v.slice
+	for i := range s {
+		if f, ok := s[i].(dgo.Freezable); ok {
+			f.Freeze()
+		}
+	}
+}
+
+func (v *circularArray) Frozen() bool {
+	return v.frozen
+}
+
+func (v *circularArray) FrozenCopy() dgo.Value {
+	return v.Copy(true)
+}
+
+func (v *circularArray) ThawedCopy() dgo.Value {
+	return v.Copy(false)
+}
+
+func (v *circularArray) Get(index int) dgo.Value {
+	l := len(v.slice)
+	if index < 0 || index >= l {
+		panic(errors.New(`index out of range`))
+	}
+	return v.slice[(v.head+index)%l]
+}
+
+// at returns the element at the given position or nil if the position is out of bounds
+func (v *circularArray) at(pos int) dgo.Value {
+	l := len(v.slice)
+	if pos >= 0 && pos < l {
+		return v.slice[(v.head+pos)%l]
+	}
+	return nil
+}
+
+func (v *circularArray) GetArray(pos int) (dgo.Array, bool) {
+	return asArray(v.at(pos))
+}
+
+func (v *circularArray) GetBool(pos int) (bool, bool) {
+	return asGoBool(v.at(pos))
+}
+
+func (v *circularArray) GetFloat(pos int) (float64, bool) {
+	return asGoFloat(v.at(pos))
+}
+
+func (v *circularArray) GetInt(pos int) (int64, bool) {
+	return asGoInt(v.at(pos))
+}
+
+func (v *circularArray) GetMap(pos int) (dgo.Map, bool) {
+	return asMap(v.at(pos))
+}
+
+func (v *circularArray) GetString(pos int) (string, bool) {
+	return asGoString(v.at(pos))
+}
+
+func (v *circularArray) GoSlice() []dgo.Value {
+	return v.values()
+}
+
+func (v *circularArray) GroupBy(keyMapper dgo.Mapper) dgo.Map {
+	return v.logical().GroupBy(keyMapper)
+}
+
+func (v *circularArray) GroupConsecutive(predicate dgo.Predicate, dropOthers bool) dgo.Array {
+	return v.logical().GroupConsecutive(predicate, dropOthers)
+}
+
+func (v *circularArray) HashCode() int {
+	return v.deepHashCode(nil)
+}
+
+func (v *circularArray) deepHashCode(seen []dgo.Value) int {
+	return v.logical().deepHashCode(seen)
+}
+
+func (v *circularArray) IndexBy(keyMapper dgo.Mapper) dgo.Map {
+	return v.logical().IndexBy(keyMapper)
+}
+
+func (v *circularArray) IndexOf(vi interface{}) int {
+	return v.logical().IndexOf(vi)
+}
+
+func (v *circularArray) Insert(pos int, vi interface{}) {
+	v.assertMutable(`Insert`)
+	e := Value(vi)
+	if !v.typ.Instance(e) {
+		panic(IllegalAssignment(v.typ, e))
+	}
+	a := v.logical()
+	a.Insert(pos, e)
+	v.reset(a.slice)
+}
+
+// InsertSorted inserts the value in sort order. When the array is full, the oldest element is dropped, which shifts the
+// returned position one step back. The position is -1 when the inserted value is the one that was dropped.
+func (v *circularArray) InsertSorted(vi interface{}) int {
+	v.assertMutable(`InsertSorted`)
+	e := Value(vi)
+	l := len(v.slice)
+	pos := sort.Search(l, func(i int) bool { return naturalLess(e, v.at(i)) })
+	v.Insert(pos, e)
+	if l == v.capacity {
+		pos--
+	}
+	return pos
+}
+
+func (v *circularArray) InterfaceSlice() []interface{} {
+	return v.logical().InterfaceSlice()
+}
+
+func (v *circularArray) Len() int {
+	return len(v.slice)
+}
+
+func (v *circularArray) Map(mapper dgo.Mapper) dgo.Array {
+	return v.logical().Map(mapper)
+}
+
+func (v *circularArray) One(predicate dgo.Predicate) bool {
+	return v.logical().One(predicate)
+}
+
+func (v *circularArray) PEach(n int, actor dgo.Consumer) {
+	v.logical().PEach(n, actor)
+}
+
+func (v *circularArray) PMap(n int, mapper dgo.Mapper) dgo.Array {
+	return v.logical().PMap(n, mapper)
+}
+
+func (v *circularArray) Partition(predicate dgo.Predicate) (dgo.Array, dgo.Array) {
+	return v.logical().Partition(predicate)
+}
+
+func (v *circularArray) Pop() (dgo.Value, bool) {
+	v.assertMutable(`Pop`)
+	a := v.logical()
+	e, ok := a.Pop()
+	v.reset(a.slice)
+	return e, ok
+}
+
+func (v *circularArray) Reduce(mi interface{}, reductor func(memo dgo.Value, elem dgo.Value) interface{}) dgo.Value {
+	return v.logical().Reduce(mi, reductor)
+}
+
+func (v *circularArray) ReduceUntil(
+	mi interface{}, reductor func(memo dgo.Value, elem dgo.Value) (dgo.Value, bool)) dgo.Value {
+	return v.logical().ReduceUntil(mi, reductor)
+}
+
+func (v *circularArray) ReflectTo(value reflect.Value) {
+	v.logical().ReflectTo(value)
+}
+
+func (v *circularArray) Reject(predicate dgo.Predicate) dgo.Array {
+	return v.logical().Reject(predicate)
+}
+
+func (v *circularArray) Remove(pos int) dgo.Value {
+	v.assertMutable(`Remove`)
+	a := v.logical()
+	e := a.Remove(pos)
+	v.reset(a.slice)
+	return e
+}
+
+func (v *circularArray) RemoveValue(value interface{}) bool {
+	v.assertMutable(`RemoveValue`)
+	a := v.logical()
+	if a.RemoveValue(value) {
+		v.reset(a.slice)
+		return true
+	}
+	return false
+}
+
+func (v *circularArray) SameValues(other dgo.Iterable) bool {
+	return v.logical().SameValues(other)
+}
+
+func (v *circularArray) SetEquals(other dgo.Iterable) bool {
+	return v.logical().SetEquals(other)
+}
+
+func (v *circularArray) Sample(n int, rnd interface{ Intn(int) int }) dgo.Array {
+	return v.logical().Sample(n, rnd)
+}
+
+func (v *circularArray) SearchSorted(vi interface{}) int {
+	e := Value(vi)
+	return sort.Search(len(v.slice), func(i int) bool { return !naturalLess(v.at(i), e) })
+}
+
+func (v *circularArray) Select(predicate dgo.Predicate) dgo.Array {
+	return v.logical().Select(predicate)
+}
+
+func (v *circularArray) Seq() dgo.Seq {
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		for i := 0; i < len(v.slice); i++ {
+			if !yield(v.at(i)) {
+				return
+			}
+		}
+	}}
+}
+
+func (v *circularArray) Set(pos int, vi interface{}) dgo.Value {
+	v.assertMutable(`Set`)
+	l := len(v.slice)
+	if pos < 0 || pos >= l {
+		panic(errors.New(`index out of range`))
+	}
+	e := Value(vi)
+	if !v.typ.Instance(e) {
+		panic(IllegalAssignment(v.typ, e))
+	}
+	i := (v.head + pos) % l
+	old := v.slice[i]
+	v.slice[i] = e
+	return old
+}
+
+func (v *circularArray) Slice(i, j int) dgo.Array {
+	return v.logical().Slice(i, j)
+}
+
+func (v *circularArray) SlidingWindow(size, step int) dgo.Array {
+	return v.logical().SlidingWindow(size, step)
+}
+
+func (v *circularArray) Sort() dgo.Array {
+	return v.logical().Sort()
+}
+
+func (v *circularArray) SortBy(compare func(a, b dgo.Value) int) dgo.Array {
+	return v.logical().SortBy(compare)
+}
+
+func (v *circularArray) SortByKey(keyMapper dgo.Mapper, descending bool) dgo.Array {
+	return v.logical().SortByKey(keyMapper, descending)
+}
+
+func (v *circularArray) SortStableBy(keyMapper dgo.Mapper) dgo.Array {
+	return v.logical().SortStableBy(keyMapper)
+}
+
+func (v *circularArray) SortUnstableBy(keyMapper dgo.Mapper) dgo.Array {
+	return v.logical().SortUnstableBy(keyMapper)
+}
+
+func (v *circularArray) String() string {
+	return util.ToStringERP(v)
+}
+
+func (v *circularArray) Tee(n int) []dgo.Array {
+	if n < 0 {
+		panic(fmt.Errorf(`illegal tee count %d`, n))
+	}
+	as := make([]dgo.Array, n)
+	for i := range as {
+		as[i] = v.copyOf(v.frozen)
+	}
+	return as
+}
+
+func (v *circularArray) ToMap() dgo.Map {
+	return v.logical().ToMap()
+}
+
+func (v *circularArray) ToMapFromEntries() (dgo.Map, bool) {
+	return v.logical().ToMapFromEntries()
+}
+
+func (v *circularArray) ToSortedMap() dgo.Map {
+	return v.logical().ToSortedMap()
+}
+
+func (v *circularArray) Type() dgo.Type {
+	return v.typedLogical().Type()
+}
+
+func (v *circularArray) Unique() dgo.Array {
+	return v.logical().Unique()
+}
+
+func (v *circularArray) Unzip() (dgo.Array, dgo.Array) {
+	return v.typedLogical().Unzip()
+}
+
+func (v *circularArray) With(vi interface{}) dgo.Array {
+	c := v.copyOf(v.frozen)
+	e := Value(vi)
+	if v.frozen {
+		e = frozenCopy(e)
+	}
+	c.add(e)
+	return c
+}
+
+func (v *circularArray) WithAll(values dgo.Iterable) dgo.Array {
+	if values.Len() == 0 {
+		return v
+	}
+	c := v.copyOf(v.frozen)
+	if v.frozen {
+		values = values.FrozenCopy().(dgo.Iterable)
+	}
+	values.Each(c.add)
+	return c
+}
+
+func (v *circularArray) WithValues(values ...interface{}) dgo.Array {
+	if len(values) == 0 {
+		return v
+	}
+	c := v.copyOf(v.frozen)
+	for _, e := range valueSlice(values, v.frozen) {
+		c.add(e)
+	}
+	return c
+}
+
+func (v *circularArray) Zip(other dgo.Array) dgo.Array {
+	return v.typedLogical().Zip(other)
+}
+
+func (v *circularArray) ZipToMap(keys dgo.Array) dgo.Map {
+	return v.logical().ZipToMap(keys)
+}
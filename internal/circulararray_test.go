@@ -0,0 +1,219 @@
+package internal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestCircularArray(t *testing.T) {
+	a := vf.CircularArray(3, typ.Integer)
+	require.Equal(t, 0, a.Len())
+	a.AddValues(1, 2)
+	require.Equal(t, vf.Values(1, 2), a)
+	a.Add(3)
+	require.Equal(t, vf.Values(1, 2, 3), a)
+	a.Add(4)
+	require.Equal(t, 3, a.Len())
+	require.Equal(t, vf.Values(2, 3, 4), a)
+	require.Equal(t, 2, a.Get(0))
+	require.Equal(t, 4, a.Get(2))
+	a.AddAll(vf.Values(5, 6, 7, 8))
+	require.Equal(t, vf.Values(6, 7, 8), a)
+	require.Equal(t, `{6,7,8}`, a.String())
+	require.Panic(t, func() { a.Get(3) }, `index out of range`)
+	require.Panic(t, func() { a.Add(`x`) }, `cannot be assigned`)
+}
+
+func TestCircularArray_Type(t *testing.T) {
+	a := vf.CircularArray(3, typ.Integer)
+	a.AddValues(1, 2)
+	require.Instance(t, tf.Array(typ.Integer), a)
+	require.Instance(t, tf.Array(typ.Integer, 2, 2), a)
+	require.NotInstance(t, tf.Array(typ.String), a)
+	require.Instance(t, tf.Tuple(typ.Integer, typ.Integer), a)
+	require.Instance(t, tf.VariadicTuple(typ.Integer), a)
+	require.NotInstance(t, tf.Tuple(typ.Integer), a)
+	require.Instance(t, a.Type(), a)
+
+	require.Assignable(t, tf.Array(typ.Integer), a.Type())
+	require.Assignable(t, tf.Tuple(typ.Integer, typ.Integer), a.Type())
+	require.NotAssignable(t, tf.Array(typ.String), a.Type())
+	require.Equal(t, tf.Array(typ.Integer), typ.Generic(a.Type()))
+
+	u := vf.CircularArray(3, nil)
+	u.AddValues(1, `a`)
+	require.Equal(t, typ.Generic(vf.Values(1, `a`).Type()), typ.Generic(u.Type()))
+
+	args := vf.ArgumentsFromArray(a)
+	require.Equal(t, 2, args.Len())
+	require.Equal(t, 2, args.Arg(`f`, 1, typ.Integer))
+}
+
+func TestCircularArray_badCapacity(t *testing.T) {
+	require.Panic(t, func() { vf.CircularArray(0, nil) }, `must be greater than zero`)
+}
+
+func TestCircularArray_operations(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(3, 1, 4, 2)
+	require.Equal(t, vf.Values(1, 2, 4), a.Sort())
+	require.Equal(t, vf.Values(2, 8, 4), a.Map(func(e dgo.Value) interface{} { return e.(dgo.Integer).GoInt() * 2 }))
+	var s []int64
+	a.Each(func(e dgo.Value) { s = append(s, e.(dgo.Integer).GoInt()) })
+	require.Equal(t, []int64{1, 4, 2}, s)
+	require.Equal(t, 2, a.IndexOf(2))
+	require.Equal(t, vf.Values(4, 2), a.Slice(1, 3))
+
+	require.Equal(t, 4, a.Set(1, 5))
+	require.Equal(t, vf.Values(1, 5, 2), a)
+
+	a.Insert(1, 6)
+	require.Equal(t, vf.Values(6, 5, 2), a)
+	a.Add(7)
+	require.Equal(t, vf.Values(5, 2, 7), a)
+
+	require.Equal(t, 2, a.Remove(1))
+	require.Equal(t, vf.Values(5, 7), a)
+	require.True(t, a.RemoveValue(5))
+	require.False(t, a.RemoveValue(5))
+	e, ok := a.Pop()
+	require.True(t, ok)
+	require.Equal(t, 7, e)
+	require.Equal(t, 0, a.Len())
+}
+
+func TestCircularArray_frozen(t *testing.T) {
+	a := vf.CircularArray(2, nil)
+	a.AddValues(1, 2, 3)
+	f := a.FrozenCopy().(dgo.Array)
+	require.True(t, f.Frozen())
+	require.False(t, a.Frozen())
+	require.Same(t, f, f.FrozenCopy())
+	require.Panic(t, func() { f.Add(4) }, `Add .* frozen`)
+	require.Panic(t, func() { f.Set(0, 4) }, `Set .* frozen`)
+
+	w := f.With(4)
+	require.True(t, w.Frozen())
+	require.Equal(t, vf.Values(3, 4), w)
+	require.Equal(t, vf.Values(2, 3), f)
+
+	c := f.ThawedCopy().(dgo.Array)
+	c.Add(5)
+	require.Equal(t, vf.Values(3, 5), c)
+
+	a.Freeze()
+	require.True(t, a.Frozen())
+	require.Equal(t, f, a)
+}
+
+func TestCircularArray_frozenWith(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(vf.MutableValues(1), vf.MutableValues(2))
+	f := a.FrozenCopy().(dgo.Array)
+
+	w := f.With(vf.MutableValues(3))
+	require.Nil(t, vf.AssertFrozen(w))
+	w = f.WithAll(vf.Values(vf.MutableValues(3)))
+	require.Nil(t, vf.AssertFrozen(w))
+	w = f.WithValues(vf.MutableValues(3))
+	require.Nil(t, vf.AssertFrozen(w))
+	require.Equal(t, vf.Values(vf.Values(1), vf.Values(2), vf.Values(3)), w)
+
+	w = a.With(vf.MutableValues(3))
+	require.False(t, w.Frozen())
+	w.Add(4)
+	require.Equal(t, 2, a.Len())
+}
+
+func TestCircularArray_Tee(t *testing.T) {
+	a := vf.CircularArray(2, nil)
+	a.AddValues(1, 2, 3)
+	ts := a.Tee(2)
+	ts[0].Add(4)
+	require.Equal(t, vf.Values(3, 4), ts[0])
+	require.Equal(t, vf.Values(2, 3), ts[1])
+	require.Equal(t, vf.Values(2, 3), a)
+	require.Panic(t, func() { a.Tee(-1) }, `illegal tee count`)
+}
+
+func TestCircularArray_CryptoShuffle(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(1, 2, 3, 4)
+	s, err := a.CryptoShuffle()
+	require.Nil(t, err)
+	require.True(t, vf.Values(2, 3, 4).SameValues(s))
+}
+
+func TestCircularArray_Sample(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(1, 2, 3, 4)
+	s := a.Sample(3, rand.New(rand.NewSource(1)))
+	require.True(t, vf.Values(2, 3, 4).SameValues(s))
+	require.Equal(t, vf.Values(2, 3, 4), a)
+	require.Panic(t, func() { a.Sample(4, rand.New(rand.NewSource(1))) }, `illegal sample size`)
+}
+
+func TestCircularArray_ReduceUntil(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(1, 2, 3, 4)
+	require.Equal(t, 2, a.ReduceUntil(nil, func(memo, v dgo.Value) (dgo.Value, bool) {
+		return v, true
+	}))
+}
+
+func TestCircularArray_ForEachChunk(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(1, 2, 3, 4)
+	var chunks []dgo.Array
+	a.ForEachChunk(2, func(c dgo.Array) { chunks = append(chunks, c) })
+	require.Equal(t, []dgo.Array{vf.Values(2, 3), vf.Values(4)}, chunks)
+}
+
+func TestCircularArray_SortBy(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(-1, 3, -2, 1)
+	abs := func(v dgo.Value) interface{} {
+		if i := v.(dgo.Integer).GoInt(); i < 0 {
+			return -i
+		}
+		return v
+	}
+	require.Equal(t, vf.Values(1, -2, 3), a.SortStableBy(abs))
+	require.Equal(t, vf.Values(1, -2, 3), a.SortUnstableBy(abs))
+}
+
+func TestCircularArray_typedGetters(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(`x`, `hello`, 42, 3.14, true, vf.Map(`a`, 1), vf.Values(1))
+	_, ok := a.GetString(0)
+	require.False(t, ok)
+	b, ok := a.GetBool(0)
+	require.True(t, ok)
+	require.True(t, b)
+	m, ok := a.GetMap(1)
+	require.True(t, ok)
+	require.Equal(t, vf.Map(`a`, 1), m)
+	_, ok = a.GetArray(2)
+	require.True(t, ok)
+	_, ok = a.GetInt(3)
+	require.False(t, ok)
+	_, ok = a.GetFloat(2)
+	require.False(t, ok)
+}
+
+func TestCircularArray_Annotate(t *testing.T) {
+	a := vf.CircularArray(2, nil)
+	a.AddValues(1, 2, 3)
+	c := a.Annotate(typ.Integer)
+	c.Add(4)
+	require.Equal(t, vf.Values(3, 4), c)
+	require.Panic(t, func() { c.Add(`x`) }, `cannot be assigned`)
+	a.Add(`x`)
+	require.Panic(t, func() { a.Annotate(typ.Integer) }, `cannot be assigned`)
+}
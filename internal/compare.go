@@ -1,6 +1,10 @@
 package internal
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/lyraproj/dgo/dgo"
 	"github.com/lyraproj/dgo/util"
 )
@@ -12,6 +16,34 @@ type deepEqual interface {
 	deepHashCode(seen []dgo.Value) int
 }
 
+// typeCheckTracer holds the current dgo.TypeCheckTracer. It is read by concurrent type checks.
+var typeCheckTracer atomic.Value
+
+// typeCheckTracerLock makes the exchange of the tracer in SetTypeCheckTracer atomic
+var typeCheckTracerLock sync.Mutex
+
+// SetTypeCheckTracer sets a function that is called after each Instance or Assignable check that isn't made under
+// the recursion guard of an enclosing check, with the type, the value or type that was checked against it, and the
+// time that the check took. Only the checks of types that contain other types are traced. Those are the array,
+// tuple, map, struct map, multi map, sensitive, and function types, the allOf, anyOf, and oneOf types, and the exact
+// types of arrays and maps. Checks of all other types, such as typ.Integer.Instance(3), are not traced unless they
+// are made by one of those types. Nested checks may therefore be traced before the check that made them. A nil
+// tracer turns tracing off. The previous tracer is returned. The tracer can be set at any time, also while type
+// checks are made concurrently.
+func SetTypeCheckTracer(tracer dgo.TypeCheckTracer) dgo.TypeCheckTracer {
+	typeCheckTracerLock.Lock()
+	defer typeCheckTracerLock.Unlock()
+	old := loadTypeCheckTracer()
+	typeCheckTracer.Store(tracer)
+	return old
+}
+
+// loadTypeCheckTracer returns the current dgo.TypeCheckTracer or nil if tracing is off
+func loadTypeCheckTracer() dgo.TypeCheckTracer {
+	t, _ := typeCheckTracer.Load().(dgo.TypeCheckTracer)
+	return t
+}
+
 type doubleSeen struct {
 	aSeen   []dgo.Value
 	bSeen   []dgo.Value
@@ -50,6 +82,18 @@ type deepCompare interface {
 
 // Assignable checks if b is assignable to a while guarding for endless recursion
 func Assignable(guard dgo.RecursionGuard, a dgo.Type, b dgo.Type) bool {
+	if guard == nil {
+		if tracer := loadTypeCheckTracer(); tracer != nil {
+			start := time.Now()
+			r := assignable(guard, a, b)
+			tracer(a, b, time.Since(start))
+			return r
+		}
+	}
+	return assignable(guard, a, b)
+}
+
+func assignable(guard dgo.RecursionGuard, a dgo.Type, b dgo.Type) bool {
 	if a == b {
 		return true
 	}
@@ -75,6 +119,18 @@ func Assignable(guard dgo.RecursionGuard, a dgo.Type, b dgo.Type) bool {
 
 // Instance checks if b is an instance of a to a while guarding for endless recursion
 func Instance(guard dgo.RecursionGuard, a dgo.Type, b interface{}) bool {
+	if guard == nil {
+		if tracer := loadTypeCheckTracer(); tracer != nil {
+			start := time.Now()
+			r := instance(guard, a, b)
+			tracer(a, Value(b), time.Since(start))
+			return r
+		}
+	}
+	return instance(guard, a, b)
+}
+
+func instance(guard dgo.RecursionGuard, a dgo.Type, b interface{}) bool {
 	da, ok := a.(dgo.DeepInstance)
 	if !ok {
 		return a.Instance(b)
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// conditionalStructType is a discriminated union of struct types. Each case has a required entry for the
+// discriminator key with an exact value type and the value of that entry selects the case.
+type conditionalStructType struct {
+	key    dgo.Value
+	values []dgo.Value
+	cases  []dgo.StructMapType
+}
+
+// ConditionalStructType returns a new dgo.ConditionalStructType where the value of the given discriminator key
+// selects one of the given cases. The function panics if there are no cases, if a case lacks a required entry with
+// an exact value type for the key, or if two cases have the same value for the key.
+func ConditionalStructType(key interface{}, cases []dgo.StructMapType) dgo.ConditionalStructType {
+	if len(cases) == 0 {
+		panic(errors.New(`a conditional struct type must have at least one case`))
+	}
+	kv := Value(key)
+	if et, ok := kv.(dgo.ExactType); ok {
+		kv = et.ExactValue()
+	}
+	values := make([]dgo.Value, len(cases))
+	for i, c := range cases {
+		e := c.Get(kv)
+		if e == nil || !e.Required() || !dgo.IsExact(e.Value().(dgo.Type)) {
+			panic(fmt.Errorf(`conditional struct case %s has no required entry with an exact value for key '%s'`,
+				TypeString(c), kv))
+		}
+		v := e.Value().(dgo.ExactType).ExactValue()
+		for j := 0; j < i; j++ {
+			if values[j].Equals(v) {
+				panic(fmt.Errorf(`conditional struct cases %s and %s have the same value for key '%s'`,
+					TypeString(cases[j]), TypeString(c), kv))
+			}
+		}
+		values[i] = v
+	}
+	return &conditionalStructType{key: kv, values: values, cases: append([]dgo.StructMapType{}, cases...)}
+}
+
+// ConditionalStructTypeFromArgs returns a dgo.ConditionalStructType created from the given arguments. The first
+// argument is the discriminator key and the remaining arguments are the struct types of the cases.
+func ConditionalStructTypeFromArgs(args []interface{}) dgo.ConditionalStructType {
+	if len(args) < 2 {
+		panic(illegalArgumentCount(`ConditionalStructType`, 2, math.MaxInt64, len(args)))
+	}
+	cases := make([]dgo.StructMapType, len(args)-1)
+	for i := 1; i < len(args); i++ {
+		st, ok := Value(args[i]).(dgo.StructMapType)
+		if !ok {
+			panic(illegalArgument(`ConditionalStructType`, `StructMapType`, args, i))
+		}
+		cases[i-1] = st
+	}
+	return ConditionalStructType(args[0], cases)
+}
+
+// caseIndex returns the index of the case selected by the given value, or -1 if no case is selected
+func (t *conditionalStructType) caseIndex(v interface{}) int {
+	for i, cv := range t.values {
+		if cv.Equals(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectCase returns the case that is selected by the discriminator value of the given map, or nil
+func (t *conditionalStructType) selectCase(m dgo.Map) dgo.StructMapType {
+	if v := m.Get(t.key); v != nil {
+		if i := t.caseIndex(v); i >= 0 {
+			return t.cases[i]
+		}
+	}
+	return nil
+}
+
+func (t *conditionalStructType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *conditionalStructType:
+		if !t.key.Equals(ot.key) {
+			return false
+		}
+		for i, oc := range ot.cases {
+			c := t.Case(ot.values[i])
+			if c == nil || !c.Assignable(oc) {
+				return false
+			}
+		}
+		return true
+	case dgo.StructMapType:
+		if e := ot.Get(t.key); e != nil && e.Required() {
+			if et, ok := e.Value().(dgo.ExactType); ok {
+				c := t.Case(et.ExactValue())
+				return c != nil && c.Assignable(ot)
+			}
+		}
+		return false
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+// AssignableTo returns true if all cases of this type are assignable to the other type
+func (t *conditionalStructType) AssignableTo(guard dgo.RecursionGuard, other dgo.Type) bool {
+	for _, c := range t.cases {
+		if !Assignable(guard, other, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *conditionalStructType) Case(discriminatorValue interface{}) dgo.StructMapType {
+	if i := t.caseIndex(Value(discriminatorValue)); i >= 0 {
+		return t.cases[i]
+	}
+	return nil
+}
+
+func (t *conditionalStructType) Cases() dgo.Array {
+	vs := make([]dgo.Value, len(t.cases))
+	for i, c := range t.cases {
+		vs[i] = c
+	}
+	return &array{slice: vs, frozen: true}
+}
+
+func (t *conditionalStructType) Describe() string {
+	return Describe(t)
+}
+
+func (t *conditionalStructType) Discriminator() dgo.Value {
+	return t.key
+}
+
+func (t *conditionalStructType) Equals(other interface{}) bool {
+	if ot, ok := other.(*conditionalStructType); ok && t.key.Equals(ot.key) && len(t.cases) == len(ot.cases) {
+		for i, c := range t.cases {
+			if !c.Equals(ot.cases[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (t *conditionalStructType) HashCode() int {
+	h := int(dgo.TiConditionalStruct)*31 + t.key.HashCode()
+	for _, c := range t.cases {
+		h = h*31 + c.HashCode()
+	}
+	return h
+}
+
+func (t *conditionalStructType) Instance(value interface{}) bool {
+	if m, ok := value.(dgo.Map); ok {
+		c := t.selectCase(m)
+		return c != nil && c.Instance(m)
+	}
+	return false
+}
+
+func (t *conditionalStructType) New(arg dgo.Value) dgo.Value {
+	return newMap(t, arg)
+}
+
+func (t *conditionalStructType) ReflectType() reflect.Type {
+	return DefaultMapType.ReflectType()
+}
+
+func (t *conditionalStructType) String() string {
+	return TypeString(t)
+}
+
+func (t *conditionalStructType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *conditionalStructType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiConditionalStruct
+}
+
+func (t *conditionalStructType) Validate(keyLabel func(key dgo.Value) string, value interface{}) []error {
+	m, ok := Value(value).(dgo.Map)
+	if !ok {
+		return []error{errors.New(`value is not a Map`)}
+	}
+	if keyLabel == nil {
+		keyLabel = parameterLabel
+	}
+	if err := t.discriminatorError(keyLabel, m); err != nil {
+		return []error{err}
+	}
+	return t.selectCase(m).Validate(keyLabel, m)
+}
+
+// discriminatorError returns an error that explains why no case is selected by the given map, or nil if a case is
+// selected
+func (t *conditionalStructType) discriminatorError(keyLabel func(key dgo.Value) string, m dgo.Map) error {
+	v := m.Get(t.key)
+	if v == nil {
+		return fmt.Errorf(`missing required %s, expected %s`, keyLabel(t.key), t.describeValues())
+	}
+	if t.caseIndex(v) < 0 {
+		return fmt.Errorf(`%s must be %s, got %s`, keyLabel(t.key), t.describeValues(), TypeString(v.Type()))
+	}
+	return nil
+}
+
+// describeValues returns a string that lists the valid discriminator values, e.g. `one of "aws" or "gcp"`
+func (t *conditionalStructType) describeValues() string {
+	ds := make([]string, len(t.values))
+	for i, v := range t.values {
+		ds[i] = TypeString(v.Type())
+	}
+	if len(ds) == 1 {
+		return ds[0]
+	}
+	return `one of ` + strings.Join(ds[:len(ds)-1], `, `) + ` or ` + ds[len(ds)-1]
+}
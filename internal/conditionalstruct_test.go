@@ -0,0 +1,145 @@
+package internal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func cloudType() dgo.ConditionalStructType {
+	return tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"aws",region:string}`).(dgo.StructMapType),
+		tf.ParseType(`{type:"gcp",project:string,zone?:string}`).(dgo.StructMapType))
+}
+
+func TestConditionalStructType(t *testing.T) {
+	tp := cloudType()
+	require.Instance(t, tp, vf.Map(`type`, `aws`, `region`, `eu-north-1`))
+	require.Instance(t, tp, vf.Map(`type`, `gcp`, `project`, `x`))
+	require.NotInstance(t, tp, vf.Map(`type`, `aws`, `project`, `x`))
+	require.NotInstance(t, tp, vf.Map(`type`, `azure`, `region`, `x`))
+	require.NotInstance(t, tp, vf.Map(`region`, `x`))
+	require.NotInstance(t, tp, `aws`)
+
+	require.Equal(t, `type`, tp.Discriminator())
+	require.Equal(t, 2, tp.Cases().Len())
+	require.Equal(t, tf.ParseType(`{type:"aws",region:string}`), tp.Case(`aws`))
+	require.Nil(t, tp.Case(`azure`))
+
+	require.Equal(t, tp, cloudType())
+	require.Equal(t, tp.HashCode(), cloudType().HashCode())
+	require.NotEqual(t, tp, tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"aws",region:string}`).(dgo.StructMapType)))
+	require.NotEqual(t, tp, tf.ParseType(`{type:"aws",region:string}|{type:"gcp",project:string,zone?:string}`))
+
+	require.Equal(t, `conditional["type",{"type":"aws","region":string},{"type":"gcp","project":string,"zone"?:string}]`,
+		tp.String())
+	require.Equal(t, tp, tf.ParseType(tp.String()))
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, reflect.TypeOf(map[interface{}]interface{}{}), tp.ReflectType())
+
+	m := tp.(dgo.Factory).New(vf.Map(`type`, `aws`, `region`, `x`))
+	require.Equal(t, vf.Map(`type`, `aws`, `region`, `x`), m)
+	require.Panic(t, func() { tp.(dgo.Factory).New(vf.Map(`type`, `aws`)) }, `cannot be assigned`)
+}
+
+func TestConditionalStructType_illegal(t *testing.T) {
+	require.Panic(t, func() { tf.ConditionalStruct(`type`) }, `at least one case`)
+	require.Panic(t, func() {
+		tf.ConditionalStruct(`type`, tf.ParseType(`{type:string}`).(dgo.StructMapType))
+	}, `no required entry with an exact value for key 'type'`)
+	require.Panic(t, func() {
+		tf.ConditionalStruct(`type`, tf.ParseType(`{type?:"aws"}`).(dgo.StructMapType))
+	}, `no required entry`)
+	require.Panic(t, func() {
+		tf.ConditionalStruct(`type`,
+			tf.ParseType(`{type:"aws",region:string}`).(dgo.StructMapType),
+			tf.ParseType(`{type:"aws",project:string}`).(dgo.StructMapType))
+	}, `have the same value for key 'type'`)
+	require.Panic(t, func() { tf.ParseType(`conditional["type"]`) }, `illegal number of arguments`)
+	require.Panic(t, func() { tf.ParseType(`conditional["type",string]`) }, `illegal argument 2`)
+	require.Panic(t, func() { tf.ParseType(`conditional`) }, `expected '\[', got EOT`)
+}
+
+func TestConditionalStructType_Assignable(t *testing.T) {
+	tp := cloudType()
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, tf.ParseType(`{type:"aws",region:"eu-north-1"}`))
+	require.Assignable(t, tp, tf.ParseType(`{type:"gcp",project:string}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{type:"azure",region:string}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{type:"aws",region:string,extra:int}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{type:string,region:string}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{type?:"aws",region:string}`))
+	require.Assignable(t, tp, tf.ParseType(`{type:"aws",region:string}|{type:"gcp",project:string}`))
+	require.NotAssignable(t, tp, typ.Map)
+
+	require.Assignable(t, tp, tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"gcp",project:"p"}`).(dgo.StructMapType)))
+	require.NotAssignable(t, tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"gcp",project:"p"}`).(dgo.StructMapType)), tp)
+	require.NotAssignable(t, tp, tf.ConditionalStruct(`kind`,
+		tf.ParseType(`{kind:"aws",region:string}`).(dgo.StructMapType)))
+
+	require.Assignable(t, tf.ParseType(`map[string]string`), tp)
+	require.Assignable(t, typ.Any, tp)
+}
+
+func TestConditionalStructType_Validate(t *testing.T) {
+	tp := cloudType()
+	require.Equal(t, 0, len(tp.Validate(nil, vf.Map(`type`, `aws`, `region`, `x`))))
+
+	es := tp.Validate(nil, vf.Map(`type`, `gcp`, `region`, `x`))
+	require.Equal(t, []string{`missing required parameter 'project'`, `unknown parameter 'region'`}, errorStrings(es))
+
+	es = tp.Validate(nil, vf.Map(`type`, `azure`))
+	require.Equal(t, []string{`parameter 'type' must be one of "aws" or "gcp", got "azure"`}, errorStrings(es))
+
+	es = tp.Validate(nil, vf.Map(`region`, `x`))
+	require.Equal(t, []string{`missing required parameter 'type', expected one of "aws" or "gcp"`}, errorStrings(es))
+
+	es = tp.Validate(nil, `x`)
+	require.Equal(t, []string{`value is not a Map`}, errorStrings(es))
+
+	single := tf.ConditionalStruct(`type`, tf.ParseType(`{type:"aws"}`).(dgo.StructMapType))
+	es = single.Validate(func(k dgo.Value) string { return `attribute ` + k.String() }, vf.Map(`type`, 1))
+	require.Equal(t, []string{`attribute type must be "aws", got 1`}, errorStrings(es))
+}
+
+func TestConditionalStructType_validation(t *testing.T) {
+	tp := cloudType()
+	ve := typ.Validate(tp, vf.Map(`type`, `gcp`, `region`, `x`)).(dgo.ValidationError)
+	require.Equal(t, []string{`field 'project': missing`, `field 'region': unexpected`}, ve.Reasons())
+
+	ve = typ.Validate(tp, vf.Map(`type`, `azure`)).(dgo.ValidationError)
+	require.Equal(t, []string{`field 'type' must be one of "aws" or "gcp", got "azure"`}, ve.Reasons())
+
+	ve = typ.Validate(tp, 3).(dgo.ValidationError)
+	require.Equal(t, 1, len(ve.Reasons()))
+
+	errs := typ.ValidateAll(tf.ParseType(`[]`+tp.String()), vf.Values(
+		vf.Map(`type`, `aws`, `region`, 1),
+		vf.Map(`type`, `gcp`),
+		vf.Map(`region`, `x`),
+		`x`))
+	require.Equal(t, []string{
+		`/0/region: the value 1 cannot be assigned to a variable of type string`,
+		`/1/project: missing required key 'project'`,
+		`/2/type: missing required key 'type', expected one of "aws" or "gcp"`,
+		`/3: the string "x" cannot be assigned to a variable of type ` + tp.String(),
+	}, errorStrings(errs))
+}
+
+func TestConditionalStructType_Describe(t *testing.T) {
+	tp := tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"aws",region:string}`).(dgo.StructMapType),
+		tf.ParseType(`{type:"gcp",project:string}`).(dgo.StructMapType))
+	require.Equal(t,
+		`a map with 'type' (the string "aws") and 'region' (a string) or `+
+			`a map with 'type' (the string "gcp") and 'project' (a string)`,
+		tp.(dgo.DescribableType).Describe())
+}
@@ -0,0 +1,522 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	// decimalVal is a fixed point decimal number with the value unscaled * 10^-scale. The unscaled value is never
+	// mutated.
+	decimalVal struct {
+		unscaled *big.Int
+		scale    int
+	}
+
+	defaultDecimalType int
+
+	// decimalType constrains the total number of digits and the number of digits after the decimal point
+	decimalType struct {
+		precision int
+		scale     int
+	}
+
+	exactDecimalType struct {
+		exactType
+		value *decimalVal
+	}
+)
+
+// DefaultDecimalType is the unconstrained Decimal type
+const DefaultDecimalType = defaultDecimalType(0)
+
+var reflectDecimalType = reflect.TypeOf(&big.Rat{})
+
+var bigTen = big.NewInt(10)
+
+// MaxDecimalScale is the largest absolute value that is permitted for the scale of a decimal and for the exponent of a
+// parsed decimal. It bounds the cost of rescaling decimals when they are created and compared.
+const MaxDecimalScale = 10000
+
+// Decimal returns a dgo.Decimal with the value unscaled * 10^-scale. The given unscaled value is copied. The function
+// will panic if the absolute value of the scale is greater than MaxDecimalScale.
+func Decimal(unscaled *big.Int, scale int) dgo.Decimal {
+	if scale < -MaxDecimalScale || scale > MaxDecimalScale {
+		panic(scaleOutOfRange(scale))
+	}
+	if scale < 0 {
+		return &decimalVal{new(big.Int).Mul(unscaled, pow10(-scale)), 0}
+	}
+	return &decimalVal{new(big.Int).Set(unscaled), scale}
+}
+
+// DecimalFromString returns the given string as a dgo.Decimal. The string must be a decimal number with an optional
+// sign, fraction, and exponent, e.g. "-12.50" or "1.5e3". The scale of the result is the number of digits after the
+// decimal point, so "12.50" has the scale 2. The function will panic if the string cannot be parsed or if the
+// absolute value of its exponent or resulting scale is greater than MaxDecimalScale.
+func DecimalFromString(s string) dgo.Decimal {
+	d, err := parseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func scaleOutOfRange(scale int) error {
+	return fmt.Errorf(`decimal scale %d is outside the range -%d to %d`, scale, MaxDecimalScale, MaxDecimalScale)
+}
+
+func parseDecimal(s string) (*decimalVal, error) {
+	m := s
+	exp := 0
+	if ei := strings.IndexAny(m, `eE`); ei >= 0 {
+		var err error
+		exp, err = strconv.Atoi(m[ei+1:])
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange || exp < -MaxDecimalScale ||
+			exp > MaxDecimalScale {
+			return nil, fmt.Errorf(`the exponent of '%s' is outside the range -%d to %d`, s, MaxDecimalScale, MaxDecimalScale)
+		}
+		if err != nil {
+			return nil, fmt.Errorf(`unable to parse '%s' as a decimal`, s)
+		}
+		m = m[:ei]
+	}
+	neg := false
+	if m != `` && (m[0] == '-' || m[0] == '+') {
+		neg = m[0] == '-'
+		m = m[1:]
+	}
+	ip, fp := m, ``
+	if pi := strings.IndexByte(m, '.'); pi >= 0 {
+		ip, fp = m[:pi], m[pi+1:]
+	}
+	ds := ip + fp
+	if ds == `` || strings.Trim(ds, `0123456789`) != `` {
+		return nil, fmt.Errorf(`unable to parse '%s' as a decimal`, s)
+	}
+	// The exponent is bounded so the subtraction cannot overflow
+	scale := len(fp) - exp
+	if scale < -MaxDecimalScale || scale > MaxDecimalScale {
+		return nil, scaleOutOfRange(scale)
+	}
+	u, _ := new(big.Int).SetString(ds, 10)
+	if neg {
+		u.Neg(u)
+	}
+	return Decimal(u, scale).(*decimalVal), nil
+}
+
+// decimalFromRat returns the given rational number as a decimal and true, or nil and false if the number has no
+// finite decimal representation.
+func decimalFromRat(r *big.Rat) (*decimalVal, bool) {
+	// The denominator of a rational number with a finite decimal representation has no prime factors other than
+	// two and five. The number of digits needed after the decimal point is the highest exponent of those factors.
+	d := new(big.Int).Set(r.Denom())
+	m := new(big.Int)
+	twos, fives := 0, 0
+	for m.Mod(d, big.NewInt(2)).Sign() == 0 {
+		d.Quo(d, big.NewInt(2))
+		twos++
+	}
+	for m.Mod(d, big.NewInt(5)).Sign() == 0 {
+		d.Quo(d, big.NewInt(5))
+		fives++
+	}
+	if !d.IsInt64() || d.Int64() != 1 {
+		return nil, false
+	}
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	if scale > MaxDecimalScale {
+		return nil, false
+	}
+	u := new(big.Int).Mul(r.Num(), pow10(scale))
+	return &decimalVal{u.Quo(u, r.Denom()), scale}, true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// DecimalType returns a dgo.DecimalType that matches decimals with at most precision digits in total, of which at
+// most scale digits are after the decimal point.
+func DecimalType(precision, scale int) dgo.DecimalType {
+	if precision < 1 || scale < 0 || scale > precision {
+		panic(fmt.Errorf(`illegal decimal precision %d and scale %d`, precision, scale))
+	}
+	return &decimalType{precision: precision, scale: scale}
+}
+
+// DecimalTypeFromArgs returns a dgo.DecimalType created from the given arguments. No arguments gives the
+// unconstrained type. One string argument gives the exact type for that decimal. One or two integer arguments give
+// the precision and the scale. The scale defaults to zero.
+func DecimalTypeFromArgs(args []interface{}) dgo.DecimalType {
+	switch len(args) {
+	case 0:
+		return DefaultDecimalType
+	case 1:
+		switch a0 := Value(args[0]).(type) {
+		case dgo.String:
+			return DecimalFromString(a0.GoString()).Type().(dgo.DecimalType)
+		case dgo.Integer:
+			return DecimalType(int(a0.GoInt()), 0)
+		}
+		panic(illegalArgument(`DecimalType`, `Integer or String`, args, 0))
+	case 2:
+		if a0, ok := Value(args[0]).(dgo.Integer); ok {
+			var a1 dgo.Integer
+			if a1, ok = Value(args[1]).(dgo.Integer); ok {
+				return DecimalType(int(a0.GoInt()), int(a1.GoInt()))
+			}
+			panic(illegalArgument(`DecimalType`, `Integer`, args, 1))
+		}
+		panic(illegalArgument(`DecimalType`, `Integer`, args, 0))
+	}
+	panic(illegalArgumentCount(`DecimalType`, 0, 2, len(args)))
+}
+
+// toDecimal returns the given value as a decimal and true, or nil and false if the value can't be represented
+// exactly as a decimal.
+func toDecimal(v interface{}) (*decimalVal, bool) {
+	switch v := v.(type) {
+	case *decimalVal:
+		return v, true
+	case *big.Rat:
+		return decimalFromRat(v)
+	}
+	if b, ok := toBig(v); ok {
+		return &decimalVal{b, 0}, true
+	}
+	return nil, false
+}
+
+func (t defaultDecimalType) Assignable(other dgo.Type) bool {
+	switch other.(type) {
+	case defaultDecimalType, *decimalType, *exactDecimalType:
+		return true
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t defaultDecimalType) Describe() string {
+	return Describe(t)
+}
+
+func (t defaultDecimalType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t defaultDecimalType) HashCode() int {
+	return int(dgo.TiDecimal)
+}
+
+func (t defaultDecimalType) Instance(value interface{}) bool {
+	_, ok := value.(*decimalVal)
+	return ok
+}
+
+func (t defaultDecimalType) New(arg dgo.Value) dgo.Value {
+	return newDecimal(t, arg)
+}
+
+func (t defaultDecimalType) Precision() int {
+	return 0
+}
+
+func (t defaultDecimalType) ReflectType() reflect.Type {
+	return reflectDecimalType
+}
+
+func (t defaultDecimalType) Scale() int {
+	return 0
+}
+
+func (t defaultDecimalType) String() string {
+	return TypeString(t)
+}
+
+func (t defaultDecimalType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t defaultDecimalType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDecimal
+}
+
+func (t *decimalType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactDecimalType:
+		return t.Instance(ot.value)
+	case *decimalType:
+		return ot.scale <= t.scale && ot.precision-ot.scale <= t.precision-t.scale
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *decimalType) Describe() string {
+	return Describe(t)
+}
+
+func (t *decimalType) Equals(other interface{}) bool {
+	if ot, ok := other.(*decimalType); ok {
+		return *t == *ot
+	}
+	return false
+}
+
+func (t *decimalType) HashCode() int {
+	return (int(dgo.TiDecimalSized)*31+t.precision)*31 + t.scale
+}
+
+func (t *decimalType) Instance(value interface{}) bool {
+	if d, ok := value.(*decimalVal); ok {
+		d = d.stripped()
+		return d.scale <= t.scale && d.intDigits() <= t.precision-t.scale
+	}
+	return false
+}
+
+func (t *decimalType) New(arg dgo.Value) dgo.Value {
+	return newDecimal(t, arg)
+}
+
+func (t *decimalType) Precision() int {
+	return t.precision
+}
+
+func (t *decimalType) ReflectType() reflect.Type {
+	return reflectDecimalType
+}
+
+func (t *decimalType) Scale() int {
+	return t.scale
+}
+
+func (t *decimalType) String() string {
+	return TypeString(t)
+}
+
+func (t *decimalType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *decimalType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDecimalSized
+}
+
+func (t *exactDecimalType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactDecimalType) Generic() dgo.Type {
+	return DefaultDecimalType
+}
+
+func (t *exactDecimalType) New(arg dgo.Value) dgo.Value {
+	return newDecimal(t, arg)
+}
+
+func (t *exactDecimalType) Precision() int {
+	return t.value.Precision()
+}
+
+func (t *exactDecimalType) ReflectType() reflect.Type {
+	return reflectDecimalType
+}
+
+func (t *exactDecimalType) Scale() int {
+	return t.value.scale
+}
+
+func (t *exactDecimalType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDecimalExact
+}
+
+func newDecimal(t dgo.Type, arg dgo.Value) dgo.Decimal {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`decimal`, 1, 1)
+		arg = args.Get(0)
+	}
+	var dv dgo.Decimal
+	switch arg := arg.(type) {
+	case dgo.Decimal:
+		dv = arg
+	case dgo.Integer:
+		dv = &decimalVal{big.NewInt(arg.GoInt()), 0}
+	case dgo.BigInt:
+		dv = &decimalVal{arg.GoBigInt(), 0}
+	case dgo.Float:
+		f := arg.GoFloat()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			panic(fmt.Errorf(`unable to convert %s to a decimal`, arg))
+		}
+		dv = DecimalFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	case dgo.String:
+		dv = DecimalFromString(arg.GoString())
+	default:
+		panic(illegalArgument(`decimal`, `decimal|int|float|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(dv) {
+		panic(IllegalAssignment(t, dv))
+	}
+	return dv
+}
+
+// stripped returns this decimal without trailing zeros in the fraction
+func (v *decimalVal) stripped() *decimalVal {
+	if v.scale == 0 || v.unscaled.Sign() == 0 {
+		if v.scale == 0 {
+			return v
+		}
+		return &decimalVal{v.unscaled, 0}
+	}
+	u := new(big.Int).Set(v.unscaled)
+	s := v.scale
+	q, m := new(big.Int), new(big.Int)
+	for s > 0 {
+		q.QuoRem(u, bigTen, m)
+		if m.Sign() != 0 {
+			break
+		}
+		u, q = q, u
+		s--
+	}
+	if s == v.scale {
+		return v
+	}
+	return &decimalVal{u, s}
+}
+
+// intDigits returns the number of digits before the decimal point, not counting a single zero
+func (v *decimalVal) intDigits() int {
+	if n := v.Precision() - v.scale; n > 0 && v.unscaled.Sign() != 0 {
+		return n
+	}
+	return 0
+}
+
+// rescaled returns the unscaled value of this decimal for the given scale which must be greater than or equal to
+// the scale of this decimal
+func (v *decimalVal) rescaled(scale int) *big.Int {
+	if scale == v.scale {
+		return v.unscaled
+	}
+	return new(big.Int).Mul(v.unscaled, pow10(scale-v.scale))
+}
+
+func (v *decimalVal) cmp(o *decimalVal) int {
+	s := v.scale
+	if o.scale > s {
+		s = o.scale
+	}
+	return v.rescaled(s).Cmp(o.rescaled(s))
+}
+
+func (v *decimalVal) CompareTo(other interface{}) (int, bool) {
+	if od, ok := toDecimal(other); ok {
+		return v.cmp(od), true
+	}
+	if of, ok := ToFloat(other); ok {
+		return v.Rat().Cmp(new(big.Rat).SetFloat64(of)), true
+	}
+	if other == Nil || other == nil {
+		return 1, true
+	}
+	return 0, false
+}
+
+// Equals returns true if the other value is a decimal that is numerically equal to this decimal. The scale is not
+// considered so 1.5 is equal to 1.50.
+func (v *decimalVal) Equals(other interface{}) bool {
+	switch ov := other.(type) {
+	case *decimalVal:
+		return v.cmp(ov) == 0
+	case *big.Rat:
+		return v.Rat().Cmp(ov) == 0
+	}
+	return false
+}
+
+func (v *decimalVal) HashCode() int {
+	s := v.stripped()
+	h := s.unscaled.Sign()*31 + s.scale
+	for _, w := range s.unscaled.Bits() {
+		h = h*31 + int(w)
+	}
+	return h
+}
+
+func (v *decimalVal) Precision() int {
+	u := v.unscaled
+	if u.Sign() < 0 {
+		u = new(big.Int).Neg(u)
+	}
+	return len(u.String())
+}
+
+func (v *decimalVal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(v.unscaled, pow10(v.scale))
+}
+
+func (v *decimalVal) ReflectTo(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(v.ToFloat())
+	case reflect.String:
+		value.SetString(v.String())
+	case reflect.Struct:
+		value.Set(reflect.ValueOf(v.Rat()).Elem())
+	default:
+		value.Set(reflect.ValueOf(v.Rat()))
+	}
+}
+
+func (v *decimalVal) Scale() int {
+	return v.scale
+}
+
+func (v *decimalVal) String() string {
+	s := v.unscaled.String()
+	if v.scale == 0 {
+		return s
+	}
+	sign := ``
+	if s[0] == '-' {
+		sign = `-`
+		s = s[1:]
+	}
+	if len(s) <= v.scale {
+		s = strings.Repeat(`0`, v.scale-len(s)+1) + s
+	}
+	p := len(s) - v.scale
+	return sign + s[:p] + `.` + s[p:]
+}
+
+// ToFloat returns the float64 that is nearest to this value
+func (v *decimalVal) ToFloat() float64 {
+	f, _ := v.Rat().Float64()
+	return f
+}
+
+// ToInt returns the integral part of this value as an int64. The result is undefined if it doesn't fit.
+func (v *decimalVal) ToInt() int64 {
+	return new(big.Int).Quo(v.unscaled, pow10(v.scale)).Int64()
+}
+
+func (v *decimalVal) Type() dgo.Type {
+	et := &exactDecimalType{value: v}
+	et.ExactType = et
+	return et
+}
+
+func (v *decimalVal) Unscaled() *big.Int {
+	return new(big.Int).Set(v.unscaled)
+}
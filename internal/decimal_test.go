@@ -0,0 +1,233 @@
+package internal_test
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestDecimalDefault(t *testing.T) {
+	tp := typ.Decimal.(dgo.DecimalType)
+	d := vf.DecimalFromString(`12.50`)
+	require.Instance(t, tp, d)
+	require.NotInstance(t, tp, 12.5)
+	require.NotInstance(t, tp, 12)
+	require.NotInstance(t, tp, `12.50`)
+	require.Equal(t, 0, tp.Precision())
+	require.Equal(t, 0, tp.Scale())
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, d.Type())
+	require.Assignable(t, tp, tf.Decimal(10, 2))
+	require.NotAssignable(t, tp, typ.Float)
+	require.NotAssignable(t, typ.Float, tp)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.Float)
+	require.Equal(t, tp.HashCode(), tp.HashCode())
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `decimal`, tp.String())
+	require.Same(t, tp, typ.Generic(d.Type()))
+	require.True(t, reflect.ValueOf(d.Rat()).Type().AssignableTo(tp.ReflectType()))
+}
+
+func TestDecimalSized(t *testing.T) {
+	tp := tf.Decimal(5, 2)
+	require.Equal(t, 5, tp.Precision())
+	require.Equal(t, 2, tp.Scale())
+	require.Instance(t, tp, vf.DecimalFromString(`123.45`))
+	require.Instance(t, tp, vf.DecimalFromString(`-123.45`))
+	require.Instance(t, tp, vf.DecimalFromString(`123.4500`))
+	require.Instance(t, tp, vf.DecimalFromString(`0.05`))
+	require.Instance(t, tp, vf.DecimalFromString(`0`))
+	require.NotInstance(t, tp, vf.DecimalFromString(`1234.5`))
+	require.NotInstance(t, tp, vf.DecimalFromString(`1.234`))
+	require.NotInstance(t, tp, 1.5)
+	require.Instance(t, tf.Decimal(2, 2), vf.DecimalFromString(`0.99`))
+	require.NotInstance(t, tf.Decimal(2, 2), vf.DecimalFromString(`1.0`).Type().(dgo.ExactType).ExactValue())
+
+	require.Assignable(t, typ.Decimal, tp)
+	require.NotAssignable(t, tp, typ.Decimal)
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, tf.Decimal(4, 1))
+	require.Assignable(t, tp, tf.Decimal(3, 0))
+	require.NotAssignable(t, tp, tf.Decimal(6, 2))
+	require.NotAssignable(t, tp, tf.Decimal(5, 3))
+	require.Assignable(t, tp, vf.DecimalFromString(`999.99`).Type())
+	require.NotAssignable(t, tp, vf.DecimalFromString(`9999.9`).Type())
+	require.NotAssignable(t, tp, typ.Float)
+
+	require.Equal(t, tp, tf.Decimal(5, 2))
+	require.NotEqual(t, tp, tf.Decimal(5, 1))
+	require.NotEqual(t, tp, typ.Decimal)
+	require.Equal(t, tp.HashCode(), tf.Decimal(5, 2).HashCode())
+	require.NotEqual(t, tp.HashCode(), tf.Decimal(5, 1).HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `decimal[5,2]`, tp.String())
+	require.Equal(t, typ.Decimal.ReflectType(), tp.ReflectType())
+
+	require.Panic(t, func() { tf.Decimal(0, 0) }, `illegal decimal precision 0 and scale 0`)
+	require.Panic(t, func() { tf.Decimal(2, 3) }, `illegal decimal precision 2 and scale 3`)
+	require.Panic(t, func() { tf.Decimal(2, -1) }, `illegal decimal precision 2 and scale -1`)
+}
+
+func TestDecimalExact(t *testing.T) {
+	d := vf.DecimalFromString(`12.50`)
+	tp := d.Type().(dgo.DecimalType)
+	require.Equal(t, 4, tp.Precision())
+	require.Equal(t, 2, tp.Scale())
+	require.Instance(t, tp, d)
+	require.Instance(t, tp, vf.DecimalFromString(`12.5`))
+	require.NotInstance(t, tp, vf.DecimalFromString(`12.51`))
+	require.NotInstance(t, tp, 12.5)
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, typ.Decimal)
+	require.Equal(t, tp, vf.DecimalFromString(`12.500`).Type())
+	require.Equal(t, `decimal["12.50"]`, tp.String())
+	require.Equal(t, typ.Decimal.ReflectType(), tp.ReflectType())
+}
+
+func TestDecimalType_New(t *testing.T) {
+	d := vf.DecimalFromString(`12.50`)
+	require.Same(t, d, vf.New(typ.Decimal, d))
+	require.Equal(t, d, vf.New(typ.Decimal, vf.Arguments(vf.String(`12.5`))))
+	require.Equal(t, d, vf.New(typ.Decimal, vf.Float(12.5)))
+	require.Equal(t, vf.DecimalFromString(`0.1`), vf.New(typ.Decimal, vf.Float(0.1)))
+	require.Equal(t, vf.DecimalFromString(`12`), vf.New(typ.Decimal, vf.Integer(12)))
+	require.Equal(t, vf.DecimalFromString(`123456789012345678901234567890`),
+		vf.New(typ.Decimal, vf.BigIntFromString(`123456789012345678901234567890`)))
+	require.Equal(t, d, vf.New(tf.Decimal(4, 2), vf.String(`12.5`)))
+
+	require.Panic(t, func() { vf.New(tf.Decimal(4, 2), vf.String(`12.555`)) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.Decimal, vf.Float(math.Inf(1))) }, `unable to convert \+Inf`)
+	require.Panic(t, func() { vf.New(typ.Decimal, vf.True) }, `illegal argument`)
+
+	require.Equal(t, 12.5, vf.New(typ.Float, d))
+}
+
+func TestDecimalFromString(t *testing.T) {
+	tests := []struct {
+		in       string
+		out      string
+		unscaled int64
+		scale    int
+	}{
+		{`12.50`, `12.50`, 1250, 2},
+		{`-12.50`, `-12.50`, -1250, 2},
+		{`+7`, `7`, 7, 0},
+		{`.5`, `0.5`, 5, 1},
+		{`5.`, `5`, 5, 0},
+		{`-0.005`, `-0.005`, -5, 3},
+		{`1.5e3`, `1500`, 1500, 0},
+		{`1.5E-3`, `0.0015`, 15, 4},
+	}
+	for _, tt := range tests {
+		d := vf.DecimalFromString(tt.in)
+		require.Equal(t, tt.out, d.String())
+		require.Equal(t, big.NewInt(tt.unscaled), d.Unscaled())
+		require.Equal(t, tt.scale, d.Scale())
+	}
+	for _, bad := range []string{``, `-`, `.`, `1.2.3`, `1e`, `1x`, `0x10`} {
+		require.Panic(t, func() { vf.DecimalFromString(bad) }, `unable to parse`)
+	}
+}
+
+func TestDecimalFromString_range(t *testing.T) {
+	require.Equal(t, 10000, vf.DecimalFromString(`1e-10000`).Scale())
+	require.Equal(t, `1`+strings.Repeat(`0`, 10000), vf.DecimalFromString(`1e10000`).String())
+	for _, bad := range []string{`1e-9223372036854775808`, `5e-9223372036854775807`, `1e9223372036854775807`,
+		`1e99999999999999999999`, `1e9999999`, `1e-99999999`, `1e10001`} {
+		require.Panic(t, func() { vf.DecimalFromString(bad) }, `exponent of '.*' is outside the range -10000 to 10000`)
+	}
+	require.Panic(t, func() { vf.DecimalFromString(`0.1e-10000`) }, `scale 10001 is outside the range`)
+	require.Panic(t, func() { vf.DecimalFromString(`0.` + strings.Repeat(`0`, 20000) + `1e10000`) }, `scale`)
+	require.Panic(t, func() { vf.Decimal(big.NewInt(1), -10001) }, `scale -10001 is outside the range`)
+	require.Panic(t, func() { vf.Decimal(big.NewInt(1), 10001) }, `scale 10001 is outside the range`)
+	require.Panic(t, func() { vf.New(typ.Decimal, vf.String(`1e-99999999`)) }, `outside the range`)
+
+	v, ok := vf.DecimalFromString(`1e-10000`).CompareTo(vf.DecimalFromString(`1e10000`))
+	require.True(t, ok)
+	require.Equal(t, -1, v)
+}
+
+func TestDecimal(t *testing.T) {
+	d := vf.Decimal(big.NewInt(1250), 2)
+	require.Equal(t, `12.50`, d.String())
+	require.Equal(t, 4, d.Precision())
+	require.Equal(t, d, vf.DecimalFromString(`12.5`))
+	require.Equal(t, d.HashCode(), vf.DecimalFromString(`12.500`).HashCode())
+	require.NotEqual(t, d, vf.DecimalFromString(`12.51`))
+	require.NotEqual(t, d, 12.5)
+	require.Equal(t, `1200`, vf.Decimal(big.NewInt(12), -2).String())
+	require.Equal(t, 1, vf.Decimal(big.NewInt(0), 3).Precision())
+	require.Equal(t, 3, vf.DecimalFromString(`-0.125`).Precision())
+
+	u := d.Unscaled()
+	u.SetInt64(1)
+	require.Equal(t, `12.50`, d.String())
+
+	require.Equal(t, big.NewRat(25, 2), d.Rat())
+	require.Equal(t, 12.5, d.ToFloat())
+	require.Equal(t, int64(12), d.ToInt())
+	require.Equal(t, int64(-12), vf.DecimalFromString(`-12.9`).ToInt())
+
+	c, ok := d.CompareTo(vf.DecimalFromString(`12.499`))
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	c, ok = d.CompareTo(13)
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = d.CompareTo(big.NewRat(25, 2))
+	require.True(t, ok)
+	require.Equal(t, 0, c)
+	c, ok = d.CompareTo(12.25)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	c, ok = d.CompareTo(vf.Nil)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	_, ok = d.CompareTo(`12.5`)
+	require.False(t, ok)
+}
+
+func TestDecimal_fromRat(t *testing.T) {
+	require.Equal(t, vf.DecimalFromString(`0.125`), vf.Value(big.NewRat(1, 8)))
+	require.Equal(t, vf.DecimalFromString(`0.04`), vf.Value(big.NewRat(1, 25)))
+	require.Equal(t, vf.DecimalFromString(`-3.3`), vf.Value(big.NewRat(-33, 10)))
+
+	// One third has no finite decimal representation
+	_, ok := vf.Value(big.NewRat(1, 3)).(dgo.Decimal)
+	require.False(t, ok)
+}
+
+func TestDecimal_ReflectTo(t *testing.T) {
+	d := vf.DecimalFromString(`12.50`)
+	var rp *big.Rat
+	vf.ReflectTo(d, reflect.ValueOf(&rp).Elem())
+	require.Equal(t, big.NewRat(25, 2), rp)
+
+	var rv big.Rat
+	vf.ReflectTo(d, reflect.ValueOf(&rv).Elem())
+	require.Equal(t, `25/2`, rv.String())
+
+	var f float64
+	vf.ReflectTo(d, reflect.ValueOf(&f).Elem())
+	require.Equal(t, 12.5, f)
+
+	var s string
+	vf.ReflectTo(d, reflect.ValueOf(&s).Elem())
+	require.Equal(t, `12.50`, s)
+
+	var mi interface{}
+	vf.ReflectTo(d, reflect.ValueOf(&mi).Elem())
+	require.Equal(t, big.NewRat(25, 2), mi)
+}
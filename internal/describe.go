@@ -0,0 +1,366 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+// Describe returns a natural language description of the given type that is suitable for messages intended for
+// end users, e.g. "an integer between 0 and 100" or "an array of 1 to 10 strings".
+func Describe(t dgo.Type) string {
+	return (&describer{}).describe(t)
+}
+
+type describer struct {
+	seen []dgo.Value
+}
+
+func (d *describer) describe(t dgo.Type) string {
+	switch t := t.(type) {
+	case anyType:
+		return `any value`
+	case nilType:
+		return `nil`
+	case booleanType:
+		return `a boolean`
+	case defaultIntegerType:
+		return `an integer`
+	case *integerType:
+		return describeIntegerRange(t)
+	case defaultFloatType:
+		return `a float`
+	case *floatType:
+		return describeFloatRange(t)
+	case defaultStringType:
+		return `a string`
+	case defaultDgoStringType:
+		return `a string containing a type expression`
+	case *encodedStringType:
+		return `a ` + t.ti.String() + ` encoded string`
+	case *sizedStringType:
+		return `a string` + describeSize(t.min, t.max, `character`, `characters`, `with`)
+	case *patternType:
+		return `a string matching /` + t.Regexp.String() + `/`
+	case *ciStringType:
+		return `a string equal to ` + strconv.Quote(t.value.s) + ` ignoring case`
+	case *exactStringType:
+		return `the string ` + strconv.Quote(t.value.s)
+	case *binaryType:
+		return `a binary` + describeSize(t.min, t.max, `byte`, `bytes`, `of`)
+	case regexpType:
+		return `a regular expression`
+	case timeType:
+		return `a time`
+	case *timeRangeType:
+		return describeTimeRange(t.min, t.max)
+	case durationType:
+		return `a duration`
+	case bigIntType:
+		return `an integer of any size`
+	case defaultDecimalType:
+		return `a decimal`
+	case *decimalType:
+		return describeDecimal(t.precision, t.scale)
+	case *durationRangeType:
+		return describeDurationRange(t.min, t.max)
+	case defaultSemVerType:
+		return `a semantic version`
+	case *semVerConstraintType:
+		return `a semantic version in the range ` + t.rng.expr
+	case defaultSemVerRangeType:
+		return `a semantic version range`
+	case *ipType:
+		return describeIPType(t)
+	case *cidrType:
+		if t.network == nil {
+			return `a CIDR network`
+		}
+		return `a CIDR network within ` + t.network.String()
+	case defaultURIType:
+		return `a URI`
+	case *uriType:
+		return describeURI(t.scheme, t.host)
+	case errType:
+		return `an error`
+	case *nativeType:
+		if t.rt == nil {
+			return `a native value`
+		}
+		return `a native value of type ` + t.rt.String()
+	case *metaType:
+		if t.tp == DefaultAnyType {
+			return `a type`
+		}
+		return `the type ` + TypeString(t.tp)
+	case dgo.ExactType:
+		return `the value ` + t.ExactValue().String()
+	}
+
+	// Remaining types may be recursive
+	if util.RecursionHit(d.seen, t) {
+		return `a value of type ` + TypeString(t)
+	}
+	d.seen = append(d.seen, t)
+	defer func() { d.seen = d.seen[:len(d.seen)-1] }()
+
+	switch t := t.(type) {
+	case defaultArrayType:
+		return `an array`
+	case *sizedArrayType:
+		return `an array` + d.describeElements(t.min, t.max, t.elementType)
+	case dgo.TupleType:
+		return d.describeTuple(t)
+	case defaultMapType:
+		return `a map`
+	case *sizedMapType:
+		s := `a map`
+		if t.keyType != DefaultAnyType || t.valueType != DefaultAnyType {
+			s += ` from ` + d.plural(t.keyType) + ` to ` + d.plural(t.valueType)
+		}
+		return s + describeSize(t.min, t.max, `entry`, `entries`, `with`)
+	case dgo.StructMapType:
+		return d.describeStruct(t)
+	case *conditionalStructType:
+		return d.join(t.Cases(), `or`)
+	case *multiMapType:
+		return `a multimap from ` + d.plural(t.keyType) + ` to ` + d.plural(t.elementType)
+	case *anyOfType:
+		return d.join(t.Operands(), `or`)
+	case *oneOfType:
+		return `exactly one of ` + d.join(t.Operands(), `or`)
+	case *allOfType:
+		return `a value that is ` + d.join(t.Operands(), `and`)
+	case *notType:
+		if t.negated == DefaultAnyType {
+			return `no value`
+		}
+		return `a value that is not ` + d.describe(t.negated)
+	case *sensitiveType:
+		if t.wrapped == DefaultAnyType {
+			return `a sensitive value`
+		}
+		return `a sensitive ` + strings.TrimPrefix(strings.TrimPrefix(d.describe(t.wrapped), `an `), `a `)
+	case dgo.FunctionType:
+		return `a function`
+	case dgo.NamedType:
+		return `a ` + t.Name()
+	}
+	return `a value of type ` + TypeString(t)
+}
+
+func describeIntegerRange(t *integerType) string {
+	min, max := t.min, t.max
+	if !t.inclusive && max != math.MaxInt64 {
+		// Integers in an exclusive range never reach the max value
+		max--
+	}
+	switch {
+	case min == math.MinInt64 && max == math.MaxInt64:
+		return `an integer`
+	case min == math.MinInt64:
+		return fmt.Sprintf(`an integer less than or equal to %d`, max)
+	case max == math.MaxInt64:
+		return fmt.Sprintf(`an integer greater than or equal to %d`, min)
+	}
+	return fmt.Sprintf(`an integer between %d and %d`, min, max)
+}
+
+func describeFloatRange(t *floatType) string {
+	lt := `less than or equal to`
+	if !t.inclusive {
+		lt = `less than`
+	}
+	switch {
+	case t.min == -math.MaxFloat64 && t.max == math.MaxFloat64:
+		return `a float`
+	case t.min == -math.MaxFloat64:
+		return fmt.Sprintf(`a float %s %g`, lt, t.max)
+	case t.max == math.MaxFloat64:
+		return fmt.Sprintf(`a float greater than or equal to %g`, t.min)
+	case t.inclusive:
+		return fmt.Sprintf(`a float between %g and %g`, t.min, t.max)
+	}
+	return fmt.Sprintf(`a float greater than or equal to %g and less than %g`, t.min, t.max)
+}
+
+// describeSize returns a size phrase such as " with 1 to 10 characters" or an empty string when the size is
+// unconstrained
+func describeSize(min, max int, singular, plural, preposition string) string {
+	noun := func(n int) string {
+		if n == 1 {
+			return singular
+		}
+		return plural
+	}
+	switch {
+	case min == 0 && max == math.MaxInt64:
+		return ``
+	case min == max:
+		return fmt.Sprintf(` %s exactly %d %s`, preposition, min, noun(min))
+	case min == 0:
+		return fmt.Sprintf(` %s at most %d %s`, preposition, max, noun(max))
+	case max == math.MaxInt64:
+		return fmt.Sprintf(` %s at least %d %s`, preposition, min, noun(min))
+	}
+	return fmt.Sprintf(` %s %d to %d %s`, preposition, min, max, plural)
+}
+
+func (d *describer) describeElements(min, max int, et dgo.Type) string {
+	if min == 0 && max == math.MaxInt64 {
+		return ` of ` + d.plural(et)
+	}
+	ns, np := `element that is `+d.describe(et), `elements that are `+d.describe(et)
+	if n, ok := pluralNoun(et); ok {
+		ns, np = n[:len(n)-1], n
+	}
+	return describeSize(min, max, ns, np, `of`)
+}
+
+func (d *describer) describeTuple(t dgo.TupleType) string {
+	n := t.Len()
+	if n == 0 {
+		return `an empty array`
+	}
+	ds := make([]string, n)
+	for i := 0; i < n; i++ {
+		ds[i] = d.describe(t.Element(i))
+	}
+	if t.Variadic() {
+		ds[n-1] = `any number of ` + d.plural(t.Element(n-1))
+	}
+	return `an array containing ` + joinDescriptions(ds, `and`)
+}
+
+func (d *describer) describeStruct(t dgo.StructMapType) string {
+	var ds []string
+	t.Each(func(e dgo.StructMapEntry) {
+		k, exact := entryKey(e)
+		var s string
+		if exact {
+			s = `'` + k.String() + `' (`
+		} else {
+			s = `keys matching ` + k.String() + ` (`
+		}
+		if !e.Required() && exact {
+			s += `optional, `
+		}
+		ds = append(ds, s+d.describe(e.Value().(dgo.Type))+`)`)
+	})
+	if t.Additional() {
+		ds = append(ds, `any other entries`)
+	}
+	if len(ds) == 0 {
+		return `an empty map`
+	}
+	return `a map with ` + joinDescriptions(ds, `and`)
+}
+
+func (d *describer) join(ops dgo.Array, conjunction string) string {
+	ds := make([]string, ops.Len())
+	ops.EachWithIndex(func(op dgo.Value, i int) {
+		ds[i] = d.describe(op.(dgo.Type))
+	})
+	return joinDescriptions(ds, conjunction)
+}
+
+func joinDescriptions(ds []string, conjunction string) string {
+	switch len(ds) {
+	case 1:
+		return ds[0]
+	case 2:
+		return ds[0] + ` ` + conjunction + ` ` + ds[1]
+	}
+	return strings.Join(ds[:len(ds)-1], `, `) + `, ` + conjunction + ` ` + ds[len(ds)-1]
+}
+
+// plural returns a plural noun for the given type, e.g. "strings", or a phrase such as "values that are an integer
+// between 1 and 3" when no such noun exists.
+func (d *describer) plural(t dgo.Type) string {
+	if n, ok := pluralNoun(t); ok {
+		return n
+	}
+	return `values that are ` + d.describe(t)
+}
+
+func pluralNoun(t dgo.Type) (string, bool) {
+	switch t {
+	case DefaultAnyType:
+		return `values`, true
+	case DefaultStringType:
+		return `strings`, true
+	case DefaultIntegerType:
+		return `integers`, true
+	case DefaultFloatType:
+		return `floats`, true
+	case DefaultBooleanType:
+		return `booleans`, true
+	case DefaultArrayType:
+		return `arrays`, true
+	case DefaultMapType:
+		return `maps`, true
+	}
+	return ``, false
+}
+
+func describeTimeRange(min, max time.Time) string {
+	switch {
+	case min.IsZero():
+		return `a time at or before ` + max.Format(time.RFC3339Nano)
+	case max.IsZero():
+		return `a time at or after ` + min.Format(time.RFC3339Nano)
+	}
+	return `a time between ` + min.Format(time.RFC3339Nano) + ` and ` + max.Format(time.RFC3339Nano)
+}
+
+func describeDurationRange(min, max time.Duration) string {
+	switch {
+	case min == math.MinInt64:
+		return `a duration of at most ` + max.String()
+	case max == math.MaxInt64:
+		return `a duration of at least ` + min.String()
+	}
+	return `a duration between ` + min.String() + ` and ` + max.String()
+}
+
+func describeDecimal(precision, scale int) string {
+	s := `a decimal with at most ` + strconv.Itoa(precision) + ` digits`
+	switch scale {
+	case 0:
+		return s + ` and no fraction`
+	case 1:
+		return s + ` of which 1 is after the decimal point`
+	}
+	return s + ` of which ` + strconv.Itoa(scale) + ` are after the decimal point`
+}
+
+func describeURI(scheme, host string) string {
+	switch {
+	case scheme == ``:
+		return `a URI with host ` + host
+	case host == ``:
+		return `a URI with scheme ` + scheme
+	}
+	return `a URI with scheme ` + scheme + ` and host ` + host
+}
+
+func describeIPType(t *ipType) string {
+	var s string
+	switch t.version {
+	case 4:
+		s = `an IPv4 address`
+	case 6:
+		s = `an IPv6 address`
+	default:
+		s = `an IP address`
+	}
+	if t.network != nil {
+		s += ` in ` + t.network.String()
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr string
+		desc string
+	}{
+		{`any`, `any value`},
+		{`nil`, `nil`},
+		{`bool`, `a boolean`},
+		{`int`, `an integer`},
+		{`0..100`, `an integer between 0 and 100`},
+		{`0...100`, `an integer between 0 and 99`},
+		{`3..`, `an integer greater than or equal to 3`},
+		{`..3`, `an integer less than or equal to 3`},
+		{`float`, `a float`},
+		{`0.0..1.0`, `a float between 0 and 1`},
+		{`0.0...1.0`, `a float greater than or equal to 0 and less than 1`},
+		{`string`, `a string`},
+		{`string[1,10]`, `a string with 1 to 10 characters`},
+		{`string[1]`, `a string with at least 1 character`},
+		{`/^[a-z]+$/`, `a string matching /^[a-z]+$/`},
+		{`"abc"`, `the string "abc"`},
+		{`~"abc"`, `a string equal to "abc" ignoring case`},
+		{`42`, `the value 42`},
+		{`[]any`, `an array`},
+		{`[]string`, `an array of strings`},
+		{`[1,10]string`, `an array of 1 to 10 strings`},
+		{`[1,1]string`, `an array of exactly 1 string`},
+		{`[0,3]0..5`, `an array of at most 3 elements that are an integer between 0 and 5`},
+		{`[]0..5`, `an array of values that are an integer between 0 and 5`},
+		{`{string,int}`, `an array containing a string and an integer`},
+		{`{string,...int}`, `an array containing a string and any number of integers`},
+		{`map[string]int`, `a map from strings to integers`},
+		{`map[string,1,5]any`, `a map from strings to values with 1 to 5 entries`},
+		{`{name:string,age?:0..150}`,
+			`a map with 'name' (a string) and 'age' (optional, an integer between 0 and 150)`},
+		{`{name:string,...}`, `a map with 'name' (a string) and any other entries`},
+		{`{name:string,/^env_/:string}`, `a map with 'name' (a string) and keys matching /^env_/ (a string)`},
+		{`string|int`, `a string or an integer`},
+		{`string|int|bool`, `a string, an integer, or a boolean`},
+		{`string^/a/`, `exactly one of a string or a string matching /a/`},
+		{`string[1]&/a/`, `a value that is a string with at least 1 character and a string matching /a/`},
+		{`!string`, `a value that is not a string`},
+		{`sensitive[string]`, `a sensitive string`},
+		{`time`, `a time`},
+		{`time["2019-01-01T00:00:00Z","2021-01-01T00:00:00Z"]`,
+			`a time between 2019-01-01T00:00:00Z and 2021-01-01T00:00:00Z`},
+		{`time["2019-01-01T00:00:00Z",nil]`, `a time at or after 2019-01-01T00:00:00Z`},
+		{`time[nil,"2021-01-01T00:00:00Z"]`, `a time at or before 2021-01-01T00:00:00Z`},
+		{`bigint`, `an integer of any size`},
+		{`decimal`, `a decimal`},
+		{`decimal[10,2]`, `a decimal with at most 10 digits of which 2 are after the decimal point`},
+		{`decimal[10,1]`, `a decimal with at most 10 digits of which 1 is after the decimal point`},
+		{`decimal[10]`, `a decimal with at most 10 digits and no fraction`},
+		{`decimal["1.5"]`, `the value 1.5`},
+		{`duration`, `a duration`},
+		{`semver`, `a semantic version`},
+		{`semver["^1.2.0"]`, `a semantic version in the range ^1.2.0`},
+		{`semver["1.2.0"]`, `the value 1.2.0`},
+		{`semverrange`, `a semantic version range`},
+		{`ipaddr`, `an IP address`},
+		{`ipv4["10.0.0.0/8"]`, `an IPv4 address in 10.0.0.0/8`},
+		{`ipv6`, `an IPv6 address`},
+		{`ipaddr["10.0.0.1"]`, `the value 10.0.0.1`},
+		{`cidr`, `a CIDR network`},
+		{`cidr["10.0.0.0/8"]`, `a CIDR network within 10.0.0.0/8`},
+		{`uri`, `a URI`},
+		{`uri["https"]`, `a URI with scheme https`},
+		{`uri[nil,"example.com"]`, `a URI with host example.com`},
+		{`uri["https","example.com"]`, `a URI with scheme https and host example.com`},
+		{`uri["https://example.com"]`, `the value https://example.com`},
+		{`duration[1s,1h]`, `a duration between 1s and 1h0m0s`},
+		{`duration[1s,nil]`, `a duration of at least 1s`},
+		{`duration[nil,1h]`, `a duration of at most 1h0m0s`},
+		{`type`, `a type`},
+		{`type[string]`, `the type string`},
+	}
+	for _, tt := range tests {
+		tp := tf.ParseType(tt.expr)
+		require.Equal(t, tt.desc, typ.Describe(tp))
+		require.Equal(t, tt.desc, tp.(dgo.DescribableType).Describe())
+	}
+}
+
+func TestDescribe_recursive(t *testing.T) {
+	tp := tf.ParseType(`describeTree={value:int,children?:[]describeTree}`)
+	require.Equal(t,
+		`a map with 'value' (an integer) and 'children' (optional, an array of values that are a value of type describeTree)`,
+		typ.Describe(tp))
+}
+
+func TestDescribe_other(t *testing.T) {
+	require.Equal(t, `the value {1,2}`, typ.Describe(vf.Values(1, 2).Type()))
+	require.Equal(t, `a native value of type chan int`, typ.Describe(vf.Value(make(chan int)).Type()))
+	require.Equal(t, `a function`, typ.Describe(tf.ParseType(`func(string) int`)))
+	require.Equal(t, `no value`, typ.Describe(tf.Not(typ.Any)))
+	require.Equal(t, `a binary of 1 to 10 bytes`, typ.Describe(tf.Binary(1, 10)))
+	require.Equal(t, `a time`, typ.Describe(typ.Time))
+	require.Equal(t, `an error`, typ.Describe(typ.Error))
+	require.Equal(t, `a regular expression`, typ.Describe(typ.Regexp))
+}
@@ -0,0 +1,349 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	durationType int
+
+	exactDurationType struct {
+		exactType
+		value durationVal
+	}
+
+	durationRangeType struct {
+		min time.Duration
+		max time.Duration
+	}
+
+	durationVal time.Duration
+)
+
+// DefaultDurationType is the unconstrained Duration type
+const DefaultDurationType = durationType(0)
+
+var reflectDurationType = reflect.TypeOf(time.Duration(0))
+
+// DurationType returns a dgo.DurationType that is limited to the inclusive range given by min and max
+func DurationType(min, max time.Duration) dgo.DurationType {
+	if max < min {
+		min, max = max, min
+	}
+	if min == max {
+		return durationVal(min).Type().(dgo.DurationType)
+	}
+	if min == math.MinInt64 && max == math.MaxInt64 {
+		return DefaultDurationType
+	}
+	return &durationRangeType{min: min, max: max}
+}
+
+// DurationTypeFromArgs returns a dgo.DurationType created from the given arguments. No arguments gives the
+// unconstrained type, one argument gives the exact type for that duration, and two arguments give a range. Each
+// argument must be a duration, a string in the format accepted by time.ParseDuration, or nil (unbounded).
+func DurationTypeFromArgs(args []interface{}) dgo.DurationType {
+	switch len(args) {
+	case 0:
+		return DefaultDurationType
+	case 1:
+		if _, ok := Value(args[0]).(nilValue); !ok {
+			return durationVal(durationArg(args, 0, 0)).Type().(dgo.DurationType)
+		}
+		panic(illegalArgument(`DurationType`, `Duration or String`, args, 0))
+	case 2:
+		return DurationType(durationArg(args, 0, math.MinInt64), durationArg(args, 1, math.MaxInt64))
+	}
+	panic(illegalArgumentCount(`DurationType`, 0, 2, len(args)))
+}
+
+func durationArg(args []interface{}, argno int, unbounded time.Duration) time.Duration {
+	switch a := Value(args[argno]).(type) {
+	case dgo.Duration:
+		return a.GoDuration()
+	case dgo.String:
+		return durationFromString(a.GoString())
+	case nilValue:
+		return unbounded
+	}
+	panic(illegalArgument(`DurationType`, `Duration, String, or nil`, args, argno))
+}
+
+func (t durationType) Assignable(ot dgo.Type) bool {
+	switch ot.(type) {
+	case durationType, *exactDurationType, *durationRangeType:
+		return true
+	}
+	return CheckAssignableTo(nil, ot, t)
+}
+
+func (t durationType) Describe() string {
+	return Describe(t)
+}
+
+func (t durationType) Equals(v interface{}) bool {
+	return t == v
+}
+
+func (t durationType) HashCode() int {
+	return int(dgo.TiDuration)
+}
+
+func (t durationType) Instance(v interface{}) bool {
+	switch v.(type) {
+	case durationVal, time.Duration, *time.Duration:
+		return true
+	}
+	return false
+}
+
+func (t durationType) IsInstance(d time.Duration) bool {
+	return true
+}
+
+func (t durationType) Max() time.Duration {
+	return math.MaxInt64
+}
+
+func (t durationType) Min() time.Duration {
+	return math.MinInt64
+}
+
+func (t durationType) New(arg dgo.Value) dgo.Value {
+	return newDuration(t, arg)
+}
+
+func (t durationType) ReflectType() reflect.Type {
+	return reflectDurationType
+}
+
+func (t durationType) String() string {
+	return TypeString(t)
+}
+
+func (t durationType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t durationType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDuration
+}
+
+func (t *exactDurationType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactDurationType) Generic() dgo.Type {
+	return DefaultDurationType
+}
+
+func (t *exactDurationType) IsInstance(d time.Duration) bool {
+	return time.Duration(t.value) == d
+}
+
+func (t *exactDurationType) Max() time.Duration {
+	return time.Duration(t.value)
+}
+
+func (t *exactDurationType) Min() time.Duration {
+	return time.Duration(t.value)
+}
+
+func (t *exactDurationType) New(arg dgo.Value) dgo.Value {
+	return newDuration(t, arg)
+}
+
+func (t *exactDurationType) ReflectType() reflect.Type {
+	return reflectDurationType
+}
+
+func (t *exactDurationType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDurationExact
+}
+
+func (t *durationRangeType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactDurationType:
+		return t.IsInstance(time.Duration(ot.value))
+	case *durationRangeType:
+		return t.min <= ot.min && ot.max <= t.max
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *durationRangeType) Describe() string {
+	return Describe(t)
+}
+
+func (t *durationRangeType) Equals(other interface{}) bool {
+	if ot, ok := other.(*durationRangeType); ok {
+		return *t == *ot
+	}
+	return false
+}
+
+func (t *durationRangeType) HashCode() int {
+	h := int(dgo.TiDurationRange)
+	if t.min > math.MinInt64 {
+		h = h*31 + int(t.min)
+	}
+	if t.max < math.MaxInt64 {
+		h = h*31 + int(t.max)
+	}
+	return h
+}
+
+func (t *durationRangeType) Instance(v interface{}) bool {
+	switch v := v.(type) {
+	case durationVal:
+		return t.IsInstance(time.Duration(v))
+	case time.Duration:
+		return t.IsInstance(v)
+	case *time.Duration:
+		return t.IsInstance(*v)
+	}
+	return false
+}
+
+func (t *durationRangeType) IsInstance(d time.Duration) bool {
+	return t.min <= d && d <= t.max
+}
+
+func (t *durationRangeType) Max() time.Duration {
+	return t.max
+}
+
+func (t *durationRangeType) Min() time.Duration {
+	return t.min
+}
+
+func (t *durationRangeType) New(arg dgo.Value) dgo.Value {
+	return newDuration(t, arg)
+}
+
+func (t *durationRangeType) ReflectType() reflect.Type {
+	return reflectDurationType
+}
+
+func (t *durationRangeType) String() string {
+	return TypeString(t)
+}
+
+func (t *durationRangeType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *durationRangeType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiDurationRange
+}
+
+// newDuration creates a duration from the given argument. An Integer is a number of nanoseconds, a Float is a
+// number of seconds, and a String must be in the format accepted by time.ParseDuration.
+func newDuration(t dgo.Type, arg dgo.Value) dgo.Duration {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`duration`, 1, 1)
+		arg = args.Get(0)
+	}
+	var dv dgo.Duration
+	switch arg := arg.(type) {
+	case dgo.Duration:
+		dv = arg
+	case dgo.Integer:
+		dv = durationVal(arg.GoInt())
+	case dgo.Float:
+		dv = durationVal(arg.GoFloat() * float64(time.Second))
+	case dgo.String:
+		dv = durationVal(durationFromString(arg.GoString()))
+	default:
+		panic(illegalArgument(`duration`, `duration|int|float|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(dv) {
+		panic(IllegalAssignment(t, dv))
+	}
+	return dv
+}
+
+func durationFromString(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Duration returns the given duration as a dgo.Duration
+func Duration(d time.Duration) dgo.Duration {
+	return durationVal(d)
+}
+
+// DurationFromString returns the given duration string as a dgo.Duration. The string must be in the format
+// accepted by time.ParseDuration. The function will panic if the given string cannot be parsed.
+func DurationFromString(s string) dgo.Duration {
+	return durationVal(durationFromString(s))
+}
+
+func (v durationVal) CompareTo(other interface{}) (int, bool) {
+	var od time.Duration
+	switch ov := other.(type) {
+	case durationVal:
+		od = time.Duration(ov)
+	case time.Duration:
+		od = ov
+	default:
+		return 0, false
+	}
+	r := 0
+	switch d := time.Duration(v); {
+	case d > od:
+		r = 1
+	case d < od:
+		r = -1
+	}
+	return r, true
+}
+
+func (v durationVal) Equals(other interface{}) bool {
+	switch ov := other.(type) {
+	case durationVal:
+		return v == ov
+	case time.Duration:
+		return time.Duration(v) == ov
+	case *time.Duration:
+		return time.Duration(v) == *ov
+	}
+	return false
+}
+
+func (v durationVal) GoDuration() time.Duration {
+	return time.Duration(v)
+}
+
+func (v durationVal) HashCode() int {
+	return int(v)
+}
+
+func (v durationVal) ReflectTo(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Int64:
+		value.SetInt(int64(v))
+	case reflect.Ptr:
+		d := time.Duration(v)
+		value.Set(reflect.ValueOf(&d))
+	default:
+		value.Set(reflect.ValueOf(time.Duration(v)))
+	}
+}
+
+func (v durationVal) String() string {
+	return time.Duration(v).String()
+}
+
+func (v durationVal) Type() dgo.Type {
+	et := &exactDurationType{value: v}
+	et.ExactType = et
+	return et
+}
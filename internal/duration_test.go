@@ -0,0 +1,161 @@
+package internal_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestDurationDefault(t *testing.T) {
+	tp := typ.Duration.(dgo.DurationType)
+	d := 3 * time.Second
+	require.Instance(t, tp, d)
+	require.Instance(t, tp, &d)
+	require.Instance(t, tp, vf.Duration(d))
+	require.NotInstance(t, tp, 3)
+	require.NotInstance(t, tp, `3s`)
+	require.True(t, tp.IsInstance(d))
+	require.Equal(t, time.Duration(math.MinInt64), tp.Min())
+	require.Equal(t, time.Duration(math.MaxInt64), tp.Max())
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, vf.Duration(d).Type())
+	require.Assignable(t, tp, tf.Duration(0, d))
+	require.NotAssignable(t, tp, typ.Integer)
+	require.NotAssignable(t, typ.Integer, tp)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.Integer)
+	require.Equal(t, tp.HashCode(), tp.HashCode())
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `duration`, tp.String())
+	require.Same(t, tp, typ.Generic(vf.Duration(d).Type()))
+	require.Same(t, tp, tf.Duration(math.MinInt64, math.MaxInt64))
+	require.True(t, reflect.ValueOf(d).Type().AssignableTo(tp.ReflectType()))
+}
+
+func TestDurationExact(t *testing.T) {
+	d := 1500 * time.Millisecond
+	tp := vf.Duration(d).Type().(dgo.DurationType)
+	require.Instance(t, tp, d)
+	require.NotInstance(t, tp, d+1)
+	require.True(t, tp.IsInstance(d))
+	require.Equal(t, d, tp.Min())
+	require.Equal(t, d, tp.Max())
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, typ.Duration)
+	require.Equal(t, tp, tf.Duration(d, d))
+	require.NotEqual(t, tp, vf.Duration(d+1).Type())
+	require.Equal(t, `1.5s`, tp.String())
+	require.Equal(t, typ.Duration.ReflectType(), tp.ReflectType())
+}
+
+func TestDurationRange(t *testing.T) {
+	tp := tf.Duration(time.Hour, time.Second)
+	require.Equal(t, tp, tf.Duration(time.Second, time.Hour))
+	require.Equal(t, time.Second, tp.Min())
+	require.Equal(t, time.Hour, tp.Max())
+	require.Instance(t, tp, time.Second)
+	require.Instance(t, tp, vf.Duration(time.Minute))
+	h := time.Hour
+	require.Instance(t, tp, &h)
+	require.NotInstance(t, tp, time.Hour+1)
+	require.NotInstance(t, tp, time.Second-1)
+	require.NotInstance(t, tp, `1m`)
+
+	require.Assignable(t, typ.Duration, tp)
+	require.NotAssignable(t, tp, typ.Duration)
+	require.Assignable(t, tp, vf.Duration(time.Minute).Type())
+	require.NotAssignable(t, tp, vf.Duration(2*time.Hour).Type())
+	require.Assignable(t, tp, tf.Duration(time.Minute, time.Hour))
+	require.NotAssignable(t, tp, tf.Duration(time.Minute, 2*time.Hour))
+	require.NotAssignable(t, tp, typ.Integer)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, tf.Duration(time.Second, time.Minute))
+	require.NotEqual(t, tp, typ.Duration)
+	require.Equal(t, tp.HashCode(), tf.Duration(time.Second, time.Hour).HashCode())
+	require.NotEqual(t, tp.HashCode(), tf.Duration(time.Second, math.MaxInt64).HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, typ.Duration.ReflectType(), tp.ReflectType())
+	require.Equal(t, `duration[1s,1h0m0s]`, tp.String())
+	require.Equal(t, `duration[nil,1h0m0s]`, tf.Duration(math.MinInt64, time.Hour).String())
+}
+
+func TestDurationType_New(t *testing.T) {
+	dv := vf.Duration(90 * time.Second)
+	require.Same(t, dv, vf.New(typ.Duration, dv))
+	require.Equal(t, dv, vf.New(typ.Duration, vf.Arguments(dv)))
+	require.Equal(t, dv, vf.New(typ.Duration, vf.Integer(int64(90*time.Second))))
+	require.Equal(t, dv, vf.New(typ.Duration, vf.Float(90)))
+	require.Equal(t, dv, vf.New(typ.Duration, vf.String(`1m30s`)))
+	require.Equal(t, dv, vf.New(dv.Type(), vf.String(`90s`)))
+	require.Equal(t, dv, vf.New(tf.Duration(0, time.Hour), vf.String(`90s`)))
+
+	require.Panic(t, func() { vf.New(dv.Type(), vf.String(`1m`)) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.Duration, vf.String(`1x`)) }, `unknown unit`)
+	require.Panic(t, func() { vf.New(typ.Duration, vf.True) }, `illegal argument`)
+
+	require.Equal(t, int64(90*time.Second), vf.New(typ.Integer, dv))
+	require.Equal(t, 90.0, vf.New(typ.Float, dv))
+}
+
+func TestDuration(t *testing.T) {
+	d := 90 * time.Second
+	dv := vf.Duration(d)
+	require.Equal(t, dv, d)
+	require.Equal(t, dv, &d)
+	require.Equal(t, dv, vf.Value(d))
+	require.Equal(t, dv, vf.DurationFromString(`1m30s`))
+	require.NotEqual(t, dv, int64(d))
+	require.Equal(t, d, dv.GoDuration())
+	require.Equal(t, `1m30s`, dv.String())
+	require.Equal(t, dv.HashCode(), vf.Duration(d).HashCode())
+
+	c, ok := dv.CompareTo(time.Minute)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	c, ok = dv.CompareTo(vf.Duration(2 * time.Minute))
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = dv.CompareTo(d)
+	require.True(t, ok)
+	require.Equal(t, 0, c)
+	_, ok = dv.CompareTo(vf.Integer(3))
+	require.False(t, ok)
+
+	require.Panic(t, func() { vf.DurationFromString(`3`) }, `missing unit`)
+}
+
+func TestDuration_ReflectTo(t *testing.T) {
+	dv := vf.Duration(time.Minute)
+
+	var d time.Duration
+	vf.ReflectTo(dv, reflect.ValueOf(&d).Elem())
+	require.Equal(t, time.Minute, d)
+
+	var dp *time.Duration
+	vf.ReflectTo(dv, reflect.ValueOf(&dp).Elem())
+	require.Equal(t, time.Minute, *dp)
+
+	var mi interface{}
+	vf.ReflectTo(dv, reflect.ValueOf(&mi).Elem())
+	require.Equal(t, time.Minute, mi)
+
+	type withDuration struct {
+		Timeout time.Duration
+	}
+	s := withDuration{Timeout: time.Second}
+	m := vf.Map(&s)
+	require.Equal(t, vf.Duration(time.Second), m.Get(`Timeout`))
+	m.Put(`Timeout`, dv)
+	require.Equal(t, time.Minute, s.Timeout)
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"reflect"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// encodedStringType represents strings that are valid in a specific binary to text encoding
+type encodedStringType struct {
+	ti     dgo.TypeIdentifier
+	valid  func(string) bool
+	encode func([]byte) string
+}
+
+// DefaultBase64StringType is the type of all strings that are valid standard or URL-safe base64 encodings
+var DefaultBase64StringType dgo.StringType = &encodedStringType{
+	ti: dgo.TiBase64String, valid: isBase64, encode: base64.StdEncoding.EncodeToString}
+
+// DefaultHexStringType is the type of all strings of even length that contain only hexadecimal digits
+var DefaultHexStringType dgo.StringType = &encodedStringType{
+	ti: dgo.TiHexString, valid: isHex, encode: hex.EncodeToString}
+
+func isBase64(s string) bool {
+	if _, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return true
+	}
+	_, err := base64.URLEncoding.DecodeString(s)
+	return err == nil
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func (t *encodedStringType) Assignable(other dgo.Type) bool {
+	if t == other {
+		return true
+	}
+	if ot, ok := other.(*exactStringType); ok {
+		return t.valid(ot.value.s)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *encodedStringType) Describe() string {
+	return Describe(t)
+}
+
+func (t *encodedStringType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t *encodedStringType) HashCode() int {
+	return int(t.ti)
+}
+
+func (t *encodedStringType) Instance(value interface{}) bool {
+	switch v := value.(type) {
+	case *hstring:
+		return t.valid(v.s)
+	case string:
+		return t.valid(v)
+	}
+	return false
+}
+
+func (t *encodedStringType) Max() int {
+	return math.MaxInt64
+}
+
+func (t *encodedStringType) Min() int {
+	return 0
+}
+
+// New creates a new instance of this type. A Binary argument is encoded, all other arguments are converted to a
+// string which must be valid in the encoding of this type.
+func (t *encodedStringType) New(arg dgo.Value) dgo.Value {
+	if b, ok := arg.(dgo.Binary); ok {
+		return String(t.encode(b.GoBytes()))
+	}
+	return newString(t, arg)
+}
+
+func (t *encodedStringType) ReflectType() reflect.Type {
+	return reflectStringType
+}
+
+func (t *encodedStringType) String() string {
+	return TypeString(t)
+}
+
+func (t *encodedStringType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *encodedStringType) TypeIdentifier() dgo.TypeIdentifier {
+	return t.ti
+}
+
+func (t *encodedStringType) Unbounded() bool {
+	return true
+}
@@ -25,6 +25,10 @@ const DefaultErrorType = errType(0)
 
 var reflectErrorType = reflect.TypeOf((*error)(nil)).Elem()
 
+func (t errType) Describe() string {
+	return Describe(t)
+}
+
 func (t errType) Type() dgo.Type {
 	return &metaType{t}
 }
@@ -16,6 +16,10 @@ func (t *exactType) Assignable(other dgo.Type) bool {
 	return t.Equals(other) || CheckAssignableTo(nil, other, t.ExactType)
 }
 
+func (t *exactType) Describe() string {
+	return Describe(t.ExactType)
+}
+
 func (t *exactType) Equals(other interface{}) bool {
 	if ot, ok := other.(dgo.ExactType); ok && t.TypeIdentifier() == ot.TypeIdentifier() {
 		return t.ExactValue().Equals(ot.ExactValue())
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// FlattenToMap traverses the given value and returns a frozen map that associates the path of each leaf value with
+// that value. A path is formed by joining the keys of maps and the indexes of arrays with the given separator, so
+// {"a":{"b":[1,2]}} becomes {"a.b.0":1,"a.b.1":2} when the separator is ".". Empty maps and arrays are leaf values.
+// A value that isn't a map or an array is associated with the empty path.
+//
+// The function panics if the value is recursive.
+func FlattenToMap(v dgo.Value, sep string) dgo.Map {
+	m := MapWithCapacity(0).(*hashMap)
+	flatten(m, nil, ``, v, sep)
+	m.frozen = true
+	return m
+}
+
+func flatten(m *hashMap, seen []dgo.Value, path string, v dgo.Value, sep string) {
+	root := len(seen) == 0
+	prefix := func(seg string) string {
+		if root {
+			return seg
+		}
+		return path + sep + seg
+	}
+	switch c := v.(type) {
+	case dgo.Array:
+		if c.Len() > 0 {
+			seen = flattenGuard(seen, c)
+			c.EachWithIndex(func(e dgo.Value, i int) { flatten(m, seen, prefix(strconv.Itoa(i)), e, sep) })
+			return
+		}
+	case dgo.Map:
+		if c.Len() > 0 {
+			seen = flattenGuard(seen, c)
+			c.EachEntry(func(e dgo.MapEntry) {
+				var seg string
+				if s, ok := e.Key().(dgo.String); ok {
+					seg = s.GoString()
+				} else {
+					seg = e.Key().String()
+				}
+				flatten(m, seen, prefix(seg), e.Value(), sep)
+			})
+			return
+		}
+	}
+	m.Put(path, v)
+}
+
+// flattenGuard panics if the given collection is found in seen and otherwise returns seen with the collection appended
+func flattenGuard(seen []dgo.Value, c dgo.Value) []dgo.Value {
+	for _, s := range seen {
+		if s == c {
+			panic(fmt.Errorf(`unable to flatten a recursive value`))
+		}
+	}
+	return append(seen, c)
+}
+
+// UnflattenFromMap is the inverse of FlattenToMap. Each key of the given map is split using the given separator and
+// the value is stored at the resulting path in a nested structure. A level where the path segments are the
+// consecutive integers starting with zero becomes an Array, all other levels become Maps. The returned value is
+// frozen. A map that contains only the empty key yields the value of that key.
+//
+// The function panics if a key isn't a string or if one path is a prefix of another.
+func UnflattenFromMap(m dgo.Map, sep string) dgo.Value {
+	if m.Len() == 1 {
+		if v := m.Get(``); v != nil {
+			return frozenCopy(v)
+		}
+	}
+	root := MapWithCapacity(0).(*hashMap)
+	nodes := map[*hashMap]bool{root: true}
+	m.EachEntry(func(e dgo.MapEntry) {
+		ks, ok := e.Key().(dgo.String)
+		if !ok {
+			panic(fmt.Errorf(`flattened key %s is not a string`, e.Key()))
+		}
+		k := ks.GoString()
+		segs := strings.Split(k, sep)
+		n := root
+		last := len(segs) - 1
+		for i := 0; i < last; i++ {
+			v := n.Get(segs[i])
+			if v == nil {
+				c := MapWithCapacity(0).(*hashMap)
+				nodes[c] = true
+				n.Put(segs[i], c)
+				n = c
+			} else if c, ok := v.(*hashMap); ok && nodes[c] {
+				n = c
+			} else {
+				panic(fmt.Errorf(`flattened key '%s' conflicts with '%s'`, k, strings.Join(segs[:i+1], sep)))
+			}
+		}
+		if n.Get(segs[last]) != nil {
+			panic(fmt.Errorf(`flattened key '%s' conflicts with another key`, k))
+		}
+		n.Put(segs[last], frozenCopy(e.Value()))
+	})
+	return unflattened(root, nodes)
+}
+
+// unflattened converts the intermediate maps created by UnflattenFromMap into frozen Maps and Arrays
+func unflattened(n *hashMap, nodes map[*hashMap]bool) dgo.Value {
+	isArray := true
+	i := 0
+	for e := n.first; e != nil; e = e.next {
+		if c, ok := e.value.(*hashMap); ok && nodes[c] {
+			e.value = unflattened(c, nodes)
+		}
+		if isArray && e.key.(dgo.String).GoString() != strconv.Itoa(i) {
+			isArray = false
+		}
+		i++
+	}
+	if isArray && n.len > 0 {
+		a := n.values()
+		return &array{slice: a, frozen: true}
+	}
+	n.frozen = true
+	return n
+}
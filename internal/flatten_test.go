@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestFlattenToMap(t *testing.T) {
+	v := vf.Map(`a`, vf.Map(`b`, vf.Values(1, 2)), `c`, `x`, `d`, vf.Map(), 3, vf.Values())
+	f := vf.FlattenToMap(v, `.`)
+	require.Equal(t, vf.Map(`a.b.0`, 1, `a.b.1`, 2, `c`, `x`, `d`, vf.Map(), `3`, vf.Values()), f)
+	require.True(t, f.Frozen())
+	require.Equal(t, vf.Map(`a/b/0`, 1, `a/b/1`, 2), vf.FlattenToMap(vf.Map(`a`, vf.Map(`b`, vf.Values(1, 2))), `/`))
+	require.Equal(t, vf.Map(``, 1), vf.FlattenToMap(1, `.`))
+	require.Equal(t, vf.Map(`0`, `a`, `1.x`, true), vf.FlattenToMap(vf.Values(`a`, vf.Map(`x`, true)), `.`))
+}
+
+func TestFlattenToMap_recursive(t *testing.T) {
+	m := vf.MutableMap()
+	m.Put(`a`, vf.Values(1, 2))
+	m.Put(`b`, m.Get(`a`))
+	require.Equal(t, vf.Map(`a.0`, 1, `a.1`, 2, `b.0`, 1, `b.1`, 2), vf.FlattenToMap(m, `.`))
+
+	m.Put(`self`, m)
+	require.Panic(t, func() { vf.FlattenToMap(m, `.`) }, `unable to flatten a recursive value`)
+}
+
+func TestUnflattenFromMap(t *testing.T) {
+	v := vf.Map(`a`, vf.Map(`b`, vf.Values(1, 2)), `c`, `x`, `d`, vf.Map(`x`, 1).Without(`x`), `e`, vf.Values(
+		vf.Map(`y`, 1), 3))
+	u := vf.UnflattenFromMap(vf.FlattenToMap(v, `.`), `.`)
+	require.Equal(t, v, u)
+	require.True(t, u.(dgo.Map).Frozen())
+	require.True(t, u.(dgo.Map).Get(`e`).(dgo.Array).Frozen())
+
+	require.Equal(t, 1, vf.UnflattenFromMap(vf.Map(``, 1), `.`))
+	require.Equal(t, vf.Values(`a`, `b`), vf.UnflattenFromMap(vf.Map(`0`, `a`, `1`, `b`), `.`))
+	require.Equal(t, vf.Map(`1`, `a`, `0`, `b`), vf.UnflattenFromMap(vf.Map(`1`, `a`, `0`, `b`), `.`))
+	require.Equal(t, vf.Map(`a`, vf.Map(`x`, 1)), vf.UnflattenFromMap(vf.Map(`a`, vf.Map(`x`, 1)), `.`))
+	require.Equal(t, vf.Map(), vf.UnflattenFromMap(vf.Map(), `.`))
+}
+
+func TestUnflattenFromMap_conflicts(t *testing.T) {
+	require.Panic(t, func() { vf.UnflattenFromMap(vf.Map(`a`, 1, `a.b`, 2), `.`) },
+		`flattened key 'a.b' conflicts with 'a'`)
+	require.Panic(t, func() { vf.UnflattenFromMap(vf.Map(`a.b`, 2, `a`, 1), `.`) },
+		`flattened key 'a' conflicts with another key`)
+	require.Panic(t, func() { vf.UnflattenFromMap(vf.Map(`a`, vf.Map(`b`, 1), `a.c`, 2), `.`) },
+		`flattened key 'a.c' conflicts with 'a'`)
+	require.Panic(t, func() { vf.UnflattenFromMap(vf.Map(1, 2), `.`) }, `flattened key 1 is not a string`)
+}
@@ -70,6 +70,10 @@ func (t *floatType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *floatType) Describe() string {
+	return Describe(t)
+}
+
 func (t *floatType) Equals(other interface{}) bool {
 	if ot, ok := other.(*floatType); ok {
 		return *t == *ot
@@ -182,6 +186,10 @@ func (t defaultFloatType) Assignable(other dgo.Type) bool {
 	return false
 }
 
+func (t defaultFloatType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultFloatType) Equals(other interface{}) bool {
 	_, ok := other.(defaultFloatType)
 	return ok
@@ -353,6 +361,12 @@ func floatFromConvertible(from dgo.Value) float64 {
 		return float64(from.GoInt())
 	case *timeVal:
 		return from.SecondsWithFraction()
+	case durationVal:
+		return from.GoDuration().Seconds()
+	case *bigIntVal:
+		return from.ToFloat()
+	case *decimalVal:
+		return from.ToFloat()
 	case dgo.Boolean:
 		if from.GoBool() {
 			return 1
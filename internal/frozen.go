@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type frozenChecker struct {
+	seen    []dgo.Value
+	mutable []string
+}
+
+// AssertFrozen traverses the value graph of the given value and returns an error that lists all mutable nodes that
+// were found, or nil if the whole graph is immutable. Each node is identified using a JSONPath such as $[1]['name'].
+// Native values are always considered mutable since dgo cannot freeze them.
+//
+// AssertFrozen is intended for debugging and testing.
+func AssertFrozen(v dgo.Value) error {
+	c := &frozenChecker{}
+	c.check(`$`, v)
+	if len(c.mutable) == 0 {
+		return nil
+	}
+	return fmt.Errorf(`mutable values found: %s`, strings.Join(c.mutable, `, `))
+}
+
+func (c *frozenChecker) check(path string, v dgo.Value) {
+	if util.RecursionHit(c.seen, v) {
+		return
+	}
+	if f, ok := v.(dgo.Freezable); ok && !f.Frozen() {
+		switch v := v.(type) {
+		case dgo.Sensitive:
+			// The wrapped value is reported
+		case dgo.Native:
+			c.mutable = append(c.mutable, path+` (native `+v.Type().(dgo.NativeType).GoType().String()+`)`)
+		case dgo.Array:
+			c.mutable = append(c.mutable, path+` (array)`)
+		case dgo.Map:
+			c.mutable = append(c.mutable, path+` (map)`)
+		default:
+			c.mutable = append(c.mutable, path+` (`+v.Type().TypeIdentifier().String()+`)`)
+		}
+	}
+	c.seen = append(c.seen, v)
+	switch v := v.(type) {
+	case dgo.Array:
+		v.EachWithIndex(func(e dgo.Value, i int) {
+			c.check(path+`[`+strconv.Itoa(i)+`]`, e)
+		})
+	case dgo.Map:
+		// Keys are not examined since a map always stores frozen copies of its keys
+		v.EachEntry(func(e dgo.MapEntry) {
+			c.check(path+`[`+jsonPathKey(e.Key())+`]`, e.Value())
+		})
+	case dgo.MapEntry:
+		c.check(path+`.value`, v.Value())
+	case dgo.Sensitive:
+		c.check(path, v.Unwrap())
+	}
+	c.seen = c.seen[:len(c.seen)-1]
+}
+
+func jsonPathKey(k dgo.Value) string {
+	if s, ok := k.(dgo.String); ok {
+		return `'` + strings.ReplaceAll(s.GoString(), `'`, `\'`) + `'`
+	}
+	return k.String()
+}
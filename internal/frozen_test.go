@@ -0,0 +1,46 @@
+package internal_test
+
+import (
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestAssertFrozen(t *testing.T) {
+	require.Nil(t, vf.AssertFrozen(vf.Integer(1)))
+	require.Nil(t, vf.AssertFrozen(vf.Values(1, vf.Values(2), vf.Map(`a`, vf.Values(3)))))
+
+	type mutableStruct struct{ A int }
+	m := vf.MutableMap(`a`, vf.MutableValues(1), `b`, vf.Value(&mutableStruct{}))
+	a := vf.MutableValues(`x`, m)
+	err := vf.AssertFrozen(a)
+	require.NotNil(t, err)
+	require.Equal(t,
+		`mutable values found: $ (array), $[1] (map), $[1]['a'] (array), $[1]['b'] (native *internal_test.mutableStruct)`,
+		err.Error())
+
+	m.Remove(`b`)
+	require.Equal(t, `mutable values found: $ (array), $[1] (map), $[1]['a'] (array)`, vf.AssertFrozen(a).Error())
+	a.Set(1, m.FrozenCopy())
+	require.Equal(t, `mutable values found: $ (array)`, vf.AssertFrozen(a).Error())
+	a.Freeze()
+	require.Nil(t, vf.AssertFrozen(a))
+}
+
+func TestAssertFrozen_recursive(t *testing.T) {
+	a := vf.MutableValues(1)
+	a.Add(a)
+	require.Equal(t, `mutable values found: $ (array)`, vf.AssertFrozen(a).Error())
+}
+
+func TestAssertFrozen_other(t *testing.T) {
+	type mutableStruct struct{ A int }
+	ns := vf.Value(&mutableStruct{})
+	m := vf.MutableMap(1, vf.Sensitive(vf.MutableValues(1)))
+	require.Equal(t, `mutable values found: $ (map), $[1] (array)`, vf.AssertFrozen(m).Error())
+
+	e := vf.MapEntry(`a`, ns)
+	require.Equal(t, `mutable values found: $ (map entry), $.value (native *internal_test.mutableStruct)`,
+		vf.AssertFrozen(e).Error())
+}
@@ -41,6 +41,10 @@ func (t *exactFunctionTuple) DeepAssignable(guard dgo.RecursionGuard, other dgo.
 	return tupleAssignable(guard, t, other)
 }
 
+func (t *exactFunctionTuple) Describe() string {
+	return Describe(t)
+}
+
 func (t *exactFunctionTuple) Element(index int) dgo.Type {
 	rt := t.element(index)
 	if t.variadic {
@@ -138,6 +142,10 @@ func (t exactFunctionType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Ty
 	return functionTypeAssignable(guard, t, other)
 }
 
+func (t exactFunctionType) Describe() string {
+	return Describe(t)
+}
+
 func (t exactFunctionType) Equals(other interface{}) bool {
 	if ot, ok := Value(other).(dgo.FunctionType); ok {
 		return t.In().Equals(ot.In()) && t.Out().Equals(ot.Out())
@@ -221,6 +229,10 @@ func functionTypeAssignable(guard dgo.RecursionGuard, t dgo.FunctionType, other
 	return CheckAssignableTo(guard, other, t)
 }
 
+func (t *functionType) Describe() string {
+	return Describe(t)
+}
+
 func (t *functionType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
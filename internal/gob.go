@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+// Values are marshaled using their MessagePack encoding and types are marshaled using their string representation.
+// All implementations that support binary marshaling are registered with gob so that they can be used as the value
+// of an interface in a gob stream. The methods and the registration are generated from the tables in gobgen.go.
+
+//go:generate go run gobgen.go
+
+// marshalValue returns the MessagePack encoding of the given value, or an error if the value contains a value that
+// has no MessagePack encoding
+func marshalValue(v dgo.Value) (data []byte, err error) {
+	err = util.Catch(func() { data = ToMsgpack(v) })
+	return
+}
+
+// marshalType returns the string representation of the given type
+func marshalType(t dgo.Type) ([]byte, error) {
+	return []byte(TypeString(t)), nil
+}
+
+// unmarshalValue decodes the given MessagePack data and assigns the result to the value that dest points to
+func unmarshalValue(data []byte, dest interface{}) error {
+	var v dgo.Value
+	if err := util.Catch(func() { v = FromMsgpack(data) }); err != nil {
+		return err
+	}
+	return assignDecoded(v, dest)
+}
+
+// unmarshalType parses the given type string and assigns the result to the type that dest points to
+func unmarshalType(data []byte, dest interface{}) error {
+	var t dgo.Type
+	if err := util.Catch(func() { t = AsType(Parse(string(data))) }); err != nil {
+		return err
+	}
+	return assignDecoded(t, dest)
+}
+
+// zeroSingletons are the shared instances of this package that are equal to the zero value of their type and hence
+// must be explicitly protected from being overwritten by UnmarshalBinary
+var zeroSingletons = []interface{}{DefaultAllOfType, DefaultAnyOfType, DefaultOneOfType, DefaultIPType, DefaultCIDRType}
+
+// assignDecoded assigns the given decoded value to the zero value that dest points to, such as the one that gob
+// allocates when it decodes an interface. An error is returned when dest is not a zero value since it then is a
+// value that is frozen, shared, or in use.
+func assignDecoded(v dgo.Value, dest interface{}) error {
+	dv := reflect.ValueOf(dest).Elem()
+	if !dv.IsZero() {
+		return fmt.Errorf(`unable to unmarshal into a %s that is not a zero value`, dv.Type())
+	}
+	for _, s := range zeroSingletons {
+		if s == dest {
+			return fmt.Errorf(`unable to unmarshal into the shared default %s`, dv.Type())
+		}
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.Type().Elem() == dv.Type() {
+		rv = rv.Elem()
+	}
+	if rv.Type() != dv.Type() {
+		return fmt.Errorf(`unable to unmarshal %s into a %s`, v, dv.Type())
+	}
+	switch d := dest.(type) {
+	case *array:
+		a := v.(*array)
+		d.slice = a.slice
+		d.elementType = a.elementType
+		d.frozen = a.frozen
+	case *hashMap:
+		m := v.(*hashMap)
+		d.table = m.table
+		d.len = m.len
+		d.first = m.first
+		d.last = m.last
+		d.frozen = m.frozen
+	default:
+		// All other implementations are plain data without synchronization state
+		dv.Set(rv)
+	}
+	return nil
+}
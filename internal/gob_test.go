@@ -0,0 +1,106 @@
+package internal_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+type gobEnvelope struct {
+	Value dgo.Value
+	Type  dgo.Type
+}
+
+func gobRoundTrip(t *testing.T, v dgo.Value, tp dgo.Type) gobEnvelope {
+	t.Helper()
+	b := bytes.Buffer{}
+	require.Nil(t, gob.NewEncoder(&b).Encode(&gobEnvelope{Value: v, Type: tp}))
+	var r gobEnvelope
+	require.Nil(t, gob.NewDecoder(&b).Decode(&r))
+	return r
+}
+
+func TestGob_values(t *testing.T) {
+	for _, v := range []dgo.Value{
+		vf.Values(1, `two`, vf.Map(`three`, 3.0)),
+		vf.Map(`a`, 1, `b`, vf.Values(true, nil)),
+		vf.String(`hello`),
+		vf.Integer(-42),
+		vf.Float(3.14),
+		vf.True,
+		vf.Nil,
+		vf.Binary([]byte{1, 2, 3}, true),
+		vf.Value(time.Unix(1600000000, 500).UTC()),
+	} {
+		r := gobRoundTrip(t, v, typ.Any)
+		require.Equal(t, v, r.Value)
+	}
+}
+
+func TestGob_types(t *testing.T) {
+	for _, s := range []string{
+		`string`, `string[1,10]`, `"a"|"b"`, `1..10`, `0.0...1.0`, `[]int`, `map[string]int`, `{a:int,b?:string}`,
+		`{string,...int}`, `/^a/`, `!nil`, `int&0..`, `int^string`, `bool`, `true`, `42`, `time`, `binary`, `any`,
+		`conditional["type",{type:"a"},{type:"b"}]`,
+	} {
+		tp := tf.ParseType(s)
+		r := gobRoundTrip(t, tp, tp)
+		require.Equal(t, tp, r.Type)
+		require.Equal(t, tp, r.Value)
+	}
+}
+
+func TestGob_unencodable(t *testing.T) {
+	v := vf.Values(1, tf.String())
+	err := gob.NewEncoder(&bytes.Buffer{}).Encode(&gobEnvelope{Value: v, Type: typ.Any})
+	require.NotNil(t, err)
+	require.Match(t, `unable to encode a value of type type\[string\] as MessagePack`, err.Error())
+
+	_, err = v.(encoding.BinaryMarshaler).MarshalBinary()
+	require.NotNil(t, err)
+}
+
+func TestMarshalBinary(t *testing.T) {
+	v := vf.Values(1, 2)
+	bs, err := v.(encoding.BinaryMarshaler).MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, vf.ToMsgpack(v), bs)
+
+	tp := tf.ParseType(`map[string]int`)
+	bs, err = tp.(encoding.BinaryMarshaler).MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, `map[string]int`, string(bs))
+
+	require.NotNil(t, tf.ParseType(`[]int`).(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte(`string`)))
+	require.NotNil(t, tf.ParseType(`[]int`).(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte(`[]`)))
+	require.NotNil(t, vf.Values().(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte{0xa1, 'a'}))
+	require.NotNil(t, vf.Values().(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte{0xc1}))
+}
+
+func TestUnmarshalBinary_nonZero(t *testing.T) {
+	data := vf.ToMsgpack(vf.Map(`x`, 1))
+	require.Match(t, `not a zero value`, vf.Map().(encoding.BinaryUnmarshaler).UnmarshalBinary(data).Error())
+	require.Equal(t, 0, vf.Map().Len())
+
+	data = vf.ToMsgpack(vf.Values(3))
+	a := vf.Values(1, 2)
+	require.Match(t, `not a zero value`, a.(encoding.BinaryUnmarshaler).UnmarshalBinary(data).Error())
+	require.Equal(t, vf.Values(1, 2), a)
+	require.Match(t, `not a zero value`, vf.Values().(encoding.BinaryUnmarshaler).UnmarshalBinary(data).Error())
+	require.Equal(t, 0, vf.Values().Len())
+
+	m := vf.MutableValues(1)
+	require.NotNil(t, m.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	require.Equal(t, vf.Values(1), m)
+
+	require.Match(t, `shared default`, typ.AllOf.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte(`int&string`)).Error())
+	require.Equal(t, 0, typ.AllOf.Operands().Len())
+}
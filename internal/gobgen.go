@@ -0,0 +1,129 @@
+//go:build ignore
+// +build ignore
+
+// This program generates gob_binary.go, which makes the value and type implementations of this package implement
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler and registers them with gob. Run it using go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+)
+
+// gobImpl is an implementation that is marshaled by gob. Its zero expression is used when registering it.
+type gobImpl struct {
+	name        string
+	ptrReceiver bool
+	zero        string
+}
+
+// values are marshaled using their MessagePack encoding
+var values = []gobImpl{
+	{`array`, true, `&array{}`},
+	{`hashMap`, true, `&hashMap{}`},
+	{`hstring`, true, `&hstring{}`},
+	{`intVal`, false, `intVal(0)`},
+	{`floatVal`, false, `floatVal(0)`},
+	{`boolean`, false, `False`},
+	{`nilValue`, false, `Nil`},
+	{`bigIntVal`, true, `&bigIntVal{}`},
+	{`binary`, true, `&binary{}`},
+	{`timeVal`, true, `&timeVal{}`},
+}
+
+// types are marshaled using their string representation
+var types = []gobImpl{
+	{`anyType`, false, `anyType(0)`},
+	{`bigIntType`, false, `bigIntType(0)`},
+	{`booleanType`, false, `booleanType(0)`},
+	{`defaultArrayType`, false, `defaultArrayType(0)`},
+	{`defaultDecimalType`, false, `defaultDecimalType(0)`},
+	{`defaultDgoStringType`, false, `defaultDgoStringType(0)`},
+	{`defaultFloatType`, false, `defaultFloatType(0)`},
+	{`defaultIntegerType`, false, `defaultIntegerType(0)`},
+	{`defaultMapType`, false, `defaultMapType(0)`},
+	{`defaultSemVerRangeType`, false, `defaultSemVerRangeType(0)`},
+	{`defaultSemVerType`, false, `defaultSemVerType(0)`},
+	{`defaultStringType`, false, `defaultStringType(0)`},
+	{`defaultURIType`, false, `defaultURIType(0)`},
+	{`durationType`, false, `durationType(0)`},
+	{`errType`, false, `errType(0)`},
+	{`nilType`, false, `nilType(0)`},
+	{`regexpType`, false, `regexpType(0)`},
+	{`timeType`, false, `timeType(0)`},
+	{`allOfType`, true, `&allOfType{}`},
+	{`anyOfType`, true, `&anyOfType{}`},
+	{`binaryType`, true, `&binaryType{}`},
+	{`ciStringType`, true, `&ciStringType{}`},
+	{`cidrType`, true, `&cidrType{}`},
+	{`conditionalStructType`, true, `&conditionalStructType{}`},
+	{`decimalType`, true, `&decimalType{}`},
+	{`durationRangeType`, true, `&durationRangeType{}`},
+	{`encodedStringType`, true, `&encodedStringType{}`},
+	{`exactArrayType`, true, `&exactArrayType{}`},
+	{`exactBigIntType`, true, `&exactBigIntType{}`},
+	{`exactBooleanType`, true, `&exactBooleanType{}`},
+	{`exactCIDRType`, true, `&exactCIDRType{}`},
+	{`exactDecimalType`, true, `&exactDecimalType{}`},
+	{`exactDurationType`, true, `&exactDurationType{}`},
+	{`exactFloatType`, true, `&exactFloatType{}`},
+	{`exactIPType`, true, `&exactIPType{}`},
+	{`exactIntegerType`, true, `&exactIntegerType{}`},
+	{`exactMapType`, true, `&exactMapType{}`},
+	{`exactRegexpType`, true, `&exactRegexpType{}`},
+	{`exactSemVerRangeType`, true, `&exactSemVerRangeType{}`},
+	{`exactSemVerType`, true, `&exactSemVerType{}`},
+	{`exactStringType`, true, `&exactStringType{}`},
+	{`exactTimeType`, true, `&exactTimeType{}`},
+	{`exactURIType`, true, `&exactURIType{}`},
+	{`floatType`, true, `&floatType{}`},
+	{`integerType`, true, `&integerType{}`},
+	{`ipType`, true, `&ipType{}`},
+	{`metaType`, true, `&metaType{}`},
+	{`multiMapType`, true, `&multiMapType{}`},
+	{`notType`, true, `&notType{}`},
+	{`oneOfType`, true, `&oneOfType{}`},
+	{`patternType`, true, `&patternType{}`},
+	{`semVerConstraintType`, true, `&semVerConstraintType{}`},
+	{`sensitiveType`, true, `&sensitiveType{}`},
+	{`sizedArrayType`, true, `&sizedArrayType{}`},
+	{`sizedMapType`, true, `&sizedMapType{}`},
+	{`sizedStringType`, true, `&sizedStringType{}`},
+	{`structType`, true, `&structType{}`},
+	{`timeRangeType`, true, `&timeRangeType{}`},
+	{`tupleType`, true, `&tupleType{}`},
+	{`uriType`, true, `&uriType{}`},
+}
+
+func main() {
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by gobgen.go. DO NOT EDIT.\n\npackage internal\n\nimport \"encoding/gob\"\n\nfunc init() {\n")
+	for _, is := range [][]gobImpl{values, types} {
+		for _, i := range is {
+			fmt.Fprintf(b, "\tgob.Register(%s)\n", i.zero)
+		}
+	}
+	b.WriteString("}\n")
+	writeMethods(b, `v`, values, `marshalValue`, `unmarshalValue`)
+	writeMethods(b, `t`, types, `marshalType`, `unmarshalType`)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err = ioutil.WriteFile(`gob_binary.go`, src, 0644); err != nil {
+		panic(err)
+	}
+}
+
+func writeMethods(b *bytes.Buffer, r string, is []gobImpl, marshal, unmarshal string) {
+	for _, i := range is {
+		ptr := ``
+		if i.ptrReceiver {
+			ptr = `*`
+		}
+		fmt.Fprintf(b, "\nfunc (%s %s%s) MarshalBinary() ([]byte, error) { return %s(%s) }\n", r, ptr, i.name, marshal, r)
+		fmt.Fprintf(b, "\nfunc (%s *%s) UnmarshalBinary(data []byte) error { return %s(data, %s) }\n", r, i.name, unmarshal, r)
+	}
+}
@@ -88,6 +88,10 @@ func (t *integerType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *integerType) Describe() string {
+	return Describe(t)
+}
+
 func (t *integerType) Equals(other interface{}) bool {
 	if ot, ok := other.(*integerType); ok {
 		return *t == *ot
@@ -202,6 +206,10 @@ func (t defaultIntegerType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t defaultIntegerType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultIntegerType) Equals(other interface{}) bool {
 	_, ok := other.(defaultIntegerType)
 	return ok
@@ -255,8 +263,27 @@ func (t defaultIntegerType) TypeIdentifier() dgo.TypeIdentifier {
 	return dgo.TiInteger
 }
 
-// Integer returns the dgo.Integer for the given int64
+// minCachedInt and maxCachedInt is the range of integers that are kept in the intCache
+const (
+	minCachedInt = -128
+	maxCachedInt = 1023
+)
+
+// intCache holds the small integers that are most commonly used as map keys and array elements, already converted to
+// a dgo.Integer, so that obtaining them doesn't cause an allocation.
+var intCache [maxCachedInt - minCachedInt + 1]dgo.Integer
+
+func init() {
+	for i := range intCache {
+		intCache[i] = intVal(i + minCachedInt)
+	}
+}
+
+// Integer returns the dgo.Integer for the given int64. Integers in the range -128 to 1023 are obtained from a cache.
 func Integer(v int64) dgo.Integer {
+	if v >= minCachedInt && v <= maxCachedInt {
+		return intCache[v-minCachedInt]
+	}
 	return intVal(v)
 }
 
@@ -273,6 +300,10 @@ func (v intVal) CompareTo(other interface{}) (int, bool) {
 		return r, true
 	}
 
+	if ob, isBig := other.(*bigIntVal); isBig {
+		return -ob.v.Sign(), true
+	}
+
 	if ov, isFloat := ToFloat(other); isFloat {
 		fv := float64(v)
 		switch {
@@ -438,6 +469,13 @@ func intFromConvertible(from dgo.Value, radix int) int64 {
 		return int64(from.GoFloat())
 	case *timeVal:
 		return from.GoTime().Unix()
+	case durationVal:
+		return int64(from)
+	case *bigIntVal:
+		if from.v.IsInt64() {
+			return from.v.Int64()
+		}
+		panic(fmt.Errorf(`value %s overflows int64`, from))
 	case dgo.Boolean:
 		if from.GoBool() {
 			return 1
@@ -32,6 +32,20 @@ func TestInteger(t *testing.T) {
 	require.True(t, reflect.ValueOf(int64(3)).Type().AssignableTo(typ.Integer.ReflectType()))
 }
 
+func TestInteger_cached(t *testing.T) {
+	require.Equal(t, -128, vf.Integer(-128))
+	require.Equal(t, 1023, vf.Integer(1023))
+	require.Equal(t, -129, vf.Integer(-129))
+	require.Equal(t, 1024, vf.Integer(1024))
+	var v dgo.Value
+	n := int64(1023)
+	require.Equal(t, 0.0, testing.AllocsPerRun(10, func() { v = vf.Integer(n) }))
+	require.Equal(t, 1023, v)
+	require.Equal(t, 0.0, testing.AllocsPerRun(10, func() { v = vf.Value(-128) }))
+	n = 1 << 20
+	require.Equal(t, 1.0, testing.AllocsPerRun(10, func() { v = vf.Integer(n) }))
+}
+
 func TestIntegerExact(t *testing.T) {
 	tp := vf.Integer(3).Type().(dgo.IntegerType)
 	require.Instance(t, tp, 3)
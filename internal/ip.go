@@ -0,0 +1,362 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// ipVal is a net.IP that implements the dgo.IP interface. IPv4 addresses are always stored in their 4-byte form
+	// and the bytes are never mutated.
+	ipVal struct {
+		ip net.IP
+	}
+
+	// ipType matches IP addresses of a specific version that are contained in a network. A zero version matches
+	// both IPv4 and IPv6 addresses and a nil network matches all addresses.
+	ipType struct {
+		version int
+		network *net.IPNet
+	}
+
+	exactIPType struct {
+		exactType
+		value *ipVal
+	}
+)
+
+// DefaultIPType is the type of all IP addresses and all strings that are valid IP addresses
+var DefaultIPType dgo.IPType = &ipType{}
+
+// DefaultIPv4Type is the type of all IPv4 addresses and all strings that are valid IPv4 addresses
+var DefaultIPv4Type dgo.IPType = &ipType{version: 4}
+
+// DefaultIPv6Type is the type of all IPv6 addresses and all strings that are valid IPv6 addresses
+var DefaultIPv6Type dgo.IPType = &ipType{version: 6}
+
+var reflectIPType = reflect.TypeOf(net.IP{})
+
+// IP returns a dgo.IP that represents a copy of the given address. The function panics if the given address
+// doesn't have a length of 4 or 16 bytes.
+func IP(ip net.IP) dgo.IP {
+	v, ok := ipFromGo(ip)
+	if !ok {
+		panic(fmt.Errorf(`'%s' is not a valid IP address`, ip))
+	}
+	return v
+}
+
+// IPFromString returns the given string as a dgo.IP. The function panics if the string is not a valid IPv4 or
+// IPv6 address.
+func IPFromString(s string) dgo.IP {
+	v, ok := parseIP(s)
+	if !ok {
+		panic(fmt.Errorf(`'%s' is not a valid IP address`, s))
+	}
+	return v
+}
+
+func ipFromGo(ip net.IP) (*ipVal, bool) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &ipVal{append(net.IP{}, ip4...)}, true
+	}
+	if len(ip) == net.IPv6len {
+		return &ipVal{append(net.IP{}, ip...)}, true
+	}
+	return nil, false
+}
+
+func parseIP(s string) (*ipVal, bool) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, false
+	}
+	return ipFromGo(ip)
+}
+
+// ipVersion returns 4 for an IPv4 address and 6 for an IPv6 address
+func ipVersion(ip net.IP) int {
+	if ip.To4() != nil {
+		return 4
+	}
+	return 6
+}
+
+// IPType returns a dgo.IPType that matches addresses of the given version that are contained in the given network.
+// The version must be 4, 6, or zero for both, and a nil network means that the type isn't constrained by a network.
+func IPType(version int, network *net.IPNet) dgo.IPType {
+	if version != 0 && version != 4 && version != 6 {
+		panic(fmt.Errorf(`illegal IP version %d`, version))
+	}
+	if network == nil {
+		switch version {
+		case 4:
+			return DefaultIPv4Type
+		case 6:
+			return DefaultIPv6Type
+		}
+		return DefaultIPType
+	}
+	if version != 0 && version != ipVersion(network.IP) {
+		panic(fmt.Errorf(`network %s is not an IPv%d network`, network, version))
+	}
+	return &ipType{version: version, network: IPNet(network).GoIPNet()}
+}
+
+// IPTypeFromArgs returns a dgo.IPType of the given version that is created from the given arguments. No arguments
+// gives the type that matches all addresses of the version. The single argument is either a network in CIDR notation
+// that constrains the addresses of the type, or an address for which the exact type is returned.
+func IPTypeFromArgs(version int, args []interface{}) dgo.IPType {
+	switch len(args) {
+	case 0:
+		return IPType(version, nil)
+	case 1:
+		switch a0 := Value(args[0]).(type) {
+		case *ipVal:
+			return exactIPTypeOfVersion(version, a0)
+		case *ipNetVal:
+			return IPType(version, &a0.n)
+		case dgo.String:
+			s := a0.GoString()
+			if strings.IndexByte(s, '/') >= 0 {
+				return IPType(version, IPNetFromString(s).(*ipNetVal).GoIPNet())
+			}
+			return exactIPTypeOfVersion(version, IPFromString(s).(*ipVal))
+		}
+		panic(illegalArgument(`IPType`, `IP, IPNet, or String`, args, 0))
+	}
+	panic(illegalArgumentCount(`IPType`, 0, 1, len(args)))
+}
+
+func exactIPTypeOfVersion(version int, v *ipVal) dgo.IPType {
+	if version != 0 && version != ipVersion(v.ip) {
+		panic(fmt.Errorf(`%s is not an IPv%d address`, v, version))
+	}
+	return v.Type().(dgo.IPType)
+}
+
+// toIP returns the given value as a net.IP and true, or nil and false if the value is neither an IP address nor a
+// string that is a valid IP address
+func toIP(value interface{}) (net.IP, bool) {
+	switch v := value.(type) {
+	case *ipVal:
+		return v.ip, true
+	case net.IP:
+		return v, len(v) == net.IPv4len || len(v) == net.IPv6len
+	case *hstring:
+		if ip, ok := parseIP(v.s); ok {
+			return ip.ip, true
+		}
+	case string:
+		if ip, ok := parseIP(v); ok {
+			return ip.ip, true
+		}
+	}
+	return nil, false
+}
+
+// networkContains returns true if the inner network is a subnet of, or equal to, the outer network
+func networkContains(outer, inner *net.IPNet) bool {
+	oo, ob := outer.Mask.Size()
+	io, ib := inner.Mask.Size()
+	return ob == ib && oo <= io && outer.Contains(inner.IP)
+}
+
+// effectiveVersion returns the version of the addresses matched by this type, or zero if it matches both IPv4 and
+// IPv6 addresses
+func (t *ipType) effectiveVersion() int {
+	if t.version == 0 && t.network != nil {
+		return ipVersion(t.network.IP)
+	}
+	return t.version
+}
+
+func (t *ipType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactIPType:
+		return t.Instance(ot.value)
+	case *exactStringType:
+		return t.Instance(ot.value)
+	case *ipType:
+		v := t.effectiveVersion()
+		return (v == 0 || v == ot.effectiveVersion()) &&
+			(t.network == nil || ot.network != nil && networkContains(t.network, ot.network))
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *ipType) Describe() string {
+	return Describe(t)
+}
+
+func (t *ipType) Equals(other interface{}) bool {
+	if ot, ok := other.(*ipType); ok {
+		return t.version == ot.version && (t.network == ot.network ||
+			t.network != nil && ot.network != nil && t.network.String() == ot.network.String())
+	}
+	return false
+}
+
+func (t *ipType) HashCode() int {
+	h := int(t.TypeIdentifier())
+	if t.network != nil {
+		h = h*31 + util.StringHash(t.network.String())
+	}
+	return h
+}
+
+func (t *ipType) Instance(value interface{}) bool {
+	ip, ok := toIP(value)
+	if !ok {
+		return false
+	}
+	if t.version != 0 && t.version != ipVersion(ip) {
+		return false
+	}
+	return t.network == nil || t.network.Contains(ip)
+}
+
+func (t *ipType) Network() dgo.IPNet {
+	if t.network == nil {
+		return nil
+	}
+	return IPNet(t.network)
+}
+
+func (t *ipType) New(arg dgo.Value) dgo.Value {
+	return newIP(t, arg)
+}
+
+func (t *ipType) ReflectType() reflect.Type {
+	return reflectIPType
+}
+
+func (t *ipType) String() string {
+	return TypeString(t)
+}
+
+func (t *ipType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *ipType) TypeIdentifier() dgo.TypeIdentifier {
+	switch t.version {
+	case 4:
+		return dgo.TiIPv4
+	case 6:
+		return dgo.TiIPv6
+	}
+	return dgo.TiIPAddr
+}
+
+func (t *ipType) Version() int {
+	return t.version
+}
+
+func (t *exactIPType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactIPType) Generic() dgo.Type {
+	return DefaultIPType
+}
+
+// Network returns a network that contains only the address of this type
+func (t *exactIPType) Network() dgo.IPNet {
+	ip := t.value.ip
+	bits := 8 * len(ip)
+	return IPNet(&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+}
+
+func (t *exactIPType) New(arg dgo.Value) dgo.Value {
+	return newIP(t, arg)
+}
+
+func (t *exactIPType) ReflectType() reflect.Type {
+	return reflectIPType
+}
+
+func (t *exactIPType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiIPAddrExact
+}
+
+func (t *exactIPType) Version() int {
+	return ipVersion(t.value.ip)
+}
+
+func newIP(t dgo.Type, arg dgo.Value) dgo.IP {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`ipaddr`, 1, 1)
+		arg = args.Get(0)
+	}
+	var iv dgo.IP
+	switch arg := arg.(type) {
+	case dgo.IP:
+		iv = arg
+	case dgo.String:
+		iv = IPFromString(arg.GoString())
+	default:
+		panic(illegalArgument(`ipaddr`, `ipaddr|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(iv) {
+		panic(IllegalAssignment(t, iv))
+	}
+	return iv
+}
+
+func (v *ipVal) CompareTo(other interface{}) (int, bool) {
+	var o net.IP
+	switch ov := other.(type) {
+	case *ipVal:
+		o = ov.ip
+	case net.IP:
+		o = ov
+	case nil, nilValue:
+		return 1, true
+	default:
+		return 0, false
+	}
+	return bytes.Compare(v.ip.To16(), o.To16()), true
+}
+
+func (v *ipVal) Equals(other interface{}) bool {
+	switch ov := other.(type) {
+	case *ipVal:
+		return v.ip.Equal(ov.ip)
+	case net.IP:
+		return v.ip.Equal(ov)
+	}
+	return false
+}
+
+func (v *ipVal) GoIP() net.IP {
+	return append(net.IP{}, v.ip...)
+}
+
+func (v *ipVal) HashCode() int {
+	return util.StringHash(string(v.ip.To16()))
+}
+
+func (v *ipVal) ReflectTo(value reflect.Value) {
+	if value.Kind() == reflect.String {
+		value.SetString(v.String())
+	} else {
+		value.Set(reflect.ValueOf(v.GoIP()))
+	}
+}
+
+func (v *ipVal) String() string {
+	return v.ip.String()
+}
+
+func (v *ipVal) Type() dgo.Type {
+	et := &exactIPType{value: v}
+	et.ExactType = et
+	return et
+}
@@ -0,0 +1,195 @@
+package internal_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestIPDefault(t *testing.T) {
+	tp := typ.IP
+	require.Instance(t, tp, vf.IPFromString(`10.0.0.1`))
+	require.Instance(t, tp, net.ParseIP(`::1`))
+	require.Instance(t, tp, `192.168.1.1`)
+	require.Instance(t, tp, vf.String(`fe80::1`))
+	require.NotInstance(t, tp, `10.0.0`)
+	require.NotInstance(t, tp, net.IP{1, 2, 3})
+	require.NotInstance(t, tp, 10)
+	require.Equal(t, 0, tp.Version())
+	require.Nil(t, tp.Network())
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, typ.IPv4)
+	require.Assignable(t, tp, typ.IPv6)
+	require.Assignable(t, tp, vf.IPFromString(`10.0.0.1`).Type())
+	require.Assignable(t, tp, vf.String(`10.0.0.1`).Type())
+	require.NotAssignable(t, tp, vf.String(`10.0.0`).Type())
+	require.NotAssignable(t, tp, typ.String)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.IPv4)
+	require.NotEqual(t, tp.HashCode(), typ.IPv4.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `ipaddr`, tp.String())
+	require.Same(t, tp, typ.Generic(vf.IPFromString(`10.0.0.1`).Type()))
+	require.Same(t, tp, tf.IP(0, ``))
+	require.True(t, reflect.TypeOf(net.IP{}).AssignableTo(tp.ReflectType()))
+
+	require.Panic(t, func() { tf.IP(5, ``) }, `illegal IP version 5`)
+}
+
+func TestIPVersion(t *testing.T) {
+	v4 := typ.IPv4
+	v6 := typ.IPv6
+	require.Instance(t, v4, `10.0.0.1`)
+	require.Instance(t, v4, `::ffff:10.0.0.1`)
+	require.NotInstance(t, v4, `::1`)
+	require.Instance(t, v6, `::1`)
+	require.NotInstance(t, v6, `10.0.0.1`)
+	require.Equal(t, 4, v4.Version())
+	require.Equal(t, 6, v6.Version())
+	require.NotAssignable(t, v4, typ.IP)
+	require.NotAssignable(t, v4, v6)
+	require.Assignable(t, v4, tf.IP(0, `10.0.0.0/8`))
+	require.NotAssignable(t, v6, tf.IP(0, `10.0.0.0/8`))
+	require.Equal(t, `ipv4`, v4.String())
+	require.Equal(t, `ipv6`, v6.String())
+	require.Same(t, v4, tf.IP(4, ``))
+	require.Same(t, v6, tf.IP(6, ``))
+
+	require.Panic(t, func() { tf.IP(6, `10.0.0.0/8`) }, `network 10.0.0.0/8 is not an IPv6 network`)
+}
+
+func TestIPNetworkConstraint(t *testing.T) {
+	tp := tf.IP(0, `10.0.0.0/8`)
+	require.Instance(t, tp, `10.1.2.3`)
+	require.Instance(t, tp, vf.IPFromString(`10.255.255.255`))
+	require.NotInstance(t, tp, `11.0.0.0`)
+	require.NotInstance(t, tp, `::1`)
+	require.Equal(t, vf.IPNetFromString(`10.0.0.0/8`), tp.Network())
+
+	require.Assignable(t, typ.IP, tp)
+	require.Assignable(t, typ.IPv4, tp)
+	require.NotAssignable(t, tp, typ.IP)
+	require.NotAssignable(t, tp, typ.IPv4)
+	require.Assignable(t, tp, tf.IP(4, `10.1.0.0/16`))
+	require.Assignable(t, tp, tf.IP(0, `10.0.0.0/8`))
+	require.NotAssignable(t, tp, tf.IP(0, `10.0.0.0/7`))
+	require.NotAssignable(t, tp, tf.IP(0, `11.0.0.0/8`))
+	require.NotAssignable(t, tp, tf.IP(6, `::/0`))
+	require.Assignable(t, tp, vf.IPFromString(`10.0.0.1`).Type())
+	require.NotAssignable(t, tp, vf.IPFromString(`11.0.0.1`).Type())
+	require.Assignable(t, tp, vf.String(`10.0.0.1`).Type())
+
+	require.Equal(t, tp, tf.IP(0, `10.0.0.0/8`))
+	require.NotEqual(t, tp, tf.IP(4, `10.0.0.0/8`))
+	require.NotEqual(t, tp, tf.IP(0, `10.0.0.0/16`))
+	require.Equal(t, tp.HashCode(), tf.IP(0, `10.0.0.0/8`).HashCode())
+	require.NotEqual(t, tp.HashCode(), typ.IP.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `ipaddr["10.0.0.0/8"]`, tp.String())
+	require.Equal(t, `ipv6["fd00::/8"]`, tf.IP(6, `fd00::/8`).String())
+}
+
+func TestIPExact(t *testing.T) {
+	ip := vf.IPFromString(`10.0.0.1`)
+	tp := ip.Type().(dgo.IPType)
+	require.Instance(t, tp, ip)
+	require.Instance(t, tp, net.ParseIP(`10.0.0.1`))
+	require.NotInstance(t, tp, `10.0.0.2`)
+	require.Equal(t, 4, tp.Version())
+	require.Equal(t, vf.IPNetFromString(`10.0.0.1/32`), tp.Network())
+	require.Equal(t, 6, vf.IPFromString(`::1`).Type().(dgo.IPType).Version())
+	require.Equal(t, vf.IPNetFromString(`::1/128`), vf.IPFromString(`::1`).Type().(dgo.IPType).Network())
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, typ.IP)
+	require.Equal(t, `ipaddr["10.0.0.1"]`, tp.String())
+	require.Equal(t, typ.IP.ReflectType(), tp.ReflectType())
+}
+
+func TestIPType_New(t *testing.T) {
+	ip := vf.IPFromString(`10.0.0.1`)
+	require.Same(t, ip, vf.New(typ.IP, ip))
+	require.Equal(t, ip, vf.New(typ.IP, vf.Arguments(ip)))
+	require.Equal(t, ip, vf.New(typ.IPv4, vf.String(`10.0.0.1`)))
+	require.Equal(t, ip, vf.New(ip.Type(), vf.String(`10.0.0.1`)))
+
+	require.Panic(t, func() { vf.New(typ.IPv6, ip) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.IP, vf.String(`x`)) }, `'x' is not a valid IP address`)
+	require.Panic(t, func() { vf.New(typ.IP, vf.True) }, `illegal argument`)
+}
+
+func TestIP(t *testing.T) {
+	ip := vf.IPFromString(`10.0.0.1`)
+	require.Equal(t, ip, net.ParseIP(`10.0.0.1`))
+	require.Equal(t, ip, net.IP{10, 0, 0, 1})
+	require.Equal(t, ip, vf.Value(net.ParseIP(`10.0.0.1`)))
+	require.Equal(t, ip, vf.IP(net.ParseIP(`::ffff:10.0.0.1`)))
+	require.NotEqual(t, ip, vf.IPFromString(`10.0.0.2`))
+	require.NotEqual(t, ip, `10.0.0.1`)
+	require.Equal(t, `10.0.0.1`, ip.String())
+	require.Equal(t, net.IP{10, 0, 0, 1}, ip.GoIP())
+	require.Equal(t, ip.HashCode(), vf.IP(net.ParseIP(`10.0.0.1`)).HashCode())
+
+	gi := ip.GoIP()
+	gi[0] = 11
+	require.Equal(t, `10.0.0.1`, ip.String())
+
+	c, ok := ip.CompareTo(vf.IPFromString(`10.0.0.2`))
+	require.True(t, ok)
+	require.Equal(t, -1, c)
+	c, ok = ip.CompareTo(net.ParseIP(`9.255.255.255`))
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	c, ok = ip.CompareTo(vf.Nil)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	_, ok = ip.CompareTo(`10.0.0.1`)
+	require.False(t, ok)
+
+	require.Panic(t, func() { vf.IP(net.IP{1, 2, 3}) }, `not a valid IP address`)
+	require.Panic(t, func() { vf.IPFromString(`10.0.0.1/8`) }, `not a valid IP address`)
+}
+
+func TestIP_ReflectTo(t *testing.T) {
+	ip := vf.IPFromString(`10.0.0.1`)
+
+	var gi net.IP
+	vf.ReflectTo(ip, reflect.ValueOf(&gi).Elem())
+	require.Equal(t, net.IP{10, 0, 0, 1}, gi)
+
+	var s string
+	vf.ReflectTo(ip, reflect.ValueOf(&s).Elem())
+	require.Equal(t, `10.0.0.1`, s)
+
+	var mi interface{}
+	vf.ReflectTo(ip, reflect.ValueOf(&mi).Elem())
+	require.Equal(t, net.IP{10, 0, 0, 1}, mi)
+
+	type host struct {
+		Address net.IP
+		Network *net.IPNet
+	}
+	h := host{}
+	m := vf.Map(&h)
+	require.Equal(t, vf.Nil, m.Get(`Address`))
+	m.Put(`Address`, ip)
+	m.Put(`Network`, vf.IPNetFromString(`10.0.0.0/8`))
+	require.Equal(t, `10.0.0.1`, h.Address.String())
+	require.Equal(t, `10.0.0.0/8`, h.Network.String())
+	require.Equal(t, ip, m.Get(`Address`))
+	require.Equal(t, vf.IPNetFromString(`10.0.0.0/8`), m.Get(`Network`))
+}
+
+func TestIP_configValidation(t *testing.T) {
+	ct := tf.ParseType(`{address:ipv4["10.0.0.0/8"],gateway:ipaddr,routes:[]cidr}`)
+	require.Instance(t, ct, vf.Map(`address`, `10.1.2.3`, `gateway`, `10.0.0.1`, `routes`, vf.Values(`10.0.0.0/8`)))
+	require.NotInstance(t, ct, vf.Map(`address`, `192.168.0.1`, `gateway`, `10.0.0.1`, `routes`, vf.Values()))
+	require.NotInstance(t, ct, vf.Map(`address`, `10.1.2.3`, `gateway`, `10.0.0.1`, `routes`, vf.Values(`10.0.0.1`)))
+}
@@ -0,0 +1,277 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// ipNetVal is a net.IPNet that implements the dgo.IPNet interface. The address of the network never has any
+	// host bits set and IPv4 networks are always stored in their 4-byte form.
+	ipNetVal struct {
+		n net.IPNet
+	}
+
+	// cidrType matches networks that are subnets of, or equal to, a network. A nil network matches all networks.
+	cidrType struct {
+		network *net.IPNet
+	}
+
+	exactCIDRType struct {
+		exactType
+		value *ipNetVal
+	}
+)
+
+// DefaultCIDRType is the type of all IP networks and all strings that are valid networks in CIDR notation
+var DefaultCIDRType dgo.CIDRType = &cidrType{}
+
+var reflectIPNetType = reflect.TypeOf(&net.IPNet{})
+
+// IPNet returns a dgo.IPNet that represents a copy of the given network. Host bits in the address are cleared. The
+// function panics if the network is invalid.
+func IPNet(n *net.IPNet) dgo.IPNet {
+	ones, bits := n.Mask.Size()
+	ip := n.IP.To4()
+	if bits == 8*net.IPv6len || ip == nil {
+		ip = n.IP.To16()
+	}
+	if ip == nil || bits != 8*len(ip) {
+		panic(fmt.Errorf(`'%s' is not a valid CIDR network`, n))
+	}
+	mask := net.CIDRMask(ones, bits)
+	return &ipNetVal{net.IPNet{IP: ip.Mask(mask), Mask: mask}}
+}
+
+// IPNetFromString returns the given network in CIDR notation, e.g. "10.0.0.0/8", as a dgo.IPNet. The function
+// panics if the string is not a valid network or if the address has host bits set.
+func IPNetFromString(s string) dgo.IPNet {
+	v, ok := parseIPNet(s)
+	if !ok {
+		panic(fmt.Errorf(`'%s' is not a valid CIDR network`, s))
+	}
+	return v
+}
+
+func parseIPNet(s string) (*ipNetVal, bool) {
+	ip, n, err := net.ParseCIDR(s)
+	if err != nil || !ip.Equal(n.IP) {
+		return nil, false
+	}
+	return IPNet(n).(*ipNetVal), true
+}
+
+// CIDRType returns a dgo.CIDRType that matches networks that are subnets of, or equal to, the given network. A nil
+// network gives the type of all networks.
+func CIDRType(network *net.IPNet) dgo.CIDRType {
+	if network == nil {
+		return DefaultCIDRType
+	}
+	return &cidrType{network: IPNet(network).GoIPNet()}
+}
+
+// CIDRTypeFromArgs returns a dgo.CIDRType created from the given arguments. No arguments gives the type of all
+// networks. A single network argument in CIDR notation gives the type of that network and all its subnets. A string
+// argument that is prefixed with "=" gives the exact type of the network.
+func CIDRTypeFromArgs(args []interface{}) dgo.CIDRType {
+	switch len(args) {
+	case 0:
+		return DefaultCIDRType
+	case 1:
+		switch a0 := Value(args[0]).(type) {
+		case *ipNetVal:
+			return CIDRType(&a0.n)
+		case dgo.String:
+			s := a0.GoString()
+			if strings.HasPrefix(s, `=`) {
+				return IPNetFromString(s[1:]).Type().(dgo.CIDRType)
+			}
+			return CIDRType(IPNetFromString(s).GoIPNet())
+		}
+		panic(illegalArgument(`CIDRType`, `IPNet or String`, args, 0))
+	}
+	panic(illegalArgumentCount(`CIDRType`, 0, 1, len(args)))
+}
+
+// toIPNet returns the given value as a *net.IPNet and true, or nil and false if the value is neither a network nor
+// a string that is a valid network in CIDR notation
+func toIPNet(value interface{}) (*net.IPNet, bool) {
+	switch v := value.(type) {
+	case *ipNetVal:
+		return &v.n, true
+	case *net.IPNet:
+		return v, v != nil
+	case net.IPNet:
+		return &v, true
+	case *hstring:
+		if n, ok := parseIPNet(v.s); ok {
+			return &n.n, true
+		}
+	case string:
+		if n, ok := parseIPNet(v); ok {
+			return &n.n, true
+		}
+	}
+	return nil, false
+}
+
+func (t *cidrType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactCIDRType:
+		return t.Instance(ot.value)
+	case *exactStringType:
+		return t.Instance(ot.value)
+	case *cidrType:
+		return t.network == nil || ot.network != nil && networkContains(t.network, ot.network)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *cidrType) Describe() string {
+	return Describe(t)
+}
+
+func (t *cidrType) Equals(other interface{}) bool {
+	if ot, ok := other.(*cidrType); ok {
+		return t.network == ot.network ||
+			t.network != nil && ot.network != nil && t.network.String() == ot.network.String()
+	}
+	return false
+}
+
+func (t *cidrType) HashCode() int {
+	h := int(dgo.TiCIDR)
+	if t.network != nil {
+		h = h*31 + util.StringHash(t.network.String())
+	}
+	return h
+}
+
+func (t *cidrType) Instance(value interface{}) bool {
+	n, ok := toIPNet(value)
+	return ok && (t.network == nil || networkContains(t.network, n))
+}
+
+func (t *cidrType) Network() dgo.IPNet {
+	if t.network == nil {
+		return nil
+	}
+	return IPNet(t.network)
+}
+
+func (t *cidrType) New(arg dgo.Value) dgo.Value {
+	return newIPNet(t, arg)
+}
+
+func (t *cidrType) ReflectType() reflect.Type {
+	return reflectIPNetType
+}
+
+func (t *cidrType) String() string {
+	return TypeString(t)
+}
+
+func (t *cidrType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *cidrType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiCIDR
+}
+
+func (t *exactCIDRType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactCIDRType) Generic() dgo.Type {
+	return DefaultCIDRType
+}
+
+func (t *exactCIDRType) Network() dgo.IPNet {
+	return t.value
+}
+
+func (t *exactCIDRType) New(arg dgo.Value) dgo.Value {
+	return newIPNet(t, arg)
+}
+
+func (t *exactCIDRType) ReflectType() reflect.Type {
+	return reflectIPNetType
+}
+
+func (t *exactCIDRType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiCIDRExact
+}
+
+func newIPNet(t dgo.Type, arg dgo.Value) dgo.IPNet {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`cidr`, 1, 1)
+		arg = args.Get(0)
+	}
+	var nv dgo.IPNet
+	switch arg := arg.(type) {
+	case dgo.IPNet:
+		nv = arg
+	case dgo.String:
+		nv = IPNetFromString(arg.GoString())
+	default:
+		panic(illegalArgument(`cidr`, `cidr|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(nv) {
+		panic(IllegalAssignment(t, nv))
+	}
+	return nv
+}
+
+func (v *ipNetVal) Contains(ip dgo.IP) bool {
+	return v.n.Contains(ip.(*ipVal).ip)
+}
+
+func (v *ipNetVal) Equals(other interface{}) bool {
+	var on *net.IPNet
+	switch ov := other.(type) {
+	case *ipNetVal:
+		on = &ov.n
+	case *net.IPNet:
+		on = ov
+	case net.IPNet:
+		on = &ov
+	default:
+		return false
+	}
+	return v.n.String() == on.String()
+}
+
+func (v *ipNetVal) GoIPNet() *net.IPNet {
+	return &net.IPNet{IP: append(net.IP{}, v.n.IP...), Mask: append(net.IPMask{}, v.n.Mask...)}
+}
+
+func (v *ipNetVal) HashCode() int {
+	return util.StringHash(v.n.String())
+}
+
+func (v *ipNetVal) ReflectTo(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(v.String())
+	case reflect.Struct:
+		value.Set(reflect.ValueOf(v.GoIPNet()).Elem())
+	default:
+		value.Set(reflect.ValueOf(v.GoIPNet()))
+	}
+}
+
+func (v *ipNetVal) String() string {
+	return v.n.String()
+}
+
+func (v *ipNetVal) Type() dgo.Type {
+	et := &exactCIDRType{value: v}
+	et.ExactType = et
+	return et
+}
@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestCIDRDefault(t *testing.T) {
+	tp := typ.CIDR
+	require.Instance(t, tp, vf.IPNetFromString(`10.0.0.0/8`))
+	_, n, _ := net.ParseCIDR(`fd00::/8`)
+	require.Instance(t, tp, n)
+	require.Instance(t, tp, *n)
+	require.Instance(t, tp, `192.168.0.0/16`)
+	require.NotInstance(t, tp, `192.168.0.1/16`)
+	require.NotInstance(t, tp, `192.168.0.1`)
+	require.NotInstance(t, tp, 1)
+	require.Nil(t, tp.Network())
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, tf.CIDR(`10.0.0.0/8`))
+	require.Assignable(t, tp, vf.IPNetFromString(`10.0.0.0/8`).Type())
+	require.Assignable(t, tp, vf.String(`10.0.0.0/8`).Type())
+	require.NotAssignable(t, tp, vf.String(`10.0.0.1/8`).Type())
+	require.NotAssignable(t, tp, typ.IP)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.IP)
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `cidr`, tp.String())
+	require.Same(t, tp, typ.Generic(vf.IPNetFromString(`10.0.0.0/8`).Type()))
+	require.Same(t, tp, tf.CIDR(``))
+	require.True(t, reflect.TypeOf(n).AssignableTo(tp.ReflectType()))
+}
+
+func TestCIDRConstraint(t *testing.T) {
+	tp := tf.CIDR(`10.0.0.0/8`)
+	require.Instance(t, tp, `10.0.0.0/8`)
+	require.Instance(t, tp, `10.1.0.0/16`)
+	require.NotInstance(t, tp, `10.0.0.0/7`)
+	require.NotInstance(t, tp, `11.0.0.0/16`)
+	require.NotInstance(t, tp, `::/0`)
+	require.Equal(t, vf.IPNetFromString(`10.0.0.0/8`), tp.Network())
+
+	require.Assignable(t, tp, tf.CIDR(`10.1.0.0/16`))
+	require.NotAssignable(t, tp, tf.CIDR(`10.0.0.0/7`))
+	require.NotAssignable(t, tp, typ.CIDR)
+	require.Assignable(t, tp, vf.IPNetFromString(`10.2.0.0/16`).Type())
+	require.NotAssignable(t, tp, vf.IPNetFromString(`11.2.0.0/16`).Type())
+
+	require.Equal(t, tp, tf.CIDR(`10.0.0.0/8`))
+	require.NotEqual(t, tp, tf.CIDR(`10.0.0.0/16`))
+	require.Equal(t, tp.HashCode(), tf.CIDR(`10.0.0.0/8`).HashCode())
+	require.NotEqual(t, tp.HashCode(), typ.CIDR.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, typ.CIDR.ReflectType(), tp.ReflectType())
+	require.Equal(t, `cidr["10.0.0.0/8"]`, tp.String())
+
+	require.Panic(t, func() { tf.CIDR(`10.0.0.1/8`) }, `'10.0.0.1/8' is not a valid CIDR network`)
+}
+
+func TestCIDRExact(t *testing.T) {
+	n := vf.IPNetFromString(`10.0.0.0/8`)
+	tp := n.Type().(dgo.CIDRType)
+	require.Instance(t, tp, n)
+	require.NotInstance(t, tp, vf.IPNetFromString(`10.1.0.0/16`))
+	require.Same(t, n, tp.Network())
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, tf.CIDR(`10.0.0.0/8`))
+	require.Equal(t, `cidr["=10.0.0.0/8"]`, tp.String())
+	require.Equal(t, typ.CIDR.ReflectType(), tp.ReflectType())
+}
+
+func TestCIDRType_New(t *testing.T) {
+	n := vf.IPNetFromString(`10.0.0.0/8`)
+	require.Same(t, n, vf.New(typ.CIDR, n))
+	require.Equal(t, n, vf.New(typ.CIDR, vf.Arguments(n)))
+	require.Equal(t, n, vf.New(typ.CIDR, vf.String(`10.0.0.0/8`)))
+	require.Equal(t, n, vf.New(n.Type(), vf.String(`10.0.0.0/8`)))
+
+	require.Panic(t, func() { vf.New(tf.CIDR(`10.0.0.0/16`), n) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.CIDR, vf.String(`10.0.0.0`)) }, `not a valid CIDR network`)
+	require.Panic(t, func() { vf.New(typ.CIDR, vf.True) }, `illegal argument`)
+}
+
+func TestIPNet(t *testing.T) {
+	_, gn, _ := net.ParseCIDR(`10.0.0.0/8`)
+	n := vf.IPNet(gn)
+	require.Equal(t, n, gn)
+	require.Equal(t, n, *gn)
+	require.Equal(t, n, vf.Value(gn))
+	require.Equal(t, n, vf.IPNetFromString(`10.0.0.0/8`))
+	require.Equal(t, n, vf.IPNet(&net.IPNet{IP: net.ParseIP(`10.1.2.3`), Mask: net.CIDRMask(8, 32)}))
+	require.NotEqual(t, n, vf.IPNetFromString(`10.0.0.0/16`))
+	require.NotEqual(t, n, `10.0.0.0/8`)
+	require.Equal(t, `10.0.0.0/8`, n.String())
+	require.Equal(t, n.HashCode(), vf.IPNetFromString(`10.0.0.0/8`).HashCode())
+	require.True(t, n.Contains(vf.IPFromString(`10.2.3.4`)))
+	require.False(t, n.Contains(vf.IPFromString(`11.2.3.4`)))
+
+	gc := n.GoIPNet()
+	gc.IP[0] = 11
+	require.Equal(t, `10.0.0.0/8`, n.String())
+
+	require.Panic(t, func() { vf.IPNet(&net.IPNet{IP: net.IP{1, 2, 3}, Mask: net.CIDRMask(8, 32)}) },
+		`not a valid CIDR network`)
+	require.Panic(t, func() { vf.IPNet(&net.IPNet{IP: net.ParseIP(`::1`), Mask: net.CIDRMask(8, 32)}) },
+		`not a valid CIDR network`)
+}
+
+func TestIPNet_ReflectTo(t *testing.T) {
+	n := vf.IPNetFromString(`10.0.0.0/8`)
+
+	var gn *net.IPNet
+	vf.ReflectTo(n, reflect.ValueOf(&gn).Elem())
+	require.Equal(t, `10.0.0.0/8`, gn.String())
+
+	var sn net.IPNet
+	vf.ReflectTo(n, reflect.ValueOf(&sn).Elem())
+	require.Equal(t, `10.0.0.0/8`, sn.String())
+
+	var s string
+	vf.ReflectTo(n, reflect.ValueOf(&s).Elem())
+	require.Equal(t, `10.0.0.0/8`, s)
+}
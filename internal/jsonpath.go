@@ -0,0 +1,616 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// jsonPathSelector calls collect with each value that it selects from the given value
+	jsonPathSelector func(v, root dgo.Value, collect dgo.Consumer)
+
+	jsonPathSegment struct {
+		descendant bool
+		selectors  []jsonPathSelector
+	}
+
+	// jsonPathOperand evaluates to a value or to nil when it doesn't produce exactly one value
+	jsonPathOperand func(current, root dgo.Value) dgo.Value
+
+	jsonPathFilter func(current, root dgo.Value) bool
+
+	jsonPathParser struct {
+		expr string
+		pos  int
+	}
+)
+
+// JSONPath evaluates the given JSONPath expression against the given value and returns an Array with all
+// matching values in document order. The values are not copied.
+//
+// The supported subset of RFC 9535 consists of the root identifier ($), child segments using dot notation
+// (.name, .*) or bracket notation, descendant segments (..name, ..*, ..[]), and the bracketed selectors name
+// ('name' or "name"), wildcard (*), index (0, -1), slice (start:end:step), and filter (?expr or ?(expr)). A
+// union of selectors is written as a comma separated list within brackets.
+//
+// A filter expression can test for existence (@.name) or compare two operands using ==, !=, <, <=, > or >=. An
+// operand is a path relative to the current node (@) or the root ($), or a literal string, number, true, false,
+// or null. The function extension instance(operand, 'type') tests if an operand is an instance of a dgo type,
+// e.g. ?instance(@.port, '1..65535'). Expressions can be combined using &&, ||, and !, and grouped using
+// parentheses.
+//
+// A descendant segment visits each value only once along a path so a value that contains itself is not revisited.
+func JSONPath(v dgo.Value, expr string) (dgo.Array, error) {
+	var segments []*jsonPathSegment
+	err := util.Catch(func() {
+		p := &jsonPathParser{expr: expr}
+		p.skipWhite()
+		p.expect('$')
+		segments = p.parseSegments(false)
+		p.skipWhite()
+		if p.pos < len(p.expr) {
+			panic(p.unexpected())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &array{slice: jsonPathQuery(segments, v, v)}, nil
+}
+
+func jsonPathQuery(segments []*jsonPathSegment, v, root dgo.Value) []dgo.Value {
+	nodes := []dgo.Value{v}
+	for _, s := range segments {
+		var next []dgo.Value
+		collect := func(e dgo.Value) { next = append(next, e) }
+		for _, n := range nodes {
+			if s.descendant {
+				jsonPathDescend(nil, n, func(d dgo.Value) { s.apply(d, root, collect) })
+			} else {
+				s.apply(n, root, collect)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// jsonPathDescend calls actor with the given value and then with all of its descendants in document order. The
+// seen slice contains the containers on the path to the value. A container that is found there is skipped.
+func jsonPathDescend(seen []dgo.Value, v dgo.Value, actor dgo.Consumer) {
+	switch v.(type) {
+	case dgo.Array, dgo.Map:
+		if util.RecursionHit(seen, v) {
+			return
+		}
+		seen = append(seen, v)
+	}
+	actor(v)
+	jsonPathChildren(v, func(c dgo.Value) { jsonPathDescend(seen, c, actor) })
+}
+
+func jsonPathChildren(v dgo.Value, actor dgo.Consumer) {
+	switch v := v.(type) {
+	case dgo.Array:
+		v.Each(actor)
+	case dgo.Map:
+		v.EachValue(actor)
+	}
+}
+
+func (s *jsonPathSegment) apply(v, root dgo.Value, collect dgo.Consumer) {
+	for _, sel := range s.selectors {
+		sel(v, root, collect)
+	}
+}
+
+func jsonPathName(name string) jsonPathSelector {
+	key := String(name)
+	return func(v, _ dgo.Value, collect dgo.Consumer) {
+		if m, ok := v.(dgo.Map); ok {
+			if e := m.Get(key); e != nil {
+				collect(e)
+			}
+		}
+	}
+}
+
+func jsonPathWildcard(v, _ dgo.Value, collect dgo.Consumer) {
+	jsonPathChildren(v, collect)
+}
+
+func jsonPathIndex(index int) jsonPathSelector {
+	return func(v, _ dgo.Value, collect dgo.Consumer) {
+		if a, ok := v.(dgo.Array); ok {
+			i := index
+			if i < 0 {
+				i += a.Len()
+			}
+			if i >= 0 && i < a.Len() {
+				collect(a.Get(i))
+			}
+		}
+	}
+}
+
+func jsonPathSlice(start, end *int, step int) jsonPathSelector {
+	return func(v, _ dgo.Value, collect dgo.Consumer) {
+		a, ok := v.(dgo.Array)
+		if !ok || step == 0 {
+			return
+		}
+		l := a.Len()
+		normalize := func(i int) int {
+			if i < 0 {
+				i += l
+			}
+			return i
+		}
+		clamp := func(i, lower, upper int) int {
+			if i < lower {
+				return lower
+			}
+			if i > upper {
+				return upper
+			}
+			return i
+		}
+		if step > 0 {
+			lower, upper := 0, l
+			if start != nil {
+				lower = clamp(normalize(*start), 0, l)
+			}
+			if end != nil {
+				upper = clamp(normalize(*end), 0, l)
+			}
+			for i := lower; i < upper; i += step {
+				collect(a.Get(i))
+			}
+		} else {
+			upper, lower := l-1, -1
+			if start != nil {
+				upper = clamp(normalize(*start), -1, l-1)
+			}
+			if end != nil {
+				lower = clamp(normalize(*end), -1, l-1)
+			}
+			for i := upper; i > lower; i += step {
+				collect(a.Get(i))
+			}
+		}
+	}
+}
+
+func jsonPathFilterSelector(filter jsonPathFilter) jsonPathSelector {
+	return func(v, root dgo.Value, collect dgo.Consumer) {
+		jsonPathChildren(v, func(c dgo.Value) {
+			if filter(c, root) {
+				collect(c)
+			}
+		})
+	}
+}
+
+func (p *jsonPathParser) unexpected() error {
+	if p.pos >= len(p.expr) {
+		return fmt.Errorf(`jsonpath: unexpected end of expression %q`, p.expr)
+	}
+	r, _ := utf8.DecodeRuneInString(p.expr[p.pos:])
+	return fmt.Errorf(`jsonpath: unexpected character '%c' at position %d in %q`, r, p.pos, p.expr)
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) skipWhite() {
+	for p.pos < len(p.expr) {
+		switch p.expr[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonPathParser) expect(c byte) {
+	if p.peek() != c {
+		panic(p.unexpected())
+	}
+	p.pos++
+}
+
+func (p *jsonPathParser) accept(s string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// parseSegments parses segments until no more segments can be found. Whitespace is permitted between
+// segments unless the segments are part of a filter operand.
+func (p *jsonPathParser) parseSegments(inFilter bool) []*jsonPathSegment {
+	var segments []*jsonPathSegment
+	for {
+		start := p.pos
+		if !inFilter {
+			p.skipWhite()
+		}
+		switch {
+		case p.accept(`..`):
+			s := &jsonPathSegment{descendant: true}
+			if p.peek() == '[' {
+				s.selectors = p.parseBracketed()
+			} else {
+				s.selectors = []jsonPathSelector{p.parseShorthand()}
+			}
+			segments = append(segments, s)
+		case p.accept(`.`):
+			segments = append(segments, &jsonPathSegment{selectors: []jsonPathSelector{p.parseShorthand()}})
+		case p.peek() == '[':
+			segments = append(segments, &jsonPathSegment{selectors: p.parseBracketed()})
+		default:
+			p.pos = start
+			return segments
+		}
+	}
+}
+
+func (p *jsonPathParser) parseShorthand() jsonPathSelector {
+	if p.accept(`*`) {
+		return jsonPathWildcard
+	}
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r, n := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if !(r == '_' || unicode.IsLetter(r) || p.pos > start && unicode.IsDigit(r)) {
+			break
+		}
+		p.pos += n
+	}
+	if start == p.pos {
+		panic(p.unexpected())
+	}
+	return jsonPathName(p.expr[start:p.pos])
+}
+
+func (p *jsonPathParser) parseBracketed() []jsonPathSelector {
+	p.expect('[')
+	var selectors []jsonPathSelector
+	for {
+		p.skipWhite()
+		selectors = append(selectors, p.parseSelector())
+		p.skipWhite()
+		if p.peek() == ']' {
+			p.pos++
+			return selectors
+		}
+		p.expect(',')
+	}
+}
+
+func (p *jsonPathParser) parseSelector() jsonPathSelector {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		return jsonPathName(p.parseString())
+	case c == '*':
+		p.pos++
+		return jsonPathWildcard
+	case c == '?':
+		p.pos++
+		p.skipWhite()
+		return jsonPathFilterSelector(p.parseOr())
+	case c == ':' || c == '-' || c >= '0' && c <= '9':
+		return p.parseIndexOrSlice()
+	}
+	panic(p.unexpected())
+}
+
+func (p *jsonPathParser) parseIndexOrSlice() jsonPathSelector {
+	start := p.parseOptionalInt()
+	p.skipWhite()
+	if p.peek() != ':' {
+		if start == nil {
+			panic(p.unexpected())
+		}
+		return jsonPathIndex(*start)
+	}
+	p.pos++
+	p.skipWhite()
+	end := p.parseOptionalInt()
+	p.skipWhite()
+	step := 1
+	if p.accept(`:`) {
+		p.skipWhite()
+		if s := p.parseOptionalInt(); s != nil {
+			step = *s
+		}
+	}
+	return jsonPathSlice(start, end, step)
+}
+
+func (p *jsonPathParser) parseOptionalInt() *int {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil
+	}
+	i, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		p.pos = start
+		panic(p.unexpected())
+	}
+	return &i
+}
+
+func (p *jsonPathParser) parseString() string {
+	q := p.peek()
+	p.pos++
+	sb := strings.Builder{}
+	for {
+		if p.pos >= len(p.expr) {
+			panic(p.unexpected())
+		}
+		c := p.expr[p.pos]
+		p.pos++
+		switch c {
+		case q:
+			return sb.String()
+		case '\\':
+			if p.pos >= len(p.expr) {
+				panic(p.unexpected())
+			}
+			c = p.expr[p.pos]
+			p.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			case 'r':
+				c = '\r'
+			}
+		}
+		sb.WriteByte(c)
+	}
+}
+
+func (p *jsonPathParser) parseOr() jsonPathFilter {
+	f := p.parseAnd()
+	for {
+		p.skipWhite()
+		if !p.accept(`||`) {
+			return f
+		}
+		p.skipWhite()
+		l, r := f, p.parseAnd()
+		f = func(c, root dgo.Value) bool { return l(c, root) || r(c, root) }
+	}
+}
+
+func (p *jsonPathParser) parseAnd() jsonPathFilter {
+	f := p.parseUnary()
+	for {
+		p.skipWhite()
+		if !p.accept(`&&`) {
+			return f
+		}
+		p.skipWhite()
+		l, r := f, p.parseUnary()
+		f = func(c, root dgo.Value) bool { return l(c, root) && r(c, root) }
+	}
+}
+
+func (p *jsonPathParser) parseUnary() jsonPathFilter {
+	switch p.peek() {
+	case '!':
+		p.pos++
+		p.skipWhite()
+		f := p.parseUnary()
+		return func(c, root dgo.Value) bool { return !f(c, root) }
+	case '(':
+		p.pos++
+		p.skipWhite()
+		f := p.parseOr()
+		p.skipWhite()
+		p.expect(')')
+		return f
+	}
+	if p.accept(`instance(`) {
+		return p.parseInstance()
+	}
+	return p.parseComparison()
+}
+
+// parseInstance parses the arguments of the instance function, an operand and a quoted dgo type expression
+func (p *jsonPathParser) parseInstance() jsonPathFilter {
+	p.skipWhite()
+	o := p.parseOperand()
+	p.skipWhite()
+	p.expect(',')
+	p.skipWhite()
+	if c := p.peek(); c != '\'' && c != '"' {
+		panic(p.unexpected())
+	}
+	start := p.pos
+	ts := p.parseString()
+	var t dgo.Type
+	if err := util.Catch(func() { t = AsType(Parse(ts)) }); err != nil {
+		panic(fmt.Errorf(`jsonpath: invalid type at position %d in %q: %s`, start, p.expr, err.Error()))
+	}
+	p.skipWhite()
+	p.expect(')')
+	return func(current, root dgo.Value) bool {
+		v := o(current, root)
+		return v != nil && t.Instance(v)
+	}
+}
+
+func (p *jsonPathParser) parseComparison() jsonPathFilter {
+	c := p.peek()
+	if c == '@' || c == '$' {
+		p.pos++
+		segments := p.parseSegments(true)
+		p.skipWhite()
+		if op := p.parseOperator(); op != `` {
+			p.skipWhite()
+			return jsonPathCompare(jsonPathQueryOperand(c == '$', segments), op, p.parseOperand())
+		}
+		return func(current, root dgo.Value) bool {
+			v := current
+			if c == '$' {
+				v = root
+			}
+			return len(jsonPathQuery(segments, v, root)) > 0
+		}
+	}
+	l := p.parseOperand()
+	p.skipWhite()
+	op := p.parseOperator()
+	if op == `` {
+		panic(p.unexpected())
+	}
+	p.skipWhite()
+	return jsonPathCompare(l, op, p.parseOperand())
+}
+
+func (p *jsonPathParser) parseOperator() string {
+	for _, op := range []string{`==`, `!=`, `<=`, `>=`, `<`, `>`} {
+		if p.accept(op) {
+			return op
+		}
+	}
+	return ``
+}
+
+func (p *jsonPathParser) parseOperand() jsonPathOperand {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		return jsonPathQueryOperand(c == '$', p.parseSegments(true))
+	case c == '\'' || c == '"':
+		return jsonPathLiteral(String(p.parseString()))
+	case c == '-' || c >= '0' && c <= '9':
+		return jsonPathLiteral(p.parseNumber())
+	case p.accept(`true`):
+		return jsonPathLiteral(True)
+	case p.accept(`false`):
+		return jsonPathLiteral(False)
+	case p.accept(`null`):
+		return jsonPathLiteral(Nil)
+	}
+	panic(p.unexpected())
+}
+
+func (p *jsonPathParser) parseNumber() dgo.Value {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for {
+		c := p.peek()
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '.' || c == 'e' || c == 'E' || c == '+' || (c == '-' && isFloat):
+			isFloat = true
+		default:
+			s := p.expr[start:p.pos]
+			if isFloat {
+				if f, err := strconv.ParseFloat(s, 64); err == nil {
+					return Float(f)
+				}
+			} else if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return Integer(i)
+			}
+			p.pos = start
+			panic(p.unexpected())
+		}
+		p.pos++
+	}
+}
+
+func jsonPathLiteral(v dgo.Value) jsonPathOperand {
+	return func(_, _ dgo.Value) dgo.Value { return v }
+}
+
+func jsonPathQueryOperand(fromRoot bool, segments []*jsonPathSegment) jsonPathOperand {
+	return func(current, root dgo.Value) dgo.Value {
+		v := current
+		if fromRoot {
+			v = root
+		}
+		if r := jsonPathQuery(segments, v, root); len(r) == 1 {
+			return r[0]
+		}
+		return nil
+	}
+}
+
+func jsonPathCompare(l jsonPathOperand, op string, r jsonPathOperand) jsonPathFilter {
+	return func(current, root dgo.Value) bool {
+		a := l(current, root)
+		b := r(current, root)
+		switch op {
+		case `==`:
+			return jsonPathEquals(a, b)
+		case `!=`:
+			return !jsonPathEquals(a, b)
+		}
+		c, ok := jsonPathOrder(a, b)
+		if !ok {
+			return false
+		}
+		switch op {
+		case `<`:
+			return c < 0
+		case `<=`:
+			return c <= 0
+		case `>`:
+			return c > 0
+		default:
+			return c >= 0
+		}
+	}
+}
+
+func jsonPathEquals(a, b dgo.Value) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if c, ok := jsonPathOrder(a, b); ok {
+		return c == 0
+	}
+	return a.Equals(b)
+}
+
+// jsonPathOrder compares two numbers or two strings. The comparison is not ok for all other values.
+func jsonPathOrder(a, b dgo.Value) (int, bool) {
+	switch a := a.(type) {
+	case dgo.Number:
+		if _, ok := b.(dgo.Number); ok {
+			return a.(dgo.Comparable).CompareTo(b)
+		}
+	case dgo.String:
+		if _, ok := b.(dgo.String); ok {
+			return a.CompareTo(b)
+		}
+	}
+	return 0, false
+}
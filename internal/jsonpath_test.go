@@ -0,0 +1,145 @@
+package internal_test
+
+import (
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+var jsonPathData = vf.Map(
+	`store`, vf.Map(
+		`book`, vf.Values(
+			vf.Map(`category`, `reference`, `author`, `Nigel Rees`, `title`, `Sayings of the Century`, `price`, 8.95),
+			vf.Map(`category`, `fiction`, `author`, `Evelyn Waugh`, `title`, `Sword of Honour`, `price`, 12.99),
+			vf.Map(`category`, `fiction`, `author`, `Herman Melville`, `title`, `Moby Dick`, `isbn`, `0-553-21311-3`,
+				`price`, 8.99),
+			vf.Map(`category`, `fiction`, `author`, `J. R. R. Tolkien`, `title`, `The Lord of the Rings`,
+				`isbn`, `0-395-19395-8`, `price`, 22.99)),
+		`bicycle`, vf.Map(`color`, `red`, `price`, 399)),
+	`people`, vf.Values(
+		vf.Map(`name`, `Alice`, `age`, 31),
+		vf.Map(`name`, `Bob`, `age`, 17),
+		vf.Map(`name`, `Carol`, `age`, 18)))
+
+func jsonPath(t *testing.T, expr string) interface{} {
+	t.Helper()
+	r, err := vf.JSONPath(jsonPathData, expr)
+	require.Nil(t, err)
+	return r
+}
+
+func TestJSONPath_root(t *testing.T) {
+	require.Equal(t, vf.Values(jsonPathData), jsonPath(t, `$`))
+}
+
+func TestJSONPath_child(t *testing.T) {
+	require.Equal(t, vf.Values(`red`), jsonPath(t, `$.store.bicycle.color`))
+	require.Equal(t, vf.Values(`red`), jsonPath(t, `$['store']["bicycle"].color`))
+	require.Equal(t, vf.Values(), jsonPath(t, `$.store.car`))
+	require.Equal(t, vf.Values(`red`, 399), jsonPath(t, `$.store.bicycle.*`))
+	require.Equal(t, vf.Values(`red`, 399), jsonPath(t, `$.store.bicycle[*]`))
+}
+
+func TestJSONPath_descendant(t *testing.T) {
+	require.Equal(t,
+		vf.Values(`Nigel Rees`, `Evelyn Waugh`, `Herman Melville`, `J. R. R. Tolkien`),
+		jsonPath(t, `$..author`))
+	require.Equal(t, vf.Values(8.95, 12.99, 8.99, 22.99, 399), jsonPath(t, `$.store..price`))
+	require.Equal(t, vf.Values(`Sayings of the Century`, `Alice`), jsonPath(t, `$..[0]['title','name']`))
+}
+
+func TestJSONPath_index(t *testing.T) {
+	require.Equal(t, vf.Values(`Alice`), jsonPath(t, `$.people[0].name`))
+	require.Equal(t, vf.Values(`Carol`), jsonPath(t, `$.people[-1].name`))
+	require.Equal(t, vf.Values(), jsonPath(t, `$.people[3]`))
+	require.Equal(t, vf.Values(`Carol`, `Alice`), jsonPath(t, `$.people[2, 0].name`))
+}
+
+func TestJSONPath_slice(t *testing.T) {
+	a := vf.Values(0, 1, 2, 3, 4, 5)
+	q := func(expr string) interface{} {
+		r, err := vf.JSONPath(a, expr)
+		require.Nil(t, err)
+		return r
+	}
+	require.Equal(t, vf.Values(1, 2), q(`$[1:3]`))
+	require.Equal(t, vf.Values(4, 5), q(`$[4:]`))
+	require.Equal(t, vf.Values(0, 1), q(`$[:2]`))
+	require.Equal(t, vf.Values(0, 2, 4), q(`$[::2]`))
+	require.Equal(t, vf.Values(4, 5), q(`$[-2:]`))
+	require.Equal(t, vf.Values(5, 4, 3, 2, 1, 0), q(`$[::-1]`))
+	require.Equal(t, vf.Values(5, 3), q(`$[5:1:-2]`))
+	require.Equal(t, vf.Values(), q(`$[1:3:0]`))
+	require.Equal(t, vf.Values(), q(`$[3:1]`))
+}
+
+func TestJSONPath_union(t *testing.T) {
+	require.Equal(t, vf.Values(`red`, 399), jsonPath(t, `$.store.bicycle['color','price']`))
+}
+
+func TestJSONPath_filter(t *testing.T) {
+	require.Equal(t, vf.Values(`Alice`, `Carol`), jsonPath(t, `$.people[?(@.age >= 18)].name`))
+	require.Equal(t, vf.Values(`Alice`), jsonPath(t, `$.people[?(@.age > 18)].name`))
+	require.Equal(t, vf.Values(`Bob`), jsonPath(t, `$.people[?@.age<18].name`))
+	require.Equal(t, vf.Values(`Bob`), jsonPath(t, `$.people[?@.name == 'Bob'].name`))
+	require.Equal(t, vf.Values(`Alice`, `Carol`), jsonPath(t, `$.people[?@.name != "Bob"].name`))
+	require.Equal(t, vf.Values(`Carol`), jsonPath(t, `$.people[?@.age == 18.0].name`))
+	require.Equal(t, vf.Values(`Carol`), jsonPath(t, `$.people[?18 <= @.age && !(@.age > 20)].name`))
+	require.Equal(t, vf.Values(`Alice`, `Bob`), jsonPath(t, `$.people[?@.age > 30 || @.age < 18].name`))
+	require.Equal(t, vf.Values(`Moby Dick`, `The Lord of the Rings`), jsonPath(t, `$..book[?@.isbn].title`))
+	require.Equal(t, vf.Values(`Sayings of the Century`, `Moby Dick`),
+		jsonPath(t, `$..book[?@.price < $.store.book[1].price].title`))
+	require.Equal(t, vf.Values(), jsonPath(t, `$.people[?@.name > 3]`))
+	require.Equal(t, vf.Values(), jsonPath(t, `$.people[?@.missing == null]`))
+	require.Equal(t, vf.Values(`Bob`), jsonPath(t, `$.people[?@.name < 'C' && @.name > 'Alice'].name`))
+}
+
+func TestJSONPath_instance(t *testing.T) {
+	require.Equal(t, vf.Values(`Alice`, `Carol`), jsonPath(t, `$.people[?instance(@.age, '18..')].name`))
+	require.Equal(t, vf.Values(399, 31, 17, 18), jsonPath(t, `$..[?instance(@, "int")]`))
+	require.Equal(t, vf.Values(`Moby Dick`, `The Lord of the Rings`),
+		jsonPath(t, `$..book[?instance(@, '{isbn:string,...}')].title`))
+	require.Equal(t, vf.Values(`Bob`),
+		jsonPath(t, `$.people[?!instance(@.age, '18..') && instance(@.name, '/^B/')].name`))
+	require.Equal(t, vf.Values(), jsonPath(t, `$.people[?instance(@.missing, 'any')]`))
+
+	_, err := vf.JSONPath(jsonPathData, `$[?instance(@, 'int[')]`)
+	require.Match(t, `jsonpath: invalid type at position 15`, err.Error())
+}
+
+func TestJSONPath_selfReference(t *testing.T) {
+	m := vf.MutableMap(`a`, 1)
+	m.Put(`self`, m)
+	a := vf.MutableValues(2)
+	a.Add(a)
+	m.Put(`b`, a)
+	r, err := vf.JSONPath(m, `$..[?instance(@, 'int')]`)
+	require.Nil(t, err)
+	require.Equal(t, vf.Values(1, 2), r)
+}
+
+func TestJSONPath_errors(t *testing.T) {
+	for _, expr := range []string{
+		``,
+		`store`,
+		`$.`,
+		`$[`,
+		`$['a'`,
+		`$['a`,
+		`$[a]`,
+		`$.a b`,
+		`$[?@.a ==]`,
+		`$[?(@.a > 1]`,
+		`$[?1]`,
+		`$[99999999999999999999]`,
+		`$[?@.a > 1e]`,
+	} {
+		_, err := vf.JSONPath(jsonPathData, expr)
+		require.True(t, err != nil, expr)
+	}
+	_, err := vf.JSONPath(jsonPathData, `$.a b`)
+	require.Equal(t, `jsonpath: unexpected character 'b' at position 4 in "$.a b"`, err.Error())
+	_, err = vf.JSONPath(jsonPathData, `$[`)
+	require.Equal(t, `jsonpath: unexpected end of expression "$["`, err.Error())
+}
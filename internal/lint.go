@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type lintWarning struct {
+	code       string
+	message    string
+	suggestion string
+}
+
+func (w *lintWarning) Code() string {
+	return w.code
+}
+
+func (w *lintWarning) Message() string {
+	return w.message
+}
+
+func (w *lintWarning) Suggestion() string {
+	return w.suggestion
+}
+
+func (w *lintWarning) String() string {
+	return fmt.Sprintf(`%s: %s (%s)`, w.code, w.message, w.suggestion)
+}
+
+// Lint examines the given type and all types nested within it and returns warnings for constructs that are valid
+// but suspicious:
+//
+// "disjoint-all-of": an AllOf type contains types that have no common instance so the type can never be satisfied
+//
+// "redundant-all-of": an AllOf type contains a type that is assignable from another one of its types
+//
+// "redundant-any-of": an AnyOf type contains a type that is assignable to another one of its types
+//
+// "overlapping-one-of": a OneOf type contains a type that is assignable to another one of its types so that the
+// instances of that type can never match
+//
+// "not-any": a Not type negates any so it can never be satisfied
+func Lint(t dgo.Type) []dgo.LintWarning {
+	l := &linter{}
+	l.lint(t)
+	return l.warnings
+}
+
+type linter struct {
+	seen     []dgo.Value
+	warnings []dgo.LintWarning
+}
+
+func (l *linter) warn(code, message, suggestion string) {
+	l.warnings = append(l.warnings, &lintWarning{code: code, message: message, suggestion: suggestion})
+}
+
+func (l *linter) lint(t dgo.Type) {
+	if _, ok := t.(dgo.ExactType); ok {
+		return
+	}
+	switch t := t.(type) {
+	case dgo.TernaryType:
+		if l.enter(t) {
+			return
+		}
+		ops := t.Operands()
+		ops.Each(func(op dgo.Value) { l.lint(op.(dgo.Type)) })
+		l.lintTernary(t, ops)
+	case dgo.UnaryType:
+		if l.enter(t) {
+			return
+		}
+		op := t.Operand()
+		l.lint(op)
+		if t.Operator() == dgo.OpNot && op.Equals(DefaultAnyType) {
+			l.warn(`not-any`, fmt.Sprintf(`%s can never be instantiated`, TypeString(t)),
+				`remove the type or use a type that represents the excluded values`)
+		}
+	case dgo.StructMapType:
+		if l.enter(t) {
+			return
+		}
+		t.Each(func(e dgo.StructMapEntry) { l.lint(e.Value().(dgo.Type)) })
+	case dgo.MapType:
+		if l.enter(t) {
+			return
+		}
+		l.lint(t.KeyType())
+		l.lint(t.ValueType())
+	case dgo.TupleType:
+		if l.enter(t) {
+			return
+		}
+		t.ElementTypes().Each(func(et dgo.Value) { l.lint(et.(dgo.Type)) })
+	case dgo.ArrayType:
+		if l.enter(t) {
+			return
+		}
+		l.lint(t.ElementType())
+	}
+}
+
+// enter returns true if the given type has been seen already. If not, the type is marked as seen.
+func (l *linter) enter(t dgo.Type) bool {
+	if util.RecursionHit(l.seen, t) {
+		return true
+	}
+	l.seen = append(l.seen, t)
+	return false
+}
+
+func (l *linter) lintTernary(t dgo.TernaryType, ops dgo.Array) {
+	ts := TypeString(t)
+	n := ops.Len()
+	for i := 0; i < n; i++ {
+		a := ops.Get(i).(dgo.Type)
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			b := ops.Get(j).(dgo.Type)
+			as := TypeString(a)
+			bs := TypeString(b)
+			switch t.Operator() {
+			case dgo.OpAnd:
+				if i < j && disjoint(a, b) {
+					l.warn(`disjoint-all-of`,
+						fmt.Sprintf(`%s contains the disjoint types %s and %s so it can never be instantiated`, ts, as, bs),
+						`remove one of the disjoint types or use an AnyOf type`)
+				} else if a.Assignable(b) && (i < j || !b.Assignable(a)) {
+					l.warn(`redundant-all-of`,
+						fmt.Sprintf(`%s contains %s which is assignable from %s`, ts, as, bs),
+						fmt.Sprintf(`remove %s`, as))
+				}
+			case dgo.OpOr:
+				if b.Assignable(a) && (i > j || !a.Assignable(b)) {
+					l.warn(`redundant-any-of`,
+						fmt.Sprintf(`%s contains %s which is assignable to %s`, ts, as, bs),
+						fmt.Sprintf(`remove %s`, as))
+				}
+			case dgo.OpOne:
+				if b.Assignable(a) && (i > j || !a.Assignable(b)) {
+					l.warn(`overlapping-one-of`,
+						fmt.Sprintf(`%s contains %s which is assignable to %s so its instances can never match`, ts, as, bs),
+						fmt.Sprintf(`remove %s or use an AnyOf type`, as))
+				}
+			}
+		}
+	}
+}
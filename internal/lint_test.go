@@ -0,0 +1,67 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+)
+
+func lintCodes(ws []dgo.LintWarning) []string {
+	cs := make([]string, len(ws))
+	for i := range ws {
+		cs[i] = ws[i].Code()
+	}
+	return cs
+}
+
+func TestLint_clean(t *testing.T) {
+	require.Equal(t, 0, len(typ.Lint(tf.ParseType(`{name:string,tags:[]string,age?:0..150}`))))
+	require.Equal(t, 0, len(typ.Lint(tf.ParseType(`string|int`))))
+	require.Equal(t, 0, len(typ.Lint(tf.ParseType(`string[1,5]&/a/`))))
+}
+
+func TestLint_disjointAllOf(t *testing.T) {
+	ws := typ.Lint(tf.ParseType(`string&int`))
+	require.Equal(t, []string{`disjoint-all-of`}, lintCodes(ws))
+	require.Equal(t, `string&int contains the disjoint types string and int so it can never be instantiated`,
+		ws[0].Message())
+	require.Equal(t, `remove one of the disjoint types or use an AnyOf type`, ws[0].Suggestion())
+	require.Equal(t, `disjoint-all-of: `+ws[0].Message()+` (`+ws[0].Suggestion()+`)`, ws[0].String())
+}
+
+func TestLint_redundantAllOf(t *testing.T) {
+	ws := typ.Lint(tf.ParseType(`string&string[1,5]`))
+	require.Equal(t, []string{`redundant-all-of`}, lintCodes(ws))
+	require.Equal(t, `remove string`, ws[0].Suggestion())
+}
+
+func TestLint_redundantAnyOf(t *testing.T) {
+	ws := typ.Lint(tf.ParseType(`any|string`))
+	require.Equal(t, []string{`redundant-any-of`}, lintCodes(ws))
+	require.Equal(t, `any|string contains string which is assignable to any`, ws[0].Message())
+
+	ws = typ.Lint(tf.ParseType(`string|string`))
+	require.Equal(t, []string{`redundant-any-of`}, lintCodes(ws))
+}
+
+func TestLint_overlappingOneOf(t *testing.T) {
+	ws := typ.Lint(tf.ParseType(`string^"a"`))
+	require.Equal(t, []string{`overlapping-one-of`}, lintCodes(ws))
+}
+
+func TestLint_notAny(t *testing.T) {
+	require.Equal(t, []string{`not-any`}, lintCodes(typ.Lint(tf.Not(typ.Any))))
+}
+
+func TestLint_nested(t *testing.T) {
+	ws := typ.Lint(tf.ParseType(`{a:[]string&int,b:map[any|string]{int,bool&float}}`))
+	require.Equal(t, []string{`disjoint-all-of`, `redundant-any-of`, `disjoint-all-of`}, lintCodes(ws))
+}
+
+func TestLint_recursive(t *testing.T) {
+	tp := tf.ParseType(`lintRec={a:lintRec|string|string}`)
+	require.Equal(t, []string{`redundant-any-of`}, lintCodes(typ.Lint(tp)))
+}
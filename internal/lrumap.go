@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// lruMap is a dgo.LRUMap that is backed by a hashMap. The linked list of the hashMap is kept in least recently used
+// order by moving each node that is accessed to the end of the list.
+type lruMap struct {
+	*hashMap
+	capacity  int
+	keyType   dgo.Type
+	valueType dgo.Type
+	onEvict   func(key, value dgo.Value)
+}
+
+// LRUMap returns a new empty and mutable dgo.LRUMap that holds at most capacity entries. All keys must be instances
+// of the given keyType and all values instances of the given valueType. A nil type is the same as any.
+func LRUMap(capacity int, keyType, valueType dgo.Type) dgo.LRUMap {
+	if capacity < 1 {
+		panic(fmt.Errorf(`illegal LRU map capacity %d`, capacity))
+	}
+	if keyType == nil {
+		keyType = DefaultAnyType
+	}
+	if valueType == nil {
+		valueType = DefaultAnyType
+	}
+	return &lruMap{hashMap: MapWithCapacity(0).(*hashMap), capacity: capacity, keyType: keyType, valueType: valueType}
+}
+
+// promote moves the node for the given key to the end of the list and returns it. The map is left unchanged and nil
+// is returned when no node exists for the key.
+func (m *lruMap) promote(k dgo.Value) *hashNode {
+	m.detach()
+	tbl := m.table
+	if len(tbl) == 0 {
+		return nil
+	}
+	for e := tbl[(len(tbl)-1)&hash(k.HashCode())]; e != nil; e = e.hashNext {
+		if k.Equals(e.key) {
+			if e != m.last {
+				if e.prev == nil {
+					m.first = e.next
+				} else {
+					e.prev.next = e.next
+				}
+				e.next.prev = e.prev
+				e.prev = m.last
+				e.next = nil
+				m.last.next = e
+				m.last = e
+			}
+			return e
+		}
+	}
+	return nil
+}
+
+// clone returns a mutable copy of this map that shares the keys and values of this map
+func (m *lruMap) clone() *lruMap {
+	c := &hashMap{len: m.len}
+	m.hashMap.resize(c, 0)
+	return m.wrap(c)
+}
+
+// wrap returns an lruMap with the same capacity, types, and eviction function as this map that is backed by the
+// given map
+func (m *lruMap) wrap(g dgo.Map) *lruMap {
+	if g == m.hashMap {
+		return m
+	}
+	return &lruMap{hashMap: g.(*hashMap), capacity: m.capacity, keyType: m.keyType, valueType: m.valueType,
+		onEvict: m.onEvict}
+}
+
+func (m *lruMap) Capacity() int {
+	return m.capacity
+}
+
+func (m *lruMap) ComputeIfAbsent(key interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	k := Value(key)
+	if v := m.Get(k); v != nil {
+		return v
+	}
+	v := Value(computer(k))
+	m.Put(k, v)
+	return v
+}
+
+func (m *lruMap) Copy(frozen bool) dgo.Map {
+	return m.wrap(m.hashMap.Copy(frozen))
+}
+
+func (m *lruMap) FrozenCopy() dgo.Value {
+	return m.Copy(true)
+}
+
+func (m *lruMap) ThawedCopy() dgo.Value {
+	return m.Copy(false)
+}
+
+// Get returns the value that is associated with the given key and promotes the entry to most recently used. Entries
+// of a frozen map are never promoted.
+func (m *lruMap) Get(key interface{}) dgo.Value {
+	if m.frozen {
+		return m.hashMap.Get(key)
+	}
+	if e := m.promote(Value(key)); e != nil {
+		return e.value
+	}
+	return nil
+}
+
+func (m *lruMap) GetArray(key interface{}) (dgo.Array, bool) {
+	return asArray(m.Get(key))
+}
+
+func (m *lruMap) GetBool(key interface{}) (bool, bool) {
+	return asGoBool(m.Get(key))
+}
+
+func (m *lruMap) GetFloat(key interface{}) (float64, bool) {
+	return asGoFloat(m.Get(key))
+}
+
+func (m *lruMap) GetInt(key interface{}) (int64, bool) {
+	return asGoInt(m.Get(key))
+}
+
+func (m *lruMap) GetMap(key interface{}) (dgo.Map, bool) {
+	return asMap(m.Get(key))
+}
+
+func (m *lruMap) GetOrElse(key interface{}, defaultValue dgo.Value) dgo.Value {
+	if v := m.Get(key); v != nil {
+		return v
+	}
+	return defaultValue
+}
+
+func (m *lruMap) GetOrElseGet(key interface{}, computer func() dgo.Value) dgo.Value {
+	if v := m.Get(key); v != nil {
+		return v
+	}
+	return computer()
+}
+
+func (m *lruMap) GetString(key interface{}) (string, bool) {
+	return asGoString(m.Get(key))
+}
+
+func (m *lruMap) Merge(associations dgo.Map) dgo.Map {
+	if associations.Len() == 0 || m == associations {
+		return m
+	}
+	c := m.clone()
+	c.PutAll(associations)
+	c.frozen = m.frozen
+	return c
+}
+
+func (m *lruMap) MustGetArray(key interface{}) dgo.Array {
+	return mustGet(m, key, DefaultArrayType).(dgo.Array)
+}
+
+func (m *lruMap) MustGetBool(key interface{}) bool {
+	return mustGet(m, key, DefaultBooleanType).(dgo.Boolean).GoBool()
+}
+
+func (m *lruMap) MustGetFloat(key interface{}) float64 {
+	return mustGet(m, key, DefaultFloatType).(dgo.Float).GoFloat()
+}
+
+func (m *lruMap) MustGetInt(key interface{}) int64 {
+	return mustGet(m, key, DefaultIntegerType).(dgo.Integer).GoInt()
+}
+
+func (m *lruMap) MustGetMap(key interface{}) dgo.Map {
+	return mustGet(m, key, DefaultMapType).(dgo.Map)
+}
+
+func (m *lruMap) MustGetString(key interface{}) string {
+	return mustGet(m, key, DefaultStringType).(dgo.String).GoString()
+}
+
+func (m *lruMap) OnEvict(onEvict func(key, value dgo.Value)) {
+	m.onEvict = onEvict
+}
+
+// Put associates the given value with the given key and makes the entry the most recently used. The least recently
+// used entry is evicted when the key is new and the map is at capacity.
+func (m *lruMap) Put(key, value interface{}) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`Put`))
+	}
+	k := Value(key)
+	if !m.keyType.Instance(k) {
+		panic(IllegalAssignment(m.keyType, k))
+	}
+	v := Value(value)
+	if !m.valueType.Instance(v) {
+		panic(IllegalAssignment(m.valueType, v))
+	}
+	if e := m.promote(k); e != nil {
+		old := e.value
+		e.value = v
+		return old
+	}
+	if m.len >= m.capacity {
+		e := m.first
+		m.hashMap.remove(e.key)
+		if m.onEvict != nil {
+			m.onEvict(e.key, e.value)
+		}
+	}
+	return m.hashMap.Put(k, v)
+}
+
+func (m *lruMap) PutAll(associations dgo.Map) {
+	if associations.Len() == 0 {
+		return
+	}
+	if m.frozen {
+		panic(frozenMap(`PutAll`))
+	}
+	associations.EachEntry(func(e dgo.MapEntry) { m.Put(e.Key(), e.Value()) })
+}
+
+func (m *lruMap) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	if m.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	k := Value(key)
+	if old := m.Get(k); old != nil {
+		return old, false
+	}
+	v := Value(value)
+	m.Put(k, v)
+	return v, true
+}
+
+func (m *lruMap) String() string {
+	return m.hashMap.String()
+}
+
+func (m *lruMap) Type() dgo.Type {
+	et := &exactMapType{value: m}
+	et.ExactType = et
+	return et
+}
+
+func (m *lruMap) With(key, value interface{}) dgo.Map {
+	v := Value(value)
+	if v.Equals(m.hashMap.Get(key)) {
+		return m
+	}
+	c := m.clone()
+	c.Put(key, v)
+	c.frozen = m.frozen
+	return c
+}
+
+func (m *lruMap) Without(key interface{}) dgo.Map {
+	return m.wrap(m.hashMap.Without(key))
+}
+
+func (m *lruMap) WithoutAll(keys dgo.Array) dgo.Map {
+	return m.wrap(m.hashMap.WithoutAll(keys))
+}
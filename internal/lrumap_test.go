@@ -0,0 +1,131 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestLRUMap(t *testing.T) {
+	m := vf.LRUMap(3, typ.String, typ.Integer)
+	require.Equal(t, 3, m.Capacity())
+	var evicted []dgo.Value
+	m.OnEvict(func(k, v dgo.Value) { evicted = append(evicted, k, v) })
+	m.Put(`a`, 1)
+	m.Put(`b`, 2)
+	m.Put(`c`, 3)
+	require.Equal(t, 1, m.Get(`a`))
+	require.Nil(t, m.Put(`d`, 4))
+	require.Equal(t, vf.Values(`c`, `a`, `d`), m.Keys())
+	require.Equal(t, 2, len(evicted))
+	require.Equal(t, `b`, evicted[0])
+	require.Equal(t, 2, evicted[1])
+
+	require.Equal(t, 3, m.Put(`c`, 5))
+	require.Equal(t, vf.Values(`a`, `d`, `c`), m.Keys())
+	require.Equal(t, 3, m.Len())
+	require.True(t, m.ContainsKey(`a`))
+	require.Equal(t, vf.Values(`a`, `d`, `c`), m.Keys())
+
+	require.Equal(t, 1, m.Remove(`a`))
+	m.Put(`e`, 6)
+	require.Equal(t, 2, len(evicted))
+	require.Equal(t, vf.Map(`d`, 4, `c`, 5, `e`, 6), m)
+}
+
+func TestLRUMap_getters(t *testing.T) {
+	m := vf.LRUMap(4, nil, nil)
+	m.PutAll(vf.Map(`a`, vf.Values(1), `b`, true, `c`, 1.5, `d`, 2))
+	m.Put(`e`, vf.Map(`x`, 1))
+	require.Equal(t, vf.Values(`b`, `c`, `d`, `e`), m.Keys())
+
+	_, ok := m.GetBool(`b`)
+	require.True(t, ok)
+	_, ok = m.GetFloat(`c`)
+	require.True(t, ok)
+	_, ok = m.GetInt(`d`)
+	require.True(t, ok)
+	_, ok = m.GetMap(`e`)
+	require.True(t, ok)
+	require.Equal(t, vf.Values(`b`, `c`, `d`, `e`), m.Keys())
+
+	require.True(t, m.MustGetBool(`b`))
+	require.Equal(t, 1.5, m.MustGetFloat(`c`))
+	require.Equal(t, 2, m.MustGetInt(`d`))
+	require.Equal(t, vf.Map(`x`, 1), m.MustGetMap(`e`))
+	require.Equal(t, vf.Values(`b`, `c`, `d`, `e`), m.Keys())
+
+	m.Put(`f`, `s`)
+	m.Put(`g`, vf.Values(1))
+	require.Equal(t, `s`, m.MustGetString(`f`))
+	require.Equal(t, vf.Values(1), m.MustGetArray(`g`))
+	_, ok = m.GetString(`f`)
+	require.True(t, ok)
+	_, ok = m.GetArray(`g`)
+	require.True(t, ok)
+	require.Equal(t, vf.Values(`d`, `e`, `f`, `g`), m.Keys())
+
+	require.Equal(t, 2, m.GetOrElse(`d`, nil))
+	require.Equal(t, 3, m.GetOrElse(`x`, vf.Integer(3)))
+	require.Equal(t, vf.Map(`x`, 1), m.GetOrElseGet(`e`, nil))
+	require.Equal(t, 3, m.GetOrElseGet(`x`, func() dgo.Value { return vf.Integer(3) }))
+	require.Equal(t, vf.Values(`f`, `g`, `d`, `e`), m.Keys())
+
+	v, ok := m.PutIfAbsent(`f`, 1)
+	require.False(t, ok)
+	require.Equal(t, `s`, v)
+	v, ok = m.PutIfAbsent(`h`, 1)
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.Equal(t, 1, m.ComputeIfAbsent(`h`, nil))
+	require.Equal(t, 2, m.ComputeIfAbsent(`i`, func(dgo.Value) dgo.Value { return vf.Integer(2) }))
+	require.Equal(t, vf.Values(`e`, `f`, `h`, `i`), m.Keys())
+}
+
+func TestLRUMap_types(t *testing.T) {
+	m := vf.LRUMap(2, typ.String, typ.Integer)
+	require.Panic(t, func() { m.Put(1, 1) }, `cannot be assigned to a variable of type string`)
+	require.Panic(t, func() { m.Put(`a`, `b`) }, `cannot be assigned to a variable of type int`)
+	require.Panic(t, func() { vf.LRUMap(0, nil, nil) }, `illegal LRU map capacity 0`)
+	require.Instance(t, typ.Map, m)
+	require.Instance(t, m.Type(), m)
+}
+
+func TestLRUMap_derived(t *testing.T) {
+	m := vf.LRUMap(2, nil, nil)
+	m.Put(`a`, 1)
+	m.Put(`b`, 2)
+	w := m.With(`c`, 3).(dgo.LRUMap)
+	require.Equal(t, vf.Map(`b`, 2, `c`, 3), w)
+	require.Equal(t, 2, w.Capacity())
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2), m)
+	require.Same(t, m, m.With(`b`, 2))
+
+	g := m.Merge(vf.Map(`x`, 1, `y`, 2))
+	require.Equal(t, vf.Map(`x`, 1, `y`, 2), g)
+	require.Same(t, m, m.Merge(vf.Map()))
+
+	require.Equal(t, vf.Map(`b`, 2), m.Without(`a`))
+	require.Same(t, m, m.Without(`x`))
+	require.Equal(t, vf.Map(`b`, 2), m.WithoutAll(vf.Values(`a`)))
+
+	f := m.FrozenCopy().(dgo.LRUMap)
+	require.True(t, f.Frozen())
+	require.Equal(t, 1, f.Get(`a`))
+	require.Equal(t, vf.Values(`a`, `b`), f.Keys())
+	require.Panic(t, func() { f.Put(`c`, 3) }, `Put .* frozen`)
+	require.Panic(t, func() { f.PutAll(vf.Map(`c`, 3)) }, `PutAll .* frozen`)
+	require.Panic(t, func() { f.PutIfAbsent(`c`, 3) }, `PutIfAbsent .* frozen`)
+	require.Panic(t, func() { f.ComputeIfAbsent(`c`, nil) }, `ComputeIfAbsent .* frozen`)
+	require.Equal(t, vf.Map(`b`, 2, `c`, 3), f.With(`c`, 3))
+	require.True(t, f.With(`c`, 3).Frozen())
+
+	c := f.ThawedCopy().(dgo.LRUMap)
+	require.False(t, c.Frozen())
+	c.Put(`c`, 3)
+	require.Equal(t, vf.Map(`b`, 2, `c`, 3), c)
+	require.Equal(t, `{"b":2,"c":3}`, c.String())
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
+	"sync/atomic"
 
 	"github.com/lyraproj/dgo/util"
 
@@ -57,6 +59,11 @@ type (
 		first  *hashNode
 		last   *hashNode
 		frozen bool
+
+		// shared holds the frozen copy (a *hashMap) of this map that shares its table and nodes. They are copied by
+		// detach before this map is modified. The copy is published atomically since Copy(true) may be called
+		// concurrently.
+		shared atomic.Value
 	}
 )
 
@@ -223,6 +230,9 @@ func newMap(t dgo.Type, arg dgo.Value) dgo.Map {
 	} else {
 		m = mapFromArgs([]interface{}{arg}, true)
 	}
+	if st, ok := t.(dgo.StructMapType); ok {
+		m = m.WithDefaults(st)
+	}
 	if !t.Instance(m) {
 		panic(IllegalAssignment(t, m))
 	}
@@ -323,12 +333,77 @@ func FromReflectedMap(rm reflect.Value, frozen bool) dgo.Value {
 	return m
 }
 
+// MapFromGoSlice creates a frozen Map from a Go slice of alternating keys and values, e.g. []string{"k1", "v1", "k2",
+// "v2"}. It panics if the argument is not a slice or if the slice has an odd number of elements.
+func MapFromGoSlice(slice interface{}) dgo.Map {
+	rs := reflect.ValueOf(slice)
+	if rs.Kind() != reflect.Slice {
+		panic(fmt.Errorf(`illegal argument: %T is not a slice`, slice))
+	}
+	top := rs.Len()
+	if top%2 != 0 {
+		panic(fmt.Errorf(`the number of elements in a slice of keys and values must be even, got: %d`, top))
+	}
+	if top == 0 {
+		return emptyMap
+	}
+	m := MapWithCapacity(top / 2).(*hashMap)
+	for i := 0; i < top; i += 2 {
+		m.Put(ValueFromReflected(rs.Index(i)), frozenCopy(ValueFromReflected(rs.Index(i+1))))
+	}
+	m.frozen = true
+	return m
+}
+
 // FromReflectedStruct creates a frozen Map from the exported fields of a go struct. It panics if rm's kind is not
 // reflect.Struct.
 func FromReflectedStruct(rv reflect.Value) dgo.Struct {
 	return &structVal{rs: rv, frozen: false}
 }
 
+// ZipToMap creates a Map that associates each key produced by the given keys Iterable with the value at the same
+// position in the given values Iterable. The returned Map is frozen when both Iterables are frozen. An error is
+// returned if the number of keys and values differ.
+func ZipToMap(keys, values dgo.Iterable) (dgo.Map, error) {
+	return zipToMap(keys, values, nil, nil)
+}
+
+// MustZipToMap is like ZipToMap but panics instead of returning an error.
+func MustZipToMap(keys, values dgo.Iterable) dgo.Map {
+	m, err := ZipToMap(keys, values)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TypedZipToMap is like ZipToMap but also returns an error if a key is not an instance of the given keyType or if a
+// value is not an instance of the given valueType.
+func TypedZipToMap(keys, values dgo.Array, keyType, valueType dgo.Type) (dgo.Map, error) {
+	return zipToMap(keys, values, keyType, valueType)
+}
+
+func zipToMap(keys, values dgo.Iterable, keyType, valueType dgo.Type) (dgo.Map, error) {
+	ks := sliceFromIterable(keys)
+	vs := sliceFromIterable(values)
+	if len(ks) != len(vs) {
+		return nil, fmt.Errorf(`the number of keys (%d) does not match the number of values (%d)`, len(ks), len(vs))
+	}
+	m := MapWithCapacity(len(ks)).(*hashMap)
+	for i := range ks {
+		k, v := ks[i], vs[i]
+		if keyType != nil && !keyType.Instance(k) {
+			return nil, IllegalAssignment(keyType, k).(error)
+		}
+		if valueType != nil && !valueType.Instance(v) {
+			return nil, IllegalAssignment(valueType, v).(error)
+		}
+		m.Put(k, v)
+	}
+	m.frozen = keys.Frozen() && values.Frozen()
+	return m, nil
+}
+
 // MapWithCapacity creates an empty dgo.Map suitable to hold a given number of entries.
 func MapWithCapacity(capacity int) dgo.Map {
 	if capacity <= 0 {
@@ -413,6 +488,19 @@ func appendMapTo(m dgo.Map, w dgo.Indenter) {
 	w.AppendRune('}')
 }
 
+func (g *hashMap) ComputeIfAbsent(ki interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	if g.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	k := Value(ki)
+	if v := g.Get(k); v != nil {
+		return v
+	}
+	v := Value(computer(k))
+	g.Put(k, v)
+	return v
+}
+
 func (g *hashMap) ContainsKey(key interface{}) bool {
 	return g.Get(key) != nil
 }
@@ -421,6 +509,17 @@ func (g *hashMap) Copy(frozen bool) dgo.Map {
 	if frozen && g.frozen {
 		return g
 	}
+	if frozen {
+		if s := g.sharedCopy(); s != nil {
+			return s
+		}
+		if g.valuesFrozen() {
+			// Concurrent callers may each store a copy. They are equivalent so the last one stored is retained.
+			s := &hashMap{table: g.table, len: g.len, first: g.first, last: g.last, frozen: true}
+			g.shared.Store(s)
+			return s
+		}
+	}
 
 	c := &hashMap{len: g.len, frozen: frozen}
 	g.resize(c, 0)
@@ -436,6 +535,21 @@ func (g *hashMap) Copy(frozen bool) dgo.Map {
 	return c
 }
 
+// detach gives this map a table and nodes of its own when they are shared with a frozen copy. It must be called
+// before the table or the nodes of the map are modified.
+func (g *hashMap) detach() {
+	if g.sharedCopy() != nil {
+		g.shared.Store((*hashMap)(nil))
+		g.resize(g, 0)
+	}
+}
+
+// sharedCopy returns the frozen copy that shares the table and nodes of this map or nil when no such copy exists
+func (g *hashMap) sharedCopy() *hashMap {
+	s, _ := g.shared.Load().(*hashMap)
+	return s
+}
+
 func (g *hashMap) Each(actor dgo.Consumer) {
 	for e := g.first; e != nil; e = e.next {
 		actor(e)
@@ -460,6 +574,15 @@ func (g *hashMap) EachValue(actor dgo.Consumer) {
 	}
 }
 
+func (g *hashMap) Entries() dgo.Array {
+	return arrayFromIterator(g.len, func(actor dgo.Consumer) {
+		for e := g.first; e != nil; e = e.next {
+			// The hashNode itself cannot be used since its value changes when the map is updated
+			actor(&mapEntry{key: e.key, value: e.value})
+		}
+	})
+}
+
 func (g *hashMap) Equals(other interface{}) bool {
 	return equals(nil, g, other)
 }
@@ -501,6 +624,16 @@ func (g *hashMap) FrozenCopy() dgo.Value {
 	return g.Copy(true)
 }
 
+// valuesFrozen returns true when all values of this map are frozen or immutable
+func (g *hashMap) valuesFrozen() bool {
+	for e := g.first; e != nil; e = e.next {
+		if !e.Frozen() {
+			return false
+		}
+	}
+	return true
+}
+
 func (g *hashMap) ThawedCopy() dgo.Value {
 	return g.Copy(false)
 }
@@ -564,6 +697,28 @@ func (g *hashMap) deepHashCode(seen []dgo.Value) int {
 	return h
 }
 
+func (g *hashMap) Invert(policy dgo.DuplicatePolicy) dgo.Map {
+	c := MapWithCapacity(g.len).(*hashMap)
+	for e := g.first; e != nil; e = e.next {
+		switch policy {
+		case dgo.DuplicateKeepFirst:
+			c.PutIfAbsent(e.value, e.key)
+		case dgo.DuplicateCollect:
+			if ks, ok := c.Get(e.value).(*array); ok {
+				ks.slice = append(ks.slice, e.key)
+			} else {
+				c.Put(e.value, &array{slice: []dgo.Value{e.key}, frozen: g.frozen})
+			}
+		default:
+			if c.Put(e.value, e.key) != nil {
+				panic(fmt.Errorf(`inversion results in more than one association for the key '%s'`, e.value))
+			}
+		}
+	}
+	c.frozen = g.frozen
+	return c
+}
+
 func (g *hashMap) Keys() dgo.Array {
 	return arrayFromIterator(g.len, g.EachKey)
 }
@@ -581,6 +736,16 @@ func (g *hashMap) Map(mapper dgo.EntryMapper) dgo.Map {
 	return c
 }
 
+func (g *hashMap) MapKeys(mapper dgo.EntryMapper, target dgo.MapType) dgo.Map {
+	return transformEntries(g, g.frozen, func(e dgo.MapEntry) (interface{}, interface{}) {
+		return mapper(e), e.Value()
+	}, target)
+}
+
+func (g *hashMap) MapValues(mapper dgo.EntryMapper, target dgo.MapType) dgo.Map {
+	return checkTarget(g.Map(mapper), target)
+}
+
 func (g *hashMap) Merge(associations dgo.Map) dgo.Map {
 	if associations.Len() == 0 || g == associations {
 		return g
@@ -596,10 +761,89 @@ func (g *hashMap) Merge(associations dgo.Map) dgo.Map {
 	return c
 }
 
+func (g *hashMap) DeepMerge(associations dgo.Map, concatArrays bool) dgo.Map {
+	return g.MergeWith(associations, deepMergeStrategy(concatArrays))
+}
+
+func (g *hashMap) MergeWith(associations dgo.Map, strategy dgo.MergeStrategy) dgo.Map {
+	if strategy == dgo.MergeShallow {
+		return g.Merge(associations)
+	}
+	if associations.Len() == 0 {
+		return g
+	}
+	c := &hashMap{len: g.len}
+	g.resize(c, g.len+associations.Len())
+	c.deepMerge(associations, strategy)
+	c.frozen = g.frozen && associations.Frozen()
+	return c
+}
+
+// deepMergeStrategy returns the strategy that corresponds to the concatArrays argument of DeepMerge
+func deepMergeStrategy(concatArrays bool) dgo.MergeStrategy {
+	if concatArrays {
+		return dgo.MergeDeepConcat
+	}
+	return dgo.MergeDeep
+}
+
+// deepMerge merges the given associations into the receiver. The receiver must be mutable.
+func (g *hashMap) deepMerge(associations dgo.Map, strategy dgo.MergeStrategy) {
+	associations.EachEntry(func(e dgo.MapEntry) {
+		k := e.Key()
+		v := e.Value()
+		switch ov := g.Get(k).(type) {
+		case dgo.Map:
+			if m, ok := v.(dgo.Map); ok {
+				v = ov.MergeWith(m, strategy)
+			}
+		case dgo.Array:
+			if a, ok := v.(dgo.Array); ok {
+				switch strategy {
+				case dgo.MergeDeepConcat:
+					v = ov.WithAll(a)
+				case dgo.MergeDeepUnique:
+					v = ov.WithAll(a).Unique()
+				}
+			}
+		}
+		g.Put(k, v)
+	})
+}
+
+func (g *hashMap) MustGetArray(key interface{}) dgo.Array {
+	return mustGet(g, key, DefaultArrayType).(dgo.Array)
+}
+
+func (g *hashMap) MustGetBool(key interface{}) bool {
+	return mustGet(g, key, DefaultBooleanType).(dgo.Boolean).GoBool()
+}
+
+func (g *hashMap) MustGetFloat(key interface{}) float64 {
+	return mustGet(g, key, DefaultFloatType).(dgo.Float).GoFloat()
+}
+
+func (g *hashMap) MustGetInt(key interface{}) int64 {
+	return mustGet(g, key, DefaultIntegerType).(dgo.Integer).GoInt()
+}
+
+func (g *hashMap) MustGetMap(key interface{}) dgo.Map {
+	return mustGet(g, key, DefaultMapType).(dgo.Map)
+}
+
+func (g *hashMap) MustGetString(key interface{}) string {
+	return mustGet(g, key, DefaultStringType).(dgo.String).GoString()
+}
+
+func (g *hashMap) None(predicate dgo.EntryPredicate) bool {
+	return !g.Any(predicate)
+}
+
 func (g *hashMap) Put(ki, vi interface{}) dgo.Value {
 	if g.frozen {
 		panic(frozenMap(`Put`))
 	}
+	g.detach()
 	k := Value(ki)
 	v := Value(vi)
 	hs := hash(k.HashCode())
@@ -637,6 +881,19 @@ func (g *hashMap) Put(ki, vi interface{}) dgo.Value {
 	return nil
 }
 
+func (g *hashMap) PutIfAbsent(ki, vi interface{}) (dgo.Value, bool) {
+	if g.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	k := Value(ki)
+	if old := g.Get(k); old != nil {
+		return old, false
+	}
+	v := Value(vi)
+	g.Put(k, v)
+	return v, true
+}
+
 func (g *hashMap) PutAll(associations dgo.Map) {
 	al := associations.Len()
 	if al == 0 {
@@ -645,6 +902,7 @@ func (g *hashMap) PutAll(associations dgo.Map) {
 	if g.frozen {
 		panic(frozenMap(`PutAll`))
 	}
+	g.detach()
 
 	l := g.len
 	if float64(l+al) > float64(l)*loadFactor {
@@ -707,7 +965,58 @@ func (g *hashMap) Remove(ki interface{}) dgo.Value {
 	if g.frozen {
 		panic(frozenMap(`Remove`))
 	}
-	key := Value(ki)
+	return g.remove(Value(ki))
+}
+
+func (g *hashMap) GetArray(key interface{}) (dgo.Array, bool) {
+	return asArray(g.Get(key))
+}
+
+func (g *hashMap) GetBool(key interface{}) (bool, bool) {
+	return asGoBool(g.Get(key))
+}
+
+func (g *hashMap) GetFloat(key interface{}) (float64, bool) {
+	return asGoFloat(g.Get(key))
+}
+
+func (g *hashMap) GetInt(key interface{}) (int64, bool) {
+	return asGoInt(g.Get(key))
+}
+
+func (g *hashMap) GetMap(key interface{}) (dgo.Map, bool) {
+	return asMap(g.Get(key))
+}
+
+func (g *hashMap) GetOrElse(key interface{}, defaultValue dgo.Value) dgo.Value {
+	if v := g.Get(key); v != nil {
+		return v
+	}
+	return defaultValue
+}
+
+func (g *hashMap) GetOrElseGet(key interface{}, computer func() dgo.Value) dgo.Value {
+	if v := g.Get(key); v != nil {
+		return v
+	}
+	return computer()
+}
+
+func (g *hashMap) GetString(key interface{}) (string, bool) {
+	return asGoString(g.Get(key))
+}
+
+func (g *hashMap) GetAndDelete(ki interface{}) (dgo.Value, bool) {
+	if g.frozen {
+		panic(frozenMap(`GetAndDelete`))
+	}
+	old := g.remove(Value(ki))
+	return old, old != nil
+}
+
+// remove removes the association for the given key and returns the old value or nil when no association exists
+func (g *hashMap) remove(key dgo.Value) dgo.Value {
+	g.detach()
 	hk := (len(g.table) - 1) & hash(key.HashCode())
 
 	var p *hashNode
@@ -741,6 +1050,7 @@ func (g *hashMap) RemoveAll(keys dgo.Array) {
 	if g.frozen {
 		panic(frozenMap(`RemoveAll`))
 	}
+	g.detach()
 	if g.len == 0 || keys.Len() == 0 {
 		return
 	}
@@ -776,11 +1086,27 @@ func (g *hashMap) RemoveAll(keys dgo.Array) {
 }
 
 func (g *hashMap) Resolve(ap dgo.AliasAdder) {
+	g.detach()
 	for e := g.first; e != nil; e = e.next {
 		e.value = ap.Replace(e.value)
 	}
 }
 
+func (g *hashMap) RenameKeys(mapping dgo.Map) dgo.Map {
+	c := MapWithCapacity(g.len).(*hashMap)
+	for e := g.first; e != nil; e = e.next {
+		k := e.key
+		if nk := mapping.Get(k); nk != nil {
+			k = nk
+		}
+		if c.Put(k, e.value) != nil {
+			panic(fmt.Errorf(`rename of keys results in more than one association for the key '%s'`, k))
+		}
+	}
+	c.frozen = g.frozen
+	return c
+}
+
 func (g *hashMap) String() string {
 	return util.ToStringERP(g)
 }
@@ -812,6 +1138,10 @@ func (g *hashMap) With(ki, vi interface{}) dgo.Map {
 	return c
 }
 
+func (g *hashMap) WithDefaults(schema dgo.StructMapType) dgo.Map {
+	return withDefaults(g, g.frozen, schema)
+}
+
 func (g *hashMap) Without(ki interface{}) dgo.Map {
 	key := Value(ki)
 	if g.Get(key) == nil {
@@ -838,6 +1168,51 @@ func (g *hashMap) WithoutAll(keys dgo.Array) dgo.Map {
 	return c
 }
 
+func (g *hashMap) ToFlatSlice() []interface{} {
+	s := make([]interface{}, 0, g.len*2)
+	for e := g.first; e != nil; e = e.next {
+		s = append(s, e.key, e.value)
+	}
+	return s
+}
+
+func (g *hashMap) ToStructSlice(mapper dgo.EntryMapper) []interface{} {
+	s := make([]interface{}, 0, g.len)
+	for e := g.first; e != nil; e = e.next {
+		s = append(s, mapper(e))
+	}
+	return s
+}
+
+func (g *hashMap) Transform(schema dgo.StructMapType) (dgo.Map, error) {
+	return transform(g, schema)
+}
+
+func (g *hashMap) TransformEntries(transformer dgo.EntryTransformer, target dgo.MapType) dgo.Map {
+	return transformEntries(g, g.frozen, transformer, target)
+}
+
+// transformEntries returns a new map with the keys and values that the given transformer returns for the entries of
+// the given map. The new map is checked against the target type, if any, and then given the frozen status.
+func transformEntries(m dgo.Map, frozen bool, transformer dgo.EntryTransformer, target dgo.MapType) dgo.Map {
+	c := MapWithCapacity(m.Len()).(*hashMap)
+	m.EachEntry(func(e dgo.MapEntry) {
+		c.Put(transformer(e))
+	})
+	checkTarget(c, target)
+	c.frozen = frozen
+	return c
+}
+
+// checkTarget panics if the given target type is not nil and the given map is not an instance of that type.
+// Otherwise, the map is returned.
+func checkTarget(m dgo.Map, target dgo.MapType) dgo.Map {
+	if target != nil && !target.Instance(m) {
+		panic(IllegalAssignment(target, m))
+	}
+	return m
+}
+
 func (g *hashMap) Type() dgo.Type {
 	et := &exactMapType{value: g}
 	et.ExactType = et
@@ -897,9 +1272,16 @@ func tableSizeFor(cap int) int {
 	return int(n)
 }
 
+// frozenCopy returns a frozen copy of the given value. An exact type that represents a mutable value is replaced
+// by the exact type of a frozen copy of that value so that a key in a map cannot change its hash code.
 func frozenCopy(v dgo.Value) dgo.Value {
-	if f, ok := v.(dgo.Freezable); ok {
-		v = f.FrozenCopy()
+	switch fv := v.(type) {
+	case dgo.Freezable:
+		v = fv.FrozenCopy()
+	case dgo.ExactType:
+		if f, ok := fv.ExactValue().(dgo.Freezable); ok && !f.Frozen() {
+			v = f.FrozenCopy().Type()
+		}
 	}
 	return v
 }
@@ -993,6 +1375,13 @@ func MapType(args []interface{}) dgo.MapType {
 	}
 }
 
+// PatternMapType returns a type that represents a Map where all keys are strings that match the given regular
+// expression and all values are instances of the given value type. A nil value type means that the values can be
+// of any type. The function panics if the pattern cannot be compiled.
+func PatternMapType(keyPattern string, valueType dgo.Type) dgo.MapType {
+	return newMapType(PatternType(regexp.MustCompile(keyPattern)), valueType, 0, math.MaxInt64)
+}
+
 func newMapType(kt, vt dgo.Type, min, max int) dgo.MapType {
 	if min < 0 {
 		min = 0
@@ -1032,6 +1421,10 @@ func (t *sizedMapType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type)
 	return CheckAssignableTo(guard, other, t)
 }
 
+func (t *sizedMapType) Describe() string {
+	return Describe(t)
+}
+
 func (t *sizedMapType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
@@ -1151,6 +1544,10 @@ func (t defaultMapType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t defaultMapType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultMapType) Equals(other interface{}) bool {
 	return t == other
 }
@@ -1210,10 +1607,14 @@ func (t *exactMapType) Additional() bool {
 
 func (t *exactMapType) Each(actor func(dgo.StructMapEntry)) {
 	t.value.EachEntry(func(e dgo.MapEntry) {
-		actor(&structEntry{mapEntry{e.Key().Type(), e.Value().Type()}, true})
+		actor(&structEntry{mapEntry: mapEntry{e.Key().Type(), e.Value().Type()}, required: true})
 	})
 }
 
+func (t *exactMapType) Extend(other dgo.StructMapType, overrides ...dgo.StructMapEntry) dgo.StructMapType {
+	return extend(t, other, overrides)
+}
+
 func (t *exactMapType) Generic() dgo.Type {
 	kt := Generic(t.KeyType())
 	vt := Generic(t.ValueType())
@@ -1233,7 +1634,7 @@ func (t *exactMapType) Get(key interface{}) dgo.StructMapEntry {
 		k = et.ExactValue()
 	}
 	if v := t.value.Get(k); v != nil {
-		return &structEntry{mapEntry{k.Type(), v.Type()}, true}
+		return &structEntry{mapEntry: mapEntry{k.Type(), v.Type()}, required: true}
 	}
 	return nil
 }
@@ -1297,9 +1698,22 @@ func (t *exactMapType) ValueType() dgo.Type {
 }
 
 func frozenMap(f string) error {
-	return fmt.Errorf(`%s called on a frozen Map`, f)
+	return &frozenError{function: f, kind: `Map`}
 }
 
 func (t *exactMapType) Unbounded() bool {
 	return false
 }
+
+// mustGet returns the value that the given map associates with the given key. It panics if no such value exists or
+// if the value isn't an instance of the given type.
+func mustGet(m dgo.Map, key interface{}, t dgo.Type) dgo.Value {
+	v := m.Get(key)
+	if v == nil {
+		panic(fmt.Errorf(`no value is associated with the key '%s'`, Value(key)))
+	}
+	if !t.Instance(v) {
+		panic(IllegalAssignment(t, v))
+	}
+	return v
+}
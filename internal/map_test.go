@@ -4,6 +4,7 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/lyraproj/dgo/util"
@@ -467,6 +468,21 @@ func TestMapType_ValueType(t *testing.T) {
 	require.NotInstance(t, mt.ValueType(), `earth`)
 }
 
+func TestPatternMapType(t *testing.T) {
+	mt := tf.PatternMap(`\AX-Custom-`, typ.String)
+	require.Equal(t, tf.ParseType(`map[/\AX-Custom-/]string`), mt)
+	require.Instance(t, mt, vf.Map(`X-Custom-A`, `a`, `X-Custom-B`, `b`))
+	require.Instance(t, mt, vf.Map())
+	require.NotInstance(t, mt, vf.Map(`X-Custom-A`, `a`, `Content-Type`, `text/plain`))
+	require.NotInstance(t, mt, vf.Map(`X-Custom-A`, 1))
+	require.NotInstance(t, mt, vf.Map(1, `a`))
+	require.Assignable(t, mt, vf.Map(`X-Custom-A`, `a`).Type())
+	require.NotAssignable(t, mt, tf.Map(typ.String, typ.String))
+
+	require.Instance(t, tf.PatternMap(`\Aa`, nil), vf.Map(`ab`, 1, `ac`, `x`))
+	require.Panic(t, func() { tf.PatternMap(`[`, typ.String) }, `missing closing \]`)
+}
+
 func TestMapNilKey(t *testing.T) {
 	m := vf.Map(nil, 5)
 	require.Instance(t, typ.Map, m)
@@ -488,6 +504,20 @@ func TestMap_Any(t *testing.T) {
 	}))
 }
 
+func TestMap_None(t *testing.T) {
+	m := vf.Map(
+		`first`, 1,
+		`second`, 2.0,
+		`third`, `three`)
+	require.True(t, m.None(func(e dgo.MapEntry) bool {
+		return e.Key().Equals(`fourth`)
+	}))
+	require.False(t, m.None(func(e dgo.MapEntry) bool {
+		return e.Key().Equals(`second`)
+	}))
+	require.True(t, vf.Map().None(func(e dgo.MapEntry) bool { return true }))
+}
+
 func TestMap_AllKeys(t *testing.T) {
 	m := vf.Map(
 		`first`, 1,
@@ -546,6 +576,44 @@ func TestMap_EachKey(t *testing.T) {
 	require.Equal(t, vf.Values(`first`, `second`, `third`), vs)
 }
 
+func TestMap_EachEntry_order(t *testing.T) {
+	m := vf.MutableMap()
+	m.Put(`a`, 1)
+	m.Put(`b`, 2)
+	m.Put(`c`, 3)
+	m.Put(`d`, 4)
+	m.Remove(`b`)
+	m.Put(`a`, 10)
+	m.Put(`b`, 20)
+	m.Remove(`c`)
+	m.Put(`e`, 5)
+	m.Put(`c`, 30)
+	m.Remove(`a`)
+	m.Put(`a`, 100)
+
+	var ks []dgo.Value
+	var vs []dgo.Value
+	m.EachEntry(func(e dgo.MapEntry) {
+		ks = append(ks, e.Key())
+		vs = append(vs, e.Value())
+	})
+	require.Equal(t, vf.Values(`d`, `b`, `e`, `c`, `a`), ks)
+	require.Equal(t, vf.Values(4, 20, 5, 30, 100), vs)
+
+	ks = nil
+	m.EachKey(func(k dgo.Value) { ks = append(ks, k) })
+	require.Equal(t, vf.Values(`d`, `b`, `e`, `c`, `a`), ks)
+
+	vs = nil
+	m.EachValue(func(v dgo.Value) { vs = append(vs, v) })
+	require.Equal(t, vf.Values(4, 20, 5, 30, 100), vs)
+
+	// Order survives copying and freezing
+	ks = nil
+	m.Copy(true).EachKey(func(k dgo.Value) { ks = append(ks, k) })
+	require.Equal(t, vf.Values(`d`, `b`, `e`, `c`, `a`), ks)
+}
+
 func TestMap_EachValue(t *testing.T) {
 	m := vf.Map(
 		`first`, 1,
@@ -590,6 +658,46 @@ func TestMap_Put(t *testing.T) {
 	require.Panic(t, func() { m.Put(`second`, 2) }, `frozen`)
 }
 
+func TestMap_ComputeIfAbsent(t *testing.T) {
+	m := vf.MutableMap(`a`, 1)
+	calls := 0
+	square := func(k dgo.Value) dgo.Value {
+		calls++
+		return vf.Integer(k.(dgo.Integer).GoInt() * k.(dgo.Integer).GoInt())
+	}
+	require.Equal(t, 1, m.ComputeIfAbsent(`a`, square))
+	require.Equal(t, 0, calls)
+
+	require.Equal(t, 9, m.ComputeIfAbsent(3, square))
+	require.Equal(t, 1, calls)
+	require.Equal(t, 9, m.ComputeIfAbsent(3, square))
+	require.Equal(t, 1, calls)
+	require.Equal(t, vf.Map(`a`, 1, 3, 9), m)
+
+	require.Equal(t, vf.Nil, m.ComputeIfAbsent(`n`, func(dgo.Value) dgo.Value { return nil }))
+	require.True(t, m.ContainsKey(`n`))
+
+	require.Panic(t, func() { m.FrozenCopy().(dgo.Map).ComputeIfAbsent(`b`, square) }, `ComputeIfAbsent .* frozen`)
+}
+
+func TestMap_PutIfAbsent(t *testing.T) {
+	m := vf.MutableMap(`first`, 1, `nil`, nil)
+	v, ok := m.PutIfAbsent(`first`, 2)
+	require.False(t, ok)
+	require.Equal(t, 1, v)
+
+	v, ok = m.PutIfAbsent(`nil`, 2)
+	require.False(t, ok)
+	require.Equal(t, vf.Nil, v)
+
+	v, ok = m.PutIfAbsent(`second`, 2)
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	require.Equal(t, vf.Map(`first`, 1, `nil`, nil, `second`, 2), m)
+
+	require.Panic(t, func() { m.FrozenCopy().(dgo.Map).PutIfAbsent(`third`, 3) }, `PutIfAbsent .* frozen`)
+}
+
 func TestMap_PutAll(t *testing.T) {
 	m := vf.MutableMap(
 		`first`, 1,
@@ -635,6 +743,53 @@ func TestMap_Freeze_recursive(t *testing.T) {
 	require.True(t, mr.Frozen(), `recursive freeze not applied`)
 }
 
+func TestMap_Copy_freeze_concurrent(t *testing.T) {
+	m := vf.MutableMap(`a`, 1, `b`, 2)
+	cs := make([]dgo.Value, 8)
+	var wg sync.WaitGroup
+	for i := range cs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cs[i] = m.FrozenCopy()
+		}(i)
+	}
+	wg.Wait()
+	m.Put(`a`, 10)
+	for i := range cs {
+		require.Equal(t, vf.Map(`a`, 1, `b`, 2), cs[i])
+	}
+}
+
+func TestMap_Copy_freeze_shared(t *testing.T) {
+	m := vf.MutableMap(`a`, 1, `b`, vf.Values(2))
+	f := m.Copy(true)
+	require.Same(t, f, m.Copy(true))
+	require.Same(t, f, m.FrozenCopy())
+
+	m.Put(`a`, 10)
+	m.Put(`c`, 3)
+	require.Equal(t, vf.Map(`a`, 1, `b`, vf.Values(2)), f)
+	require.Equal(t, vf.Map(`a`, 10, `b`, vf.Values(2), `c`, 3), m)
+	require.NotSame(t, f, m.Copy(true))
+
+	f = m.Copy(true)
+	m.Remove(`a`)
+	m.RemoveAll(vf.Values(`b`))
+	require.Equal(t, vf.Map(`a`, 10, `b`, vf.Values(2), `c`, 3), f)
+	require.Equal(t, vf.Map(`c`, 3), m)
+
+	f = m.Copy(true)
+	m.PutAll(vf.Map(`c`, 30, `d`, 4))
+	require.Equal(t, vf.Map(`c`, 3), f)
+	require.Equal(t, vf.Map(`c`, 30, `d`, 4), m)
+
+	m = vf.MutableMap(`a`, vf.MutableValues(1))
+	f = m.Copy(true)
+	m.Get(`a`).(dgo.Array).Add(2)
+	require.Equal(t, vf.Map(`a`, vf.Values(1)), f)
+}
+
 func TestMap_Copy_freeze_recursive(t *testing.T) {
 	m := vf.MutableMap()
 	mr := vf.MutableMap()
@@ -700,6 +855,82 @@ func TestMap_Map(t *testing.T) {
 	}))
 }
 
+func TestMap_MapKeys(t *testing.T) {
+	a := vf.Map(`a`, 1, `b`, 2, `c`, 3)
+	m := a.MapKeys(func(e dgo.MapEntry) interface{} {
+		return strings.ToUpper(e.Key().String())
+	}, nil)
+	require.Equal(t, vf.Map(`A`, 1, `B`, 2, `C`, 3), m)
+	require.True(t, m.Frozen())
+
+	m = a.MapKeys(func(e dgo.MapEntry) interface{} { return `x` }, tf.Map(typ.String, typ.Integer))
+	require.Equal(t, vf.Map(`x`, 3), m)
+
+	require.Panic(t, func() {
+		a.MapKeys(func(e dgo.MapEntry) interface{} { return e.Value() }, tf.Map(typ.String, typ.Integer))
+	}, `cannot be assigned`)
+
+	a = vf.MutableMap(`a`, 1)
+	require.False(t, a.MapKeys(func(e dgo.MapEntry) interface{} { return e.Value() }, nil).Frozen())
+}
+
+func TestMap_MapValues(t *testing.T) {
+	a := vf.Map(`a`, 1, `b`, 2, `c`, 3)
+	m := a.MapValues(func(e dgo.MapEntry) interface{} {
+		return e.Value().(dgo.Integer).GoInt() * 10
+	}, tf.Map(typ.String, typ.Integer))
+	require.Equal(t, vf.Map(`a`, 10, `b`, 20, `c`, 30), m)
+
+	require.Panic(t, func() {
+		a.MapValues(func(e dgo.MapEntry) interface{} { return e.Key() }, tf.Map(typ.String, typ.Integer))
+	}, `cannot be assigned`)
+}
+
+func TestMap_WithDefaults(t *testing.T) {
+	schema := tf.ParseType(`{name:string,port:80 int,tags?:[]string}`).(dgo.StructMapType)
+	m := vf.Map(`name`, `a`)
+	d := m.WithDefaults(schema)
+	require.Equal(t, vf.Map(`name`, `a`, `port`, 80), d)
+	require.True(t, d.Frozen())
+
+	m = vf.Map(`name`, `a`, `port`, 8080)
+	require.Same(t, m, m.WithDefaults(schema))
+
+	m = vf.MutableMap(`name`, `a`)
+	d = m.WithDefaults(schema)
+	require.False(t, d.Frozen())
+	require.Equal(t, 1, m.Len())
+}
+
+func TestMap_TransformEntries(t *testing.T) {
+	a := vf.Map(`a`, 1, `b`, 2, `c`, 3)
+	m := a.TransformEntries(func(e dgo.MapEntry) (interface{}, interface{}) {
+		return e.Value(), e.Key()
+	}, tf.Map(typ.Integer, typ.String))
+	require.Equal(t, vf.Map(1, `a`, 2, `b`, 3, `c`), m)
+
+	require.Panic(t, func() {
+		a.TransformEntries(func(e dgo.MapEntry) (interface{}, interface{}) {
+			return e.Key(), e.Key()
+		}, tf.Map(typ.String, typ.Integer))
+	}, `cannot be assigned`)
+}
+
+func TestMap_Invert(t *testing.T) {
+	m := vf.Map(`a`, 1, `b`, 2, `c`, 3)
+	require.Equal(t, vf.Map(1, `a`, 2, `b`, 3, `c`), m.Invert(dgo.DuplicatePanic))
+	require.True(t, m.Invert(dgo.DuplicatePanic).Frozen())
+	require.False(t, vf.MutableMap(`a`, 1).Invert(dgo.DuplicatePanic).Frozen())
+
+	m = vf.Map(`a`, 1, `b`, 2, `c`, 1)
+	require.Panic(t, func() { m.Invert(dgo.DuplicatePanic) }, `more than one association for the key '1'`)
+	require.Equal(t, vf.Map(1, `a`, 2, `b`), m.Invert(dgo.DuplicateKeepFirst))
+
+	c := m.Invert(dgo.DuplicateCollect)
+	require.Equal(t, vf.Map(1, vf.Values(`a`, `c`), 2, vf.Values(`b`)), c)
+	require.True(t, c.Get(1).(dgo.Array).Frozen())
+}
+
 func TestMap_ReflectTo(t *testing.T) {
 	m := vf.Map(
 		`first`, 1,
@@ -768,6 +999,269 @@ func TestMap_Remove(t *testing.T) {
 	require.Panic(t, func() { m.Remove(`first`) }, `frozen`)
 }
 
+func TestMap_typeKeys(t *testing.T) {
+	registry := vf.MapWithCapacity(5)
+	registry.Put(typ.String, `string handler`)
+	registry.Put(tf.String(1, 10), `short string handler`)
+	registry.Put(tf.Array(typ.Integer), `int array handler`)
+	registry.Put(vf.Values(1, 2).Type(), `exact array handler`)
+	registry.Put(tf.AnyOf(typ.String, typ.Integer), `string or int handler`)
+
+	require.Equal(t, 5, registry.Len())
+	require.Equal(t, `string handler`, registry.Get(typ.String))
+	require.Equal(t, `short string handler`, registry.Get(tf.String(1, 10)))
+	require.Equal(t, `int array handler`, registry.Get(tf.Array(typ.Integer)))
+	require.Equal(t, `exact array handler`, registry.Get(vf.Values(1, 2).Type()))
+	require.Equal(t, `string or int handler`, registry.Get(tf.AnyOf(typ.String, typ.Integer)))
+	require.Nil(t, registry.Get(tf.String(1, 11)))
+	require.Same(t, typ.String, registry.Keys().Get(0))
+
+	registry.Put(tf.String(1, 10), `replaced`)
+	require.Equal(t, 5, registry.Len())
+	require.Equal(t, `replaced`, registry.Get(tf.String(1, 10)))
+	require.Equal(t, `replaced`, registry.Remove(tf.String(1, 10)))
+	require.Nil(t, registry.Get(tf.String(1, 10)))
+}
+
+func TestMap_typeKeys_mutableExact(t *testing.T) {
+	a := vf.MutableValues(1, 2)
+	m := vf.MutableMap()
+	m.Put(a.Type(), `a`)
+	a.Add(3)
+	require.Equal(t, `a`, m.Get(vf.Values(1, 2).Type()))
+	require.Nil(t, m.Get(a.Type()))
+	require.True(t, m.Keys().Get(0).(dgo.ExactType).ExactValue().(dgo.Freezable).Frozen())
+}
+
+func TestMap_GetOrElse(t *testing.T) {
+	m := vf.Map(`a`, 1, `n`, nil)
+	require.Equal(t, 1, m.GetOrElse(`a`, vf.Integer(2)))
+	require.Equal(t, vf.Nil, m.GetOrElse(`n`, vf.Integer(2)))
+	require.Equal(t, 2, m.GetOrElse(`b`, vf.Integer(2)))
+	require.Nil(t, m.GetOrElse(`b`, nil))
+
+	called := false
+	computer := func() dgo.Value {
+		called = true
+		return vf.Integer(2)
+	}
+	require.Equal(t, vf.Nil, m.GetOrElseGet(`n`, computer))
+	require.False(t, called)
+	require.Equal(t, 2, m.GetOrElseGet(`b`, computer))
+	require.True(t, called)
+}
+
+func TestMapFromGoSlice(t *testing.T) {
+	m := vf.MapFromGoSlice([]string{`k1`, `v1`, `k2`, `v2`})
+	require.Equal(t, vf.Map(`k1`, `v1`, `k2`, `v2`), m)
+	require.True(t, m.Frozen())
+
+	a := vf.MutableValues(1)
+	m = vf.MapFromGoSlice([]interface{}{`a`, 1, 2, a})
+	require.Equal(t, vf.Map(`a`, 1, 2, vf.Values(1)), m)
+	require.True(t, m.Get(2).(dgo.Array).Frozen())
+	require.Nil(t, vf.AssertFrozen(m))
+
+	require.Same(t, vf.Map(), vf.MapFromGoSlice([]int{}))
+	require.Panic(t, func() { vf.MapFromGoSlice([]int{1}) }, `must be even, got: 1`)
+	require.Panic(t, func() { vf.MapFromGoSlice(map[int]int{}) }, `map\[int\]int is not a slice`)
+}
+
+func TestZipToMap(t *testing.T) {
+	m, err := vf.ZipToMap(vf.Strings(`a`, `b`), vf.Values(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2), m)
+	require.True(t, m.Frozen())
+
+	m, err = vf.ZipToMap(vf.MutableValues(`a`), vf.Values(1))
+	require.Nil(t, err)
+	require.False(t, m.Frozen())
+	m.Put(`b`, 2)
+
+	_, err = vf.ZipToMap(vf.Values(), vf.Values(1))
+	require.Equal(t, `the number of keys (0) does not match the number of values (1)`, err.Error())
+	require.Equal(t, vf.Map(), vf.MustZipToMap(vf.Values(), vf.Values()))
+	require.Panic(t, func() { vf.MustZipToMap(vf.Values(1), vf.Values()) }, `number of keys \(1\)`)
+
+	m, err = vf.TypedZipToMap(vf.Strings(`a`), vf.Values(1), typ.String, typ.Integer)
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`a`, 1), m)
+	_, err = vf.TypedZipToMap(vf.Values(1), vf.Values(1), typ.String, typ.Integer)
+	require.Equal(t, `the value 1 cannot be assigned to a variable of type string`, err.Error())
+	_, err = vf.TypedZipToMap(vf.Strings(`a`), vf.Values(`b`), typ.String, typ.Integer)
+	require.Equal(t, `the string "b" cannot be assigned to a variable of type int`, err.Error())
+}
+
+func TestMap_ToFlatSlice(t *testing.T) {
+	m := vf.Map(`a`, 1, `b`, vf.Values(2))
+	s := m.ToFlatSlice()
+	require.Equal(t, []interface{}{vf.String(`a`), vf.Integer(1), vf.String(`b`), vf.Values(2)}, s)
+	require.Equal(t, m, vf.Map(s...))
+	require.Equal(t, 0, len(vf.Map().ToFlatSlice()))
+
+	s = m.ToStructSlice(func(e dgo.MapEntry) interface{} { return e.Key().String() + `=` + e.Value().String() })
+	require.Equal(t, []interface{}{`a=1`, `b={2}`}, s)
+}
+
+func TestMap_Transform(t *testing.T) {
+	schema := tf.ParseType(`{name:string,age:int,email?:string}`).(dgo.StructMapType)
+	m, err := vf.Map(`age`, `42`, `name`, `Bob`).Transform(schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`name`, `Bob`, `age`, 42), m)
+	require.Equal(t, vf.Strings(`name`, `age`), m.Keys())
+	require.True(t, m.Frozen())
+	require.Instance(t, schema, m)
+
+	m, err = vf.Map(`name`, `Bob`, `age`, 42.0).ThawedCopy().(dgo.Map).Transform(schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`name`, `Bob`, `age`, 42), m)
+	require.False(t, m.Frozen())
+
+	_, err = vf.Map(`name`, `Bob`, `age`, `old`, `phone`, `555`).Transform(schema)
+	require.NotNil(t, err)
+	require.Equal(t,
+		`the map cannot be transformed to type {"name":string,"age":int,"email"?:string}: `+
+			`key 'age': the value 'old' cannot be converted to an int; unknown key 'phone'`, err.Error())
+
+	_, err = vf.Map(`email`, `bob@example.com`).Transform(schema)
+	require.NotNil(t, err)
+	require.Match(t, `: missing required key 'name'; missing required key 'age'\z`, err.Error())
+
+	_, err = vf.Map(`kind`, `c`).Transform(tf.ParseType(`{kind:"a"|"b"}`).(dgo.StructMapType))
+	require.NotNil(t, err)
+	require.Match(t, `key 'kind': .*"c".* "a"\|"b"`, err.Error())
+
+	open := tf.ParseType(`{name:string,...}`).(dgo.StructMapType)
+	m, err = vf.Map(`phone`, `555`, `name`, `Bob`).Transform(open)
+	require.Nil(t, err)
+	require.Equal(t, vf.Strings(`name`, `phone`), m.Keys())
+}
+
+func TestMap_Transform_default(t *testing.T) {
+	schema := tf.ParseType(`{name:string,port:80 int}`).(dgo.StructMapType)
+	m, err := vf.Map(`name`, `Bob`).Transform(schema)
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`name`, `Bob`, `port`, 80), m)
+
+	_, err = vf.Map(`port`, 8080).Transform(schema)
+	require.NotNil(t, err)
+	require.Match(t, `: missing required key 'name'\z`, err.Error())
+}
+
+func TestMap_typedGetters(t *testing.T) {
+	m := vf.Map(
+		`s`, `hello`,
+		`i`, 42,
+		`f`, 3.14,
+		`b`, true,
+		`m`, vf.Map(`a`, 1),
+		`a`, vf.Values(1, 2),
+		1, `one`)
+
+	s, ok := m.GetString(`s`)
+	require.True(t, ok)
+	require.Equal(t, `hello`, s)
+	s, ok = m.GetString(vf.String(`i`))
+	require.False(t, ok)
+	require.Equal(t, ``, s)
+	s, ok = m.GetString(1)
+	require.True(t, ok)
+	require.Equal(t, `one`, s)
+	_, ok = m.GetString(`missing`)
+	require.False(t, ok)
+
+	i, ok := m.GetInt(`i`)
+	require.True(t, ok)
+	require.Equal(t, 42, i)
+	_, ok = m.GetInt(`f`)
+	require.False(t, ok)
+
+	f, ok := m.GetFloat(`f`)
+	require.True(t, ok)
+	require.Equal(t, 3.14, f)
+	_, ok = m.GetFloat(`i`)
+	require.False(t, ok)
+
+	b, ok := m.GetBool(`b`)
+	require.True(t, ok)
+	require.True(t, b)
+	_, ok = m.GetBool(`s`)
+	require.False(t, ok)
+
+	sm, ok := m.GetMap(`m`)
+	require.True(t, ok)
+	require.Equal(t, vf.Map(`a`, 1), sm)
+	_, ok = m.GetMap(`a`)
+	require.False(t, ok)
+
+	a, ok := m.GetArray(`a`)
+	require.True(t, ok)
+	require.Equal(t, vf.Values(1, 2), a)
+	_, ok = m.GetArray(`m`)
+	require.False(t, ok)
+}
+
+func TestMap_mustGetters(t *testing.T) {
+	m := vf.Map(
+		`s`, `hello`,
+		`i`, 42,
+		`f`, 3.14,
+		`b`, true,
+		`m`, vf.Map(`a`, 1),
+		`a`, vf.Values(1, 2))
+
+	require.Equal(t, `hello`, m.MustGetString(`s`))
+	require.Equal(t, 42, m.MustGetInt(`i`))
+	require.Equal(t, 3.14, m.MustGetFloat(`f`))
+	require.True(t, m.MustGetBool(`b`))
+	require.Equal(t, vf.Map(`a`, 1), m.MustGetMap(`m`))
+	require.Equal(t, vf.Values(1, 2), m.MustGetArray(`a`))
+
+	require.Panic(t, func() { m.MustGetString(`x`) }, `no value is associated with the key 'x'`)
+	require.Panic(t, func() { m.MustGetString(`i`) }, `the value 42 cannot be assigned to a variable of type string`)
+	require.Panic(t, func() { m.MustGetInt(`f`) }, `cannot be assigned to a variable of type int`)
+	require.Panic(t, func() { m.MustGetFloat(`i`) }, `cannot be assigned to a variable of type float`)
+	require.Panic(t, func() { m.MustGetBool(`s`) }, `cannot be assigned to a variable of type bool`)
+	require.Panic(t, func() { m.MustGetMap(`a`) }, `cannot be assigned to a variable of type map`)
+	require.Panic(t, func() { m.MustGetArray(`m`) }, `cannot be assigned to a variable of type \[\]any`)
+}
+
+func TestMap_GetAndDelete(t *testing.T) {
+	m := vf.MutableMap(
+		`first`, 1,
+		`second`, nil,
+		`third`, `three`)
+
+	v, ok := m.GetAndDelete(`second`)
+	require.True(t, ok)
+	require.Equal(t, vf.Nil, v)
+
+	v, ok = m.GetAndDelete(`first`)
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.Equal(t, m, map[string]interface{}{`third`: `three`})
+
+	v, ok = m.GetAndDelete(`first`)
+	require.False(t, ok)
+	require.Nil(t, v)
+
+	require.Panic(t, func() { m.FrozenCopy().(dgo.Map).GetAndDelete(`third`) }, `GetAndDelete .* frozen`)
+}
+
+func TestMap_RenameKeys(t *testing.T) {
+	m := vf.Map(`first_name`, `Bob`, `last_name`, `Smith`, `age`, 42)
+	r := m.RenameKeys(vf.Map(`first_name`, `firstName`, `last_name`, `lastName`, `unused`, `x`))
+	require.Equal(t, vf.Map(`firstName`, `Bob`, `lastName`, `Smith`, `age`, 42), r)
+	require.Equal(t, vf.Strings(`firstName`, `lastName`, `age`), r.Keys())
+	require.True(t, r.Frozen())
+	require.False(t, m.Copy(false).RenameKeys(vf.Map()).Frozen())
+
+	require.Panic(t, func() { m.RenameKeys(vf.Map(`first_name`, `name`, `last_name`, `name`)) },
+		`more than one association for the key 'name'`)
+	require.Panic(t, func() { m.RenameKeys(vf.Map(`first_name`, `age`)) },
+		`more than one association for the key 'age'`)
+}
+
 func TestMap_RemoveAll(t *testing.T) {
 	mi := vf.Map(
 		`first`, 1,
@@ -890,6 +1384,54 @@ func TestMap_Merge(t *testing.T) {
 	require.Same(t, m1, vf.Map().Merge(m1))
 }
 
+func TestMap_DeepMerge(t *testing.T) {
+	m1 := vf.Map(
+		`name`, `base`,
+		`server`, vf.Map(`host`, `localhost`, `port`, 80, `tls`, vf.Map(`enabled`, false)),
+		`tags`, vf.Strings(`a`, `b`),
+		`extra`, vf.Map(`x`, 1))
+
+	m2 := vf.Map(
+		`server`, vf.Map(`port`, 8080, `tls`, vf.Map(`cert`, `c.pem`)),
+		`tags`, vf.Strings(`c`),
+		`extra`, `none`,
+		`added`, true)
+
+	r := m1.DeepMerge(m2, false)
+	require.Equal(t, vf.Map(
+		`name`, `base`,
+		`server`, vf.Map(`host`, `localhost`, `port`, 8080, `tls`, vf.Map(`enabled`, false, `cert`, `c.pem`)),
+		`tags`, vf.Strings(`c`),
+		`extra`, `none`,
+		`added`, true), r)
+	require.True(t, r.Frozen())
+
+	r = m1.DeepMerge(m2, true)
+	require.Equal(t, vf.Strings(`a`, `b`, `c`), r.Get(`tags`))
+	require.Equal(t, vf.Map(`host`, `localhost`, `port`, 80, `tls`, vf.Map(`enabled`, false)), m1.Get(`server`))
+
+	r = m1.DeepMerge(m2.Copy(false), false)
+	require.False(t, r.Frozen())
+	require.Same(t, m1, m1.DeepMerge(vf.Map(), true))
+}
+
+func TestMap_MergeWith(t *testing.T) {
+	m1 := vf.Map(`server`, vf.Map(`host`, `localhost`, `port`, 80), `tags`, vf.Strings(`a`, `b`))
+	m2 := vf.Map(`server`, vf.Map(`port`, 8080), `tags`, vf.Strings(`b`, `c`))
+
+	require.Equal(t, vf.Map(`server`, vf.Map(`port`, 8080), `tags`, vf.Strings(`b`, `c`)),
+		m1.MergeWith(m2, dgo.MergeShallow))
+	require.Equal(t, vf.Map(`server`, vf.Map(`host`, `localhost`, `port`, 8080), `tags`, vf.Strings(`b`, `c`)),
+		m1.MergeWith(m2, dgo.MergeDeep))
+	require.Equal(t, vf.Strings(`a`, `b`, `b`, `c`), m1.MergeWith(m2, dgo.MergeDeepConcat).Get(`tags`))
+	require.Equal(t, vf.Strings(`a`, `b`, `c`), m1.MergeWith(m2, dgo.MergeDeepUnique).Get(`tags`))
+
+	n1 := vf.Map(`a`, vf.Map(`list`, vf.Values(1, 2)))
+	n2 := vf.Map(`a`, vf.Map(`list`, vf.Values(2, 3)))
+	require.Equal(t, vf.Map(`a`, vf.Map(`list`, vf.Values(1, 2, 3))), n1.MergeWith(n2, dgo.MergeDeepUnique))
+	require.Same(t, n1, n1.MergeWith(vf.Map(), dgo.MergeDeepUnique))
+}
+
 func TestMap_HashCode(t *testing.T) {
 	m := vf.Map(
 		`first`, 1,
@@ -944,6 +1486,29 @@ func TestMap_Equal(t *testing.T) {
 	require.NotEqual(t, m1, m3)
 }
 
+func TestMap_Entries(t *testing.T) {
+	m := vf.MutableMap(
+		`first`, 1,
+		`second`, vf.MutableValues(2),
+		`third`, `three`)
+
+	es := m.Entries()
+	require.True(t, es.Frozen())
+	require.Equal(t, vf.Values(
+		vf.MapEntry(`first`, 1),
+		vf.MapEntry(`second`, vf.Values(2)),
+		vf.MapEntry(`third`, `three`)), es)
+
+	m.Put(`first`, 10)
+	m.Get(`second`).(dgo.Array).Add(3)
+	m.Remove(`third`)
+	m.Put(`fourth`, 4)
+	require.Equal(t, 3, es.Len())
+	require.Equal(t, vf.MapEntry(`first`, 1), es.Get(0))
+	require.Equal(t, vf.MapEntry(`second`, vf.Values(2)), es.Get(1))
+	require.Equal(t, 0, vf.Map().Entries().Len())
+}
+
 func TestMap_Keys(t *testing.T) {
 	m := vf.Map(
 		`first`, 1,
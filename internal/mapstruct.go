@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
 )
 
 type (
@@ -16,11 +18,19 @@ type (
 		keys       array
 		values     array
 		required   []bool
+
+		// defaults is nil unless at least one entry has a default value
+		defaults []dgo.Value
+
+		// annotations is nil unless at least one entry has annotations
+		annotations []dgo.Value
 	}
 
 	structEntry struct {
 		mapEntry
-		required bool
+		required    bool
+		dflt        dgo.Value
+		annotations dgo.Map
 	}
 )
 
@@ -33,6 +43,7 @@ func StructMapTypeUnresolved(additional bool, entries []dgo.StructMapEntry) dgo.
 	keys := make([]dgo.Value, l)
 	values := make([]dgo.Value, l)
 	required := make([]bool, l)
+	var defaults, annotations []dgo.Value
 	for i := 0; i < l; i++ {
 		e := entries[i]
 		kt := e.Key().(dgo.Type)
@@ -42,18 +53,32 @@ func StructMapTypeUnresolved(additional bool, entries []dgo.StructMapEntry) dgo.
 		}
 		keys[i] = kt
 		values[i] = vt
-		required[i] = e.Required()
+		required[i] = e.Required() && !isPatternKey(kt)
+		if d := e.Default(); d != nil {
+			if defaults == nil {
+				defaults = make([]dgo.Value, l)
+			}
+			defaults[i] = d
+		}
+		if a := e.Annotations(); a.Len() > 0 {
+			if annotations == nil {
+				annotations = make([]dgo.Value, l)
+			}
+			annotations[i] = a
+		}
 	}
 
-	if exact {
+	if exact && annotations == nil {
 		return createExactMap(keys, values)
 	}
 
 	return &structType{
-		additional: additional,
-		keys:       array{slice: keys, frozen: true},
-		values:     array{slice: values, frozen: true},
-		required:   required}
+		additional:  additional,
+		keys:        array{slice: keys, frozen: true},
+		values:      array{slice: values, frozen: true},
+		required:    required,
+		defaults:    defaults,
+		annotations: annotations}
 }
 
 func createExactMap(keys, values []dgo.Value) dgo.StructMapType {
@@ -70,6 +95,7 @@ func StructMapType(additional bool, entries []dgo.StructMapEntry) dgo.StructMapT
 	t := StructMapTypeUnresolved(additional, entries)
 	if st, ok := t.(*structType); ok {
 		st.checkExactKeys()
+		st.checkDefaults()
 	}
 	return t
 }
@@ -80,12 +106,15 @@ var sfmType dgo.MapType
 // StructMapTypeFromMap
 func StructFromMapType() dgo.MapType {
 	if sfmType == nil {
-		sfmType = Parse(`map[string](dgo|type|{type:dgo|type,required?:bool,...})`).(dgo.MapType)
+		sfmType = Parse(
+			`map[string](dgo|type|{type:dgo|type,required?:bool,default?:any,annotations?:map[string]any,...})`).(dgo.MapType)
 	}
 	return sfmType
 }
 
-// StructMapTypeFromMap returns a new type built from a map[string](dgo|type|{type:dgo|type,required?:bool,...})
+// StructMapTypeFromMap returns a new type built from a
+// map[string](dgo|type|{type:dgo|type,required?:bool,default?:any,annotations?:map[string]any,...}). An entry that
+// has a default value is never required.
 func StructMapTypeFromMap(additional bool, entries dgo.Map) dgo.StructMapType {
 	if !StructFromMapType().Instance(entries) {
 		panic(IllegalAssignment(sfmType, entries))
@@ -94,6 +123,7 @@ func StructMapTypeFromMap(additional bool, entries dgo.Map) dgo.StructMapType {
 	keys := make([]dgo.Value, l)
 	values := make([]dgo.Value, l)
 	required := make([]bool, l)
+	var defaults, annotations []dgo.Value
 	i := 0
 
 	// turn dgo|type into type
@@ -122,6 +152,19 @@ func StructMapTypeFromMap(additional bool, entries dgo.Map) dgo.StructMapType {
 			if rqv := vm.Get(`required`); rqv != nil {
 				rq = rqv.(dgo.Boolean).GoBool()
 			}
+			if d := vm.Get(`default`); d != nil {
+				if defaults == nil {
+					defaults = make([]dgo.Value, l)
+				}
+				defaults[i] = frozenCopy(d)
+				rq = false
+			}
+			if a, ok := vm.Get(`annotations`).(dgo.Map); ok && a.Len() > 0 {
+				if annotations == nil {
+					annotations = make([]dgo.Value, l)
+				}
+				annotations[i] = a.FrozenCopy()
+			}
 		} else {
 			vt = asType(e.Value())
 		}
@@ -134,29 +177,126 @@ func StructMapTypeFromMap(additional bool, entries dgo.Map) dgo.StructMapType {
 		i++
 	})
 
-	if exact {
+	if exact && annotations == nil {
 		return createExactMap(keys, values)
 	}
 
 	t := &structType{
-		additional: additional,
-		keys:       array{slice: keys, frozen: true},
-		values:     array{slice: values, frozen: true},
-		required:   required}
+		additional:  additional,
+		keys:        array{slice: keys, frozen: true},
+		values:      array{slice: values, frozen: true},
+		required:    required,
+		defaults:    defaults,
+		annotations: annotations}
 
 	t.checkExactKeys()
+	t.checkDefaults()
 	return t
 }
 
+// StructTagKey is the key of the Go struct field tag that declares the dgo type of a field, e.g. `dgo:"0..100"`
+const StructTagKey = `dgo`
+
+// StructMapTypeFromReflected returns a StructMapType with one required entry for each field of the given Go struct
+// type, keyed by the field name. The value type of an entry is parsed from the dgo struct tag of the field, or
+// derived from the Go type of the field when it has no such tag. A pointer to a struct type is dereferenced. The
+// function panics if the type isn't a struct or if a tag cannot be parsed into a type.
+func StructMapTypeFromReflected(rt reflect.Type) dgo.StructMapType {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Errorf(`%s is not a struct`, rt))
+	}
+	n := rt.NumField()
+	entries := make([]dgo.StructMapEntry, n)
+	for i := 0; i < n; i++ {
+		f := rt.Field(i)
+		var vt dgo.Type
+		if tag, ok := f.Tag.Lookup(StructTagKey); ok {
+			if err := util.Catch(func() { vt = AsType(Parse(tag)) }); err != nil {
+				panic(fmt.Errorf(`invalid %s tag on field %s.%s: %s`, StructTagKey, rt, f.Name, err.Error()))
+			}
+		} else {
+			vt = TypeFromReflected(f.Type)
+		}
+		entries[i] = StructMapEntry(f.Name, vt, true)
+	}
+	return StructMapType(false, entries)
+}
+
+func (t *structType) Describe() string {
+	return Describe(t)
+}
+
 func (t *structType) checkExactKeys() {
 	ks := t.keys.slice
 	for i := range ks {
-		if !dgo.IsExact(ks[i].(dgo.Type)) {
-			panic(`non exact key types is not yet supported`)
+		if kt := ks[i].(dgo.Type); !(dgo.IsExact(kt) || isPatternKey(kt)) {
+			panic(`key types that are neither exact nor patterns are not yet supported`)
 		}
 	}
 }
 
+// isPatternKey returns true if the given key of a struct entry is a pattern type. Such an entry describes all keys
+// that match the pattern. It is never required.
+func isPatternKey(kt dgo.Value) bool {
+	t, ok := kt.(dgo.Type)
+	return ok && t.TypeIdentifier() == dgo.TiStringPattern
+}
+
+// entryKey returns the exact value of the key of the given entry and true, or the pattern type of the key and false
+// when the entry describes all keys that match a pattern.
+func entryKey(e dgo.StructMapEntry) (dgo.Value, bool) {
+	k := e.Key()
+	if isPatternKey(k) {
+		return k, false
+	}
+	return k.(dgo.ExactType).ExactValue(), true
+}
+
+// eachMatchingPattern calls the given actor with each entry of the given type that has a pattern key that matches
+// the given key.
+func eachMatchingPattern(t dgo.StructMapType, key dgo.Value, actor func(dgo.StructMapEntry)) {
+	t.Each(func(e dgo.StructMapEntry) {
+		if k := e.Key(); isPatternKey(k) && k.(dgo.Type).Instance(key) {
+			actor(e)
+		}
+	})
+}
+
+// checkDefaults panics if a default value is declared for a pattern key or if it is not an instance of the type of
+// its entry
+func (t *structType) checkDefaults() {
+	ds := t.defaults
+	for i := range ds {
+		if d := ds[i]; d != nil {
+			if kt := t.keys.slice[i]; isPatternKey(kt) {
+				panic(fmt.Errorf(`the pattern key %s cannot have a default value`, kt))
+			}
+			if vt := t.values.slice[i].(dgo.Type); !vt.Instance(d) {
+				panic(IllegalAssignment(vt, d))
+			}
+		}
+	}
+}
+
+// annotationsAt returns the annotations of the entry at the given index, or nil if that entry has no annotations
+func (t *structType) annotationsAt(i int) dgo.Map {
+	if t.annotations == nil || t.annotations[i] == nil {
+		return nil
+	}
+	return t.annotations[i].(dgo.Map)
+}
+
+// defaultAt returns the default value of the entry at the given index, or nil if that entry has no default value
+func (t *structType) defaultAt(i int) dgo.Value {
+	if t.defaults == nil {
+		return nil
+	}
+	return t.defaults[i]
+}
+
 func (t *structType) Additional() bool {
 	return t.additional
 }
@@ -197,6 +337,26 @@ func (t *structType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type) bo
 				return false
 			}
 		}
+
+		// exact keys of the other type must be assignable to all patterns of this type that match them
+		for oi := range oks {
+			ok := oks[oi].(dgo.Type)
+			if !dgo.IsExact(ok) {
+				continue
+			}
+			matched := false
+			for mi := range mks {
+				if mk := mks[mi]; isPatternKey(mk) && mk.(dgo.Type).Instance(ok.(dgo.ExactType).ExactValue()) {
+					if !Assignable(guard, mvs[mi].(dgo.Type), ovs[oi].(dgo.Type)) {
+						return false
+					}
+					matched = true
+				}
+			}
+			if matched && t.keys.IndexOf(ok) < 0 {
+				oc++
+			}
+		}
 		return t.additional || oc == len(oks)
 	case *exactMapType:
 		ov := ot.value
@@ -206,12 +366,62 @@ func (t *structType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type) bo
 }
 
 func (t *structType) Each(actor func(dgo.StructMapEntry)) {
-	ks := t.keys.slice
-	vs := t.values.slice
-	rs := t.required
-	for i := range ks {
-		actor(&structEntry{mapEntry: mapEntry{key: ks[i], value: vs[i]}, required: rs[i]})
+	for i := range t.keys.slice {
+		actor(t.entryAt(i))
+	}
+}
+
+// entryAt returns the entry at the given index
+func (t *structType) entryAt(i int) dgo.StructMapEntry {
+	return &structEntry{
+		mapEntry:    mapEntry{key: t.keys.slice[i], value: t.values.slice[i]},
+		required:    t.required[i],
+		dflt:        t.defaultAt(i),
+		annotations: t.annotationsAt(i)}
+}
+
+func (t *structType) Extend(other dgo.StructMapType, overrides ...dgo.StructMapEntry) dgo.StructMapType {
+	return extend(t, other, overrides)
+}
+
+// extend returns a new StructMapType with the entries of the given base, followed by the entries of the given
+// extension that the base doesn't have and then the overrides that neither of them have. Entries of the extension
+// must narrow the inherited entries that they replace. Overrides replace entries unconditionally.
+func extend(base, ext dgo.StructMapType, overrides []dgo.StructMapEntry) dgo.StructMapType {
+	entries := make([]dgo.StructMapEntry, 0, base.Len()+ext.Len()+len(overrides))
+	base.Each(func(e dgo.StructMapEntry) { entries = append(entries, e) })
+	indexOf := func(key dgo.Value) int {
+		for i := range entries {
+			if entries[i].Key().Equals(key) {
+				return i
+			}
+		}
+		return -1
+	}
+	ext.Each(func(e dgo.StructMapEntry) {
+		i := indexOf(e.Key())
+		if i < 0 {
+			entries = append(entries, e)
+			return
+		}
+		ie := entries[i]
+		ek, _ := entryKey(e)
+		if it := ie.Value().(dgo.Type); !it.Assignable(e.Value().(dgo.Type)) {
+			panic(fmt.Errorf(`the type %s of key '%s' is not assignable to the inherited type %s`, e.Value(), ek, it))
+		}
+		if ie.Required() && !e.Required() {
+			panic(fmt.Errorf(`the inherited key '%s' is required and cannot be made optional`, ek))
+		}
+		entries[i] = e
+	})
+	for _, o := range overrides {
+		if i := indexOf(o.Key()); i >= 0 {
+			entries[i] = o
+		} else {
+			entries = append(entries, o)
+		}
 	}
+	return StructMapType(base.Additional() && ext.Additional(), entries)
 }
 
 func (t *structType) Equals(other interface{}) bool {
@@ -222,6 +432,7 @@ func (t *structType) deepEqual(seen []dgo.Value, other deepEqual) bool {
 	if ot, ok := other.(*structType); ok {
 		return t.additional == ot.additional &&
 			boolsEqual(t.required, ot.required) &&
+			sliceEquals(seen, t.defaults, ot.defaults) &&
 			equals(seen, &t.keys, &ot.keys) &&
 			equals(seen, &t.values, &ot.values)
 	}
@@ -238,6 +449,11 @@ func (t *structType) HashCode() int {
 
 func (t *structType) deepHashCode(seen []dgo.Value) int {
 	h := boolsHash(t.required)*31 + deepHashCode(seen, &t.keys)*31 + deepHashCode(seen, &t.values)
+	for i := range t.defaults {
+		if d := t.defaults[i]; d != nil {
+			h = h*31 + deepHashCode(seen, d)
+		}
+	}
 	if t.additional {
 		h *= 3
 	}
@@ -254,7 +470,12 @@ func (t *structType) DeepInstance(guard dgo.RecursionGuard, value interface{}) b
 		vs := t.values.slice
 		rs := t.required
 		oc := 0
+		patterns := false
 		for i := range ks {
+			if isPatternKey(ks[i]) {
+				patterns = true
+				continue
+			}
 			k := ks[i].(dgo.ExactType)
 			if ov := om.Get(k.ExactValue()); ov != nil {
 				oc++
@@ -265,6 +486,23 @@ func (t *structType) DeepInstance(guard dgo.RecursionGuard, value interface{}) b
 				return false
 			}
 		}
+		if patterns && !om.All(func(e dgo.MapEntry) bool {
+			matched := false
+			for i := range ks {
+				if isPatternKey(ks[i]) && ks[i].(dgo.Type).Instance(e.Key()) {
+					if !Instance(guard, vs[i].(dgo.Type), e.Value()) {
+						return false
+					}
+					matched = true
+				}
+			}
+			if matched && t.keys.IndexOf(e.Key().Type()) < 0 {
+				oc++
+			}
+			return true
+		}) {
+			return false
+		}
 		return t.additional || oc == om.Len()
 	}
 	return false
@@ -277,7 +515,15 @@ func (t *structType) Get(key interface{}) dgo.StructMapEntry {
 	}
 	i := t.keys.IndexOf(kv)
 	if i >= 0 {
-		return StructMapEntry(kv, t.values.slice[i], t.required[i])
+		return t.entryAt(i)
+	}
+	if et, ok := kv.(dgo.ExactType); ok && dgo.IsExact(et) {
+		ks := t.keys.slice
+		for i = range ks {
+			if isPatternKey(ks[i]) && ks[i].(dgo.Type).Instance(et.ExactValue()) {
+				return t.entryAt(i)
+			}
+		}
 	}
 	return nil
 }
@@ -302,6 +548,12 @@ func (t *structType) Max() int {
 	if m == 0 || t.additional {
 		return math.MaxInt64
 	}
+	ks := t.keys.slice
+	for i := range ks {
+		if isPatternKey(ks[i]) {
+			return math.MaxInt64
+		}
+	}
 	return m
 }
 
@@ -336,6 +588,7 @@ func (t *structType) Resolve(ap dgo.AliasAdder) {
 	t.keys.slice = ks
 	t.values.slice = vs
 	t.checkExactKeys()
+	t.checkDefaults()
 }
 
 func (t *structType) String() string {
@@ -377,17 +630,26 @@ func validate(t dgo.StructMapType, keyLabel func(key dgo.Value) string, value in
 		keyLabel = parameterLabel
 	}
 	t.Each(func(e dgo.StructMapEntry) {
-		ek := e.Key().(dgo.ExactType).ExactValue()
+		ek, exact := entryKey(e)
+		if !exact {
+			return
+		}
 		if v := pm.Get(ek); v != nil {
 			ev := e.Value().(dgo.Type)
 			if !ev.Instance(v) {
-				errs = append(errs, fmt.Errorf(`%s is not an instance of type %s`, keyLabel(ek), ev))
+				errs = append(errs, fmt.Errorf(`%s is not an instance of type %s%s`, keyLabel(ek), ev, describedAs(e)))
 			}
 		} else if e.Required() {
-			errs = append(errs, fmt.Errorf(`missing required %s`, keyLabel(ek)))
+			errs = append(errs, fmt.Errorf(`missing required %s%s`, keyLabel(ek), describedAs(e)))
 		}
 	})
-	pm.EachKey(func(k dgo.Value) {
+	pm.EachEntry(func(pe dgo.MapEntry) {
+		k := pe.Key()
+		eachMatchingPattern(t, k, func(e dgo.StructMapEntry) {
+			if ev := e.Value().(dgo.Type); !ev.Instance(pe.Value()) {
+				errs = append(errs, fmt.Errorf(`%s is not an instance of type %s%s`, keyLabel(k), ev, describedAs(e)))
+			}
+		})
 		if t.Get(k) == nil {
 			errs = append(errs, fmt.Errorf(`unknown %s`, keyLabel(k)))
 		}
@@ -395,6 +657,106 @@ func validate(t dgo.StructMapType, keyLabel func(key dgo.Value) string, value in
 	return errs
 }
 
+// describedAs returns the description annotation of the given entry in parentheses, preceded by a space, or an
+// empty string when the entry has no description
+func describedAs(e dgo.StructMapEntry) string {
+	if d, ok := e.Annotations().Get(dgo.AnnotationDescription).(dgo.String); ok {
+		return ` (` + d.GoString() + `)`
+	}
+	return ``
+}
+
+// transform returns a new map where the entries of the given map have been adapted to the given schema. A value
+// that isn't an instance of the type of its entry is converted using the type's New function when the type is a
+// dgo.Factory. Entries that are described by the schema come first, in the order of the schema, followed by any
+// entries that match a pattern of the schema and any additional entries in the order of the given map. An entry
+// that matches a pattern is adapted to the first matching pattern entry. An error that lists all violations is returned when some
+// value cannot be converted, when a required entry is missing, or when the map contains entries not described by
+// a schema that disallows additional entries.
+func transform(m dgo.Map, schema dgo.StructMapType) (dgo.Map, error) {
+	var errs []string
+	c := MapWithCapacity(m.Len()).(*hashMap)
+	conform := func(e dgo.StructMapEntry, k, v dgo.Value) {
+		et := e.Value().(dgo.Type)
+		if !et.Instance(v) {
+			f, ok := et.(dgo.Factory)
+			if !ok {
+				errs = append(errs, fmt.Sprintf(`key '%s': %s`, k, IllegalAssignment(et, v)))
+				return
+			}
+			if err := util.Catch(func() { v = f.New(v) }); err != nil {
+				errs = append(errs, fmt.Sprintf(`key '%s': %s`, k, err))
+				return
+			}
+		}
+		c.Put(k, v)
+	}
+	schema.Each(func(e dgo.StructMapEntry) {
+		ek, exact := entryKey(e)
+		if !exact {
+			return
+		}
+		v := m.Get(ek)
+		if v == nil {
+			if d := e.Default(); d != nil {
+				c.Put(ek, d)
+				return
+			}
+			if e.Required() {
+				errs = append(errs, fmt.Sprintf(`missing required key '%s'`, ek))
+			}
+			return
+		}
+		conform(e, ek, v)
+	})
+	m.EachEntry(func(e dgo.MapEntry) {
+		switch se := schema.Get(e.Key()); {
+		case se != nil:
+			if isPatternKey(se.Key()) {
+				conform(se, e.Key(), e.Value())
+			}
+		case schema.Additional():
+			c.Put(e.Key(), e.Value())
+		default:
+			errs = append(errs, fmt.Sprintf(`unknown key '%s'`, e.Key()))
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf(`the map cannot be transformed to type %s: %s`, TypeString(schema), strings.Join(errs, `; `))
+	}
+	if m.Frozen() {
+		c.Freeze()
+	}
+	return c, nil
+}
+
+// withDefaults returns a map where each entry of the given schema that has a default value and is missing in the
+// given map has been added with that value. The given map is returned as is when no such entry is missing. A new
+// map is given the frozen status.
+func withDefaults(m dgo.Map, frozen bool, schema dgo.StructMapType) dgo.Map {
+	var c *hashMap
+	schema.Each(func(e dgo.StructMapEntry) {
+		d := e.Default()
+		if d == nil {
+			return
+		}
+		ek, exact := entryKey(e)
+		if !exact || m.Get(ek) != nil {
+			return
+		}
+		if c == nil {
+			c = MapWithCapacity(m.Len() + 1).(*hashMap)
+			c.PutAll(m)
+		}
+		c.Put(ek, d)
+	})
+	if c == nil {
+		return m
+	}
+	c.frozen = frozen
+	return c
+}
+
 func validateVerbose(t dgo.StructMapType, value interface{}, out dgo.Indenter) bool {
 	pm, ok := Value(value).(dgo.Map)
 	if !ok {
@@ -404,7 +766,10 @@ func validateVerbose(t dgo.StructMapType, value interface{}, out dgo.Indenter) b
 
 	inner := out.Indent()
 	t.Each(func(e dgo.StructMapEntry) {
-		ek := e.Key().(dgo.ExactType).ExactValue()
+		ek, exact := entryKey(e)
+		if !exact {
+			return
+		}
 		ev := e.Value().(dgo.Type)
 		out.Printf(`Validating '%s' against definition %s`, ek, ev)
 		inner.NewLine()
@@ -416,17 +781,34 @@ func validateVerbose(t dgo.StructMapType, value interface{}, out dgo.Indenter) b
 				ok = false
 				inner.Append(`FAILED!`)
 				inner.NewLine()
-				inner.Printf(`Reason: expected a value of type %s, got %s`, ev, v.Type())
+				inner.Printf(`Reason: expected a value of type %s%s, got %s`, ev, describedAs(e), v.Type())
 			}
 		} else if e.Required() {
 			ok = false
 			inner.Append(`FAILED!`)
 			inner.NewLine()
 			inner.Append(`Reason: required key not found in input`)
+			inner.Append(describedAs(e))
 		}
 		out.NewLine()
 	})
-	pm.EachKey(func(k dgo.Value) {
+	pm.EachEntry(func(pe dgo.MapEntry) {
+		k := pe.Key()
+		eachMatchingPattern(t, k, func(e dgo.StructMapEntry) {
+			ev := e.Value().(dgo.Type)
+			out.Printf(`Validating '%s' against definition %s`, k, ev)
+			inner.NewLine()
+			inner.Printf(`'%s' `, k)
+			if ev.Instance(pe.Value()) {
+				inner.Append(`OK!`)
+			} else {
+				ok = false
+				inner.Append(`FAILED!`)
+				inner.NewLine()
+				inner.Printf(`Reason: expected a value of type %s%s, got %s`, ev, describedAs(e), pe.Value().Type())
+			}
+			out.NewLine()
+		})
 		if t.Get(k) == nil {
 			ok = false
 			out.Printf(`Validating '%s'`, k)
@@ -464,6 +846,29 @@ func StructMapEntry(key interface{}, value interface{}, required bool) dgo.Struc
 	return &structEntry{mapEntry: mapEntry{key: kv, value: vv}, required: required}
 }
 
+// StructMapEntryWithDefault returns a new optional StructMapEntry initiated with the given parameters. The given
+// default value is used when the entry is missing in a Map. The function panics if the key is a pattern or if the
+// default value is not an instance of the value type.
+func StructMapEntryWithDefault(key interface{}, value interface{}, defaultValue interface{}) dgo.StructMapEntry {
+	e := StructMapEntryWithDefaultUnresolved(key, value, defaultValue).(*structEntry)
+	if isPatternKey(e.key) {
+		panic(fmt.Errorf(`the pattern key %s cannot have a default value`, e.key))
+	}
+	if vt := e.value.(dgo.Type); !vt.Instance(e.dflt) {
+		panic(IllegalAssignment(vt, e.dflt))
+	}
+	return e
+}
+
+// StructMapEntryWithDefaultUnresolved is like StructMapEntryWithDefault but doesn't check the key or the default
+// value. It is used by the parser since the value type may contain aliases that are not yet resolved. The checks are
+// made when the struct is resolved.
+func StructMapEntryWithDefaultUnresolved(key, value, defaultValue interface{}) dgo.StructMapEntry {
+	e := StructMapEntry(key, value, false).(*structEntry)
+	e.dflt = frozenCopy(Value(defaultValue))
+	return e
+}
+
 func (t *structEntry) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
@@ -471,12 +876,33 @@ func (t *structEntry) Equals(other interface{}) bool {
 func (t *structEntry) deepEqual(seen []dgo.Value, other deepEqual) bool {
 	if ot, ok := other.(dgo.StructMapEntry); ok {
 		return t.required == ot.Required() &&
+			equals(seen, t.dflt, ot.Default()) &&
 			equals(seen, t.mapEntry.key, ot.Key()) &&
 			equals(seen, t.mapEntry.value, ot.Value())
 	}
 	return false
 }
 
+// AnnotatedStructMapEntry returns a copy of the given StructMapEntry that has the given annotations
+func AnnotatedStructMapEntry(entry dgo.StructMapEntry, annotations dgo.Map) dgo.StructMapEntry {
+	return &structEntry{
+		mapEntry:    mapEntry{key: entry.Key(), value: entry.Value()},
+		required:    entry.Required(),
+		dflt:        entry.Default(),
+		annotations: annotations.FrozenCopy().(dgo.Map)}
+}
+
+func (t *structEntry) Annotations() dgo.Map {
+	if t.annotations == nil {
+		return emptyMap
+	}
+	return t.annotations
+}
+
+func (t *structEntry) Default() dgo.Value {
+	return t.dflt
+}
+
 func (t *structEntry) Required() bool {
 	return t.required
 }
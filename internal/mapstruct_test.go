@@ -61,6 +61,25 @@ func ExampleMap_Put_structTypeIllegalValue() {
 	// Output: reflect: call of reflect.Value.SetString on int Value
 }
 
+type reflectedStruct struct {
+	A int    `dgo:"0..10"`
+	B string `json:"b"`
+	C []string
+}
+
+func TestStructMapFromReflected(t *testing.T) {
+	tp := tf.StructMapFromReflected(reflect.TypeOf(&reflectedStruct{}))
+	require.Equal(t, tf.ParseType(`{A:0..10,B:string,C:[]string}`), tp)
+	require.Instance(t, tp, vf.Map(&reflectedStruct{A: 3, C: []string{`x`}}))
+	require.NotInstance(t, tp, vf.Map(&reflectedStruct{A: 11}))
+
+	require.Panic(t, func() { tf.StructMapFromReflected(reflect.TypeOf(1)) }, `int is not a struct`)
+	require.Panic(t, func() {
+		tf.StructMapFromReflected(reflect.StructOf([]reflect.StructField{
+			{Name: `X`, Type: reflect.TypeOf(0), Tag: `dgo:"0.."`}, {Name: `Y`, Type: reflect.TypeOf(0), Tag: `dgo:"[int"`}}))
+	}, `invalid dgo tag on field struct .*\.Y`)
+}
+
 func TestStructType_Get(t *testing.T) {
 	tp := tf.ParseType(`{a:int,b:string}`).(dgo.StructMapType)
 	require.Equal(t, tp.Get(`a`).Value(), typ.Integer)
@@ -94,6 +113,26 @@ func TestStructType_Validate_unknownKey(t *testing.T) {
 	require.Equal(t, `unknown parameter 'c'`, es[0].Error())
 }
 
+func TestStructType_Validate_annotated(t *testing.T) {
+	tp := tf.StructMap(false,
+		tf.AnnotatedStructMapEntry(tf.StructMapEntry(`a`, typ.Integer, true), vf.Map(`description`, `the a value`)),
+		tf.AnnotatedStructMapEntry(tf.StructMapEntry(`b`, typ.String, true), vf.Map(`description`, `the b value`)))
+	es := tp.Validate(nil, vf.Map(`a`, `no`))
+	require.Equal(t, 2, len(es))
+	require.Equal(t, `parameter 'a' is not an instance of type int (the a value)`, es[0].Error())
+	require.Equal(t, `missing required parameter 'b' (the b value)`, es[1].Error())
+
+	out := util.NewIndenter(`  `)
+	require.False(t, tp.ValidateVerbose(vf.Map(`a`, `no`), out))
+	require.Equal(t, `Validating 'a' against definition int
+  'a' FAILED!
+  Reason: expected a value of type int (the a value), got "no"
+Validating 'b' against definition string
+  'b' FAILED!
+  Reason: required key not found in input (the b value)
+`, out.String())
+}
+
 func TestStructType_Validate_notMap(t *testing.T) {
 	tp := tf.ParseType(`{a:int,b:string}`).(dgo.StructMapType)
 	es := tp.Validate(nil, vf.Values(1, 2))
@@ -257,14 +296,89 @@ func TestStructType(t *testing.T) {
 	require.NotEqual(t, tp.HashCode(), tf.ParseType(`{a:int,b?:string,...}`).HashCode())
 
 	require.Panic(t, func() {
-		tf.StructMap(false,
-			tf.StructMapEntry(tf.Pattern(regexp.MustCompile(`a*`)), typ.Integer, true))
-	}, `non exact key types`)
+		tf.StructMap(false, tf.StructMapEntry(typ.String, typ.Integer, true))
+	}, `key types that are neither exact nor patterns`)
 
 	tps = tf.ParseType(`{a:0..10,b?:int}`).(dgo.StructMapType)
 	require.True(t, reflect.ValueOf(map[string]int64{}).Type().AssignableTo(tps.ReflectType()))
 }
 
+func TestStructType_Extend(t *testing.T) {
+	base := tf.ParseType(`{name:string,port?:int,...}`).(dgo.StructMapType)
+	ext := tf.ParseType(`{port:1..65535,tls?:bool}`).(dgo.StructMapType)
+	tp := base.Extend(ext)
+	require.Equal(t, tf.ParseType(`{name:string,port:1..65535,tls?:bool}`), tp)
+	require.Assignable(t, base, tp)
+	require.NotAssignable(t, tp, base)
+	require.Equal(t, tf.ParseType(`{name:string,port:1..65535,tls?:bool,...}`),
+		base.Extend(tf.ParseType(`{port:1..65535,tls?:bool,...}`).(dgo.StructMapType)))
+
+	require.Panic(t, func() {
+		base.Extend(tf.ParseType(`{port:string}`).(dgo.StructMapType))
+	}, `the type string of key 'port' is not assignable to the inherited type int`)
+	require.Panic(t, func() {
+		tp.Extend(tf.ParseType(`{port?:80}`).(dgo.StructMapType))
+	}, `the inherited key 'port' is required and cannot be made optional`)
+
+	tp = tp.Extend(tf.StructMap(false), tf.StructMapEntry(`port`, typ.String, false), tf.StructMapEntry(`host`, typ.String, true))
+	require.Equal(t, tf.ParseType(`{name:string,port?:string,tls?:bool,host:string}`), tp)
+
+	exact := tf.ParseType(`{name:"a"}`).(dgo.StructMapType)
+	require.Equal(t, tf.ParseType(`{name:"a",age?:int}`), exact.Extend(tf.ParseType(`{age?:int}`).(dgo.StructMapType)))
+	require.Equal(t, tf.ParseType(`{name:"a",age:3}`), exact.Extend(tf.ParseType(`{age:3}`).(dgo.StructMapType)))
+}
+
+func TestStructType_pattern(t *testing.T) {
+	tp := tf.ParseType(`{name:string,/^env_/:string}`).(dgo.StructMapType)
+	require.Equal(t, `{"name":string,/^env_/:string}`, tp.String())
+	require.Equal(t, tp, tf.ParseType(tp.String()))
+	require.Equal(t, tp, tf.StructMap(false,
+		tf.StructMapEntry(`name`, typ.String, true),
+		tf.StructMapEntry(tf.Pattern(regexp.MustCompile(`^env_`)), typ.String, true)))
+	require.False(t, tp.Get(tf.Pattern(regexp.MustCompile(`^env_`))).Required())
+	require.Equal(t, 1, tp.Min())
+	require.Equal(t, math.MaxInt64, tp.Max())
+
+	require.Instance(t, tp, vf.Map(`name`, `a`))
+	require.Instance(t, tp, vf.Map(`name`, `a`, `env_home`, `/home`, `env_user`, `bob`))
+	require.NotInstance(t, tp, vf.Map(`name`, `a`, `env_home`, 1))
+	require.NotInstance(t, tp, vf.Map(`name`, `a`, `other`, `x`))
+	require.NotInstance(t, tp, vf.Map(`env_home`, `/home`))
+	require.Instance(t, tf.ParseType(`{/^env_/:string,...}`), vf.Map(`other`, 1, `env_x`, `y`))
+	require.NotInstance(t, tf.ParseType(`{env_x?:string|int,/^env_/:string}`), vf.Map(`env_x`, 1))
+
+	e := tp.Get(`env_home`)
+	require.NotNil(t, e)
+	require.Equal(t, tf.Pattern(regexp.MustCompile(`^env_`)), e.Key())
+	require.Nil(t, tp.Get(`other`))
+
+	require.Assignable(t, tp, tf.ParseType(`{name:string,env_home:string}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{name:string,env_home:int}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{name:string,other:string}`))
+	require.Assignable(t, tp, tf.ParseType(`{name:"a",/^env_/:"b"}`))
+	require.NotAssignable(t, tp, tf.ParseType(`{name:string,...}`))
+
+	es := tp.Validate(nil, vf.Map(`name`, `a`, `env_home`, 1, `other`, `x`))
+	require.Equal(t, 2, len(es))
+	require.Equal(t, `parameter 'env_home' is not an instance of type string`, es[0].Error())
+	require.Equal(t, `unknown parameter 'other'`, es[1].Error())
+
+	out := util.NewIndenter(`  `)
+	require.False(t, tp.ValidateVerbose(vf.Map(`name`, `a`, `env_home`, 1), out))
+	require.Equal(t, `Validating 'name' against definition string
+  'name' OK!
+Validating 'env_home' against definition string
+  'env_home' FAILED!
+  Reason: expected a value of type string, got 1
+`, out.String())
+
+	m, err := vf.Map(`env_port`, 8080, `name`, `a`).Transform(tf.ParseType(`{name:string,/^env_/:string}`).(dgo.StructMapType))
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`name`, `a`, `env_port`, `8080`), m)
+
+	require.Panic(t, func() { tf.ParseType(`{/^env_/:"x" string}`) }, `the pattern key /\^env_/ cannot have a default value`)
+}
+
 func TestStructEntry(t *testing.T) {
 	tp := tf.StructMapEntry(`a`, typ.String, true)
 	require.Equal(t, tp, tf.StructMapEntry(`a`, typ.String, true))
@@ -273,6 +387,71 @@ func TestStructEntry(t *testing.T) {
 	require.Equal(t, `"a":string`, tp.String())
 }
 
+func TestStructEntry_default(t *testing.T) {
+	tp := tf.StructMapEntryWithDefault(`a`, typ.Integer, 80)
+	require.False(t, tp.Required())
+	require.Equal(t, vf.Integer(80), tp.Default())
+	require.Nil(t, tf.StructMapEntry(`a`, typ.Integer, false).Default())
+	require.Equal(t, tp, tf.StructMapEntryWithDefault(`a`, typ.Integer, 80))
+	require.NotEqual(t, tp, tf.StructMapEntryWithDefault(`a`, typ.Integer, 8080))
+	require.NotEqual(t, tp, tf.StructMapEntry(`a`, typ.Integer, false))
+
+	require.Panic(t, func() { tf.StructMapEntryWithDefault(`port`, typ.Integer, `x`) },
+		`the string "x" cannot be assigned to a variable of type int`)
+	require.Panic(t, func() { tf.StructMapEntryWithDefault(tf.Pattern(regexp.MustCompile(`^env_`)), typ.String, `x`) },
+		`the pattern key /\^env_/ cannot have a default value`)
+
+	// the parser makes the same checks when the struct is resolved
+	require.Panic(t, func() { tf.ParseType(`{port:"x" int}`) }, `cannot be assigned to a variable of type int`)
+	tf.ParseType(`defaultedNode={value?:int,next:{value:1} defaultedNode}`)
+}
+
+func TestStructType_default(t *testing.T) {
+	tp := tf.ParseType(`{host:string,port:80 int,tags?:{"a","b"} []string}`).(dgo.StructMapType)
+	require.Equal(t, `{"host":string,"port"?:80 int,"tags"?:{"a","b"} []string}`, tp.String())
+	require.Equal(t, tp, tf.ParseType(tp.String()))
+	require.Equal(t, tp.HashCode(), tf.ParseType(tp.String()).HashCode())
+	require.NotEqual(t, tp, tf.ParseType(`{host:string,port?:int,tags?:[]string}`))
+	require.Equal(t, vf.Integer(80), tp.Get(`port`).Default())
+	require.Nil(t, tp.Get(`host`).Default())
+	require.True(t, tp.Instance(vf.Map(`host`, `example.com`)))
+
+	require.Equal(t, vf.Map(`host`, `example.com`, `port`, 80, `tags`, vf.Values(`a`, `b`)),
+		vf.New(tp, vf.Map(`host`, `example.com`)))
+	require.Equal(t, vf.Map(`host`, `example.com`, `port`, 8080, `tags`, vf.Values(`a`, `b`)),
+		vf.New(tp, vf.Map(`host`, `example.com`, `port`, 8080)))
+
+	require.Panic(t, func() {
+		tf.StructMap(false, tf.StructMapEntryWithDefault(`port`, typ.Integer, `80`))
+	}, `cannot be assigned to a variable of type int`)
+	require.Panic(t, func() { tf.ParseType(`{port:int int}`) }, `default value of key 'port' is not a literal value`)
+}
+
+func TestStructEntry_annotations(t *testing.T) {
+	e := tf.StructMapEntry(`a`, typ.String, true)
+	require.Equal(t, 0, e.Annotations().Len())
+	a := vf.MutableMap(`description`, `the a`, `deprecated`, true)
+	ae := tf.AnnotatedStructMapEntry(e, a)
+	require.Equal(t, vf.Map(`description`, `the a`, `deprecated`, true), ae.Annotations())
+	require.True(t, ae.Annotations().Frozen())
+	require.True(t, ae.Required())
+	require.Equal(t, e, ae)
+
+	tp := tf.StructMap(false, ae, tf.StructMapEntry(`b`, typ.Integer, false))
+	require.Equal(t, ae.Annotations(), tp.Get(`a`).Annotations())
+	require.Equal(t, 0, tp.Get(`b`).Annotations().Len())
+	tp.Each(func(e dgo.StructMapEntry) {
+		if e.Key().Equals(vf.String(`a`).Type()) {
+			require.Equal(t, ae.Annotations(), e.Annotations())
+		}
+	})
+	ext := tp.Extend(tf.ParseType(`{c:int}`).(dgo.StructMapType))
+	require.Equal(t, ae.Annotations(), ext.Get(`a`).Annotations())
+
+	exact := tf.StructMap(false, tf.AnnotatedStructMapEntry(tf.StructMapEntry(`a`, `x`, true), a))
+	require.Equal(t, ae.Annotations(), exact.Get(`a`).Annotations())
+}
+
 func TestStructFromMap(t *testing.T) {
 	require.Panic(t, func() {
 		tf.StructMapFromMap(false, vf.Map(`nope`, `dope`))
@@ -299,6 +478,16 @@ func TestStructFromMap(t *testing.T) {
 	tp = tf.StructMapFromMap(false, vf.Map(`first`, `"x"`))
 	require.Equal(t, tp, tf.StructMap(false, tf.StructMapEntry(`first`, vf.String(`x`).Type(), true)))
 
+	tp = tf.StructMapFromMap(false, vf.Map(`first`, vf.Map(`type`, `int`, `required`, true, `default`, 80)))
+	require.Equal(t, tp, tf.StructMap(false, tf.StructMapEntryWithDefault(`first`, typ.Integer, 80)))
+
+	require.Panic(t, func() {
+		tf.StructMapFromMap(false, vf.Map(`first`, vf.Map(`type`, `int`, `default`, `80`)))
+	}, `cannot be assigned to a variable of type int`)
+
+	tp = tf.StructMapFromMap(false, vf.Map(`first`, vf.Map(`type`, `int`, `annotations`, vf.Map(`description`, `d`))))
+	require.Equal(t, vf.Map(`description`, `d`), tp.Get(`first`).Annotations())
+
 	tp = tf.StructMapFromMap(false, vf.Map())
 	require.Equal(t, tp, tf.StructMap(false))
 	require.False(t, tp.Unbounded())
@@ -21,6 +21,10 @@ func MetaType(t dgo.Type) dgo.Meta {
 	return &metaType{t}
 }
 
+func (t *metaType) Describe() string {
+	return Describe(t)
+}
+
 func (t *metaType) Type() dgo.Type {
 	if t.tp == nil {
 		return t // type of meta type is meta type
@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// msgpackTimestamp is the MessagePack extension type that is reserved for timestamps
+const msgpackTimestamp = -1
+
+// msgpackMaxDepth is the maximum nesting depth of arrays and maps that the decoder accepts
+const msgpackMaxDepth = 10000
+
+type msgpackEncoder struct {
+	buf []byte
+}
+
+type msgpackDecoder struct {
+	buf   []byte
+	pos   int
+	depth int
+}
+
+// ToMsgpack returns the MessagePack encoding of the given value. Nil, Boolean, Integer, BigInt (within the range of
+// an int64 or an uint64), Float, String, Binary, Time, Array, and Map values are supported. A Binary is encoded using
+// the bin family and a Time is encoded using the timestamp extension type. The function panics if the value contains
+// a value that cannot be encoded.
+func ToMsgpack(v dgo.Value) []byte {
+	e := &msgpackEncoder{buf: make([]byte, 0, 64)}
+	e.encode(v)
+	return e.buf
+}
+
+// FromMsgpack decodes the given MessagePack encoded data into a frozen value. The function panics if the data is
+// malformed, if it contains more than one value, or if it contains an extension type other than the timestamp.
+func FromMsgpack(data []byte) dgo.Value {
+	d := &msgpackDecoder{buf: data}
+	v := d.decode()
+	if d.pos < len(d.buf) {
+		panic(fmt.Errorf(`unexpected data at offset %d after the end of the MessagePack value`, d.pos))
+	}
+	return v
+}
+
+func (e *msgpackEncoder) encode(v dgo.Value) {
+	switch v := v.(type) {
+	case nil, nilValue:
+		e.buf = append(e.buf, 0xc0)
+	case dgo.Boolean:
+		if v.GoBool() {
+			e.buf = append(e.buf, 0xc3)
+		} else {
+			e.buf = append(e.buf, 0xc2)
+		}
+	case dgo.Integer:
+		e.encodeInt(v.GoInt())
+	case dgo.BigInt:
+		bi := v.GoBigInt()
+		switch {
+		case bi.IsInt64():
+			e.encodeInt(bi.Int64())
+		case bi.IsUint64():
+			e.buf = append(e.buf, 0xcf)
+			e.buf = appendUint(e.buf, bi.Uint64(), 8)
+		default:
+			panic(fmt.Errorf(`the big integer %s is too large to be encoded as MessagePack`, bi))
+		}
+	case dgo.Float:
+		e.buf = append(e.buf, 0xcb)
+		e.buf = appendUint(e.buf, math.Float64bits(v.GoFloat()), 8)
+	case dgo.String:
+		s := v.GoString()
+		e.encodeLength(len(s), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		e.buf = append(e.buf, s...)
+	case dgo.Binary:
+		bs := v.GoBytes()
+		e.encodeLength(len(bs), 0, 0, 0xc4, 0xc5, 0xc6)
+		e.buf = append(e.buf, bs...)
+	case dgo.Time:
+		e.encodeTime(v.GoTime())
+	case dgo.Array:
+		e.encodeLength(v.Len(), 0x90, 16, 0, 0xdc, 0xdd)
+		v.Each(e.encode)
+	case dgo.Map:
+		e.encodeLength(v.Len(), 0x80, 16, 0, 0xde, 0xdf)
+		v.EachEntry(func(me dgo.MapEntry) {
+			e.encode(me.Key())
+			e.encode(me.Value())
+		})
+	default:
+		panic(fmt.Errorf(`unable to encode a value of type %s as MessagePack`, v.Type()))
+	}
+}
+
+func (e *msgpackEncoder) encodeInt(i int64) {
+	switch {
+	case i >= 0 && i <= 0x7f, i < 0 && i >= -32:
+		e.buf = append(e.buf, byte(i))
+	case i > 0:
+		switch {
+		case i <= math.MaxUint8:
+			e.buf = append(e.buf, 0xcc, byte(i))
+		case i <= math.MaxUint16:
+			e.buf = appendUint(append(e.buf, 0xcd), uint64(i), 2)
+		case i <= math.MaxUint32:
+			e.buf = appendUint(append(e.buf, 0xce), uint64(i), 4)
+		default:
+			e.buf = appendUint(append(e.buf, 0xcf), uint64(i), 8)
+		}
+	default:
+		switch {
+		case i >= math.MinInt8:
+			e.buf = append(e.buf, 0xd0, byte(i))
+		case i >= math.MinInt16:
+			e.buf = appendUint(append(e.buf, 0xd1), uint64(i), 2)
+		case i >= math.MinInt32:
+			e.buf = appendUint(append(e.buf, 0xd2), uint64(i), 4)
+		default:
+			e.buf = appendUint(append(e.buf, 0xd3), uint64(i), 8)
+		}
+	}
+}
+
+// encodeLength appends the header for a string, binary, array, or map of the given length. The fix code is used
+// when the length is less than fixLimit. A zero code means that the format lacks that variant.
+func (e *msgpackEncoder) encodeLength(n int, fix byte, fixLimit int, code8, code16, code32 byte) {
+	switch {
+	case n < fixLimit:
+		e.buf = append(e.buf, fix|byte(n))
+	case code8 != 0 && n <= math.MaxUint8:
+		e.buf = append(e.buf, code8, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = appendUint(append(e.buf, code16), uint64(n), 2)
+	case uint64(n) <= math.MaxUint32:
+		e.buf = appendUint(append(e.buf, code32), uint64(n), 4)
+	default:
+		panic(fmt.Errorf(`the length %d is too large to be encoded as MessagePack`, n))
+	}
+}
+
+func (e *msgpackEncoder) encodeTime(t time.Time) {
+	sec := t.Unix()
+	nsec := uint64(t.Nanosecond())
+	switch {
+	case sec >= 0 && sec>>34 == 0:
+		if v := nsec<<34 | uint64(sec); v&0xffffffff00000000 == 0 {
+			e.buf = appendUint(append(e.buf, 0xd6, 0xff), v, 4)
+		} else {
+			e.buf = appendUint(append(e.buf, 0xd7, 0xff), v, 8)
+		}
+	default:
+		e.buf = append(e.buf, 0xc7, 12, 0xff)
+		e.buf = appendUint(e.buf, nsec, 4)
+		e.buf = appendUint(e.buf, uint64(sec), 8)
+	}
+}
+
+// appendUint appends the n least significant bytes of v in big-endian order
+func appendUint(buf []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		buf = append(buf, byte(v>>(uint(i)*8)))
+	}
+	return buf
+}
+
+func (d *msgpackDecoder) next(n int) []byte {
+	if n < 0 || len(d.buf)-d.pos < n {
+		panic(errors.New(`unexpected end of MessagePack data`))
+	}
+	bs := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return bs
+}
+
+// uint reads an unsigned integer of n bytes in big-endian order
+func (d *msgpackDecoder) uint(n int) uint64 {
+	var v uint64
+	for _, b := range d.next(n) {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+func (d *msgpackDecoder) decode() dgo.Value {
+	c := d.next(1)[0]
+	switch {
+	case c <= 0x7f:
+		return intVal(c)
+	case c >= 0xe0:
+		return intVal(int8(c))
+	case c&0xf0 == 0x80:
+		return d.decodeMap(int(c & 0x0f))
+	case c&0xf0 == 0x90:
+		return d.decodeArray(int(c & 0x0f))
+	case c&0xe0 == 0xa0:
+		return String(string(d.next(int(c & 0x1f))))
+	}
+
+	switch c {
+	case 0xc0:
+		return Nil
+	case 0xc2:
+		return False
+	case 0xc3:
+		return True
+	case 0xc4, 0xc5, 0xc6:
+		return Binary(d.next(d.length(c-0xc4)), true)
+	case 0xc7, 0xc8, 0xc9:
+		n := d.length(c - 0xc7)
+		return d.decodeExt(int8(d.next(1)[0]), n)
+	case 0xca:
+		return floatVal(math.Float32frombits(uint32(d.uint(4))))
+	case 0xcb:
+		return floatVal(math.Float64frombits(d.uint(8)))
+	case 0xcc, 0xcd, 0xce:
+		return intVal(d.uint(1 << (c - 0xcc)))
+	case 0xcf:
+		u := d.uint(8)
+		if u > math.MaxInt64 {
+			return BigInt(new(big.Int).SetUint64(u))
+		}
+		return intVal(u)
+	case 0xd0:
+		return intVal(int8(d.uint(1)))
+	case 0xd1:
+		return intVal(int16(d.uint(2)))
+	case 0xd2:
+		return intVal(int32(d.uint(4)))
+	case 0xd3:
+		return intVal(d.uint(8))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		et := int8(d.next(1)[0])
+		return d.decodeExt(et, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		return String(string(d.next(d.length(c - 0xd9))))
+	case 0xdc, 0xdd:
+		return d.decodeArray(d.length(c - 0xdb))
+	case 0xde, 0xdf:
+		return d.decodeMap(d.length(c - 0xdd))
+	}
+	panic(fmt.Errorf(`invalid MessagePack format byte 0x%x at offset %d`, c, d.pos-1))
+}
+
+// length reads a length of 1, 2, or 4 bytes for a size class of 0, 1, or 2
+func (d *msgpackDecoder) length(sizeClass byte) int {
+	return int(d.uint(1 << sizeClass))
+}
+
+func (d *msgpackDecoder) decodeArray(n int) dgo.Value {
+	if n > len(d.buf)-d.pos {
+		panic(errors.New(`unexpected end of MessagePack data`))
+	}
+	d.enter()
+	vs := make([]dgo.Value, n)
+	for i := range vs {
+		vs[i] = d.decode()
+	}
+	d.depth--
+	return &array{slice: vs, frozen: true}
+}
+
+func (d *msgpackDecoder) decodeMap(n int) dgo.Value {
+	if n > (len(d.buf)-d.pos)/2 {
+		panic(errors.New(`unexpected end of MessagePack data`))
+	}
+	d.enter()
+	m := MapWithCapacity(n)
+	for i := 0; i < n; i++ {
+		k := d.decode()
+		m.Put(k, d.decode())
+	}
+	d.depth--
+	m.Freeze()
+	return m
+}
+
+// enter increments the nesting depth and panics if it exceeds msgpackMaxDepth
+func (d *msgpackDecoder) enter() {
+	if d.depth >= msgpackMaxDepth {
+		panic(fmt.Errorf(`MessagePack data exceeds the maximum nesting depth of %d at offset %d`, msgpackMaxDepth, d.pos))
+	}
+	d.depth++
+}
+
+func (d *msgpackDecoder) decodeExt(et int8, n int) dgo.Value {
+	if et != msgpackTimestamp {
+		panic(fmt.Errorf(`unsupported MessagePack extension type %d`, et))
+	}
+	var sec int64
+	var nsec uint64
+	switch n {
+	case 4:
+		sec = int64(d.uint(4))
+	case 8:
+		v := d.uint(8)
+		sec = int64(v & 0x3ffffffff)
+		nsec = v >> 34
+	case 12:
+		nsec = d.uint(4)
+		sec = int64(d.uint(8))
+	default:
+		panic(fmt.Errorf(`invalid MessagePack timestamp length %d`, n))
+	}
+	if nsec > 999999999 {
+		panic(fmt.Errorf(`invalid MessagePack timestamp nanoseconds %d`, nsec))
+	}
+	return Time(time.Unix(sec, int64(nsec)).UTC())
+}
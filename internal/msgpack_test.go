@@ -0,0 +1,93 @@
+package internal_test
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestToMsgpack_scalars(t *testing.T) {
+	require.Equal(t, []byte{0xc0}, vf.ToMsgpack(vf.Nil))
+	require.Equal(t, []byte{0xc0}, vf.ToMsgpack(nil))
+	require.Equal(t, []byte{0xc3}, vf.ToMsgpack(vf.True))
+	require.Equal(t, []byte{0xc2}, vf.ToMsgpack(vf.False))
+	require.Equal(t, []byte{0x07}, vf.ToMsgpack(vf.Integer(7)))
+	require.Equal(t, []byte{0xff}, vf.ToMsgpack(vf.Integer(-1)))
+	require.Equal(t, []byte{0xcc, 0xc8}, vf.ToMsgpack(vf.Integer(200)))
+	require.Equal(t, []byte{0xcd, 0x01, 0x00}, vf.ToMsgpack(vf.Integer(256)))
+	require.Equal(t, []byte{0xd0, 0xdf}, vf.ToMsgpack(vf.Integer(-33)))
+	require.Equal(t, []byte{0xd1, 0xff, 0x00}, vf.ToMsgpack(vf.Integer(-256)))
+	require.Equal(t, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, vf.ToMsgpack(vf.Float(1.5)))
+	require.Equal(t, []byte{0xa3, 'a', 'b', 'c'}, vf.ToMsgpack(vf.String(`abc`)))
+	require.Equal(t, []byte{0xc4, 0x02, 0x01, 0x02}, vf.ToMsgpack(vf.Binary([]byte{1, 2}, true)))
+	require.Equal(t, []byte{0xd6, 0xff, 0, 0, 0, 0x01}, vf.ToMsgpack(vf.Value(time.Unix(1, 0))))
+}
+
+func TestToMsgpack_collections(t *testing.T) {
+	require.Equal(t, []byte{0x92, 0x01, 0xa1, 'a'}, vf.ToMsgpack(vf.Values(1, `a`)))
+	require.Equal(t, []byte{0x81, 0xa1, 'a', 0x90}, vf.ToMsgpack(vf.Map(`a`, vf.Values())))
+
+	long := vf.ArrayWithCapacity(20)
+	for i := 0; i < 20; i++ {
+		long.Add(i)
+	}
+	require.Equal(t, []byte{0xdc, 0x00, 0x14}, vf.ToMsgpack(long)[:3])
+	require.Equal(t, []byte{0xd9, 0x28}, vf.ToMsgpack(vf.String(strings.Repeat(`x`, 40)))[:2])
+}
+
+func TestToMsgpack_unsupported(t *testing.T) {
+	require.Panic(t, func() { vf.ToMsgpack(vf.Sensitive(`secret`)) }, `unable to encode a value of type sensitive`)
+	bi, _ := new(big.Int).SetString(`100000000000000000000`, 10)
+	require.Panic(t, func() { vf.ToMsgpack(vf.BigInt(bi)) }, `too large to be encoded`)
+}
+
+func TestFromMsgpack_roundTrip(t *testing.T) {
+	bi := new(big.Int).SetUint64(math.MaxUint64)
+	for _, v := range []dgo.Value{
+		vf.Nil, vf.True, vf.False,
+		vf.Integer(0), vf.Integer(127), vf.Integer(-32), vf.Integer(-129), vf.Integer(70000), vf.Integer(-70000),
+		vf.Integer(math.MaxInt64), vf.Integer(math.MinInt64), vf.BigInt(bi),
+		vf.Float(3.14), vf.String(``), vf.String(strings.Repeat(`y`, 300)),
+		vf.Binary([]byte{0, 1, 2, 255}, true),
+		vf.Value(time.Unix(1, 500)), vf.Value(time.Unix(1<<35, 0)), vf.Value(time.Unix(-10, 3)),
+		vf.Values(1, `two`, vf.Values(3.0, nil)),
+		vf.Map(`a`, 1, 2, vf.Map(`b`, true), vf.Values(1, 2), `list`),
+	} {
+		rv := vf.FromMsgpack(vf.ToMsgpack(v))
+		require.Equal(t, v, rv)
+		require.True(t, vf.AssertFrozen(rv) == nil)
+	}
+}
+
+func TestFromMsgpack(t *testing.T) {
+	require.Equal(t, vf.Float(float64(float32(0.25))), vf.FromMsgpack([]byte{0xca, 0x3e, 0x80, 0, 0}))
+	require.Equal(t, `abc`, vf.FromMsgpack([]byte{0xda, 0x00, 0x03, 'a', 'b', 'c'}))
+	require.Equal(t, vf.Values(1), vf.FromMsgpack([]byte{0xdd, 0, 0, 0, 0x01, 0x01}))
+	require.Equal(t, vf.Map(`a`, 1), vf.FromMsgpack([]byte{0xde, 0x00, 0x01, 0xa1, 'a', 0x01}))
+}
+
+func TestFromMsgpack_illegal(t *testing.T) {
+	require.Panic(t, func() { vf.FromMsgpack([]byte{}) }, `unexpected end of MessagePack data`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0xa3, 'a'}) }, `unexpected end of MessagePack data`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0x93, 0x01}) }, `unexpected end of MessagePack data`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0x01, 0x02}) }, `unexpected data at offset 1`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0xc1}) }, `invalid MessagePack format byte 0xc1`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0xd4, 0x05, 0x00}) }, `unsupported MessagePack extension type 5`)
+	require.Panic(t, func() { vf.FromMsgpack([]byte{0xd5, 0xff, 0x00, 0x00}) }, `invalid MessagePack timestamp length 2`)
+}
+
+func TestFromMsgpack_maxDepth(t *testing.T) {
+	require.Panic(t, func() { vf.FromMsgpack(bytes.Repeat([]byte{0x91}, 20000000)) },
+		`MessagePack data exceeds the maximum nesting depth of 10000`)
+	require.Panic(t, func() { vf.FromMsgpack(bytes.Repeat([]byte{0x81}, 20000000)) },
+		`MessagePack data exceeds the maximum nesting depth of 10000`)
+	v := vf.FromMsgpack(append(bytes.Repeat([]byte{0x91}, 9999), 0x01))
+	require.Equal(t, 1, v.(dgo.Array).Len())
+}
@@ -0,0 +1,385 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	// multiMap is a dgo.MultiMap that is backed by a hashMap which maps each key to an *array of values
+	multiMap struct {
+		*hashMap
+	}
+
+	// multiMapType is the type of a multiMap
+	multiMapType struct {
+		keyType     dgo.Type
+		elementType dgo.Type
+	}
+)
+
+// MultiMap returns a new empty and mutable dgo.MultiMap
+func MultiMap() dgo.MultiMap {
+	return &multiMap{MapWithCapacity(0).(*hashMap)}
+}
+
+// MultiMapType returns a dgo.MultiMapType where all keys are instances of the given keyType and all values that are
+// associated with a key are instances of the given elementType.
+func MultiMapType(keyType, elementType dgo.Type) dgo.MultiMapType {
+	return &multiMapType{keyType: keyType, elementType: elementType}
+}
+
+func (m *multiMap) Add(key, value interface{}) {
+	if m.frozen {
+		panic(frozenMap(`Add`))
+	}
+	m.valuesFor(Value(key)).Add(value)
+}
+
+func (m *multiMap) AddAll(key interface{}, values dgo.Array) {
+	if m.frozen {
+		panic(frozenMap(`AddAll`))
+	}
+	if values.Len() > 0 {
+		m.valuesFor(Value(key)).AddAll(values)
+	}
+}
+
+// valuesFor returns the array of values that is associated with the given key. The array is created if it doesn't
+// exist.
+func (m *multiMap) valuesFor(key dgo.Value) *array {
+	if a, ok := m.hashMap.Get(key).(*array); ok {
+		return a
+	}
+	a := &array{}
+	m.hashMap.Put(key, a)
+	return a
+}
+
+func (m *multiMap) ComputeIfAbsent(key interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	k := Value(key)
+	if v := m.Get(k); v != nil {
+		return v
+	}
+	v := Value(computer(k))
+	m.Put(k, v)
+	return v
+}
+
+func (m *multiMap) Copy(frozen bool) dgo.Map {
+	if frozen && m.frozen {
+		return m
+	}
+	return &multiMap{m.hashMap.Copy(frozen).(*hashMap)}
+}
+
+// derive returns a mutable copy of this map that shares no arrays with this map, passes it to the given function,
+// and then freezes it if this map is frozen.
+func (m *multiMap) derive(f func(c *multiMap)) dgo.Map {
+	c := &multiMap{m.hashMap.Copy(false).(*hashMap)}
+	f(c)
+	if m.frozen {
+		c.Freeze()
+	}
+	return c
+}
+
+func (m *multiMap) Equals(other interface{}) bool {
+	return equals(nil, m, other)
+}
+
+func (m *multiMap) deepEqual(seen []dgo.Value, other deepEqual) bool {
+	if om, ok := other.(*multiMap); ok {
+		return m.hashMap.deepEqual(seen, om.hashMap)
+	}
+	return false
+}
+
+func (m *multiMap) FrozenCopy() dgo.Value {
+	return m.Copy(true)
+}
+
+func (m *multiMap) ThawedCopy() dgo.Value {
+	return m.Copy(false)
+}
+
+func (m *multiMap) Get(key interface{}) dgo.Value {
+	if a, ok := m.hashMap.Get(key).(*array); ok && len(a.slice) > 0 {
+		return a.slice[0]
+	}
+	return nil
+}
+
+func (m *multiMap) GetAll(key interface{}) dgo.Array {
+	if a, ok := m.hashMap.Get(key).(*array); ok {
+		return a.FrozenCopy().(dgo.Array)
+	}
+	return &array{slice: []dgo.Value{}, frozen: true}
+}
+
+func (m *multiMap) GetAndDelete(key interface{}) (dgo.Value, bool) {
+	if m.frozen {
+		panic(frozenMap(`GetAndDelete`))
+	}
+	old := m.Get(key)
+	m.hashMap.Remove(key)
+	return old, old != nil
+}
+
+func (m *multiMap) GetArray(key interface{}) (dgo.Array, bool) {
+	return asArray(m.Get(key))
+}
+
+func (m *multiMap) GetBool(key interface{}) (bool, bool) {
+	return asGoBool(m.Get(key))
+}
+
+func (m *multiMap) GetFloat(key interface{}) (float64, bool) {
+	return asGoFloat(m.Get(key))
+}
+
+func (m *multiMap) GetInt(key interface{}) (int64, bool) {
+	return asGoInt(m.Get(key))
+}
+
+func (m *multiMap) GetMap(key interface{}) (dgo.Map, bool) {
+	return asMap(m.Get(key))
+}
+
+func (m *multiMap) GetOrElse(key interface{}, defaultValue dgo.Value) dgo.Value {
+	if v := m.Get(key); v != nil {
+		return v
+	}
+	return defaultValue
+}
+
+func (m *multiMap) GetOrElseGet(key interface{}, computer func() dgo.Value) dgo.Value {
+	if v := m.Get(key); v != nil {
+		return v
+	}
+	return computer()
+}
+
+func (m *multiMap) GetString(key interface{}) (string, bool) {
+	return asGoString(m.Get(key))
+}
+
+func (m *multiMap) Merge(associations dgo.Map) dgo.Map {
+	if associations.Len() == 0 || m == associations {
+		return m
+	}
+	return m.derive(func(c *multiMap) { c.PutAll(associations) })
+}
+
+func (m *multiMap) MustGetArray(key interface{}) dgo.Array {
+	return mustGet(m, key, DefaultArrayType).(dgo.Array)
+}
+
+func (m *multiMap) MustGetBool(key interface{}) bool {
+	return mustGet(m, key, DefaultBooleanType).(dgo.Boolean).GoBool()
+}
+
+func (m *multiMap) MustGetFloat(key interface{}) float64 {
+	return mustGet(m, key, DefaultFloatType).(dgo.Float).GoFloat()
+}
+
+func (m *multiMap) MustGetInt(key interface{}) int64 {
+	return mustGet(m, key, DefaultIntegerType).(dgo.Integer).GoInt()
+}
+
+func (m *multiMap) MustGetMap(key interface{}) dgo.Map {
+	return mustGet(m, key, DefaultMapType).(dgo.Map)
+}
+
+func (m *multiMap) MustGetString(key interface{}) string {
+	return mustGet(m, key, DefaultStringType).(dgo.String).GoString()
+}
+
+func (m *multiMap) Put(key, value interface{}) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`Put`))
+	}
+	k := Value(key)
+	old := m.Get(k)
+	m.hashMap.Put(k, &array{slice: []dgo.Value{Value(value)}})
+	return old
+}
+
+// PutAll replaces the values for all keys of the given associations. When the associations is a MultiMap, all of its
+// values for a key replaces the values for that key in this map.
+func (m *multiMap) PutAll(associations dgo.Map) {
+	if associations.Len() == 0 {
+		return
+	}
+	if m.frozen {
+		panic(frozenMap(`PutAll`))
+	}
+	if om, ok := associations.(dgo.MultiMap); ok {
+		om.EachKey(func(k dgo.Value) {
+			m.hashMap.Put(k, om.GetAll(k).ThawedCopy())
+		})
+	} else {
+		associations.EachEntry(func(e dgo.MapEntry) { m.Put(e.Key(), e.Value()) })
+	}
+}
+
+func (m *multiMap) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	if m.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	k := Value(key)
+	if old := m.Get(k); old != nil {
+		return old, false
+	}
+	v := Value(value)
+	m.Put(k, v)
+	return v, true
+}
+
+func (m *multiMap) Remove(key interface{}) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`Remove`))
+	}
+	old := m.Get(key)
+	m.hashMap.Remove(key)
+	return old
+}
+
+func (m *multiMap) RenameKeys(mapping dgo.Map) dgo.Map {
+	return m.derive(func(c *multiMap) { c.hashMap = c.hashMap.RenameKeys(mapping).(*hashMap) })
+}
+
+func (m *multiMap) String() string {
+	return m.hashMap.String()
+}
+
+func (m *multiMap) Transform(schema dgo.StructMapType) (dgo.Map, error) {
+	return transform(m, schema)
+}
+
+func (m *multiMap) Type() dgo.Type {
+	et := &exactMapType{value: m}
+	et.ExactType = et
+	return et
+}
+
+func (m *multiMap) With(key, value interface{}) dgo.Map {
+	return m.derive(func(c *multiMap) { c.Add(key, value) })
+}
+
+func (m *multiMap) Without(key interface{}) dgo.Map {
+	if m.hashMap.Get(key) == nil {
+		return m
+	}
+	return m.derive(func(c *multiMap) { c.hashMap.remove(Value(key)) })
+}
+
+func (m *multiMap) WithoutAll(keys dgo.Array) dgo.Map {
+	if m.len == 0 || keys.Len() == 0 {
+		return m
+	}
+	return m.derive(func(c *multiMap) { c.hashMap.RemoveAll(keys) })
+}
+
+func (t *multiMapType) Assignable(other dgo.Type) bool {
+	return Assignable(nil, t, other)
+}
+
+func (t *multiMapType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type) bool {
+	if ot, ok := other.(*multiMapType); ok {
+		return Assignable(guard, t.keyType, ot.keyType) && Assignable(guard, t.elementType, ot.elementType)
+	}
+	return CheckAssignableTo(guard, other, t)
+}
+
+func (t *multiMapType) Describe() string {
+	return Describe(t)
+}
+
+func (t *multiMapType) ElementType() dgo.Type {
+	return t.elementType
+}
+
+func (t *multiMapType) Equals(other interface{}) bool {
+	return equals(nil, t, other)
+}
+
+func (t *multiMapType) deepEqual(seen []dgo.Value, other deepEqual) bool {
+	if ot, ok := other.(*multiMapType); ok {
+		return equals(seen, t.keyType, ot.keyType) && equals(seen, t.elementType, ot.elementType)
+	}
+	return false
+}
+
+func (t *multiMapType) HashCode() int {
+	return deepHashCode(nil, t)
+}
+
+func (t *multiMapType) deepHashCode(seen []dgo.Value) int {
+	return (int(dgo.TiMultiMap)*31+deepHashCode(seen, t.keyType))*31 + deepHashCode(seen, t.elementType)
+}
+
+func (t *multiMapType) Instance(value interface{}) bool {
+	return Instance(nil, t, value)
+}
+
+func (t *multiMapType) DeepInstance(guard dgo.RecursionGuard, value interface{}) bool {
+	if ov, ok := value.(*multiMap); ok {
+		return ov.hashMap.All(func(e dgo.MapEntry) bool {
+			return Instance(guard, t.keyType, e.Key()) &&
+				e.Value().(*array).All(func(v dgo.Value) bool { return Instance(guard, t.elementType, v) })
+		})
+	}
+	return false
+}
+
+func (t *multiMapType) KeyType() dgo.Type {
+	return t.keyType
+}
+
+func (t *multiMapType) Max() int {
+	return math.MaxInt64
+}
+
+func (t *multiMapType) Min() int {
+	return 0
+}
+
+func (t *multiMapType) ReflectType() reflect.Type {
+	return reflect.MapOf(t.keyType.ReflectType(), reflect.SliceOf(t.elementType.ReflectType()))
+}
+
+func (t *multiMapType) Resolve(ap dgo.AliasAdder) {
+	kt := t.keyType
+	et := t.elementType
+	t.keyType = DefaultAnyType
+	t.elementType = DefaultAnyType
+	t.keyType = ap.Replace(kt).(dgo.Type)
+	t.elementType = ap.Replace(et).(dgo.Type)
+}
+
+func (t *multiMapType) String() string {
+	return TypeString(t)
+}
+
+func (t *multiMapType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *multiMapType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiMultiMap
+}
+
+func (t *multiMapType) Unbounded() bool {
+	return true
+}
+
+// ValueType returns the type of the array of values that is associated with each key
+func (t *multiMapType) ValueType() dgo.Type {
+	return newArrayType(t.elementType, 1, math.MaxInt64)
+}
@@ -0,0 +1,188 @@
+package internal_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/util"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestMultiMap(t *testing.T) {
+	m := vf.MultiMap()
+	m.Add(`Accept`, `text/html`)
+	m.Add(`Accept`, `application/json`)
+	m.AddAll(`Cache-Control`, vf.Strings(`no-cache`, `no-store`))
+	m.AddAll(`Empty`, vf.Values())
+
+	require.Equal(t, 2, m.Len())
+	require.False(t, m.ContainsKey(`Empty`))
+	require.Equal(t, `text/html`, m.Get(`Accept`))
+	require.Nil(t, m.Get(`Empty`))
+	require.Equal(t, vf.Strings(`text/html`, `application/json`), m.GetAll(`Accept`))
+	require.True(t, m.GetAll(`Accept`).Frozen())
+	require.Equal(t, 0, m.GetAll(`Empty`).Len())
+
+	var keys []string
+	m.EachEntry(func(e dgo.MapEntry) {
+		keys = append(keys, e.Key().String())
+		require.Instance(t, typ.Array, e.Value())
+	})
+	require.Equal(t, []string{`Accept`, `Cache-Control`}, keys)
+	require.Equal(t, vf.Values(vf.Strings(`text/html`, `application/json`), vf.Strings(`no-cache`, `no-store`)),
+		m.Values())
+
+	require.Equal(t, `text/html`, m.GetOrElse(`Accept`, vf.String(`*/*`)))
+	require.Equal(t, `*/*`, m.GetOrElse(`Accept-Encoding`, vf.String(`*/*`)))
+	require.Equal(t, `no-cache`, m.GetOrElseGet(`Cache-Control`, func() dgo.Value { return vf.Nil }))
+	require.Equal(t, vf.Nil, m.GetOrElseGet(`Empty`, func() dgo.Value { return vf.Nil }))
+
+	s, ok := m.GetString(`Accept`)
+	require.True(t, ok)
+	require.Equal(t, `text/html`, s)
+	require.Equal(t, `no-cache`, m.MustGetString(`Cache-Control`))
+	require.Panic(t, func() { m.MustGetInt(`Accept`) }, `cannot be assigned`)
+}
+
+func TestMultiMap_Put(t *testing.T) {
+	m := vf.MultiMap()
+	require.Nil(t, m.Put(`a`, 1))
+	m.Add(`a`, 2)
+	require.Equal(t, 1, m.Put(`a`, 3))
+	require.Equal(t, vf.Values(3), m.GetAll(`a`))
+
+	v, ok := m.PutIfAbsent(`a`, 4)
+	require.False(t, ok)
+	require.Equal(t, 3, v)
+	v, ok = m.PutIfAbsent(`b`, 4)
+	require.True(t, ok)
+	require.Equal(t, 4, v)
+	require.Equal(t, 5, m.ComputeIfAbsent(`c`, func(dgo.Value) dgo.Value { return vf.Integer(5) }))
+	require.Equal(t, 5, m.ComputeIfAbsent(`c`, func(dgo.Value) dgo.Value { return vf.Integer(6) }))
+
+	m.PutAll(vf.Map(`a`, 7, `d`, 8))
+	require.Equal(t, vf.Values(7), m.GetAll(`a`))
+	require.Equal(t, vf.Values(8), m.GetAll(`d`))
+
+	o := vf.MultiMap()
+	o.AddAll(`a`, vf.Values(9, 10))
+	m.PutAll(o)
+	require.Equal(t, vf.Values(9, 10), m.GetAll(`a`))
+	o.Add(`a`, 11)
+	require.Equal(t, vf.Values(9, 10), m.GetAll(`a`))
+}
+
+func TestMultiMap_Remove(t *testing.T) {
+	m := vf.MultiMap()
+	require.Nil(t, m.Remove(`a`))
+	m.AddAll(`a`, vf.Values(1, 2))
+	m.Add(`b`, 3)
+	require.Equal(t, 1, m.Remove(`a`))
+	require.False(t, m.ContainsKey(`a`))
+	v, ok := m.GetAndDelete(`b`)
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	require.Equal(t, 0, m.Len())
+}
+
+func TestMultiMap_frozen(t *testing.T) {
+	m := vf.MultiMap()
+	m.AddAll(`a`, vf.Values(1, 2))
+	f := m.FrozenCopy().(dgo.MultiMap)
+	require.Same(t, f, f.FrozenCopy())
+	m.Add(`a`, 3)
+	require.Equal(t, vf.Values(1, 2), f.GetAll(`a`))
+
+	require.Panic(t, func() { f.Add(`a`, 3) }, `Add .* frozen`)
+	require.Panic(t, func() { f.AddAll(`a`, vf.Values(3)) }, `AddAll .* frozen`)
+	require.Panic(t, func() { f.Put(`a`, 3) }, `Put .* frozen`)
+	require.Panic(t, func() { f.PutAll(vf.Map(`a`, 3)) }, `PutAll .* frozen`)
+	require.Panic(t, func() { f.PutIfAbsent(`a`, 3) }, `PutIfAbsent .* frozen`)
+	require.Panic(t, func() { f.ComputeIfAbsent(`a`, nil) }, `ComputeIfAbsent .* frozen`)
+	require.Panic(t, func() { f.Remove(`a`) }, `Remove .* frozen`)
+	require.Panic(t, func() { f.GetAndDelete(`a`) }, `GetAndDelete .* frozen`)
+	require.True(t, errors.Is(util.Catch(func() { f.Add(`a`, 3) }), dgo.ErrFrozen))
+
+	c := f.ThawedCopy().(dgo.MultiMap)
+	c.Add(`a`, 3)
+	require.Equal(t, vf.Values(1, 2, 3), c.GetAll(`a`))
+	require.Equal(t, vf.Values(1, 2), f.GetAll(`a`))
+}
+
+func TestMultiMap_With(t *testing.T) {
+	m := vf.MultiMap()
+	m.Add(`a`, 1)
+	m.Freeze()
+
+	w := m.With(`a`, 2).(dgo.MultiMap)
+	require.True(t, w.Frozen())
+	require.Equal(t, vf.Values(1, 2), w.GetAll(`a`))
+	require.Equal(t, vf.Values(1), m.GetAll(`a`))
+
+	w = w.Merge(vf.Map(`b`, 3)).(dgo.MultiMap)
+	require.True(t, w.Frozen())
+	require.Equal(t, vf.Values(3), w.GetAll(`b`))
+	require.Same(t, w, w.Merge(vf.Map()))
+
+	require.Same(t, w, w.Without(`c`))
+	require.False(t, w.Without(`a`).ContainsKey(`a`))
+	require.Equal(t, 1, w.WithoutAll(vf.Values(`a`)).Len())
+	require.Same(t, w, w.WithoutAll(vf.Values()))
+
+	r := w.RenameKeys(vf.Map(`a`, `x`)).(dgo.MultiMap)
+	require.Equal(t, vf.Values(1, 2), r.GetAll(`x`))
+	require.True(t, r.Frozen())
+}
+
+func TestMultiMap_Equals(t *testing.T) {
+	a := vf.MultiMap()
+	a.AddAll(`a`, vf.Values(1, 2))
+	b := vf.MultiMap()
+	b.Add(`a`, 1)
+	require.NotEqual(t, a, b)
+	b.Add(`a`, 2)
+	require.Equal(t, a, b)
+	require.Equal(t, a.HashCode(), b.HashCode())
+	require.NotEqual(t, a, vf.Map(`a`, vf.Values(1, 2)))
+	require.NotEqual(t, vf.Map(`a`, vf.Values(1, 2)), a)
+	require.Instance(t, a.Type(), a)
+	require.Equal(t, `{"a":{1,2}}`, a.String())
+}
+
+func TestMultiMapType(t *testing.T) {
+	mt := tf.MultiMap(typ.String, typ.Integer)
+	require.Equal(t, `multimap[string]int`, mt.String())
+	require.Equal(t, typ.String, mt.KeyType())
+	require.Equal(t, typ.Integer, mt.ElementType())
+	require.Equal(t, tf.Array(typ.Integer, 1, math.MaxInt64), mt.ValueType())
+	require.Equal(t, 0, mt.Min())
+	require.Equal(t, math.MaxInt64, mt.Max())
+	require.True(t, mt.Unbounded())
+	require.Equal(t, `a multimap from strings to integers`, typ.Describe(mt))
+	require.Equal(t, reflect.TypeOf(map[string][]int64{}), mt.ReflectType())
+
+	m := vf.MultiMap()
+	m.AddAll(`a`, vf.Values(1, 2))
+	require.Instance(t, mt, m)
+	m.Add(`b`, `three`)
+	require.NotInstance(t, mt, m)
+	require.NotInstance(t, mt, vf.Map(`a`, vf.Values(1, 2)))
+
+	require.Assignable(t, mt, tf.MultiMap(typ.String, tf.Integer(0, 10, true)))
+	require.NotAssignable(t, mt, tf.MultiMap(typ.Any, typ.Integer))
+	require.NotAssignable(t, mt, tf.Map(typ.String, typ.Integer))
+	require.Assignable(t, typ.Any, mt)
+
+	require.Equal(t, mt, tf.MultiMap(typ.String, typ.Integer))
+	require.NotEqual(t, mt, tf.MultiMap(typ.String, typ.Float))
+	require.NotEqual(t, mt, tf.Map(typ.String, typ.Integer))
+	require.Equal(t, mt.HashCode(), tf.MultiMap(typ.String, typ.Integer).HashCode())
+	require.Equal(t, typ.Type.TypeIdentifier(), mt.Type().TypeIdentifier())
+	require.Equal(t, dgo.TiMultiMap, mt.TypeIdentifier())
+}
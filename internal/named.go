@@ -33,6 +33,8 @@ type (
 
 var namedTypes = sync.Map{}
 
+var namedAnnotations = sync.Map{}
+
 func defaultAsgChecker(t dgo.NamedType, other dgo.Type) bool {
 	if ot, ok := other.(dgo.NamedType); ok {
 		return ot.ReflectType().AssignableTo(t.AssignableType())
@@ -44,6 +46,13 @@ func defaultAsgChecker(t dgo.NamedType, other dgo.Type) bool {
 // testing purposes.
 func RemoveNamedType(name string) {
 	namedTypes.Delete(name)
+	namedAnnotations.Delete(name)
+}
+
+// AnnotateNamedType replaces the annotations of the given named type. The annotations are shared by all
+// parameterized and exact variants of the type.
+func AnnotateNamedType(namedType dgo.NamedType, annotations dgo.Map) {
+	namedAnnotations.Store(namedType.Name(), annotations.FrozenCopy())
 }
 
 // NewNamedType registers a new named and optionally parameterized type under the given name with the global type registry.
@@ -111,6 +120,13 @@ func NamedTypeFromReflected(rt reflect.Type) dgo.NamedType {
 	return t
 }
 
+func (t *named) Annotations() dgo.Map {
+	if a, ok := namedAnnotations.Load(t.name); ok {
+		return a.(dgo.Map)
+	}
+	return emptyMap
+}
+
 func (t *named) AssignableType() reflect.Type {
 	if t.ifdType != nil {
 		return t.ifdType
@@ -126,6 +142,10 @@ func (t *named) Assignable(other dgo.Type) bool {
 	return f(t, other) || CheckAssignableTo(nil, other, t)
 }
 
+func (t *named) Describe() string {
+	return Describe(t)
+}
+
 func (t *named) New(arg dgo.Value) dgo.Value {
 	return newNamed(t, arg)
 }
@@ -198,6 +218,10 @@ func (t *parameterized) Assignable(other dgo.Type) bool {
 	return f(t, other) || CheckAssignableTo(nil, other, t)
 }
 
+func (t *parameterized) Describe() string {
+	return Describe(t)
+}
+
 func (t *parameterized) Equals(other interface{}) bool {
 	if ot, ok := other.(*parameterized); ok {
 		return t.NamedType.Equals(ot.NamedType) && t.params.Equals(ot.params)
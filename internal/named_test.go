@@ -66,6 +66,20 @@ func TestNamedType(t *testing.T) {
 	require.Equal(t, tp.HashCode(), tp.HashCode())
 }
 
+func TestNamedType_Annotations(t *testing.T) {
+	defer tf.RemoveNamed(`testNamed`)
+	tp := tf.NewNamed(`testNamed`, nil, nil, reflect.TypeOf(testNamed(0)), nil, nil)
+	require.Equal(t, 0, tp.Annotations().Len())
+	tf.AnnotateNamed(tp, vf.Map(`description`, `a test type`))
+	require.Equal(t, vf.Map(`description`, `a test type`), tp.Annotations())
+	require.Equal(t, vf.Map(`description`, `a test type`), testNamed(3).Type().(dgo.Annotated).Annotations())
+	require.Equal(t, vf.Map(`description`, `a test type`), tf.Parameterized(tp, vf.Values(1)).Annotations())
+
+	tf.RemoveNamed(`testNamed`)
+	tp = tf.NewNamed(`testNamed`, nil, nil, reflect.TypeOf(testNamed(0)), nil, nil)
+	require.Equal(t, 0, tp.Annotations().Len())
+}
+
 func TestNamedType_redefined(t *testing.T) {
 	defer tf.RemoveNamed(`testNamed`)
 	tf.NewNamed(`testNamed`, nil, nil, reflect.TypeOf(testNamed(0)), nil, nil)
@@ -38,6 +38,10 @@ func (t *nativeType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *nativeType) Describe() string {
+	return Describe(t)
+}
+
 func (t *nativeType) Equals(other interface{}) bool {
 	if ot, ok := other.(*nativeType); ok {
 		return t.rt == ot.rt
@@ -59,6 +59,10 @@ func (t nilType) Assignable(ot dgo.Type) bool {
 	return ok || CheckAssignableTo(nil, ot, t)
 }
 
+func (t nilType) Describe() string {
+	return Describe(t)
+}
+
 func (t nilType) Equals(v interface{}) bool {
 	return t == v
 }
@@ -22,6 +22,10 @@ func NotType(t dgo.Type) dgo.Type {
 	return &notType{negated: t}
 }
 
+func (t *notType) Describe() string {
+	return Describe(t)
+}
+
 func (t *notType) Equals(other interface{}) bool {
 	if ot, ok := other.(*notType); ok {
 		return t.negated.Equals(ot.negated)
@@ -33,12 +37,22 @@ func (t *notType) HashCode() int {
 	return 1579 + t.negated.HashCode()
 }
 
+// Assignable returns true if the other type is proven to have no instance in common with the negated type. Types
+// that neither are assignable to the other may still overlap, so when disjointness cannot be proven, the answer
+// is false.
 func (t *notType) Assignable(other dgo.Type) bool {
 	switch ot := other.(type) {
 	case *notType:
 		// Reverse order of Negated test
 		return ot.negated.Assignable(t.negated)
 	case *anyOfType:
+		// The union is assignable when all of its types are
+		return t.assignableFromAll(ot.slice)
+	case *oneOfType:
+		// Values that match exactly one of the types are in the union of the types
+		return t.assignableFromAll(ot.slice)
+	case *allOfType:
+		// The intersection is assignable when one of its types is
 		ts := ot.slice
 		for i := range ts {
 			if t.Assignable(ts[i].(dgo.Type)) {
@@ -46,29 +60,123 @@ func (t *notType) Assignable(other dgo.Type) bool {
 			}
 		}
 		return false
+	}
+	switch nt := t.negated.(type) {
+	case *anyOfType:
+		// !(a|b) is the same as !a&!b
+		return allNegationsAssignable(nt.slice, other)
+	case *oneOfType:
+		// !(a^b) contains !a&!b
+		return allNegationsAssignable(nt.slice, other)
 	case *allOfType:
-		ts := ot.slice
+		// !(a&b) is the same as !a|!b
+		ts := nt.slice
 		for i := range ts {
-			if !t.Assignable(ts[i].(dgo.Type)) {
-				return false
+			if NotType(ts[i].(dgo.Type)).Assignable(other) {
+				return true
 			}
 		}
+		return false
+	}
+	return disjoint(t.negated, other)
+}
+
+// valueFamilies maps the identifiers of types whose instances all belong to one family of values to an identifier
+// for that family. Types from different families have no instances in common.
+var valueFamilies = map[dgo.TypeIdentifier]dgo.TypeIdentifier{
+	dgo.TiNil:           dgo.TiNil,
+	dgo.TiBoolean:       dgo.TiBoolean,
+	dgo.TiBooleanExact:  dgo.TiBoolean,
+	dgo.TiInteger:       dgo.TiInteger,
+	dgo.TiIntegerRange:  dgo.TiInteger,
+	dgo.TiIntegerExact:  dgo.TiInteger,
+	dgo.TiBigInt:        dgo.TiInteger,
+	dgo.TiBigIntExact:   dgo.TiInteger,
+	dgo.TiFloat:         dgo.TiFloat,
+	dgo.TiFloatRange:    dgo.TiFloat,
+	dgo.TiFloatExact:    dgo.TiFloat,
+	dgo.TiString:        dgo.TiString,
+	dgo.TiStringExact:   dgo.TiString,
+	dgo.TiStringSized:   dgo.TiString,
+	dgo.TiStringPattern: dgo.TiString,
+	dgo.TiCiString:      dgo.TiString,
+	dgo.TiDgoString:     dgo.TiString,
+	dgo.TiBase64String:  dgo.TiString,
+	dgo.TiHexString:     dgo.TiString,
+	dgo.TiBinary:        dgo.TiBinary,
+	dgo.TiBinaryExact:   dgo.TiBinary,
+	dgo.TiRegexp:        dgo.TiRegexp,
+	dgo.TiRegexpExact:   dgo.TiRegexp,
+	dgo.TiTime:          dgo.TiTime,
+	dgo.TiTimeRange:     dgo.TiTime,
+	dgo.TiTimeExact:     dgo.TiTime,
+	dgo.TiArray:         dgo.TiArray,
+	dgo.TiArrayExact:    dgo.TiArray,
+	dgo.TiTuple:         dgo.TiArray,
+	dgo.TiMap:           dgo.TiMap,
+	dgo.TiMapExact:      dgo.TiMap,
+	dgo.TiStruct:        dgo.TiMap,
+}
+
+// disjoint returns true when it can be proven that the two types have no instances in common. A false return
+// doesn't mean that the types overlap, only that it is unknown.
+func disjoint(a, b dgo.Type) bool {
+	if et, ok := b.(dgo.ExactType); ok && dgo.IsExact(b) {
+		return !a.Instance(et.ExactValue())
+	}
+	if et, ok := a.(dgo.ExactType); ok && dgo.IsExact(a) {
+		return !b.Instance(et.ExactValue())
+	}
+	fa, okA := valueFamilies[a.TypeIdentifier()]
+	fb, okB := valueFamilies[b.TypeIdentifier()]
+	if !(okA && okB) {
+		return false
+	}
+	if fa != fb {
 		return true
-	case *oneOfType:
-		f := false
-		ts := ot.slice
-		for i := range ts {
-			if t.Assignable(ts[i].(dgo.Type)) {
-				if f {
-					return false
-				}
-				f = true
-			}
+	}
+	switch a := a.(type) {
+	case *integerType:
+		if b, ok := b.(*integerType); ok {
+			return intRangeMax(a) < b.min || intRangeMax(b) < a.min
+		}
+	case *floatType:
+		if b, ok := b.(*floatType); ok {
+			return floatRangeBelow(a, b) || floatRangeBelow(b, a)
+		}
+	}
+	return false
+}
+
+// intRangeMax returns the largest instance of the given range
+func intRangeMax(t *integerType) int64 {
+	if t.inclusive {
+		return t.max
+	}
+	return t.max - 1
+}
+
+// floatRangeBelow returns true if all instances of a are less than all instances of b
+func floatRangeBelow(a, b *floatType) bool {
+	return a.max < b.min || a.max == b.min && !a.inclusive
+}
+
+func (t *notType) assignableFromAll(ts []dgo.Value) bool {
+	for i := range ts {
+		if !t.Assignable(ts[i].(dgo.Type)) {
+			return false
+		}
+	}
+	return true
+}
+
+func allNegationsAssignable(ts []dgo.Value, other dgo.Type) bool {
+	for i := range ts {
+		if !NotType(ts[i].(dgo.Type)).Assignable(other) {
+			return false
 		}
-		return f
-	default:
-		return !t.negated.Assignable(other)
 	}
+	return true
 }
 
 func (t *notType) Instance(value interface{}) bool {
@@ -1,6 +1,7 @@
 package internal_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/lyraproj/dgo/dgo"
@@ -27,13 +28,21 @@ func TestNotType(t *testing.T) {
 
 	require.Assignable(t, tf.Not(typ.Float), tf.AnyOf(typ.String, typ.Integer, typ.Boolean))
 
+	require.NotAssignable(t, tf.Not(typ.Float), tf.AnyOf(typ.String, typ.Float))
+
 	require.Assignable(t, tf.Not(typ.Float), tf.AllOf(typ.String, typ.Integer, typ.Boolean))
-	require.NotAssignable(t, tf.Not(typ.Float), tf.OneOf(typ.String, typ.Integer, typ.Boolean))
+	require.Assignable(t, tf.Not(typ.Float), tf.OneOf(typ.String, typ.Integer, typ.Boolean))
+
+	// The intersection is assignable when one of its types is
+	require.Assignable(t, tf.Not(typ.Float), tf.AllOf(typ.String, typ.Float))
+	require.NotAssignable(t, tf.Not(typ.Float), tf.AllOf(typ.Float, tf.Float(0, 1, true)))
 
-	require.NotAssignable(t, tf.Not(typ.Float), tf.AllOf(typ.String, typ.Float))
-	require.Assignable(t, tf.Not(typ.Float), tf.OneOf(typ.String, typ.Float))
+	// The values of a OneOf may be instances of any of its types
+	require.NotAssignable(t, tf.Not(typ.Float), tf.OneOf(typ.String, typ.Float))
 
-	require.Assignable(t, tf.Not(typ.String), tf.AnyOf(tf.Not(typ.String), tf.Not(typ.Integer)))
+	// !string|!int is the same as !(string&int) which contains all values
+	require.NotAssignable(t, tf.Not(typ.String), tf.AnyOf(tf.Not(typ.String), tf.Not(typ.Integer)))
+	require.Assignable(t, tf.Not(typ.String), tf.AnyOf(tf.Not(typ.String), tf.Not(typ.Any)))
 
 	require.NotAssignable(t, tf.Not(typ.Float), tf.AnyOf(tf.Not(typ.String), tf.Not(typ.Integer), tf.Not(typ.Boolean)))
 	require.NotAssignable(t, tf.Not(typ.Float), tf.OneOf(tf.Not(typ.String), tf.Not(typ.Integer), tf.Not(typ.Boolean)))
@@ -49,3 +58,60 @@ func TestNotType(t *testing.T) {
 
 	require.Equal(t, notNil.ReflectType(), typ.Any.ReflectType())
 }
+
+func TestNotType_overlap(t *testing.T) {
+	// Types that neither are assignable to the other may still have instances in common
+	require.NotAssignable(t, tf.Not(tf.Pattern(regexp.MustCompile(`^a`))), typ.String)
+	require.NotAssignable(t, tf.Not(typ.String), tf.Pattern(regexp.MustCompile(`^a`)))
+	require.NotAssignable(t, tf.Not(typ.String), typ.Any)
+	require.NotAssignable(t, tf.Not(typ.Integer), tf.Integer(0, 10, true))
+	require.Assignable(t, tf.Not(typ.Integer), typ.String)
+	require.Assignable(t, tf.Not(typ.String), typ.Nil)
+	require.NotAssignable(t, tf.Not(typ.Nil), typ.Any)
+	require.Assignable(t, tf.Not(tf.Integer(0, 10, true)), vf.Integer(11).Type())
+	require.NotAssignable(t, tf.Not(tf.Integer(0, 10, true)), vf.Integer(10).Type())
+
+	// Overlapping ranges
+	require.NotAssignable(t, tf.Not(tf.Integer(0, 10, true)), tf.Integer(5, 20, true))
+	require.Assignable(t, tf.Not(tf.Integer(0, 10, true)), tf.Integer(11, 20, true))
+	require.Assignable(t, tf.Not(tf.Integer(0, 10, false)), tf.Integer(10, 20, true))
+	require.NotAssignable(t, tf.Not(tf.Float(0, 10, true)), tf.Float(5, 20, true))
+	require.NotAssignable(t, tf.Not(tf.Float(0, 10, true)), tf.Float(10, 20, true))
+	require.Assignable(t, tf.Not(tf.Float(0, 10, false)), tf.Float(10, 20, true))
+
+	// Patterns may match the same strings
+	require.NotAssignable(t, tf.Not(tf.Pattern(regexp.MustCompile(`^a`))), tf.Pattern(regexp.MustCompile(`b$`)))
+	require.Assignable(t, tf.Not(tf.Pattern(regexp.MustCompile(`^a`))), vf.String(`b`).Type())
+	require.Assignable(t, tf.Not(tf.Pattern(regexp.MustCompile(`^a`))), typ.Integer)
+}
+
+func TestNotType_negatedTernary(t *testing.T) {
+	// !(a|b) is the same as !a&!b
+	ne := tf.Not(tf.AnyOf(vf.String(`a`).Type(), vf.String(`b`).Type()))
+	require.Assignable(t, ne, vf.String(`c`).Type())
+	require.NotAssignable(t, ne, vf.String(`b`).Type())
+	require.Assignable(t, ne, typ.Integer)
+	require.NotAssignable(t, ne, typ.String)
+
+	// !(a&b) is the same as !a|!b
+	na := tf.Not(tf.AllOf(typ.String, tf.String(3, 5)))
+	require.Assignable(t, na, typ.Integer)
+	require.Assignable(t, na, vf.String(`ab`).Type())
+	require.NotAssignable(t, na, vf.String(`abc`).Type())
+
+	// !(a^b) contains !a&!b
+	no := tf.Not(tf.OneOf(typ.String, typ.Integer))
+	require.Assignable(t, no, typ.Float)
+	require.NotAssignable(t, no, typ.Integer)
+}
+
+func TestNotType_enumExclusion(t *testing.T) {
+	tp := tf.ParseType(`string&!("admin"|"root")`)
+	require.Instance(t, tp, `alice`)
+	require.NotInstance(t, tp, `root`)
+	require.NotInstance(t, tp, 3)
+	require.Assignable(t, tp, vf.String(`alice`).Type())
+	require.NotAssignable(t, tp, vf.String(`admin`).Type())
+	require.NotAssignable(t, tp, typ.String)
+	require.Equal(t, tp, tf.ParseType(`string&not["admin"|"root"]`))
+}
@@ -0,0 +1,50 @@
+package internal
+
+import "github.com/lyraproj/dgo/dgo"
+
+//go:generate go run predicategen.go
+
+func isExactType(t dgo.Type) bool {
+	_, ok := t.(dgo.ExactType)
+	return ok
+}
+
+func isArrayType(t dgo.Type) bool {
+	_, ok := t.(dgo.ArrayType)
+	return ok
+}
+
+func isMapType(t dgo.Type) bool {
+	_, ok := t.(dgo.MapType)
+	return ok
+}
+
+// isStringType checks the type identifier instead of the dgo.StringType interface, because array and map types
+// implement that interface too.
+func isStringType(t dgo.Type) bool {
+	switch t.TypeIdentifier() {
+	case dgo.TiString, dgo.TiStringExact, dgo.TiStringSized, dgo.TiStringPattern, dgo.TiCiString, dgo.TiDgoString,
+		dgo.TiBase64String, dgo.TiHexString:
+		return true
+	}
+	return false
+}
+
+func isIntegerType(t dgo.Type) bool {
+	_, ok := t.(dgo.IntegerType)
+	return ok
+}
+
+func isFloatType(t dgo.Type) bool {
+	_, ok := t.(dgo.FloatType)
+	return ok
+}
+
+func isBooleanType(t dgo.Type) bool {
+	_, ok := t.(dgo.BooleanType)
+	return ok
+}
+
+func isNilType(t dgo.Type) bool {
+	return t.TypeIdentifier() == dgo.TiNil
+}
@@ -0,0 +1,117 @@
+//go:build ignore
+// +build ignore
+
+// This program generates predicate_methods.go, which adds the predicate methods of dgo.Type, such as IsArray and
+// IsExact, to every type implementation of this package. Run it using go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+)
+
+// predicates maps the name of each predicate method to the function that implements it
+var predicates = [][2]string{
+	{`IsExact`, `isExactType`},
+	{`IsArray`, `isArrayType`},
+	{`IsMap`, `isMapType`},
+	{`IsString`, `isStringType`},
+	{`IsInteger`, `isIntegerType`},
+	{`IsFloat`, `isFloatType`},
+	{`IsBoolean`, `isBooleanType`},
+	{`IsNil`, `isNilType`},
+}
+
+// types are the receivers of the predicate methods. A leading asterisk denotes a pointer receiver.
+var types = []string{
+	`anyType`,
+	`bigIntType`,
+	`booleanType`,
+	`defaultArrayType`,
+	`defaultDecimalType`,
+	`defaultDgoStringType`,
+	`defaultFloatType`,
+	`defaultIntegerType`,
+	`defaultMapType`,
+	`defaultSemVerRangeType`,
+	`defaultSemVerType`,
+	`defaultStringType`,
+	`defaultURIType`,
+	`durationType`,
+	`errType`,
+	`exactFunctionType`,
+	`nilType`,
+	`regexpType`,
+	`timeType`,
+	`*alias`,
+	`*allOfType`,
+	`*allOfValueType`,
+	`*anyOfType`,
+	`*binaryType`,
+	`*ciStringType`,
+	`*cidrType`,
+	`*conditionalStructType`,
+	`*decimalType`,
+	`*durationRangeType`,
+	`*encodedStringType`,
+	`*exactArrayType`,
+	`*exactBigIntType`,
+	`*exactBinaryType`,
+	`*exactBooleanType`,
+	`*exactCIDRType`,
+	`*exactDecimalType`,
+	`*exactDurationType`,
+	`*exactEntryType`,
+	`*exactErrorType`,
+	`*exactFloatType`,
+	`*exactFunctionTuple`,
+	`*exactIPType`,
+	`*exactIntegerType`,
+	`*exactMapType`,
+	`*exactNamed`,
+	`*exactRegexpType`,
+	`*exactSemVerRangeType`,
+	`*exactSemVerType`,
+	`*exactStringType`,
+	`*exactTimeType`,
+	`*exactURIType`,
+	`*floatType`,
+	`*functionType`,
+	`*integerType`,
+	`*ipType`,
+	`*metaType`,
+	`*multiMapType`,
+	`*named`,
+	`*nativeType`,
+	`*notType`,
+	`*oneOfType`,
+	`*patternType`,
+	`*semVerConstraintType`,
+	`*sensitiveType`,
+	`*sizedArrayType`,
+	`*sizedMapType`,
+	`*sizedStringType`,
+	`*structType`,
+	`*timeRangeType`,
+	`*tupleType`,
+	`*uriType`,
+}
+
+func main() {
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by predicategen.go. DO NOT EDIT.\n\npackage internal\n")
+	for _, t := range types {
+		for _, p := range predicates {
+			fmt.Fprintf(b, "\nfunc (t %s) %s() bool { return %s(t) }\n", t, p[0], p[1])
+		}
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err = ioutil.WriteFile(`predicate_methods.go`, src, 0644); err != nil {
+		panic(err)
+	}
+}
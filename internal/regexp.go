@@ -35,6 +35,10 @@ func (t regexpType) Assignable(ot dgo.Type) bool {
 	return CheckAssignableTo(nil, ot, t)
 }
 
+func (t regexpType) Describe() string {
+	return Describe(t)
+}
+
 func (t regexpType) Equals(v interface{}) bool {
 	return t == v
 }
@@ -0,0 +1,480 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// semVer is a semantic version as defined by https://semver.org
+	semVer struct {
+		major      int
+		minor      int
+		patch      int
+		prerelease []string
+		build      string
+	}
+
+	defaultSemVerType int
+
+	// semVerConstraintType matches versions that are included in a semantic version range
+	semVerConstraintType struct {
+		rng *semVerRange
+	}
+
+	exactSemVerType struct {
+		exactType
+		value *semVer
+	}
+)
+
+// DefaultSemVerType is the type of all semantic versions and all strings that are valid semantic versions
+const DefaultSemVerType = defaultSemVerType(0)
+
+var reflectSemVerType = reflect.TypeOf((*dgo.SemVer)(nil)).Elem()
+
+// minSemVer is the lowest possible version, 0.0.0-0
+var minSemVer = &semVer{prerelease: []string{`0`}}
+
+// SemVer returns the given string as a dgo.SemVer. The function panics if the string is not a valid semantic
+// version.
+func SemVer(s string) dgo.SemVer {
+	v, ok := parseSemVer(s)
+	if !ok {
+		panic(fmt.Errorf(`'%s' is not a valid semantic version`, s))
+	}
+	return v
+}
+
+// SemVerType returns a dgo.SemVerType that matches the versions included in the given range expression
+func SemVerType(rangeExpr string) dgo.SemVerType {
+	return semVerTypeFromRange(SemVerRange(rangeExpr).(*semVerRange))
+}
+
+func semVerTypeFromRange(r *semVerRange) dgo.SemVerType {
+	if r.matchesAll() {
+		return DefaultSemVerType
+	}
+	if _, ok := parseSemVer(r.expr); ok {
+		// A plain version would be mistaken for the exact type when the type string is parsed
+		r = &semVerRange{expr: `=` + r.expr, intervals: r.intervals}
+	}
+	return &semVerConstraintType{rng: r}
+}
+
+// SemVerTypeFromArgs returns a dgo.SemVerType created from the given arguments. No arguments gives the
+// unconstrained type. A SemVer or a string that is a valid semantic version gives the exact type for that version,
+// and a SemVerRange or any other string gives a type that matches the versions included in that range.
+func SemVerTypeFromArgs(args []interface{}) dgo.SemVerType {
+	switch len(args) {
+	case 0:
+		return DefaultSemVerType
+	case 1:
+		switch a0 := Value(args[0]).(type) {
+		case *semVer:
+			return a0.Type().(dgo.SemVerType)
+		case *semVerRange:
+			return semVerTypeFromRange(a0)
+		case dgo.String:
+			if v, ok := parseSemVer(a0.GoString()); ok {
+				return v.Type().(dgo.SemVerType)
+			}
+			return SemVerType(a0.GoString())
+		}
+		panic(illegalArgument(`SemVerType`, `SemVer, SemVerRange, or String`, args, 0))
+	}
+	panic(illegalArgumentCount(`SemVerType`, 0, 1, len(args)))
+}
+
+// parseSemVer parses a strict semantic version
+func parseSemVer(s string) (*semVer, bool) {
+	p, ok := parsePartialSemVer(s)
+	if !ok || p.n < 3 {
+		return nil, false
+	}
+	return p.v, true
+}
+
+// partialSemVer is a version where only the n first numeric parts are given, e.g. "1.2" or "1.x".
+type partialSemVer struct {
+	v *semVer
+	n int
+}
+
+func parsePartialSemVer(s string) (*partialSemVer, bool) {
+	v := &semVer{}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.build = s[i+1:]
+		s = s[:i]
+		if !validIdentifiers(strings.Split(v.build, `.`), false) {
+			return nil, false
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.prerelease = strings.Split(s[i+1:], `.`)
+		s = s[:i]
+		if !validIdentifiers(v.prerelease, true) {
+			return nil, false
+		}
+	}
+	parts := strings.Split(s, `.`)
+	if len(parts) > 3 {
+		return nil, false
+	}
+	n := 0
+	nums := [3]int{}
+	for i, part := range parts {
+		if part == `x` || part == `X` || part == `*` {
+			continue
+		}
+		if n < i {
+			// a numeric part after a wildcard
+			return nil, false
+		}
+		num, ok := numericIdentifier(part)
+		if !ok {
+			return nil, false
+		}
+		nums[i] = num
+		n++
+	}
+	if n < 3 && (v.prerelease != nil || v.build != ``) {
+		return nil, false
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return &partialSemVer{v: v, n: n}, true
+}
+
+func numericIdentifier(s string) (int, bool) {
+	if s == `` || len(s) > 1 && s[0] == '0' {
+		return 0, false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func validIdentifiers(ids []string, noLeadingZero bool) bool {
+	for _, id := range ids {
+		if id == `` {
+			return false
+		}
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if numeric && noLeadingZero && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareSemVer compares two versions according to the precedence rules of semantic versioning. Build metadata
+// is ignored.
+func compareSemVer(a, b *semVer) int {
+	if c := compareInts(a.major, b.major); c != 0 {
+		return c
+	}
+	if c := compareInts(a.minor, b.minor); c != 0 {
+		return c
+	}
+	if c := compareInts(a.patch, b.patch); c != 0 {
+		return c
+	}
+	// A version without a prerelease has higher precedence than one with a prerelease
+	switch {
+	case len(a.prerelease) == 0:
+		if len(b.prerelease) == 0 {
+			return 0
+		}
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+	for i, ai := range a.prerelease {
+		if i >= len(b.prerelease) {
+			return 1
+		}
+		if c := comparePrereleaseIdentifiers(ai, b.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	if len(a.prerelease) < len(b.prerelease) {
+		return -1
+	}
+	return 0
+}
+
+func comparePrereleaseIdentifiers(a, b string) int {
+	an, aNum := numericIdentifier(a)
+	bn, bNum := numericIdentifier(b)
+	switch {
+	case aNum && bNum:
+		return compareInts(an, bn)
+	case aNum:
+		// numeric identifiers have lower precedence than alphanumeric identifiers
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (t defaultSemVerType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case defaultSemVerType, *semVerConstraintType, *exactSemVerType:
+		return true
+	case *exactStringType:
+		return t.Instance(ot.value)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t defaultSemVerType) Describe() string {
+	return Describe(t)
+}
+
+func (t defaultSemVerType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t defaultSemVerType) HashCode() int {
+	return int(dgo.TiSemVer)
+}
+
+func (t defaultSemVerType) Instance(value interface{}) bool {
+	_, ok := toSemVer(value)
+	return ok
+}
+
+func (t defaultSemVerType) New(arg dgo.Value) dgo.Value {
+	return newSemVer(t, arg)
+}
+
+func (t defaultSemVerType) Range() dgo.SemVerRange {
+	return SemVerRange(`*`)
+}
+
+func (t defaultSemVerType) ReflectType() reflect.Type {
+	return reflectSemVerType
+}
+
+func (t defaultSemVerType) String() string {
+	return TypeString(t)
+}
+
+func (t defaultSemVerType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t defaultSemVerType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiSemVer
+}
+
+func (t *semVerConstraintType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactSemVerType:
+		return t.rng.includes(ot.value)
+	case *exactStringType:
+		return t.Instance(ot.value)
+	case *semVerConstraintType:
+		return t.rng.covers(ot.rng)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *semVerConstraintType) Describe() string {
+	return Describe(t)
+}
+
+func (t *semVerConstraintType) Equals(other interface{}) bool {
+	if ot, ok := other.(*semVerConstraintType); ok {
+		return t.rng.Equals(ot.rng)
+	}
+	return false
+}
+
+func (t *semVerConstraintType) HashCode() int {
+	return int(dgo.TiSemVerConstraint)*31 + t.rng.HashCode()
+}
+
+func (t *semVerConstraintType) Instance(value interface{}) bool {
+	v, ok := toSemVer(value)
+	return ok && t.rng.includes(v)
+}
+
+func (t *semVerConstraintType) New(arg dgo.Value) dgo.Value {
+	return newSemVer(t, arg)
+}
+
+func (t *semVerConstraintType) Range() dgo.SemVerRange {
+	return t.rng
+}
+
+func (t *semVerConstraintType) ReflectType() reflect.Type {
+	return reflectSemVerType
+}
+
+func (t *semVerConstraintType) String() string {
+	return TypeString(t)
+}
+
+func (t *semVerConstraintType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *semVerConstraintType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiSemVerConstraint
+}
+
+func (t *exactSemVerType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactSemVerType) Generic() dgo.Type {
+	return DefaultSemVerType
+}
+
+func (t *exactSemVerType) New(arg dgo.Value) dgo.Value {
+	return newSemVer(t, arg)
+}
+
+// Range returns the narrowest range that includes the version of this type. Such a range will also include
+// versions that only differ in build metadata.
+func (t *exactSemVerType) Range() dgo.SemVerRange {
+	return SemVerRange(`=` + t.value.String())
+}
+
+func (t *exactSemVerType) ReflectType() reflect.Type {
+	return reflectSemVerType
+}
+
+func (t *exactSemVerType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiSemVerExact
+}
+
+// toSemVer returns the given value as a *semVer and true or nil and false if the value is neither a version nor a
+// string that is a valid semantic version.
+func toSemVer(value interface{}) (*semVer, bool) {
+	switch v := value.(type) {
+	case *semVer:
+		return v, true
+	case *hstring:
+		return parseSemVer(v.s)
+	case string:
+		return parseSemVer(v)
+	}
+	return nil, false
+}
+
+func newSemVer(t dgo.Type, arg dgo.Value) dgo.SemVer {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`semver`, 1, 1)
+		arg = args.Get(0)
+	}
+	var sv dgo.SemVer
+	switch arg := arg.(type) {
+	case dgo.SemVer:
+		sv = arg
+	case dgo.String:
+		sv = SemVer(arg.GoString())
+	default:
+		panic(illegalArgument(`semver`, `semver|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(sv) {
+		panic(IllegalAssignment(t, sv))
+	}
+	return sv
+}
+
+func (v *semVer) Build() string {
+	return v.build
+}
+
+func (v *semVer) CompareTo(other interface{}) (int, bool) {
+	switch ov := other.(type) {
+	case *semVer:
+		return compareSemVer(v, ov), true
+	case nil, nilValue:
+		return 1, true
+	}
+	return 0, false
+}
+
+func (v *semVer) Equals(other interface{}) bool {
+	if ov, ok := other.(*semVer); ok {
+		return compareSemVer(v, ov) == 0 && v.build == ov.build
+	}
+	return false
+}
+
+func (v *semVer) HashCode() int {
+	return util.StringHash(v.String())
+}
+
+func (v *semVer) Major() int {
+	return v.major
+}
+
+func (v *semVer) Minor() int {
+	return v.minor
+}
+
+func (v *semVer) Patch() int {
+	return v.patch
+}
+
+func (v *semVer) Prerelease() string {
+	return strings.Join(v.prerelease, `.`)
+}
+
+func (v *semVer) ReflectTo(value reflect.Value) {
+	if value.Kind() == reflect.String {
+		value.SetString(v.String())
+	} else {
+		value.Set(reflect.ValueOf(v))
+	}
+}
+
+func (v *semVer) String() string {
+	s := strconv.Itoa(v.major) + `.` + strconv.Itoa(v.minor) + `.` + strconv.Itoa(v.patch)
+	if len(v.prerelease) > 0 {
+		s += `-` + v.Prerelease()
+	}
+	if v.build != `` {
+		s += `+` + v.build
+	}
+	return s
+}
+
+func (v *semVer) Type() dgo.Type {
+	et := &exactSemVerType{value: v}
+	et.ExactType = et
+	return et
+}
@@ -0,0 +1,169 @@
+package internal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestSemVerDefault(t *testing.T) {
+	tp := typ.SemVer
+	require.Instance(t, tp, vf.SemVer(`1.2.3`))
+	require.Instance(t, tp, `1.2.3-alpha.1+build.5`)
+	require.Instance(t, tp, vf.String(`0.0.0`))
+	require.NotInstance(t, tp, `1.2`)
+	require.NotInstance(t, tp, `01.2.3`)
+	require.NotInstance(t, tp, 3)
+
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, vf.SemVer(`1.2.3`).Type())
+	require.Assignable(t, tp, tf.SemVer(`^1.2.0`))
+	require.Assignable(t, tp, vf.String(`1.2.3`).Type())
+	require.NotAssignable(t, tp, vf.String(`1.2`).Type())
+	require.NotAssignable(t, tp, typ.String)
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.String)
+	require.Equal(t, tp.HashCode(), tp.HashCode())
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `semver`, tp.String())
+	require.Equal(t, `*`, tp.Range().String())
+	require.Same(t, tp, typ.Generic(vf.SemVer(`1.2.3`).Type()))
+	require.Same(t, tp, tf.SemVer(`*`))
+	require.Same(t, tp, tf.SemVer(`>=1.0.0 || *`))
+	require.Equal(t, reflect.TypeOf((*dgo.SemVer)(nil)).Elem(), tp.ReflectType())
+}
+
+func TestSemVerExact(t *testing.T) {
+	v := vf.SemVer(`1.2.3-rc.1+b5`)
+	tp := v.Type().(dgo.SemVerType)
+	require.Instance(t, tp, v)
+	require.NotInstance(t, tp, vf.SemVer(`1.2.3-rc.1`))
+	require.Assignable(t, tp, tp)
+	require.NotAssignable(t, tp, typ.SemVer)
+	require.Equal(t, tp, vf.SemVer(`1.2.3-rc.1+b5`).Type())
+	require.NotEqual(t, tp, vf.SemVer(`1.2.3`).Type())
+	require.Equal(t, `semver["1.2.3-rc.1+b5"]`, tp.String())
+	require.True(t, tp.Range().Includes(v))
+	require.True(t, tp.Range().Includes(vf.SemVer(`1.2.3-rc.1`)))
+	require.False(t, tp.Range().Includes(vf.SemVer(`1.2.3`)))
+	require.Equal(t, typ.SemVer.ReflectType(), tp.ReflectType())
+}
+
+func TestSemVerConstraint(t *testing.T) {
+	tp := tf.SemVer(`^1.2.0`)
+	require.Instance(t, tp, vf.SemVer(`1.2.0`))
+	require.Instance(t, tp, vf.SemVer(`1.9.9`))
+	require.Instance(t, tp, `1.3.0`)
+	require.NotInstance(t, tp, vf.SemVer(`1.1.9`))
+	require.NotInstance(t, tp, vf.SemVer(`2.0.0`))
+	require.NotInstance(t, tp, vf.SemVer(`2.0.0-alpha`))
+	require.NotInstance(t, tp, `1.3`)
+	require.NotInstance(t, tp, 1)
+
+	require.Assignable(t, typ.SemVer, tp)
+	require.NotAssignable(t, tp, typ.SemVer)
+	require.Assignable(t, tp, vf.SemVer(`1.5.0`).Type())
+	require.NotAssignable(t, tp, vf.SemVer(`2.5.0`).Type())
+	require.Assignable(t, tp, vf.String(`1.5.0`).Type())
+	require.NotAssignable(t, tp, vf.String(`2.5.0`).Type())
+	require.Assignable(t, tp, tf.SemVer(`~1.4`))
+	require.Assignable(t, tp, tf.SemVer(`1.3 - 1.5 || 1.7.x`))
+	require.NotAssignable(t, tp, tf.SemVer(`>=1.4`))
+	require.NotAssignable(t, tp, tf.SemVer(`^1.1.0`))
+	require.NotAssignable(t, tp, typ.String)
+
+	require.Equal(t, tp, tf.SemVer(`>=1.2.0 <2.0.0-0`))
+	require.Equal(t, tp, tf.SemVer(`^1.2`))
+	require.NotEqual(t, tp, tf.SemVer(`^1.2.1`))
+	require.NotEqual(t, tp, typ.SemVer)
+	require.Equal(t, tp.HashCode(), tf.SemVer(`^1.2`).HashCode())
+	require.NotEqual(t, tp.HashCode(), tf.SemVer(`^1.2.1`).HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, typ.SemVer.ReflectType(), tp.ReflectType())
+	require.Equal(t, `semver["^1.2.0"]`, tp.String())
+	require.Equal(t, `semver["=1.2.3"]`, tf.SemVer(`1.2.3`).String())
+
+	require.Panic(t, func() { tf.SemVer(`^1.2.x.4`) }, `'\^1\.2\.x\.4' is not a valid semantic version range`)
+}
+
+func TestSemVerType_New(t *testing.T) {
+	v := vf.SemVer(`1.2.3`)
+	require.Same(t, v, vf.New(typ.SemVer, v))
+	require.Equal(t, v, vf.New(typ.SemVer, vf.Arguments(v)))
+	require.Equal(t, v, vf.New(typ.SemVer, vf.String(`1.2.3`)))
+	require.Equal(t, v, vf.New(v.Type(), vf.String(`1.2.3`)))
+	require.Equal(t, v, vf.New(tf.SemVer(`1.x`), vf.String(`1.2.3`)))
+
+	require.Panic(t, func() { vf.New(tf.SemVer(`2.x`), v) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(typ.SemVer, vf.String(`1.2`)) }, `not a valid semantic version`)
+	require.Panic(t, func() { vf.New(typ.SemVer, vf.True) }, `illegal argument`)
+}
+
+func TestSemVer(t *testing.T) {
+	v := vf.SemVer(`1.22.333-alpha.1+build.007`)
+	require.Equal(t, 1, v.Major())
+	require.Equal(t, 22, v.Minor())
+	require.Equal(t, 333, v.Patch())
+	require.Equal(t, `alpha.1`, v.Prerelease())
+	require.Equal(t, `build.007`, v.Build())
+	require.Equal(t, `1.22.333-alpha.1+build.007`, v.String())
+	require.Equal(t, v, vf.SemVer(`1.22.333-alpha.1+build.007`))
+	require.NotEqual(t, v, vf.SemVer(`1.22.333-alpha.1`))
+	require.NotEqual(t, v, `1.22.333-alpha.1+build.007`)
+	require.Equal(t, v.HashCode(), vf.SemVer(`1.22.333-alpha.1+build.007`).HashCode())
+
+	// precedence as defined by semver.org
+	ordered := []string{`1.0.0-alpha`, `1.0.0-alpha.1`, `1.0.0-alpha.beta`, `1.0.0-beta`, `1.0.0-beta.2`,
+		`1.0.0-beta.11`, `1.0.0-rc.1`, `1.0.0`, `1.0.1`, `1.1.0`, `2.0.0`}
+	for i := 1; i < len(ordered); i++ {
+		c, ok := vf.SemVer(ordered[i-1]).CompareTo(vf.SemVer(ordered[i]))
+		require.True(t, ok)
+		require.Equal(t, -1, c)
+		c, _ = vf.SemVer(ordered[i]).CompareTo(vf.SemVer(ordered[i-1]))
+		require.Equal(t, 1, c)
+	}
+	c, ok := vf.SemVer(`1.0.0+a`).CompareTo(vf.SemVer(`1.0.0+b`))
+	require.True(t, ok)
+	require.Equal(t, 0, c)
+	c, ok = v.CompareTo(vf.Nil)
+	require.True(t, ok)
+	require.Equal(t, 1, c)
+	_, ok = v.CompareTo(`1.0.0`)
+	require.False(t, ok)
+
+	for _, s := range []string{``, `1`, `1.2`, `1.2.3.4`, `01.2.3`, `1.02.3`, `1.2.03`, `1.2.3-`, `1.2.3-01`,
+		`1.2.3-a..b`, `1.2.3+`, `1.2.3+a_b`, `a.b.c`, `1.x.3`, `v1.2.3`} {
+		require.Panic(t, func() { vf.SemVer(s) }, `is not a valid semantic version`)
+	}
+}
+
+func TestSemVer_ReflectTo(t *testing.T) {
+	v := vf.SemVer(`1.2.3`)
+
+	var s string
+	vf.ReflectTo(v, reflect.ValueOf(&s).Elem())
+	require.Equal(t, `1.2.3`, s)
+
+	var sv dgo.SemVer
+	vf.ReflectTo(v, reflect.ValueOf(&sv).Elem())
+	require.Same(t, v, sv)
+
+	var mi interface{}
+	vf.ReflectTo(v, reflect.ValueOf(&mi).Elem())
+	require.Same(t, v, mi)
+}
+
+func TestSemVer_mapValidation(t *testing.T) {
+	mt := tf.ParseType(`{name:string,version:semver["^1.2.0"]}`)
+	require.Instance(t, mt, vf.Map(`name`, `a`, `version`, `1.4.2`))
+	require.Instance(t, mt, vf.Map(`name`, `a`, `version`, vf.SemVer(`1.4.2`)))
+	require.NotInstance(t, mt, vf.Map(`name`, `a`, `version`, `2.0.0`))
+	require.NotInstance(t, mt, vf.Map(`name`, `a`, `version`, `latest`))
+}
@@ -0,0 +1,496 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// semVerRange is a union of version intervals parsed from a range expression such as ">=1.2.0 <2.0.0 || ^3.1"
+	semVerRange struct {
+		expr      string
+		intervals []*semVerInterval
+	}
+
+	// semVerInterval is a version interval. A nil bound means that the interval is unbounded in that direction.
+	semVerInterval struct {
+		lo     *semVer
+		hi     *semVer
+		loIncl bool
+		hiIncl bool
+	}
+
+	defaultSemVerRangeType int
+
+	exactSemVerRangeType struct {
+		exactType
+		value *semVerRange
+	}
+)
+
+// DefaultSemVerRangeType is the type of all semantic version ranges and all strings that are valid range expressions
+const DefaultSemVerRangeType = defaultSemVerRangeType(0)
+
+var reflectSemVerRangeType = reflect.TypeOf((*dgo.SemVerRange)(nil)).Elem()
+
+// SemVerRange returns the given range expression as a dgo.SemVerRange. The expression uses the same syntax as npm,
+// i.e. one or more sets of comparators separated by "||" where each set is either whitespace separated comparators
+// such as ">=1.2.0 <2.0.0", "~1.2", "^1.2.3", "1.x", or a hyphen range such as "1.2 - 2". The function panics if
+// the expression cannot be parsed.
+func SemVerRange(s string) dgo.SemVerRange {
+	r, ok := parseSemVerRange(s)
+	if !ok {
+		panic(fmt.Errorf(`'%s' is not a valid semantic version range`, s))
+	}
+	return r
+}
+
+func parseSemVerRange(s string) (*semVerRange, bool) {
+	r := &semVerRange{expr: strings.TrimSpace(s)}
+	for _, set := range strings.Split(s, `||`) {
+		iv := &semVerInterval{}
+		fields := strings.Fields(set)
+		if len(fields) == 3 && fields[1] == `-` {
+			if !iv.restrictHyphen(fields[0], fields[2]) {
+				return nil, false
+			}
+		} else {
+			for i := 0; i < len(fields); i++ {
+				c := fields[i]
+				if strings.Trim(c, `<>=~^`) == `` && i+1 < len(fields) {
+					// allow whitespace between the operator and the version
+					i++
+					c += fields[i]
+				}
+				if !iv.restrict(c) {
+					return nil, false
+				}
+			}
+		}
+		if !iv.empty() {
+			r.intervals = append(r.intervals, iv)
+		}
+	}
+	return r, true
+}
+
+// upperSemVer returns the version major.minor.patch-0 which is used as an exclusive upper bound that excludes all
+// prereleases of major.minor.patch
+func upperSemVer(major, minor, patch int) *semVer {
+	return &semVer{major: major, minor: minor, patch: patch, prerelease: []string{`0`}}
+}
+
+// bump returns the version that follows all versions matched by the partial version p, or nil if p matches all
+// versions
+func (p *partialSemVer) bump() *semVer {
+	switch p.n {
+	case 0:
+		return nil
+	case 1:
+		return upperSemVer(p.v.major+1, 0, 0)
+	case 2:
+		return upperSemVer(p.v.major, p.v.minor+1, 0)
+	}
+	return upperSemVer(p.v.major, p.v.minor, p.v.patch+1)
+}
+
+func splitSemVerOperator(c string) (string, string) {
+	i := 0
+	for i < len(c) && strings.IndexByte(`<>=~^`, c[i]) >= 0 {
+		i++
+	}
+	op := c[:i]
+	vs := c[i:]
+	if strings.HasPrefix(vs, `v`) || strings.HasPrefix(vs, `V`) {
+		vs = vs[1:]
+	}
+	return op, vs
+}
+
+// restrict narrows the interval using the given comparator
+func (iv *semVerInterval) restrict(c string) bool {
+	op, vs := splitSemVerOperator(c)
+	p, ok := parsePartialSemVer(vs)
+	if !ok {
+		return false
+	}
+	v := p.v
+	switch op {
+	case ``, `=`:
+		if p.n == 3 {
+			iv.raiseLow(v, true)
+			iv.lowerHigh(v, true)
+		} else if p.n > 0 {
+			iv.raiseLow(v, true)
+			iv.lowerHigh(p.bump(), false)
+		}
+	case `>`:
+		switch p.n {
+		case 0:
+			iv.none()
+		case 3:
+			iv.raiseLow(v, false)
+		default:
+			b := p.bump()
+			b.prerelease = nil
+			iv.raiseLow(b, true)
+		}
+	case `>=`:
+		if p.n > 0 {
+			iv.raiseLow(v, true)
+		}
+	case `<`:
+		switch p.n {
+		case 0:
+			iv.none()
+		case 3:
+			iv.lowerHigh(v, false)
+		default:
+			iv.lowerHigh(upperSemVer(v.major, v.minor, 0), false)
+		}
+	case `<=`:
+		switch p.n {
+		case 0:
+		case 3:
+			iv.lowerHigh(v, true)
+		default:
+			iv.lowerHigh(p.bump(), false)
+		}
+	case `~`:
+		if p.n > 0 {
+			iv.raiseLow(v, true)
+			if p.n == 1 {
+				iv.lowerHigh(upperSemVer(v.major+1, 0, 0), false)
+			} else {
+				iv.lowerHigh(upperSemVer(v.major, v.minor+1, 0), false)
+			}
+		}
+	case `^`:
+		if p.n > 0 {
+			iv.raiseLow(v, true)
+			switch {
+			case v.major > 0 || p.n == 1:
+				iv.lowerHigh(upperSemVer(v.major+1, 0, 0), false)
+			case v.minor > 0 || p.n == 2:
+				iv.lowerHigh(upperSemVer(0, v.minor+1, 0), false)
+			default:
+				iv.lowerHigh(upperSemVer(0, 0, v.patch+1), false)
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// restrictHyphen narrows the interval using the inclusive hyphen range "lo - hi"
+func (iv *semVerInterval) restrictHyphen(lo, hi string) bool {
+	lp, ok := parsePartialSemVer(lo)
+	if !ok {
+		return false
+	}
+	hp, ok := parsePartialSemVer(hi)
+	if !ok {
+		return false
+	}
+	if lp.n > 0 {
+		iv.raiseLow(lp.v, true)
+	}
+	switch hp.n {
+	case 0:
+	case 3:
+		iv.lowerHigh(hp.v, true)
+	default:
+		iv.lowerHigh(hp.bump(), false)
+	}
+	return true
+}
+
+func (iv *semVerInterval) raiseLow(v *semVer, incl bool) {
+	if iv.lo != nil {
+		c := compareSemVer(v, iv.lo)
+		if c < 0 || c == 0 && (incl || !iv.loIncl) {
+			return
+		}
+	}
+	iv.lo = v
+	iv.loIncl = incl
+}
+
+func (iv *semVerInterval) lowerHigh(v *semVer, incl bool) {
+	if iv.hi != nil {
+		c := compareSemVer(v, iv.hi)
+		if c > 0 || c == 0 && (incl || !iv.hiIncl) {
+			return
+		}
+	}
+	iv.hi = v
+	iv.hiIncl = incl
+}
+
+// none makes the interval empty
+func (iv *semVerInterval) none() {
+	iv.lowerHigh(minSemVer, false)
+}
+
+func (iv *semVerInterval) empty() bool {
+	if iv.hi == nil {
+		return false
+	}
+	if iv.lo == nil {
+		return !iv.hiIncl && compareSemVer(iv.hi, minSemVer) == 0
+	}
+	c := compareSemVer(iv.lo, iv.hi)
+	return c > 0 || c == 0 && !(iv.loIncl && iv.hiIncl)
+}
+
+func (iv *semVerInterval) includes(v *semVer) bool {
+	if iv.lo != nil {
+		c := compareSemVer(v, iv.lo)
+		if c < 0 || c == 0 && !iv.loIncl {
+			return false
+		}
+	}
+	if iv.hi != nil {
+		c := compareSemVer(v, iv.hi)
+		if c > 0 || c == 0 && !iv.hiIncl {
+			return false
+		}
+	}
+	return true
+}
+
+// covers returns true if all versions in the given interval are included in this interval
+func (iv *semVerInterval) covers(o *semVerInterval) bool {
+	if iv.lo != nil {
+		if o.lo == nil {
+			return false
+		}
+		c := compareSemVer(o.lo, iv.lo)
+		if c < 0 || c == 0 && o.loIncl && !iv.loIncl {
+			return false
+		}
+	}
+	if iv.hi != nil {
+		if o.hi == nil {
+			return false
+		}
+		c := compareSemVer(o.hi, iv.hi)
+		if c > 0 || c == 0 && o.hiIncl && !iv.hiIncl {
+			return false
+		}
+	}
+	return true
+}
+
+func (iv *semVerInterval) equals(o *semVerInterval) bool {
+	return boundEquals(iv.lo, o.lo, iv.loIncl, o.loIncl) && boundEquals(iv.hi, o.hi, iv.hiIncl, o.hiIncl)
+}
+
+func boundEquals(a, b *semVer, aIncl, bIncl bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return aIncl == bIncl && compareSemVer(a, b) == 0
+}
+
+func (r *semVerRange) includes(v *semVer) bool {
+	for _, iv := range r.intervals {
+		if iv.includes(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true if each interval of the given range is covered by one of the intervals of this range
+func (r *semVerRange) covers(o *semVerRange) bool {
+	for _, oi := range o.intervals {
+		covered := false
+		for _, iv := range r.intervals {
+			if iv.covers(oi) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *semVerRange) matchesAll() bool {
+	for _, iv := range r.intervals {
+		if iv.lo == nil && iv.hi == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *semVerRange) Equals(other interface{}) bool {
+	or, ok := other.(*semVerRange)
+	if !ok || len(r.intervals) != len(or.intervals) {
+		return false
+	}
+	for i, iv := range r.intervals {
+		if !iv.equals(or.intervals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *semVerRange) HashCode() int {
+	h := 1
+	for _, iv := range r.intervals {
+		if iv.lo != nil {
+			h = h*31 + util.StringHash(iv.lo.String())
+		}
+		if iv.hi != nil {
+			h = h*31 + util.StringHash(iv.hi.String())
+		}
+	}
+	return h
+}
+
+func (r *semVerRange) Includes(v dgo.SemVer) bool {
+	return r.includes(v.(*semVer))
+}
+
+func (r *semVerRange) ReflectTo(value reflect.Value) {
+	if value.Kind() == reflect.String {
+		value.SetString(r.expr)
+	} else {
+		value.Set(reflect.ValueOf(r))
+	}
+}
+
+func (r *semVerRange) String() string {
+	return r.expr
+}
+
+func (r *semVerRange) Type() dgo.Type {
+	et := &exactSemVerRangeType{value: r}
+	et.ExactType = et
+	return et
+}
+
+func (t defaultSemVerRangeType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case defaultSemVerRangeType, *exactSemVerRangeType:
+		return true
+	case *exactStringType:
+		return t.Instance(ot.value)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t defaultSemVerRangeType) Describe() string {
+	return Describe(t)
+}
+
+func (t defaultSemVerRangeType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t defaultSemVerRangeType) HashCode() int {
+	return int(dgo.TiSemVerRange)
+}
+
+func (t defaultSemVerRangeType) Instance(value interface{}) bool {
+	switch v := value.(type) {
+	case *semVerRange:
+		return true
+	case *hstring:
+		_, ok := parseSemVerRange(v.s)
+		return ok
+	case string:
+		_, ok := parseSemVerRange(v)
+		return ok
+	}
+	return false
+}
+
+func (t defaultSemVerRangeType) New(arg dgo.Value) dgo.Value {
+	return newSemVerRange(t, arg)
+}
+
+func (t defaultSemVerRangeType) ReflectType() reflect.Type {
+	return reflectSemVerRangeType
+}
+
+func (t defaultSemVerRangeType) String() string {
+	return TypeString(t)
+}
+
+func (t defaultSemVerRangeType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t defaultSemVerRangeType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiSemVerRange
+}
+
+func (t *exactSemVerRangeType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactSemVerRangeType) Generic() dgo.Type {
+	return DefaultSemVerRangeType
+}
+
+func (t *exactSemVerRangeType) New(arg dgo.Value) dgo.Value {
+	return newSemVerRange(t, arg)
+}
+
+func (t *exactSemVerRangeType) ReflectType() reflect.Type {
+	return reflectSemVerRangeType
+}
+
+func (t *exactSemVerRangeType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiSemVerRangeExact
+}
+
+// SemVerRangeTypeFromArgs returns the default semantic version range type when no arguments are given and the
+// exact type of the range given as a SemVerRange or a String otherwise.
+func SemVerRangeTypeFromArgs(args []interface{}) dgo.Type {
+	switch len(args) {
+	case 0:
+		return DefaultSemVerRangeType
+	case 1:
+		switch a0 := Value(args[0]).(type) {
+		case *semVerRange:
+			return a0.Type()
+		case dgo.String:
+			return SemVerRange(a0.GoString()).Type()
+		}
+		panic(illegalArgument(`SemVerRangeType`, `SemVerRange or String`, args, 0))
+	}
+	panic(illegalArgumentCount(`SemVerRangeType`, 0, 1, len(args)))
+}
+
+func newSemVerRange(t dgo.Type, arg dgo.Value) dgo.SemVerRange {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`semverrange`, 1, 1)
+		arg = args.Get(0)
+	}
+	var rv dgo.SemVerRange
+	switch arg := arg.(type) {
+	case dgo.SemVerRange:
+		rv = arg
+	case dgo.String:
+		rv = SemVerRange(arg.GoString())
+	default:
+		panic(illegalArgument(`semverrange`, `semverrange|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(rv) {
+		panic(IllegalAssignment(t, rv))
+	}
+	return rv
+}
@@ -0,0 +1,124 @@
+package internal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestSemVerRange_Includes(t *testing.T) {
+	tests := []struct {
+		rng string
+		in  []string
+		out []string
+	}{
+		{`*`, []string{`0.0.0`, `1.2.3`, `1.0.0-alpha`}, nil},
+		{``, []string{`0.0.0`, `9.9.9`}, nil},
+		{`1.2.3`, []string{`1.2.3`, `1.2.3+b`}, []string{`1.2.4`, `1.2.3-alpha`}},
+		{`=1.2.3`, []string{`1.2.3`}, []string{`1.2.2`}},
+		{`v1.2.3`, []string{`1.2.3`}, []string{`1.2.2`}},
+		{`1.x`, []string{`1.0.0`, `1.9.9`}, []string{`0.9.9`, `2.0.0`, `2.0.0-0`}},
+		{`1.2`, []string{`1.2.0`, `1.2.9`}, []string{`1.1.9`, `1.3.0`}},
+		{`1.2.*`, []string{`1.2.0`, `1.2.9`}, []string{`1.1.9`, `1.3.0`}},
+		{`>1.2.3`, []string{`1.2.4`, `2.0.0`}, []string{`1.2.3`, `1.2.3-rc`}},
+		{`>1.2`, []string{`1.3.0`}, []string{`1.2.9`, `1.3.0-alpha`}},
+		{`>*`, nil, []string{`0.0.0-0`, `1.0.0`}},
+		{`>=1.2.3`, []string{`1.2.3`, `5.0.0`}, []string{`1.2.2`}},
+		{`>= 1.2`, []string{`1.2.0`}, []string{`1.1.9`}},
+		{`<1.2.3`, []string{`1.2.2`, `1.2.3-rc`}, []string{`1.2.3`}},
+		{`<1.2`, []string{`1.1.9`}, []string{`1.2.0`, `1.2.0-alpha`}},
+		{`<*`, nil, []string{`0.0.0-0`, `1.0.0`}},
+		{`<=1.2.3`, []string{`1.2.3`}, []string{`1.2.4`}},
+		{`<=1.2`, []string{`1.2.9`}, []string{`1.3.0`}},
+		{`<=*`, []string{`1.0.0`}, nil},
+		{`~1.2.3`, []string{`1.2.3`, `1.2.9`}, []string{`1.2.2`, `1.3.0`}},
+		{`~1.2`, []string{`1.2.0`, `1.2.9`}, []string{`1.3.0`}},
+		{`~1`, []string{`1.0.0`, `1.9.0`}, []string{`2.0.0`}},
+		{`^1.2.3`, []string{`1.2.3`, `1.9.9`}, []string{`1.2.2`, `2.0.0`}},
+		{`^0.2.3`, []string{`0.2.3`, `0.2.9`}, []string{`0.3.0`}},
+		{`^0.0.3`, []string{`0.0.3`}, []string{`0.0.4`}},
+		{`^0.0`, []string{`0.0.9`}, []string{`0.1.0`}},
+		{`^0`, []string{`0.9.9`}, []string{`1.0.0`}},
+		{`^1.x`, []string{`1.9.9`}, []string{`2.0.0`}},
+		{`1.2.3 - 2.3.4`, []string{`1.2.3`, `2.3.4`}, []string{`1.2.2`, `2.3.5`}},
+		{`1.2 - 2.3`, []string{`1.2.0`, `2.3.9`}, []string{`1.1.9`, `2.4.0`}},
+		{`* - 2`, []string{`0.0.0`, `2.9.9`}, []string{`3.0.0`}},
+		{`>=1.2.0 <1.4.0`, []string{`1.2.0`, `1.3.9`}, []string{`1.4.0`}},
+		{`<1.4.0 >=1.2.0`, []string{`1.2.0`, `1.3.9`}, []string{`1.4.0`}},
+		{`^1.2 || ~3.1`, []string{`1.5.0`, `3.1.5`}, []string{`2.0.0`, `3.2.0`}},
+		{`>2 <1`, nil, []string{`1.5.0`}},
+	}
+	for _, tt := range tests {
+		r := vf.SemVerRange(tt.rng)
+		for _, v := range tt.in {
+			if !r.Includes(vf.SemVer(v)) {
+				t.Errorf(`%q does not include %s`, tt.rng, v)
+			}
+		}
+		for _, v := range tt.out {
+			if r.Includes(vf.SemVer(v)) {
+				t.Errorf(`%q includes %s`, tt.rng, v)
+			}
+		}
+	}
+
+	for _, s := range []string{`1.2.3.4`, `>>1`, `!1.0.0`, `^x.1`, `1.2 - `, `1.2.3 - x.1`, `01.2`} {
+		require.Panic(t, func() { vf.SemVerRange(s) }, `is not a valid semantic version range`)
+	}
+}
+
+func TestSemVerRange(t *testing.T) {
+	r := vf.SemVerRange(` ^1.2 `)
+	require.Equal(t, `^1.2`, r.String())
+	require.Equal(t, r, vf.SemVerRange(`>=1.2.0 <2.0.0-0`))
+	require.NotEqual(t, r, vf.SemVerRange(`^1.3`))
+	require.NotEqual(t, r, vf.SemVerRange(`^1.2 || ^3`))
+	require.NotEqual(t, r, `^1.2`)
+	require.Equal(t, r.HashCode(), vf.SemVerRange(`1.2 - 1`).HashCode())
+
+	var s string
+	vf.ReflectTo(r, reflect.ValueOf(&s).Elem())
+	require.Equal(t, `^1.2`, s)
+	var rv dgo.SemVerRange
+	vf.ReflectTo(r, reflect.ValueOf(&rv).Elem())
+	require.Same(t, r, rv)
+}
+
+func TestSemVerRangeType(t *testing.T) {
+	tp := typ.SemVerRange
+	r := vf.SemVerRange(`^1.2`)
+	require.Instance(t, tp, r)
+	require.Instance(t, tp, `>=1.0 <2`)
+	require.Instance(t, tp, vf.String(`~1`))
+	require.NotInstance(t, tp, `latest`)
+	require.NotInstance(t, tp, 1)
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, r.Type())
+	require.Assignable(t, tp, vf.String(`^1`).Type())
+	require.NotAssignable(t, tp, vf.String(`latest`).Type())
+	require.NotAssignable(t, tp, typ.String)
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, typ.SemVer)
+	require.NotEqual(t, 0, tp.HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `semverrange`, tp.String())
+	require.Equal(t, reflect.TypeOf((*dgo.SemVerRange)(nil)).Elem(), tp.ReflectType())
+
+	et := r.Type()
+	require.Instance(t, et, r)
+	require.Instance(t, et, vf.SemVerRange(`1.x >=1.2`))
+	require.NotInstance(t, et, vf.SemVerRange(`~1.2 || 1.3 - 1`))
+	require.Same(t, tp, typ.Generic(et))
+	require.Equal(t, `semverrange["^1.2"]`, et.String())
+	require.Equal(t, tp.ReflectType(), et.ReflectType())
+
+	require.Same(t, r, vf.New(tp, r))
+	require.Equal(t, r, vf.New(tp, vf.Arguments(vf.String(`^1.2`))))
+	require.Equal(t, r, vf.New(et, vf.String(`1.x >=1.2`)))
+	require.Panic(t, func() { vf.New(et, vf.String(`^1.3`)) }, `cannot be assigned`)
+	require.Panic(t, func() { vf.New(tp, vf.True) }, `illegal argument`)
+}
@@ -44,6 +44,10 @@ func (t *sensitiveType) DeepAssignable(guard dgo.RecursionGuard, other dgo.Type)
 	return CheckAssignableTo(guard, other, t)
 }
 
+func (t *sensitiveType) Describe() string {
+	return Describe(t)
+}
+
 func (t *sensitiveType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
@@ -158,6 +162,11 @@ func (v *sensitive) deepHashCode(seen []dgo.Value) int {
 	return deepHashCode(seen, v.value) * 7
 }
 
+// GoString returns the same redacted string as String so that the wrapped value isn't revealed by the %#v verb
+func (v *sensitive) GoString() string {
+	return v.String()
+}
+
 func (v *sensitive) String() string {
 	return `sensitive [value redacted]`
 }
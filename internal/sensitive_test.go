@@ -1,6 +1,7 @@
 package internal_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/lyraproj/dgo/typ"
 
 	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/util"
 )
 
 func TestSensitiveType(t *testing.T) {
@@ -105,3 +107,13 @@ func TestSensitive(t *testing.T) {
 	require.NotEqual(t, typ.Sensitive.HashCode(), s.HashCode())
 	require.Equal(t, s.HashCode(), s.HashCode())
 }
+
+func TestSensitive_redacted(t *testing.T) {
+	s := vf.Sensitive(42)
+	require.Equal(t, `sensitive [value redacted]`, fmt.Sprintf(`%v`, s))
+	require.Equal(t, `sensitive [value redacted]`, fmt.Sprintf(`%#v`, s))
+	require.Equal(t, `{"a":sensitive [value redacted]}`, vf.Map(`a`, s).String())
+	require.Equal(t, "{\n  \"a\": sensitive [value redacted]\n}", util.ToIndentedString(vf.Map(`a`, s)))
+	require.Panic(t, func() { vf.New(typ.Integer, s) }, `^[^4]+$`)
+	require.Equal(t, 42, s.Unwrap())
+}
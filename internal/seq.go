@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// seq is a lazy dgo.Seq. Its each function calls yield with each value of the sequence until yield returns false.
+type seq struct {
+	each func(yield func(dgo.Value) bool)
+}
+
+func (v *array) Seq() dgo.Seq {
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		for i := 0; i < len(v.slice); i++ {
+			if !yield(v.slice[i]) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *seq) Drop(n int) dgo.Seq {
+	assertSeqCount(`Drop`, n)
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		i := 0
+		s.each(func(v dgo.Value) bool {
+			if i < n {
+				i++
+				return true
+			}
+			return yield(v)
+		})
+	}}
+}
+
+func (s *seq) Each(actor dgo.Consumer) {
+	s.each(func(v dgo.Value) bool {
+		actor(v)
+		return true
+	})
+}
+
+func (s *seq) Filter(predicate dgo.Predicate) dgo.Seq {
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		s.each(func(v dgo.Value) bool {
+			return !predicate(v) || yield(v)
+		})
+	}}
+}
+
+func (s *seq) First() dgo.Value {
+	var first dgo.Value
+	s.each(func(v dgo.Value) bool {
+		first = v
+		return false
+	})
+	return first
+}
+
+func (s *seq) Into(array dgo.Array) dgo.Array {
+	s.each(func(v dgo.Value) bool {
+		array.Add(v)
+		return true
+	})
+	return array
+}
+
+func (s *seq) Map(mapper dgo.Mapper) dgo.Seq {
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		s.each(func(v dgo.Value) bool {
+			return yield(Value(mapper(v)))
+		})
+	}}
+}
+
+func (s *seq) Take(n int) dgo.Seq {
+	assertSeqCount(`Take`, n)
+	return &seq{each: func(yield func(dgo.Value) bool) {
+		if n == 0 {
+			return
+		}
+		i := 0
+		s.each(func(v dgo.Value) bool {
+			i++
+			return yield(v) && i < n
+		})
+	}}
+}
+
+func assertSeqCount(f string, n int) {
+	if n < 0 {
+		panic(fmt.Errorf(`%s: negative count %d`, f, n))
+	}
+}
@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestSeq(t *testing.T) {
+	a := vf.Values(1, 2, 3, 4, 5, 6)
+	s := a.Seq().
+		Filter(func(v dgo.Value) bool { return v.(dgo.Integer).GoInt()%2 == 0 }).
+		Map(func(v dgo.Value) interface{} { return v.(dgo.Integer).GoInt() * 10 })
+	require.Equal(t, vf.Values(20, 40, 60), s.Into(vf.MutableValues()))
+	require.Equal(t, 20, s.First())
+	require.Equal(t, vf.Values(40), s.Drop(1).Take(1).Into(vf.MutableValues()))
+	require.Equal(t, vf.Values(), s.Take(0).Into(vf.MutableValues()))
+	require.Nil(t, s.Drop(3).First())
+
+	sum := int64(0)
+	a.Seq().Drop(4).Each(func(v dgo.Value) { sum += v.(dgo.Integer).GoInt() })
+	require.Equal(t, 11, sum)
+
+	require.Panic(t, func() { a.Seq().Take(-1) }, `Take: negative count -1`)
+	require.Panic(t, func() { a.Seq().Drop(-1) }, `Drop: negative count -1`)
+	require.Panic(t, func() { a.Seq().Into(vf.Values()) }, `frozen`)
+}
+
+func TestSeq_lazy(t *testing.T) {
+	calls := 0
+	s := vf.Values(1, 2, 3, 4).Seq().Map(func(v dgo.Value) interface{} {
+		calls++
+		return v
+	})
+	require.Equal(t, 0, calls)
+	require.Equal(t, vf.Values(1, 2), s.Take(2).Into(vf.MutableValues()))
+	require.Equal(t, 2, calls)
+	require.Equal(t, 1, s.First())
+	require.Equal(t, 3, calls)
+
+	a := vf.MutableValues(1)
+	s = a.Seq()
+	a.Add(2)
+	require.Equal(t, vf.Values(1, 2), s.Into(vf.MutableValues()))
+}
+
+func TestSeq_circularArray(t *testing.T) {
+	a := vf.CircularArray(3, nil)
+	a.AddValues(1, 2, 3, 4)
+	require.Equal(t, vf.Values(2, 3, 4), a.Seq().Into(vf.MutableValues()))
+	require.Equal(t, 3, a.Seq().Drop(1).First())
+}
@@ -0,0 +1,250 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// sortedMap is a dgo.SortedMap that is backed by a hashMap. The linked list of the hashMap is kept in key order by
+// moving each node that is added to its sorted position.
+type sortedMap struct {
+	*hashMap
+	compare func(a, b dgo.Value) int
+
+	// nodes holds the nodes of the hashMap in list order. It is rebuilt when it is out of sync with the list, which
+	// happens when the hashMap is resized or when entries are removed.
+	nodes []*hashNode
+}
+
+// SortedMap returns a new empty and mutable dgo.SortedMap that orders its keys using the given compare function. The
+// function must return a negative number when a is less than b, zero when they are equal, and a positive number
+// otherwise. The natural order of the keys is used when the function is nil.
+func SortedMap(compare func(a, b dgo.Value) int) dgo.SortedMap {
+	if compare == nil {
+		compare = naturalCompare
+	}
+	return &sortedMap{hashMap: MapWithCapacity(0).(*hashMap), compare: compare}
+}
+
+// naturalCompare compares a and b using their natural order. Values that are not comparable are ordered by their
+// TypeIdentifier.
+func naturalCompare(a, b dgo.Value) int {
+	switch {
+	case naturalLess(a, b):
+		return -1
+	case naturalLess(b, a):
+		return 1
+	}
+	return 0
+}
+
+// sorted returns the nodes of the map in key order. The given number of nodes at the end of the list are excluded.
+func (m *sortedMap) sorted(exclude int) []*hashNode {
+	n := m.len - exclude
+	if len(m.nodes) != n || n > 0 && m.nodes[0] != m.first {
+		ns := make([]*hashNode, 0, m.len)
+		for e := m.first; len(ns) < n; e = e.next {
+			ns = append(ns, e)
+		}
+		m.nodes = ns
+	}
+	return m.nodes
+}
+
+// search returns the index of the first node whose key is not less than the given key, or the first node whose key
+// is greater than the given key when after is true.
+func (m *sortedMap) search(ns []*hashNode, key dgo.Value, after bool) int {
+	if after {
+		return sort.Search(len(ns), func(i int) bool { return m.compare(ns[i].key, key) > 0 })
+	}
+	return sort.Search(len(ns), func(i int) bool { return m.compare(ns[i].key, key) >= 0 })
+}
+
+// place moves the last node of the list, which must be newly added, to its sorted position
+func (m *sortedMap) place() {
+	nd := m.last
+	ns := m.sorted(1)
+	i := m.search(ns, nd.key, true)
+	if i < len(ns) {
+		m.last = nd.prev
+		m.last.next = nil
+		t := ns[i]
+		nd.prev = t.prev
+		nd.next = t
+		if t.prev == nil {
+			m.first = nd
+		} else {
+			t.prev.next = nd
+		}
+		t.prev = nd
+	}
+	ns = append(ns, nil)
+	copy(ns[i+1:], ns[i:])
+	ns[i] = nd
+	m.nodes = ns
+}
+
+// clone returns a mutable copy of this map that shares the keys and values of this map
+func (m *sortedMap) clone() *sortedMap {
+	c := &hashMap{len: m.len}
+	m.hashMap.resize(c, 0)
+	return m.wrap(c)
+}
+
+// wrap returns a sortedMap with the same compare function as this map that is backed by the given map. The given
+// map must be in key order.
+func (m *sortedMap) wrap(g dgo.Map) *sortedMap {
+	if g == m.hashMap {
+		return m
+	}
+	c := &sortedMap{hashMap: g.(*hashMap), compare: m.compare}
+	c.sorted(0)
+	return c
+}
+
+func (m *sortedMap) Ceiling(key interface{}) dgo.MapEntry {
+	ns := m.sorted(0)
+	if i := m.search(ns, Value(key), false); i < len(ns) {
+		return ns[i]
+	}
+	return nil
+}
+
+func (m *sortedMap) ComputeIfAbsent(key interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	k := Value(key)
+	if v := m.Get(k); v != nil {
+		return v
+	}
+	v := Value(computer(k))
+	m.Put(k, v)
+	return v
+}
+
+func (m *sortedMap) Copy(frozen bool) dgo.Map {
+	return m.wrap(m.hashMap.Copy(frozen))
+}
+
+func (m *sortedMap) First() dgo.MapEntry {
+	if m.first == nil {
+		return nil
+	}
+	return m.first
+}
+
+func (m *sortedMap) Floor(key interface{}) dgo.MapEntry {
+	ns := m.sorted(0)
+	if i := m.search(ns, Value(key), true); i > 0 {
+		return ns[i-1]
+	}
+	return nil
+}
+
+// Freeze makes this map immutable. The sorted nodes are computed first so that a frozen map is never modified.
+func (m *sortedMap) Freeze() {
+	m.sorted(0)
+	m.hashMap.Freeze()
+}
+
+func (m *sortedMap) FrozenCopy() dgo.Value {
+	return m.Copy(true)
+}
+
+func (m *sortedMap) ThawedCopy() dgo.Value {
+	return m.Copy(false)
+}
+
+func (m *sortedMap) Last() dgo.MapEntry {
+	if m.last == nil {
+		return nil
+	}
+	return m.last
+}
+
+func (m *sortedMap) Merge(associations dgo.Map) dgo.Map {
+	if associations.Len() == 0 || m == associations {
+		return m
+	}
+	c := m.clone()
+	c.PutAll(associations)
+	c.frozen = m.frozen
+	return c
+}
+
+// Put associates the given value with the given key. A new key is placed at its sorted position.
+func (m *sortedMap) Put(key, value interface{}) dgo.Value {
+	old := m.hashMap.Put(key, value)
+	if old == nil {
+		m.place()
+	}
+	return old
+}
+
+func (m *sortedMap) PutAll(associations dgo.Map) {
+	if associations.Len() == 0 {
+		return
+	}
+	if m.frozen {
+		panic(frozenMap(`PutAll`))
+	}
+	associations.EachEntry(func(e dgo.MapEntry) { m.Put(e.Key(), e.Value()) })
+}
+
+func (m *sortedMap) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	if m.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	k := Value(key)
+	if old := m.Get(k); old != nil {
+		return old, false
+	}
+	v := Value(value)
+	m.Put(k, v)
+	return v, true
+}
+
+func (m *sortedMap) Range(from, to interface{}, actor dgo.EntryActor) {
+	ns := m.sorted(0)
+	start, end := 0, len(ns)
+	if from != nil {
+		start = m.search(ns, Value(from), false)
+	}
+	if to != nil {
+		end = m.search(ns, Value(to), false)
+	}
+	for i := start; i < end; i++ {
+		actor(ns[i])
+	}
+}
+
+func (m *sortedMap) String() string {
+	return m.hashMap.String()
+}
+
+func (m *sortedMap) Type() dgo.Type {
+	et := &exactMapType{value: m}
+	et.ExactType = et
+	return et
+}
+
+func (m *sortedMap) With(key, value interface{}) dgo.Map {
+	v := Value(value)
+	if v.Equals(m.hashMap.Get(key)) {
+		return m
+	}
+	c := m.clone()
+	c.Put(key, v)
+	c.frozen = m.frozen
+	return c
+}
+
+func (m *sortedMap) Without(key interface{}) dgo.Map {
+	return m.wrap(m.hashMap.Without(key))
+}
+
+func (m *sortedMap) WithoutAll(keys dgo.Array) dgo.Map {
+	return m.wrap(m.hashMap.WithoutAll(keys))
+}
@@ -0,0 +1,103 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestSortedMap(t *testing.T) {
+	m := vf.SortedMap(nil)
+	require.Nil(t, m.First())
+	require.Nil(t, m.Last())
+	for _, k := range []int{5, 1, 9, 3, 7, 2} {
+		require.Nil(t, m.Put(k, k*10))
+	}
+	require.Equal(t, vf.Values(1, 2, 3, 5, 7, 9), m.Keys())
+	require.Equal(t, 50, m.Put(5, 55))
+	require.Equal(t, vf.Values(10, 20, 30, 55, 70, 90), m.Values())
+	require.Equal(t, 1, m.First().Key())
+	require.Equal(t, 9, m.Last().Key())
+
+	require.Equal(t, 30, m.Remove(3))
+	m.Put(4, 40)
+	m.PutIfAbsent(0, 0)
+	m.ComputeIfAbsent(8, func(k dgo.Value) dgo.Value { return vf.Integer(80) })
+	m.PutAll(vf.Map(6, 60, 10, 100))
+	require.Equal(t, vf.Values(0, 1, 2, 4, 5, 6, 7, 8, 9, 10), m.Keys())
+	require.Equal(t, vf.Map(0, 0, 1, 10, 2, 20, 4, 40, 5, 55, 6, 60, 7, 70, 8, 80, 9, 90, 10, 100), m)
+}
+
+func TestSortedMap_navigation(t *testing.T) {
+	m := vf.SortedMap(nil)
+	m.PutAll(vf.Map(`b`, 2, `d`, 4, `f`, 6))
+
+	require.Equal(t, `d`, m.Floor(`d`).Key())
+	require.Equal(t, `d`, m.Floor(`e`).Key())
+	require.Nil(t, m.Floor(`a`))
+	require.Equal(t, `d`, m.Ceiling(`c`).Key())
+	require.Equal(t, `b`, m.Ceiling(`a`).Key())
+	require.Nil(t, m.Ceiling(`g`))
+
+	var ks []string
+	collect := func(e dgo.MapEntry) { ks = append(ks, e.Key().String()) }
+	m.Range(`b`, `f`, collect)
+	require.Equal(t, []string{`b`, `d`}, ks)
+	ks = nil
+	m.Range(`c`, nil, collect)
+	require.Equal(t, []string{`d`, `f`}, ks)
+	ks = nil
+	m.Range(nil, `c`, collect)
+	require.Equal(t, []string{`b`}, ks)
+}
+
+func TestSortedMap_comparator(t *testing.T) {
+	m := vf.SortedMap(func(a, b dgo.Value) int {
+		return strings.Compare(strings.ToLower(b.String()), strings.ToLower(a.String()))
+	})
+	m.PutAll(vf.Map(`a`, 1, `C`, 3, `b`, 2))
+	require.Equal(t, vf.Values(`C`, `b`, `a`), m.Keys())
+}
+
+func TestSortedMap_copies(t *testing.T) {
+	m := vf.SortedMap(nil)
+	m.PutAll(vf.Map(2, `b`, 1, `a`))
+
+	w := m.With(0, `z`).(dgo.SortedMap)
+	require.Equal(t, vf.Values(0, 1, 2), w.Keys())
+	require.Equal(t, vf.Values(1, 2), m.Keys())
+	require.Same(t, m, m.With(1, `a`))
+
+	mg := m.Merge(vf.Map(3, `c`, 0, `z`)).(dgo.SortedMap)
+	require.Equal(t, vf.Values(0, 1, 2, 3), mg.Keys())
+	require.Equal(t, 0, mg.First().Key())
+	require.Same(t, m, m.Merge(vf.Map()))
+
+	wo := w.Without(1).(dgo.SortedMap)
+	require.Equal(t, vf.Values(0, 2), wo.Keys())
+	wo.Put(1, `a`)
+	require.Equal(t, vf.Values(0, 1, 2), wo.Keys())
+	require.Equal(t, vf.Values(2), w.WithoutAll(vf.Values(0, 1)).Keys())
+
+	f := m.FrozenCopy().(dgo.SortedMap)
+	require.True(t, f.Frozen())
+	require.Same(t, f, f.FrozenCopy())
+	require.Equal(t, 2, f.Floor(5).Key())
+	require.Panic(t, func() { f.Put(3, `c`) }, `frozen`)
+	require.Panic(t, func() { f.PutAll(vf.Map(3, `c`)) }, `frozen`)
+	require.Panic(t, func() { f.PutIfAbsent(3, `c`) }, `frozen`)
+	require.Panic(t, func() { f.ComputeIfAbsent(3, func(k dgo.Value) dgo.Value { return k }) }, `frozen`)
+
+	tc := f.ThawedCopy().(dgo.SortedMap)
+	tc.Put(0, `z`)
+	require.Equal(t, vf.Values(0, 1, 2), tc.Keys())
+	require.Equal(t, `{1:"a",2:"b"}`, m.String())
+	require.Instance(t, m.Type(), m)
+
+	m.Freeze()
+	require.True(t, m.Frozen())
+	require.Equal(t, 1, m.Ceiling(0).Key())
+}
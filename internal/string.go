@@ -78,6 +78,10 @@ func newString(t dgo.Type, arg dgo.Value) dgo.String {
 	return s
 }
 
+func (t defaultDgoStringType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultDgoStringType) String() string {
 	return TypeString(t)
 }
@@ -197,12 +201,17 @@ func String(s string) dgo.String {
 
 func (t defaultStringType) Assignable(other dgo.Type) bool {
 	switch other.(type) {
-	case defaultStringType, defaultDgoStringType, *exactStringType, *ciStringType, *sizedStringType, *patternType:
+	case defaultStringType, defaultDgoStringType, *encodedStringType, *exactStringType, *ciStringType,
+		*sizedStringType, *patternType:
 		return true
 	}
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t defaultStringType) Describe() string {
+	return Describe(t)
+}
+
 func (t defaultStringType) Equals(other interface{}) bool {
 	return t == other
 }
@@ -370,6 +379,10 @@ func (t *patternType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *patternType) Describe() string {
+	return Describe(t)
+}
+
 func (t *patternType) Equals(v interface{}) bool {
 	if ov, ok := v.(*patternType); ok {
 		return t.rxString() == ov.rxString()
@@ -470,6 +483,10 @@ func (t *sizedStringType) Assignable(other dgo.Type) bool {
 	return CheckAssignableTo(nil, other, t)
 }
 
+func (t *sizedStringType) Describe() string {
+	return Describe(t)
+}
+
 func (t *sizedStringType) Equals(v interface{}) bool {
 	if ob, ok := v.(*sizedStringType); ok {
 		return *t == *ob
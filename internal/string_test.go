@@ -245,6 +245,51 @@ func TestDgoStringType(t *testing.T) {
 	require.Equal(t, typ.String.ReflectType(), typ.DgoString.ReflectType())
 }
 
+func TestBase64StringType(t *testing.T) {
+	tp := typ.Base64String
+	require.Same(t, tp, tf.ParseType(`base64`))
+	require.Equal(t, `base64`, tp.String())
+	require.Instance(t, tp, `aGVsbG8=`)
+	require.Instance(t, tp, vf.String(`-_-_`))
+	require.Instance(t, tp, ``)
+	require.NotInstance(t, tp, `aGVsbG8`)
+	require.NotInstance(t, tp, `a+b/c_d=`)
+	require.NotInstance(t, tp, 3)
+	require.Assignable(t, tp, tp)
+	require.Assignable(t, tp, vf.String(`aGVsbG8=`).Type())
+	require.NotAssignable(t, tp, vf.String(`hello`).Type())
+	require.NotAssignable(t, tp, typ.String)
+	require.NotAssignable(t, tp, typ.HexString)
+	require.Assignable(t, typ.String, tp)
+	require.Equal(t, 0, tp.Min())
+	require.Equal(t, math.MaxInt64, tp.Max())
+	require.True(t, tp.Unbounded())
+	require.NotEqual(t, tp, typ.String)
+	require.NotEqual(t, tp.HashCode(), typ.HexString.HashCode())
+	require.Equal(t, typ.String.ReflectType(), tp.ReflectType())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, `a base64 encoded string`, typ.Describe(tp))
+
+	require.Equal(t, `aGVsbG8=`, vf.New(tp, vf.Binary([]byte(`hello`), true)))
+	require.Equal(t, `aGVsbG8=`, vf.New(tp, vf.String(`aGVsbG8=`)))
+	require.Panic(t, func() { vf.New(tp, vf.String(`hello`)) }, `cannot be assigned`)
+}
+
+func TestHexStringType(t *testing.T) {
+	tp := typ.HexString
+	require.Same(t, tp, tf.ParseType(`hex`))
+	require.Equal(t, `hex`, tp.String())
+	require.Instance(t, tp, `0aFF`)
+	require.Instance(t, tp, ``)
+	require.NotInstance(t, tp, `0aF`)
+	require.NotInstance(t, tp, `0g`)
+	require.Assignable(t, tp, vf.String(`cafe`).Type())
+	require.NotAssignable(t, tp, vf.String(`coffee`).Type())
+	require.Equal(t, `a hex encoded string`, typ.Describe(tp))
+	require.Equal(t, `68656c6c6f`, vf.New(tp, vf.Binary([]byte(`hello`), true)))
+	require.Instance(t, tf.ParseType(`{data:hex,sig?:base64}`), vf.Map(`data`, `cafe`))
+}
+
 func TestString(t *testing.T) {
 	v := vf.String(`hello`)
 	require.Equal(t, v, `hello`)
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// stringKeyMap is a dgo.Map that is backed by a hashMap and normalizes all string keys before they are stored or
+// looked up. Keys that are not strings are used as is.
+type stringKeyMap struct {
+	*hashMap
+	normalize func(string) string
+}
+
+// StringKeyMap returns a new empty and mutable dgo.Map that passes all string keys through the given normalize
+// function before they are stored or looked up. Keys are compared case insensitively, using strings.ToLower, when
+// normalize is nil.
+func StringKeyMap(normalize func(string) string) dgo.Map {
+	if normalize == nil {
+		normalize = strings.ToLower
+	}
+	return &stringKeyMap{hashMap: MapWithCapacity(0).(*hashMap), normalize: normalize}
+}
+
+// key returns the given key in normalized form
+func (m *stringKeyMap) key(key interface{}) dgo.Value {
+	k := Value(key)
+	if s, ok := k.(dgo.String); ok {
+		if n := m.normalize(s.GoString()); n != s.GoString() {
+			k = String(n)
+		}
+	}
+	return k
+}
+
+// normalized returns a map with the same entries as the given map but with all keys normalized.
+func (m *stringKeyMap) normalized(associations dgo.Map) dgo.Map {
+	c := MapWithCapacity(associations.Len()).(*hashMap)
+	associations.EachEntry(func(e dgo.MapEntry) { c.Put(m.key(e.Key()), e.Value()) })
+	c.frozen = associations.Frozen()
+	return c
+}
+
+// wrap returns a stringKeyMap that uses the same normalize function as this map and is backed by the given map
+func (m *stringKeyMap) wrap(g dgo.Map) dgo.Map {
+	if g == m.hashMap {
+		return m
+	}
+	return &stringKeyMap{hashMap: g.(*hashMap), normalize: m.normalize}
+}
+
+func (m *stringKeyMap) ComputeIfAbsent(key interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	return m.hashMap.ComputeIfAbsent(m.key(key), computer)
+}
+
+func (m *stringKeyMap) ContainsKey(key interface{}) bool {
+	return m.hashMap.ContainsKey(m.key(key))
+}
+
+func (m *stringKeyMap) Copy(frozen bool) dgo.Map {
+	return m.wrap(m.hashMap.Copy(frozen))
+}
+
+func (m *stringKeyMap) DeepMerge(associations dgo.Map, concatArrays bool) dgo.Map {
+	return m.wrap(m.hashMap.DeepMerge(m.normalized(associations), concatArrays))
+}
+
+func (m *stringKeyMap) FrozenCopy() dgo.Value {
+	return m.Copy(true)
+}
+
+func (m *stringKeyMap) ThawedCopy() dgo.Value {
+	return m.Copy(false)
+}
+
+func (m *stringKeyMap) Get(key interface{}) dgo.Value {
+	return m.hashMap.Get(m.key(key))
+}
+
+func (m *stringKeyMap) GetAndDelete(key interface{}) (dgo.Value, bool) {
+	return m.hashMap.GetAndDelete(m.key(key))
+}
+
+func (m *stringKeyMap) GetArray(key interface{}) (dgo.Array, bool) {
+	return asArray(m.Get(key))
+}
+
+func (m *stringKeyMap) GetBool(key interface{}) (bool, bool) {
+	return asGoBool(m.Get(key))
+}
+
+func (m *stringKeyMap) GetFloat(key interface{}) (float64, bool) {
+	return asGoFloat(m.Get(key))
+}
+
+func (m *stringKeyMap) GetInt(key interface{}) (int64, bool) {
+	return asGoInt(m.Get(key))
+}
+
+func (m *stringKeyMap) GetMap(key interface{}) (dgo.Map, bool) {
+	return asMap(m.Get(key))
+}
+
+func (m *stringKeyMap) GetOrElse(key interface{}, defaultValue dgo.Value) dgo.Value {
+	return m.hashMap.GetOrElse(m.key(key), defaultValue)
+}
+
+func (m *stringKeyMap) GetOrElseGet(key interface{}, computer func() dgo.Value) dgo.Value {
+	return m.hashMap.GetOrElseGet(m.key(key), computer)
+}
+
+func (m *stringKeyMap) GetString(key interface{}) (string, bool) {
+	return asGoString(m.Get(key))
+}
+
+func (m *stringKeyMap) Map(mapper dgo.EntryMapper) dgo.Map {
+	return m.wrap(m.hashMap.Map(mapper))
+}
+
+func (m *stringKeyMap) Merge(associations dgo.Map) dgo.Map {
+	if associations.Len() == 0 || m == associations {
+		return m
+	}
+	if m.len == 0 {
+		c := m.wrap(MapWithCapacity(associations.Len())).(*stringKeyMap)
+		c.PutAll(associations)
+		c.frozen = m.frozen
+		return c
+	}
+	return m.wrap(m.hashMap.Merge(m.normalized(associations)))
+}
+
+func (m *stringKeyMap) MergeWith(associations dgo.Map, strategy dgo.MergeStrategy) dgo.Map {
+	if strategy == dgo.MergeShallow {
+		return m.Merge(associations)
+	}
+	return m.wrap(m.hashMap.MergeWith(m.normalized(associations), strategy))
+}
+
+func (m *stringKeyMap) MustGetArray(key interface{}) dgo.Array {
+	return mustGet(m, key, DefaultArrayType).(dgo.Array)
+}
+
+func (m *stringKeyMap) MustGetBool(key interface{}) bool {
+	return mustGet(m, key, DefaultBooleanType).(dgo.Boolean).GoBool()
+}
+
+func (m *stringKeyMap) MustGetFloat(key interface{}) float64 {
+	return mustGet(m, key, DefaultFloatType).(dgo.Float).GoFloat()
+}
+
+func (m *stringKeyMap) MustGetInt(key interface{}) int64 {
+	return mustGet(m, key, DefaultIntegerType).(dgo.Integer).GoInt()
+}
+
+func (m *stringKeyMap) MustGetMap(key interface{}) dgo.Map {
+	return mustGet(m, key, DefaultMapType).(dgo.Map)
+}
+
+func (m *stringKeyMap) MustGetString(key interface{}) string {
+	return mustGet(m, key, DefaultStringType).(dgo.String).GoString()
+}
+
+func (m *stringKeyMap) Put(key, value interface{}) dgo.Value {
+	return m.hashMap.Put(m.key(key), value)
+}
+
+func (m *stringKeyMap) PutAll(associations dgo.Map) {
+	if associations.Len() > 0 {
+		m.hashMap.PutAll(m.normalized(associations))
+	}
+}
+
+func (m *stringKeyMap) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	return m.hashMap.PutIfAbsent(m.key(key), value)
+}
+
+func (m *stringKeyMap) Remove(key interface{}) dgo.Value {
+	return m.hashMap.Remove(m.key(key))
+}
+
+func (m *stringKeyMap) RemoveAll(keys dgo.Array) {
+	m.hashMap.RemoveAll(keys.Map(func(k dgo.Value) interface{} { return m.key(k) }))
+}
+
+func (m *stringKeyMap) RenameKeys(mapping dgo.Map) dgo.Map {
+	mapping = m.normalized(mapping)
+	c := m.wrap(MapWithCapacity(m.len)).(*stringKeyMap)
+	for e := m.first; e != nil; e = e.next {
+		k := e.key
+		if nk := mapping.Get(k); nk != nil {
+			k = nk
+		}
+		if c.Put(k, e.value) != nil {
+			panic(fmt.Errorf(`rename of keys results in more than one association for the key '%s'`, k))
+		}
+	}
+	c.frozen = m.frozen
+	return c
+}
+
+func (m *stringKeyMap) String() string {
+	return m.hashMap.String()
+}
+
+func (m *stringKeyMap) Transform(schema dgo.StructMapType) (dgo.Map, error) {
+	return transform(m, schema)
+}
+
+func (m *stringKeyMap) Type() dgo.Type {
+	et := &exactMapType{value: m}
+	et.ExactType = et
+	return et
+}
+
+func (m *stringKeyMap) With(key, value interface{}) dgo.Map {
+	return m.wrap(m.hashMap.With(m.key(key), value))
+}
+
+func (m *stringKeyMap) Without(key interface{}) dgo.Map {
+	return m.wrap(m.hashMap.Without(m.key(key)))
+}
+
+func (m *stringKeyMap) WithoutAll(keys dgo.Array) dgo.Map {
+	return m.wrap(m.hashMap.WithoutAll(keys.Map(func(k dgo.Value) interface{} { return m.key(k) })))
+}
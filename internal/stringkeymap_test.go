@@ -0,0 +1,91 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestStringKeyMap(t *testing.T) {
+	m := vf.StringKeyMap(nil)
+	require.Nil(t, m.Put(`Content-Type`, `text/html`))
+	require.Equal(t, `text/html`, m.Put(`CONTENT-TYPE`, `application/json`))
+	m.Put(1, `one`)
+	require.Equal(t, 2, m.Len())
+	require.Equal(t, vf.Values(`content-type`, 1), m.Keys())
+	require.Equal(t, `application/json`, m.Get(`content-type`))
+	require.Equal(t, `one`, m.Get(1))
+	require.True(t, m.ContainsKey(`Content-Type`))
+	require.Equal(t, `application/json`, m.MustGetString(`Content-TYPE`))
+	s, ok := m.GetString(`CONTENT-type`)
+	require.True(t, ok)
+	require.Equal(t, `application/json`, s)
+	require.Equal(t, `application/json`, m.GetOrElse(`Content-Type`, nil))
+	require.Equal(t, `application/json`, m.GetOrElseGet(`Content-Type`, nil))
+
+	v, ok := m.PutIfAbsent(`Accept`, `*/*`)
+	require.True(t, ok)
+	require.Equal(t, `*/*`, v)
+	_, ok = m.PutIfAbsent(`ACCEPT`, `text/html`)
+	require.False(t, ok)
+	require.Equal(t, `*/*`, m.ComputeIfAbsent(`ACCEPT`, nil))
+
+	m.PutAll(vf.Map(`X-Custom`, `a`, `x-custom`, `b`))
+	require.Equal(t, `b`, m.Get(`X-CUSTOM`))
+	require.Equal(t, `b`, m.Remove(`X-Custom`))
+	v, ok = m.GetAndDelete(`Accept`)
+	require.True(t, ok)
+	require.Equal(t, `*/*`, v)
+	m.RemoveAll(vf.Values(1))
+	require.Equal(t, vf.Map(`content-type`, `application/json`), m)
+	require.Instance(t, m.Type(), m)
+}
+
+func TestStringKeyMap_normalize(t *testing.T) {
+	m := vf.StringKeyMap(strings.TrimSpace)
+	m.Put(` a `, 1)
+	require.Equal(t, 1, m.Get(`a`))
+	require.Nil(t, m.Get(`A`))
+}
+
+func TestStringKeyMap_derived(t *testing.T) {
+	m := vf.StringKeyMap(nil)
+	m.Put(`A`, 1)
+	f := m.FrozenCopy().(dgo.Map)
+	require.True(t, f.Frozen())
+	require.Equal(t, 1, f.Get(`A`))
+	require.Same(t, f, f.FrozenCopy())
+	require.Panic(t, func() { f.Put(`b`, 2) }, `Put .* frozen`)
+	require.Equal(t, 1, f.ThawedCopy().(dgo.Map).Get(`A`))
+
+	w := f.With(`B`, 2)
+	require.True(t, w.Frozen())
+	require.Equal(t, 2, w.Get(`b`))
+	require.Equal(t, 1, w.Without(`B`).Len())
+	require.Equal(t, 0, w.WithoutAll(vf.Strings(`A`, `B`)).Len())
+	require.Same(t, w, w.WithoutAll(vf.Strings(`C`)))
+
+	require.Equal(t, 3, w.Merge(vf.Map(`B`, 3)).Get(`b`))
+	require.Equal(t, 3, vf.StringKeyMap(nil).Merge(vf.Map(`B`, 3)).Get(`b`))
+	require.Same(t, w, w.Merge(vf.Map()))
+	require.Equal(t, vf.Map(`a`, 1, `b`, 3), w.DeepMerge(vf.Map(`B`, 3), false))
+	require.Equal(t, 3, w.MergeWith(vf.Map(`B`, 3), dgo.MergeShallow).Get(`b`))
+	require.Equal(t, 3, w.MergeWith(vf.Map(`B`, 3), dgo.MergeDeep).Get(`b`))
+	require.Equal(t, vf.Map(`a`, 2, `b`, 3), w.Map(func(e dgo.MapEntry) interface{} {
+		return e.Value().(dgo.Integer).GoInt() + 1
+	}))
+	require.Equal(t, 2, w.Map(func(e dgo.MapEntry) interface{} { return e.Value() }).Get(`B`))
+
+	r := w.RenameKeys(vf.Map(`A`, `C`))
+	require.Equal(t, 1, r.Get(`C`))
+	require.True(t, r.Frozen())
+	require.Panic(t, func() { w.RenameKeys(vf.Map(`A`, `B`)) }, `more than one association`)
+
+	tm, err := w.Transform(tf.ParseType(`{a:int,b:int}`).(dgo.StructMapType))
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2), tm)
+}
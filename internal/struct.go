@@ -66,6 +66,17 @@ func (v *structVal) AnyValue(predicate dgo.Predicate) bool {
 	return !v.AllValues(func(entry dgo.Value) bool { return !predicate(entry) })
 }
 
+func (v *structVal) ComputeIfAbsent(key interface{}, _ func(dgo.Value) dgo.Value) dgo.Value {
+	if v.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	// All fields of a struct are always present
+	if old := v.Get(key); old != nil {
+		return old
+	}
+	panic(fmt.Errorf(`%s has no field named '%s'`, v.rs.Type(), key))
+}
+
 func (v *structVal) ContainsKey(key interface{}) bool {
 	if s, ok := stringKey(key); ok {
 		return v.rs.FieldByName(s).IsValid()
@@ -86,6 +97,10 @@ func (v *structVal) Copy(frozen bool) dgo.Map {
 	return &structVal{rs: rs, frozen: false}
 }
 
+func (v *structVal) DeepMerge(associations dgo.Map, concatArrays bool) dgo.Map {
+	return v.MergeWith(associations, deepMergeStrategy(concatArrays))
+}
+
 func (v *structVal) Each(actor dgo.Consumer) {
 	v.All(func(entry dgo.MapEntry) bool { actor(entry); return true })
 }
@@ -102,10 +117,56 @@ func (v *structVal) EachValue(actor dgo.Consumer) {
 	v.AllValues(func(entry dgo.Value) bool { actor(entry); return true })
 }
 
+func (v *structVal) Entries() dgo.Array {
+	return arrayFromIterator(v.Len(), v.Each)
+}
+
 func (v *structVal) Equals(other interface{}) bool {
 	return equals(nil, v, other)
 }
 
+func (v *structVal) GetArray(key interface{}) (dgo.Array, bool) {
+	return asArray(v.Get(key))
+}
+
+func (v *structVal) GetBool(key interface{}) (bool, bool) {
+	return asGoBool(v.Get(key))
+}
+
+func (v *structVal) GetFloat(key interface{}) (float64, bool) {
+	return asGoFloat(v.Get(key))
+}
+
+func (v *structVal) GetInt(key interface{}) (int64, bool) {
+	return asGoInt(v.Get(key))
+}
+
+func (v *structVal) GetMap(key interface{}) (dgo.Map, bool) {
+	return asMap(v.Get(key))
+}
+
+func (v *structVal) GetOrElse(key interface{}, defaultValue dgo.Value) dgo.Value {
+	if val := v.Get(key); val != nil {
+		return val
+	}
+	return defaultValue
+}
+
+func (v *structVal) GetOrElseGet(key interface{}, computer func() dgo.Value) dgo.Value {
+	if val := v.Get(key); val != nil {
+		return val
+	}
+	return computer()
+}
+
+func (v *structVal) GetString(key interface{}) (string, bool) {
+	return asGoString(v.Get(key))
+}
+
+func (v *structVal) GetAndDelete(key interface{}) (dgo.Value, bool) {
+	panic(errors.New(`struct fields cannot be removed`))
+}
+
 func (v *structVal) GoStruct() interface{} {
 	return v.rs.Addr().Interface()
 }
@@ -217,6 +278,12 @@ func (v *structVal) Get(key interface{}) dgo.Value {
 	return nil
 }
 
+func (v *structVal) Invert(policy dgo.DuplicatePolicy) dgo.Map {
+	c := v.toHashMap()
+	c.frozen = v.frozen
+	return c.Invert(policy)
+}
+
 func (v *structVal) Keys() dgo.Array {
 	return arrayFromIterator(v.Len(), v.EachKey)
 }
@@ -234,6 +301,16 @@ func (v *structVal) Map(mapper dgo.EntryMapper) dgo.Map {
 	return c
 }
 
+func (v *structVal) MapKeys(mapper dgo.EntryMapper, target dgo.MapType) dgo.Map {
+	return transformEntries(v, v.frozen, func(e dgo.MapEntry) (interface{}, interface{}) {
+		return mapper(e), e.Value()
+	}, target)
+}
+
+func (v *structVal) MapValues(mapper dgo.EntryMapper, target dgo.MapType) dgo.Map {
+	return checkTarget(v.Map(mapper), target)
+}
+
 func (v *structVal) Merge(associations dgo.Map) dgo.Map {
 	if associations.Len() == 0 || v == associations {
 		return v
@@ -244,6 +321,47 @@ func (v *structVal) Merge(associations dgo.Map) dgo.Map {
 	return c
 }
 
+func (v *structVal) MergeWith(associations dgo.Map, strategy dgo.MergeStrategy) dgo.Map {
+	if strategy == dgo.MergeShallow {
+		return v.Merge(associations)
+	}
+	if associations.Len() == 0 {
+		return v
+	}
+	c := v.toHashMap()
+	c.deepMerge(associations, strategy)
+	c.frozen = v.frozen && associations.Frozen()
+	return c
+}
+
+func (v *structVal) MustGetArray(key interface{}) dgo.Array {
+	return mustGet(v, key, DefaultArrayType).(dgo.Array)
+}
+
+func (v *structVal) MustGetBool(key interface{}) bool {
+	return mustGet(v, key, DefaultBooleanType).(dgo.Boolean).GoBool()
+}
+
+func (v *structVal) MustGetFloat(key interface{}) float64 {
+	return mustGet(v, key, DefaultFloatType).(dgo.Float).GoFloat()
+}
+
+func (v *structVal) MustGetInt(key interface{}) int64 {
+	return mustGet(v, key, DefaultIntegerType).(dgo.Integer).GoInt()
+}
+
+func (v *structVal) MustGetMap(key interface{}) dgo.Map {
+	return mustGet(v, key, DefaultMapType).(dgo.Map)
+}
+
+func (v *structVal) MustGetString(key interface{}) string {
+	return mustGet(v, key, DefaultStringType).(dgo.String).GoString()
+}
+
+func (v *structVal) None(predicate dgo.EntryPredicate) bool {
+	return !v.Any(predicate)
+}
+
 func (v *structVal) Put(key, value interface{}) dgo.Value {
 	if v.frozen {
 		panic(frozenMap(`Put`))
@@ -260,6 +378,17 @@ func (v *structVal) Put(key, value interface{}) dgo.Value {
 	panic(fmt.Errorf(`%s has no field named '%s'`, v.rs.Type(), key))
 }
 
+func (v *structVal) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	if v.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	// All fields of a struct are always present
+	if old := v.Get(key); old != nil {
+		return old, false
+	}
+	panic(fmt.Errorf(`%s has no field named '%s'`, v.rs.Type(), key))
+}
+
 func (v *structVal) PutAll(associations dgo.Map) {
 	associations.EachEntry(func(e dgo.MapEntry) { v.Put(e.Key(), e.Value()) })
 }
@@ -288,6 +417,12 @@ func (v *structVal) RemoveAll(keys dgo.Array) {
 	panic(errors.New(`struct fields cannot be removed`))
 }
 
+func (v *structVal) RenameKeys(mapping dgo.Map) dgo.Map {
+	c := v.toHashMap()
+	c.frozen = v.frozen
+	return c.RenameKeys(mapping)
+}
+
 func (v *structVal) String() string {
 	return util.ToStringERP(v)
 }
@@ -296,6 +431,26 @@ func (v *structVal) StringKeys() bool {
 	return true
 }
 
+func (v *structVal) ToFlatSlice() []interface{} {
+	s := make([]interface{}, 0, v.Len()*2)
+	v.EachEntry(func(e dgo.MapEntry) { s = append(s, e.Key(), e.Value()) })
+	return s
+}
+
+func (v *structVal) ToStructSlice(mapper dgo.EntryMapper) []interface{} {
+	s := make([]interface{}, 0, v.Len())
+	v.EachEntry(func(e dgo.MapEntry) { s = append(s, mapper(e)) })
+	return s
+}
+
+func (v *structVal) Transform(schema dgo.StructMapType) (dgo.Map, error) {
+	return transform(v, schema)
+}
+
+func (v *structVal) TransformEntries(transformer dgo.EntryTransformer, target dgo.MapType) dgo.Map {
+	return transformEntries(v, v.frozen, transformer, target)
+}
+
 func (v *structVal) Type() dgo.Type {
 	et := &exactMapType{value: v}
 	et.ExactType = et
@@ -313,6 +468,10 @@ func (v *structVal) With(key, value interface{}) dgo.Map {
 	return c
 }
 
+func (v *structVal) WithDefaults(schema dgo.StructMapType) dgo.Map {
+	return withDefaults(v, v.frozen, schema)
+}
+
 func (v *structVal) Without(key interface{}) dgo.Map {
 	if v.Get(key) == nil {
 		return v
@@ -7,6 +7,8 @@ import (
 
 	"github.com/lyraproj/dgo/dgo"
 	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
 	"github.com/lyraproj/dgo/vf"
 )
 
@@ -25,6 +27,21 @@ func Test_structMap_Any(t *testing.T) {
 	}))
 }
 
+func Test_structMap_None(t *testing.T) {
+	type structA struct {
+		First  int
+		Second float64
+		Third  string
+	}
+	m := vf.Map(&structA{1, 2.0, `three`})
+	require.True(t, m.None(func(e dgo.MapEntry) bool {
+		return e.Key().Equals(`Fourth`)
+	}))
+	require.False(t, m.None(func(e dgo.MapEntry) bool {
+		return e.Key().Equals(`Second`) && e.Value().Equals(2.0)
+	}))
+}
+
 func Test_structMap_AllKeys(t *testing.T) {
 	type structA struct {
 		First  int
@@ -160,6 +177,43 @@ func Test_structMap_Each(t *testing.T) {
 	require.Equal(t, vf.Values(`First`, 1, `Second`, 2.0, `Third`, `three`), vs)
 }
 
+func Test_structMap_Entries(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+	}
+	s := structA{A: `a`, B: 2}
+	m := vf.MutableMap(&s)
+	es := m.Entries()
+	require.True(t, es.Frozen())
+	require.Equal(t, vf.Values(vf.MapEntry(`A`, `a`), vf.MapEntry(`B`, 2)), es)
+	m.Put(`A`, `b`)
+	require.Equal(t, vf.MapEntry(`A`, `a`), es.Get(0))
+}
+
+func Test_structMap_ToFlatSlice(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+	}
+	m := vf.Map(&structA{A: `a`, B: 2})
+	require.Equal(t, []interface{}{vf.String(`A`), vf.String(`a`), vf.String(`B`), vf.Integer(2)}, m.ToFlatSlice())
+	require.Equal(t, []interface{}{`A`, `B`}, m.ToStructSlice(func(e dgo.MapEntry) interface{} {
+		return e.Key().String()
+	}))
+}
+
+func Test_structMap_Transform(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+	}
+	m := vf.Map(&structA{A: `a`, B: 2})
+	tm, err := m.Transform(tf.ParseType(`{A:string,B:float}`).(dgo.StructMapType))
+	require.Nil(t, err)
+	require.Equal(t, vf.Map(`A`, `a`, `B`, 2.0), tm)
+}
+
 func Test_structMap_Get(t *testing.T) {
 	type structA struct {
 		A string
@@ -272,6 +326,31 @@ func Test_structMap_GoStruct(t *testing.T) {
 	require.True(t, ok)
 }
 
+func Test_structMap_GetOrElse(t *testing.T) {
+	type structA struct {
+		A string
+		B *int
+	}
+	m := vf.Map(&structA{A: `a`})
+	require.Equal(t, `a`, m.GetOrElse(`A`, vf.String(`x`)))
+	require.Equal(t, vf.Nil, m.GetOrElse(`B`, vf.String(`x`)))
+	require.Equal(t, `x`, m.GetOrElse(`C`, vf.String(`x`)))
+	require.Equal(t, `a`, m.GetOrElseGet(`A`, func() dgo.Value { panic(`unexpected call`) }))
+	require.Equal(t, `y`, m.GetOrElseGet(`C`, func() dgo.Value { return vf.String(`y`) }))
+}
+
+func Test_structMap_Invert(t *testing.T) {
+	type structA struct {
+		A string
+		B string
+		C string
+	}
+	m := vf.Map(&structA{A: `x`, B: `y`, C: `x`})
+	require.Panic(t, func() { m.Invert(dgo.DuplicatePanic) }, `more than one association for the key 'x'`)
+	require.Equal(t, vf.Map(`x`, `A`, `y`, `B`), m.Invert(dgo.DuplicateKeepFirst))
+	require.Equal(t, vf.Map(`x`, vf.Values(`A`, `C`), `y`, vf.Values(`B`)), m.Invert(dgo.DuplicateCollect))
+}
+
 func Test_structMap_Keys(t *testing.T) {
 	type structA struct {
 		A string
@@ -300,6 +379,45 @@ func Test_structMap_Map(t *testing.T) {
 	}))
 }
 
+func Test_structMap_MapKeys(t *testing.T) {
+	type structA struct {
+		A int
+		B int
+	}
+	a := vf.Map(&structA{A: 1, B: 2})
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2), a.MapKeys(func(e dgo.MapEntry) interface{} {
+		return strings.ToLower(e.Key().String())
+	}, tf.Map(typ.String, typ.Integer)))
+	require.Panic(t, func() {
+		a.MapKeys(func(e dgo.MapEntry) interface{} { return e.Value() }, tf.Map(typ.String, typ.Integer))
+	}, `cannot be assigned`)
+}
+
+func Test_structMap_MapValues(t *testing.T) {
+	type structA struct {
+		A int
+		B int
+	}
+	a := vf.Map(&structA{A: 1, B: 2})
+	require.Equal(t, vf.Map(`A`, `1`, `B`, `2`), a.MapValues(func(e dgo.MapEntry) interface{} {
+		return e.Value().String()
+	}, tf.Map(typ.String, typ.String)))
+	require.Panic(t, func() {
+		a.MapValues(func(e dgo.MapEntry) interface{} { return e.Value() }, tf.Map(typ.String, typ.String))
+	}, `cannot be assigned`)
+}
+
+func Test_structMap_TransformEntries(t *testing.T) {
+	type structA struct {
+		A int
+		B int
+	}
+	a := vf.Map(&structA{A: 1, B: 2})
+	require.Equal(t, vf.Map(1, `A`, 2, `B`), a.TransformEntries(func(e dgo.MapEntry) (interface{}, interface{}) {
+		return e.Value(), e.Key()
+	}, nil))
+}
+
 func Test_structMap_Merge(t *testing.T) {
 	type structA struct {
 		First  int
@@ -323,6 +441,71 @@ func Test_structMap_Merge(t *testing.T) {
 	require.Same(t, m1, vf.Map().Merge(m1))
 }
 
+func Test_structMap_DeepMerge(t *testing.T) {
+	type structA struct {
+		First  map[string]int
+		Second []string
+	}
+	m1 := vf.Map(&structA{map[string]int{`a`: 1}, []string{`x`}})
+
+	m2 := vf.Map(
+		`First`, vf.Map(`b`, 2),
+		`Second`, vf.Strings(`y`))
+
+	require.Equal(t, vf.Map(
+		`First`, vf.Map(`a`, 1, `b`, 2),
+		`Second`, vf.Strings(`x`, `y`)), m1.DeepMerge(m2, true))
+	require.Same(t, m1, m1.DeepMerge(vf.Map(), false))
+	require.Equal(t, vf.Map(
+		`First`, vf.Map(`b`, 2),
+		`Second`, vf.Strings(`y`)), m1.MergeWith(m2, dgo.MergeShallow))
+	require.Equal(t, vf.Strings(`x`, `y`), m1.MergeWith(vf.Map(`Second`, vf.Strings(`x`, `y`)), dgo.MergeDeepUnique).Get(`Second`))
+}
+
+func Test_structMap_typedGetters(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+		C float64
+		D bool
+		E map[string]int
+		F []int
+	}
+	m := vf.Map(&structA{`a`, 1, 2.0, true, map[string]int{`x`: 1}, []int{1}})
+	s, ok := m.GetString(`A`)
+	require.True(t, ok)
+	require.Equal(t, `a`, s)
+	_, ok = m.GetString(`B`)
+	require.False(t, ok)
+	i, _ := m.GetInt(`B`)
+	require.Equal(t, 1, i)
+	f, _ := m.GetFloat(`C`)
+	require.Equal(t, 2.0, f)
+	b, _ := m.GetBool(`D`)
+	require.True(t, b)
+	_, ok = m.GetMap(`E`)
+	require.True(t, ok)
+	_, ok = m.GetArray(`F`)
+	require.True(t, ok)
+
+	require.Equal(t, `a`, m.MustGetString(`A`))
+	require.Equal(t, 1, m.MustGetInt(`B`))
+	require.Equal(t, 2.0, m.MustGetFloat(`C`))
+	require.True(t, m.MustGetBool(`D`))
+	require.Equal(t, vf.Map(`x`, 1), m.MustGetMap(`E`))
+	require.Equal(t, vf.Values(1), m.MustGetArray(`F`))
+	require.Panic(t, func() { m.MustGetString(`G`) }, `no value is associated with the key 'G'`)
+}
+
+func Test_structMap_RenameKeys(t *testing.T) {
+	type structA struct {
+		First  int
+		Second string
+	}
+	m := vf.Map(&structA{1, `two`})
+	require.Equal(t, vf.Map(`first`, 1, `Second`, `two`), m.RenameKeys(vf.Map(`First`, `first`)))
+}
+
 func Test_structMap_Put(t *testing.T) {
 	type structA struct {
 		A string
@@ -409,9 +592,37 @@ func Test_structMap_Remove(t *testing.T) {
 	s := structA{}
 	m := vf.Map(&s)
 	require.Panic(t, func() { m.Remove(`B`) }, `cannot be removed`)
+	require.Panic(t, func() { m.GetAndDelete(`B`) }, `cannot be removed`)
 	require.Panic(t, func() { m.RemoveAll(vf.Values(`A`, `B`)) }, `cannot be removed`)
 }
 
+func Test_structMap_ComputeIfAbsent(t *testing.T) {
+	type structA struct {
+		A string
+	}
+	s := structA{A: `a`}
+	m := vf.MutableMap(&s)
+	require.Equal(t, `a`, m.ComputeIfAbsent(`A`, func(dgo.Value) dgo.Value { return vf.String(`b`) }))
+	require.Panic(t, func() { m.ComputeIfAbsent(`B`, func(dgo.Value) dgo.Value { return vf.Nil }) },
+		`has no field named 'B'`)
+	require.Panic(t, func() { m.FrozenCopy().(dgo.Map).ComputeIfAbsent(`A`, nil) }, `ComputeIfAbsent .* frozen`)
+}
+
+func Test_structMap_PutIfAbsent(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+	}
+	s := structA{A: `a`}
+	m := vf.MutableMap(&s)
+	v, ok := m.PutIfAbsent(`A`, `b`)
+	require.False(t, ok)
+	require.Equal(t, `a`, v)
+	require.Equal(t, `a`, s.A)
+	require.Panic(t, func() { m.PutIfAbsent(`C`, 1) }, `has no field named 'C'`)
+	require.Panic(t, func() { m.FrozenCopy().(dgo.Map).PutIfAbsent(`A`, `b`) }, `PutIfAbsent .* frozen`)
+}
+
 func Test_structMap_String(t *testing.T) {
 	type structA struct {
 		A string
@@ -466,6 +677,17 @@ func Test_structMap_With(t *testing.T) {
 	require.Equal(t, m, vf.Map(`First`, 1, `Second`, 2.0, `Third`, `three`, `Fourth`, `quad`))
 }
 
+func Test_structMap_WithDefaults(t *testing.T) {
+	type structA struct {
+		A string
+		B int
+	}
+	schema := tf.ParseType(`{A:string,B?:int,C:"c" string}`).(dgo.StructMapType)
+	m := vf.Map(&structA{A: `a`})
+	require.Equal(t, vf.Map(`A`, `a`, `B`, 0, `C`, `c`), m.WithDefaults(schema))
+	require.Same(t, m, m.WithDefaults(tf.ParseType(`{A:string,B:2 int}`).(dgo.StructMapType)))
+}
+
 func Test_structMap_Without(t *testing.T) {
 	type structA struct {
 		First  int
@@ -0,0 +1,199 @@
+package internal
+
+import (
+	"sync"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	syncMap struct {
+		lock sync.RWMutex
+		m    dgo.Map
+
+		// promotes is true when Get modifies the wrapped map, as the Get of an LRUMap does, and must be called
+		// under the write lock
+		promotes bool
+	}
+
+	syncArray struct {
+		lock sync.RWMutex
+		a    dgo.Array
+	}
+)
+
+// SyncMap returns a new dgo.SyncMap that initially contains a copy of the entries of the given map. A nil map
+// results in an empty SyncMap.
+func SyncMap(m dgo.Map) dgo.SyncMap {
+	if m == nil {
+		m = MapWithCapacity(0)
+	} else {
+		m = m.Copy(false)
+		freezeValues(m)
+	}
+	_, promotes := m.(dgo.LRUMap)
+	return &syncMap{m: m, promotes: promotes}
+}
+
+// unfrozen returns true if the given value is, or is the exact type of, a value that isn't frozen
+func unfrozen(v dgo.Value) bool {
+	switch fv := v.(type) {
+	case dgo.Freezable:
+		return !fv.Frozen()
+	case dgo.ExactType:
+		f, ok := fv.ExactValue().(dgo.Freezable)
+		return ok && !f.Frozen()
+	}
+	return false
+}
+
+func (s *syncMap) CompareAndSet(key, expected, value interface{}) bool {
+	k := Value(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cv := s.m.Get(k)
+	if expected == nil {
+		if cv != nil {
+			return false
+		}
+	} else if cv == nil || !cv.Equals(expected) {
+		return false
+	}
+	s.m.Put(k, frozenCopy(Value(value)))
+	return true
+}
+
+func (s *syncMap) Get(key interface{}) dgo.Value {
+	if s.promotes {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	} else {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+	return s.m.Get(key)
+}
+
+func (s *syncMap) GetOrCompute(key interface{}, computer func(key dgo.Value) dgo.Value) dgo.Value {
+	k := Value(key)
+	if v := s.Get(k); v != nil {
+		return v
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m.ComputeIfAbsent(k, func(k dgo.Value) dgo.Value { return frozenCopy(computer(k)) })
+}
+
+func (s *syncMap) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.m.Len()
+}
+
+func (s *syncMap) Put(key, value interface{}) dgo.Value {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m.Put(key, frozenCopy(Value(value)))
+}
+
+func (s *syncMap) Remove(key interface{}) dgo.Value {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m.Remove(key)
+}
+
+func (s *syncMap) Snapshot() dgo.Map {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.m.Copy(true)
+}
+
+func (s *syncMap) Update(updater func(m dgo.Map)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	updater(s.m)
+	freezeValues(s.m)
+}
+
+// freezeValues replaces the values of the given map that aren't frozen with frozen copies so that they can be
+// shared outside of the lock
+func freezeValues(m dgo.Map) {
+	var ks []dgo.Value
+	m.EachEntry(func(e dgo.MapEntry) {
+		if unfrozen(e.Value()) {
+			ks = append(ks, e.Key())
+		}
+	})
+	for _, k := range ks {
+		m.Put(k, frozenCopy(m.Get(k)))
+	}
+}
+
+// SyncArray returns a new dgo.SyncArray that initially contains a copy of the elements of the given array. A nil
+// array results in an empty SyncArray.
+func SyncArray(a dgo.Array) dgo.SyncArray {
+	if a == nil {
+		a = ArrayWithCapacity(0)
+	} else {
+		a = a.Copy(false)
+		freezeElements(a)
+	}
+	return &syncArray{a: a}
+}
+
+func (s *syncArray) Add(value interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.a.Add(frozenCopy(Value(value)))
+}
+
+func (s *syncArray) CompareAndSet(pos int, expected, value interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.a.Get(pos).Equals(expected) {
+		return false
+	}
+	s.a.Set(pos, frozenCopy(Value(value)))
+	return true
+}
+
+func (s *syncArray) Get(pos int) dgo.Value {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.a.Get(pos)
+}
+
+func (s *syncArray) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.a.Len()
+}
+
+func (s *syncArray) Set(pos int, value interface{}) dgo.Value {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.a.Set(pos, frozenCopy(Value(value)))
+}
+
+func (s *syncArray) Snapshot() dgo.Array {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.a.Copy(true)
+}
+
+func (s *syncArray) Update(updater func(a dgo.Array)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	updater(s.a)
+	freezeElements(s.a)
+}
+
+// freezeElements replaces the elements of the given array that aren't frozen with frozen copies so that they can
+// be shared outside of the lock
+func freezeElements(a dgo.Array) {
+	for i, n := 0, a.Len(); i < n; i++ {
+		if e := a.Get(i); unfrozen(e) {
+			a.Set(i, frozenCopy(e))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package internal_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestSyncMap(t *testing.T) {
+	src := vf.MutableMap(`a`, 1)
+	m := vf.SyncMap(src)
+	src.Put(`b`, 2)
+	require.Equal(t, 1, m.Len())
+	require.Equal(t, 1, m.Get(`a`))
+	require.Nil(t, m.Put(`b`, 2))
+	require.Equal(t, 2, m.Remove(`b`))
+
+	require.False(t, m.CompareAndSet(`a`, 2, 3))
+	require.True(t, m.CompareAndSet(`a`, 1, 3))
+	require.False(t, m.CompareAndSet(`a`, nil, 4))
+	require.True(t, m.CompareAndSet(`c`, nil, 4))
+	require.False(t, m.CompareAndSet(`d`, 1, 5))
+
+	require.Equal(t, 3, m.GetOrCompute(`a`, func(k dgo.Value) dgo.Value { return vf.Integer(0) }))
+	require.Equal(t, `dd`, m.GetOrCompute(`d`, func(k dgo.Value) dgo.Value { return vf.String(k.String() + k.String()) }))
+
+	m.Update(func(um dgo.Map) {
+		um.Put(`e`, 5)
+		um.Remove(`c`)
+	})
+	s := m.Snapshot()
+	require.True(t, s.Frozen())
+	require.Equal(t, vf.Map(`a`, 3, `d`, `dd`, `e`, 5), s)
+	require.Equal(t, 0, vf.SyncMap(nil).Len())
+}
+
+func TestSyncMap_concurrent(t *testing.T) {
+	m := vf.SyncMap(nil)
+	m.Put(`count`, 0)
+	calls := vf.SyncArray(nil)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				for {
+					c := m.Get(`count`).(dgo.Integer)
+					if m.CompareAndSet(`count`, c, c.GoInt()+1) {
+						break
+					}
+				}
+				m.GetOrCompute(`once`, func(k dgo.Value) dgo.Value {
+					calls.Add(k)
+					return k
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1000, m.Get(`count`))
+	require.Equal(t, 1, calls.Len())
+}
+
+func TestSyncMap_lru(t *testing.T) {
+	lru := vf.LRUMap(3, nil, nil)
+	lru.Put(`a`, 1)
+	lru.Put(`b`, 2)
+	lru.Put(`c`, 3)
+	m := vf.SyncMap(lru)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Get(`a`)
+				m.Get(`b`)
+				m.GetOrCompute(`c`, func(k dgo.Value) dgo.Value { return k })
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 3, m.Len())
+
+	// b is the least recently used entry since a and c were promoted by Get
+	m.Get(`a`)
+	m.Get(`c`)
+	m.Put(`d`, 4)
+	require.Nil(t, m.Get(`b`))
+	require.Equal(t, 1, m.Get(`a`))
+}
+
+func TestSyncArray(t *testing.T) {
+	src := vf.MutableValues(1, 2)
+	a := vf.SyncArray(src)
+	src.Add(3)
+	require.Equal(t, 2, a.Len())
+	a.Add(3)
+	require.Equal(t, 3, a.Get(2))
+	require.Equal(t, 2, a.Set(1, 20))
+	require.False(t, a.CompareAndSet(0, 2, 10))
+	require.True(t, a.CompareAndSet(0, 1, 10))
+	a.Update(func(ua dgo.Array) { ua.Add(4) })
+	s := a.Snapshot()
+	require.True(t, s.Frozen())
+	require.Equal(t, vf.Values(10, 20, 3, 4), s)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.Add(i)
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, 14, a.Len())
+}
+
+func TestSyncMap_frozenValues(t *testing.T) {
+	m := vf.SyncMap(vf.MutableMap(`a`, vf.MutableValues(1)))
+	require.Nil(t, vf.AssertFrozen(m.Get(`a`)))
+
+	v := vf.MutableValues(2)
+	m.Put(`b`, v)
+	v.Add(3)
+	require.Equal(t, vf.Values(2), m.Get(`b`))
+	require.True(t, m.Get(`b`).(dgo.Array).Frozen())
+
+	require.True(t, m.CompareAndSet(`c`, nil, vf.MutableValues(4)))
+	require.True(t, m.Get(`c`).(dgo.Array).Frozen())
+	m.GetOrCompute(`d`, func(k dgo.Value) dgo.Value { return vf.MutableValues(5) })
+	require.True(t, m.Get(`d`).(dgo.Array).Frozen())
+	m.Update(func(um dgo.Map) { um.Put(`e`, vf.MutableValues(6)) })
+	require.True(t, m.Get(`e`).(dgo.Array).Frozen())
+	require.Nil(t, vf.AssertFrozen(m.Snapshot()))
+}
+
+func TestSyncArray_frozenValues(t *testing.T) {
+	a := vf.SyncArray(vf.MutableValues(vf.MutableValues(1)))
+	require.Nil(t, vf.AssertFrozen(a.Get(0)))
+
+	v := vf.MutableValues(2)
+	a.Add(v)
+	v.Add(3)
+	require.Equal(t, vf.Values(2), a.Get(1))
+	require.True(t, a.Get(1).(dgo.Array).Frozen())
+
+	a.Set(0, vf.MutableValues(4))
+	require.True(t, a.Get(0).(dgo.Array).Frozen())
+	require.True(t, a.CompareAndSet(0, vf.Values(4), vf.MutableValues(5)))
+	require.True(t, a.Get(0).(dgo.Array).Frozen())
+	a.Update(func(ua dgo.Array) { ua.Add(vf.MutableValues(6)) })
+	require.True(t, a.Get(2).(dgo.Array).Frozen())
+	require.Nil(t, vf.AssertFrozen(a.Snapshot()))
+}
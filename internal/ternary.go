@@ -73,6 +73,10 @@ func (t *allOfType) AssignableTo(guard dgo.RecursionGuard, other dgo.Type) bool
 	return true
 }
 
+func (t *allOfType) Describe() string {
+	return Describe(t)
+}
+
 func (t *allOfType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
@@ -177,6 +181,10 @@ func (t *allOfValueType) AssignableTo(guard dgo.RecursionGuard, other dgo.Type)
 	return true
 }
 
+func (t *allOfValueType) Describe() string {
+	return Describe(t)
+}
+
 func (t *allOfValueType) Generic() dgo.Type {
 	return commonGeneric(t.slice, valueAsType)
 }
@@ -292,6 +300,10 @@ func (t *anyOfType) AssignableTo(guard dgo.RecursionGuard, other dgo.Type) bool
 	return len(ts) > 0
 }
 
+func (t *anyOfType) Describe() string {
+	return Describe(t)
+}
+
 func (t *anyOfType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
@@ -410,6 +422,10 @@ func (t *oneOfType) AssignableTo(guard dgo.RecursionGuard, other dgo.Type) bool
 	return len(ts) > 0
 }
 
+func (t *oneOfType) Describe() string {
+	return Describe(t)
+}
+
 func (t *oneOfType) Equals(other interface{}) bool {
 	return equals(nil, t, other)
 }
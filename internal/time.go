@@ -16,6 +16,11 @@ type (
 		value *timeVal
 	}
 
+	timeRangeType struct {
+		min time.Time
+		max time.Time
+	}
+
 	timeVal time.Time
 )
 
@@ -24,14 +29,65 @@ const DefaultTimeType = timeType(0)
 
 var reflectTimeType = reflect.TypeOf(time.Time{})
 
+// TimeType returns a dgo.TimeType that is limited to the inclusive range given by min and max. A zero min or max
+// means that the range is unbounded in that direction.
+func TimeType(min, max time.Time) dgo.TimeType {
+	if min.IsZero() && max.IsZero() {
+		return DefaultTimeType
+	}
+	if !(min.IsZero() || max.IsZero()) {
+		if max.Before(min) {
+			min, max = max, min
+		}
+		if min.Equal(max) {
+			return Time(min).Type().(dgo.TimeType)
+		}
+	}
+	return &timeRangeType{min: min, max: max}
+}
+
+// TimeTypeFromArgs returns a dgo.TimeType created from the given arguments. No arguments gives the unconstrained
+// type, one argument gives the exact type for that time, and two arguments give a range. Each argument must be a
+// time, a string using the time.RFC3339Nano format, or nil (unbounded).
+func TimeTypeFromArgs(args []interface{}) dgo.TimeType {
+	switch len(args) {
+	case 0:
+		return DefaultTimeType
+	case 1:
+		if tv := timeArg(args, 0); !tv.IsZero() {
+			return Time(tv).Type().(dgo.TimeType)
+		}
+		panic(illegalArgument(`TimeType`, `Time or String`, args, 0))
+	case 2:
+		return TimeType(timeArg(args, 0), timeArg(args, 1))
+	}
+	panic(illegalArgumentCount(`TimeType`, 0, 2, len(args)))
+}
+
+func timeArg(args []interface{}, argno int) time.Time {
+	switch a := Value(args[argno]).(type) {
+	case dgo.Time:
+		return a.GoTime()
+	case dgo.String:
+		return TimeFromString(a.GoString()).GoTime()
+	case nilValue:
+		return time.Time{}
+	}
+	panic(illegalArgument(`TimeType`, `Time, String, or nil`, args, argno))
+}
+
 func (t timeType) Assignable(ot dgo.Type) bool {
 	switch ot.(type) {
-	case timeType, *exactTimeType:
+	case timeType, *exactTimeType, *timeRangeType:
 		return true
 	}
 	return CheckAssignableTo(nil, ot, t)
 }
 
+func (t timeType) Describe() string {
+	return Describe(t)
+}
+
 func (t timeType) Equals(v interface{}) bool {
 	return t == v
 }
@@ -48,6 +104,18 @@ func (t timeType) Instance(v interface{}) bool {
 	return false
 }
 
+func (t timeType) IsInstance(tv time.Time) bool {
+	return true
+}
+
+func (t timeType) Max() time.Time {
+	return time.Time{}
+}
+
+func (t timeType) Min() time.Time {
+	return time.Time{}
+}
+
 func (t timeType) New(arg dgo.Value) dgo.Value {
 	return newTime(t, arg)
 }
@@ -76,6 +144,14 @@ func (t *exactTimeType) IsInstance(tv time.Time) bool {
 	return (*time.Time)(t.value).Equal(tv)
 }
 
+func (t *exactTimeType) Max() time.Time {
+	return t.value.GoTime()
+}
+
+func (t *exactTimeType) Min() time.Time {
+	return t.value.GoTime()
+}
+
 func (t *exactTimeType) New(arg dgo.Value) dgo.Value {
 	return newTime(t, arg)
 }
@@ -92,6 +168,90 @@ func (t *exactTimeType) ExactValue() dgo.Value {
 	return t.value
 }
 
+func (t *timeRangeType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactTimeType:
+		return t.IsInstance(ot.value.GoTime())
+	case *timeRangeType:
+		return t.includes(ot.min, ot.max)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+// includes returns true if the range given by min and max is within the range of this type
+func (t *timeRangeType) includes(min, max time.Time) bool {
+	if !t.min.IsZero() && (min.IsZero() || min.Before(t.min)) {
+		return false
+	}
+	return t.max.IsZero() || !(max.IsZero() || max.After(t.max))
+}
+
+func (t *timeRangeType) Describe() string {
+	return Describe(t)
+}
+
+func (t *timeRangeType) Equals(other interface{}) bool {
+	if ot, ok := other.(*timeRangeType); ok {
+		return t.min.Equal(ot.min) && t.max.Equal(ot.max)
+	}
+	return false
+}
+
+func (t *timeRangeType) HashCode() int {
+	h := int(dgo.TiTimeRange)
+	if !t.min.IsZero() {
+		h = h*31 + int(t.min.UnixNano())
+	}
+	if !t.max.IsZero() {
+		h = h*31 + int(t.max.UnixNano())
+	}
+	return h
+}
+
+func (t *timeRangeType) Instance(v interface{}) bool {
+	switch v := v.(type) {
+	case *timeVal:
+		return t.IsInstance(v.GoTime())
+	case *time.Time:
+		return t.IsInstance(*v)
+	case time.Time:
+		return t.IsInstance(v)
+	}
+	return false
+}
+
+func (t *timeRangeType) IsInstance(tv time.Time) bool {
+	return (t.min.IsZero() || !tv.Before(t.min)) && (t.max.IsZero() || !tv.After(t.max))
+}
+
+func (t *timeRangeType) Max() time.Time {
+	return t.max
+}
+
+func (t *timeRangeType) Min() time.Time {
+	return t.min
+}
+
+func (t *timeRangeType) New(arg dgo.Value) dgo.Value {
+	return newTime(t, arg)
+}
+
+func (t *timeRangeType) ReflectType() reflect.Type {
+	return reflectTimeType
+}
+
+func (t *timeRangeType) String() string {
+	return TypeString(t)
+}
+
+func (t *timeRangeType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *timeRangeType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiTimeRange
+}
+
 func newTime(t dgo.Type, arg dgo.Value) dgo.Time {
 	if args, ok := arg.(dgo.Arguments); ok {
 		args.AssertSize(`time`, 1, 1)
@@ -8,6 +8,7 @@ import (
 	"github.com/lyraproj/dgo/dgo"
 
 	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
 	"github.com/lyraproj/dgo/typ"
 	"github.com/lyraproj/dgo/vf"
 )
@@ -75,6 +76,55 @@ func TestTimeExact(t *testing.T) {
 	require.Equal(t, typ.Time.ReflectType(), tp.ReflectType())
 }
 
+func TestTimeRange(t *testing.T) {
+	t1, _ := time.Parse(time.RFC3339, `2019-01-01T00:00:00Z`)
+	t2, _ := time.Parse(time.RFC3339, `2020-01-01T00:00:00Z`)
+	t3, _ := time.Parse(time.RFC3339, `2021-01-01T00:00:00Z`)
+	tp := tf.Time(t1, t3)
+	require.Equal(t, tp, tf.Time(t3, t1))
+	require.Equal(t, t1, tp.Min())
+	require.Equal(t, t3, tp.Max())
+	require.Instance(t, tp, t1)
+	require.Instance(t, tp, &t2)
+	require.Instance(t, tp, vf.Time(t3))
+	require.NotInstance(t, tp, t1.Add(-1))
+	require.NotInstance(t, tp, t3.Add(1))
+	require.NotInstance(t, tp, `2020-01-01T00:00:00Z`)
+
+	require.Assignable(t, typ.Time, tp)
+	require.NotAssignable(t, tp, typ.Time)
+	require.Assignable(t, tp, vf.Time(t2).Type())
+	require.NotAssignable(t, tp, vf.Time(t3.Add(1)).Type())
+	require.Assignable(t, tp, tf.Time(t1, t2))
+	require.NotAssignable(t, tp, tf.Time(t2, time.Time{}))
+	require.NotAssignable(t, tp, tf.Time(time.Time{}, t2))
+	require.NotAssignable(t, tf.Time(t1, t2), tp)
+	require.Assignable(t, tf.Time(time.Time{}, t3), tf.Time(time.Time{}, t2))
+	require.Assignable(t, tf.Time(t1, time.Time{}), tf.Time(t2, time.Time{}))
+
+	require.Equal(t, tp, tp)
+	require.NotEqual(t, tp, tf.Time(t1, t2))
+	require.NotEqual(t, tp, typ.Time)
+	require.Equal(t, tp.HashCode(), tf.Time(t1, t3).HashCode())
+	require.NotEqual(t, tp.HashCode(), tf.Time(t1, time.Time{}).HashCode())
+	require.Instance(t, tp.Type(), tp)
+	require.Equal(t, typ.Time.ReflectType(), tp.ReflectType())
+
+	require.Equal(t, `time["2019-01-01T00:00:00Z","2021-01-01T00:00:00Z"]`, tp.String())
+	require.Equal(t, `time[nil,"2021-01-01T00:00:00Z"]`, tf.Time(time.Time{}, t3).String())
+	require.Equal(t, `time["2019-01-01T00:00:00Z",nil]`, tf.Time(t1, time.Time{}).String())
+
+	require.Same(t, typ.Time, tf.Time(time.Time{}, time.Time{}))
+	require.Equal(t, vf.Time(t1).Type(), tf.Time(t1, t1))
+	require.True(t, typ.Time.(dgo.TimeType).Min().IsZero())
+	require.True(t, typ.Time.(dgo.TimeType).Max().IsZero())
+	require.True(t, typ.Time.(dgo.TimeType).IsInstance(t1))
+	require.Equal(t, t1, vf.Time(t1).Type().(dgo.TimeType).Max())
+
+	require.Equal(t, vf.Time(t2), vf.New(tp, vf.String(`2020-01-01T00:00:00Z`)))
+	require.Panic(t, func() { vf.New(tp, vf.String(`2022-01-01T00:00:00Z`)) }, `cannot be assigned`)
+}
+
 func TestTime(t *testing.T) {
 	ts, _ := time.Parse(time.RFC3339, `2019-10-06T07:15:00-07:00`)
 	zt, _ := time.Parse(time.RFC3339, `2019-10-06T16:15:00+02:00`)
@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// timestampedMap is a dgo.TimestampedMap that is backed by a hashMap. The time of each Put is kept in a parallel
+// hashMap that uses the same keys.
+type timestampedMap struct {
+	*hashMap
+	times *hashMap
+}
+
+// TimestampedMap returns a new empty and mutable dgo.TimestampedMap
+func TimestampedMap() dgo.TimestampedMap {
+	return &timestampedMap{hashMap: MapWithCapacity(0).(*hashMap), times: MapWithCapacity(0).(*hashMap)}
+}
+
+// clone returns a mutable copy of this map that shares the keys, values, and timestamps of this map
+func (m *timestampedMap) clone() *timestampedMap {
+	c := &timestampedMap{hashMap: &hashMap{len: m.len}, times: &hashMap{len: m.times.len}}
+	m.hashMap.resize(c.hashMap, 0)
+	m.times.resize(c.times, 0)
+	return c
+}
+
+// wrap returns a timestampedMap that is backed by the given map and has the timestamps of this map for all keys of
+// that map.
+func (m *timestampedMap) wrap(g dgo.Map) dgo.Map {
+	if g == m.hashMap {
+		return m
+	}
+	h := g.(*hashMap)
+	times := MapWithCapacity(h.len).(*hashMap)
+	for e := h.first; e != nil; e = e.next {
+		times.Put(e.key, m.times.Get(e.key))
+	}
+	times.frozen = h.frozen
+	return &timestampedMap{hashMap: h, times: times}
+}
+
+func (m *timestampedMap) ComputeIfAbsent(key interface{}, computer func(dgo.Value) dgo.Value) dgo.Value {
+	if m.frozen {
+		panic(frozenMap(`ComputeIfAbsent`))
+	}
+	k := Value(key)
+	if v := m.Get(k); v != nil {
+		return v
+	}
+	v := Value(computer(k))
+	m.Put(k, v)
+	return v
+}
+
+func (m *timestampedMap) Copy(frozen bool) dgo.Map {
+	return m.wrap(m.hashMap.Copy(frozen))
+}
+
+func (m *timestampedMap) Freeze() {
+	m.hashMap.Freeze()
+	m.times.frozen = true
+}
+
+func (m *timestampedMap) FrozenCopy() dgo.Value {
+	return m.Copy(true)
+}
+
+func (m *timestampedMap) ThawedCopy() dgo.Value {
+	return m.Copy(false)
+}
+
+func (m *timestampedMap) GetAndDelete(key interface{}) (dgo.Value, bool) {
+	v, ok := m.hashMap.GetAndDelete(key)
+	if ok {
+		m.times.Remove(key)
+	}
+	return v, ok
+}
+
+// Put associates the given value with the given key and records the current time as the timestamp of the key.
+func (m *timestampedMap) Put(key, value interface{}) dgo.Value {
+	k := Value(key)
+	old := m.hashMap.Put(k, value)
+	m.times.Put(k, Time(time.Now()))
+	return old
+}
+
+func (m *timestampedMap) PutAll(associations dgo.Map) {
+	if associations.Len() == 0 {
+		return
+	}
+	if m.frozen {
+		panic(frozenMap(`PutAll`))
+	}
+	associations.EachEntry(func(e dgo.MapEntry) { m.Put(e.Key(), e.Value()) })
+}
+
+func (m *timestampedMap) PutIfAbsent(key, value interface{}) (dgo.Value, bool) {
+	if m.frozen {
+		panic(frozenMap(`PutIfAbsent`))
+	}
+	k := Value(key)
+	if old := m.Get(k); old != nil {
+		return old, false
+	}
+	v := Value(value)
+	m.Put(k, v)
+	return v, true
+}
+
+func (m *timestampedMap) Remove(key interface{}) dgo.Value {
+	old := m.hashMap.Remove(key)
+	if old != nil {
+		m.times.Remove(key)
+	}
+	return old
+}
+
+func (m *timestampedMap) RemoveAll(keys dgo.Array) {
+	m.hashMap.RemoveAll(keys)
+	m.times.RemoveAll(keys)
+}
+
+func (m *timestampedMap) String() string {
+	return m.hashMap.String()
+}
+
+func (m *timestampedMap) TimestampOf(key interface{}) (time.Time, bool) {
+	if t, ok := m.times.Get(key).(dgo.Time); ok {
+		return t.GoTime(), true
+	}
+	return time.Time{}, false
+}
+
+func (m *timestampedMap) Type() dgo.Type {
+	et := &exactMapType{value: m}
+	et.ExactType = et
+	return et
+}
+
+func (m *timestampedMap) With(key, value interface{}) dgo.Map {
+	v := Value(value)
+	if v.Equals(m.hashMap.Get(key)) {
+		return m
+	}
+	c := m.clone()
+	c.Put(key, v)
+	c.hashMap.frozen = m.frozen
+	c.times.frozen = m.frozen
+	return c
+}
+
+func (m *timestampedMap) Without(key interface{}) dgo.Map {
+	return m.wrap(m.hashMap.Without(key))
+}
+
+func (m *timestampedMap) WithoutAll(keys dgo.Array) dgo.Map {
+	return m.wrap(m.hashMap.WithoutAll(keys))
+}
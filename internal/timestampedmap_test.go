@@ -0,0 +1,104 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestTimestampedMap(t *testing.T) {
+	m := vf.TimestampedMap()
+	start := time.Now()
+	m.Put(`a`, 1)
+	m.Put(`b`, 2)
+	ta, ok := m.TimestampOf(`a`)
+	require.True(t, ok)
+	tb, _ := m.TimestampOf(`b`)
+	require.False(t, ta.Before(start))
+	require.False(t, tb.Before(ta))
+
+	m.Put(`a`, 3)
+	ta2, _ := m.TimestampOf(`a`)
+	require.False(t, ta2.Before(tb))
+	require.Equal(t, vf.Values(`a`, `b`), m.Keys())
+
+	_, ok = m.TimestampOf(`c`)
+	require.False(t, ok)
+
+	require.Equal(t, 3, m.Remove(`a`))
+	_, ok = m.TimestampOf(`a`)
+	require.False(t, ok)
+	require.Nil(t, m.Remove(`a`))
+
+	v, ok := m.PutIfAbsent(`c`, 4)
+	require.True(t, ok)
+	require.Equal(t, 4, v)
+	_, ok = m.PutIfAbsent(`c`, 5)
+	require.False(t, ok)
+	require.Equal(t, 6, m.ComputeIfAbsent(`d`, func(dgo.Value) dgo.Value { return vf.Integer(6) }))
+	require.Equal(t, 6, m.ComputeIfAbsent(`d`, nil))
+	m.PutAll(vf.Map(`e`, 7))
+	m.PutAll(vf.Map())
+	for _, k := range []string{`b`, `c`, `d`, `e`} {
+		_, ok = m.TimestampOf(k)
+		require.True(t, ok)
+	}
+
+	v, ok = m.GetAndDelete(`b`)
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	_, ok = m.TimestampOf(`b`)
+	require.False(t, ok)
+	_, ok = m.GetAndDelete(`b`)
+	require.False(t, ok)
+
+	m.RemoveAll(vf.Values(`c`, `d`))
+	_, ok = m.TimestampOf(`c`)
+	require.False(t, ok)
+	require.Equal(t, `{"e":7}`, m.String())
+	require.Instance(t, m.Type(), m)
+}
+
+func TestTimestampedMap_derived(t *testing.T) {
+	m := vf.TimestampedMap()
+	m.Put(`a`, 1)
+	m.Put(`b`, 2)
+	ta, _ := m.TimestampOf(`a`)
+
+	w := m.With(`c`, 3).(dgo.TimestampedMap)
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2, `c`, 3), w)
+	wa, _ := w.TimestampOf(`a`)
+	require.Equal(t, ta, wa)
+	_, ok := w.TimestampOf(`c`)
+	require.True(t, ok)
+	require.Same(t, m, m.With(`a`, 1))
+
+	wo := m.Without(`b`).(dgo.TimestampedMap)
+	require.Equal(t, vf.Map(`a`, 1), wo)
+	_, ok = wo.TimestampOf(`b`)
+	require.False(t, ok)
+	wa, _ = wo.TimestampOf(`a`)
+	require.Equal(t, ta, wa)
+	require.Equal(t, vf.Map(`b`, 2), m.WithoutAll(vf.Values(`a`)))
+
+	f := m.FrozenCopy().(dgo.TimestampedMap)
+	require.True(t, f.Frozen())
+	require.Same(t, f, f.FrozenCopy())
+	wa, _ = f.TimestampOf(`a`)
+	require.Equal(t, ta, wa)
+	require.Panic(t, func() { f.Put(`c`, 3) }, `Put .* frozen`)
+	require.Panic(t, func() { f.PutAll(vf.Map(`c`, 3)) }, `PutAll .* frozen`)
+	require.Panic(t, func() { f.PutIfAbsent(`c`, 3) }, `PutIfAbsent .* frozen`)
+	require.Panic(t, func() { f.ComputeIfAbsent(`c`, nil) }, `ComputeIfAbsent .* frozen`)
+	require.True(t, f.With(`c`, 3).Frozen())
+
+	c := f.ThawedCopy().(dgo.TimestampedMap)
+	c.Put(`c`, 3)
+	require.Equal(t, vf.Map(`a`, 1, `b`, 2, `c`, 3), c)
+
+	m.Freeze()
+	require.Panic(t, func() { m.Put(`c`, 3) }, `Put .* frozen`)
+}
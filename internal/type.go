@@ -62,6 +62,20 @@ func AsType(value dgo.Value) dgo.Type {
 	return value.Type()
 }
 
+// ToType returns the given value and true if the value is a dgo.Type. Otherwise it returns nil and false.
+func ToType(value dgo.Value) (dgo.Type, bool) {
+	tp, ok := value.(dgo.Type)
+	return tp, ok
+}
+
+// MustToType returns the given value if it is a dgo.Type. Otherwise it panics.
+func MustToType(value dgo.Value) dgo.Type {
+	if tp, ok := value.(dgo.Type); ok {
+		return tp
+	}
+	panic(IllegalAssignment(DefaultMetaType, value))
+}
+
 // ExactValue returns the "exact value" that a value represents. If the given value is a dgo.ExactType, then the value
 // that it represents is the exact value. For all other cases, the exact value is the value itself.
 func ExactValue(value dgo.Value) dgo.Value {
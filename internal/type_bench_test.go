@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+var benchDepths = []int{1, 5, 10}
+
+// nestedArrayType returns []...[]int nested to the given depth
+func nestedArrayType(depth int) dgo.Type {
+	var t dgo.Type = DefaultIntegerType
+	for i := 0; i < depth; i++ {
+		t = ArrayType([]interface{}{t})
+	}
+	return t
+}
+
+// nestedArray returns {...{1,2,3}...} nested to the given depth
+func nestedArray(depth int) dgo.Value {
+	var v dgo.Value = Values([]interface{}{1, 2, 3})
+	for i := 1; i < depth; i++ {
+		v = Values([]interface{}{v, v, v})
+	}
+	return v
+}
+
+// nestedAnyOfType returns an anyOf type nested to the given depth where each level has the given breadth
+func nestedAnyOfType(depth, breadth int) dgo.Type {
+	var t dgo.Type = DefaultBooleanType
+	for i := 0; i < depth; i++ {
+		ts := make([]interface{}, breadth)
+		for j := 0; j < breadth-1; j++ {
+			ts[j] = StringType([]interface{}{i*breadth + j})
+		}
+		ts[breadth-1] = t
+		t = AnyOfType(ts)
+	}
+	return t
+}
+
+// nestedTupleType returns {int,string,{int,string,...}} nested to the given depth
+func nestedTupleType(depth int) dgo.Type {
+	var t dgo.Type = TupleType([]interface{}{DefaultIntegerType, DefaultStringType})
+	for i := 1; i < depth; i++ {
+		t = TupleType([]interface{}{DefaultIntegerType, DefaultStringType, t})
+	}
+	return t
+}
+
+// nestedTuple returns a value that is an instance of the nestedTupleType of the same depth
+func nestedTuple(depth int) dgo.Value {
+	var v dgo.Value = Values([]interface{}{1, `a`})
+	for i := 1; i < depth; i++ {
+		v = Values([]interface{}{1, `a`, v})
+	}
+	return v
+}
+
+// nestedStructMapType returns {a:int,b?:string,c:{a:int,b?:string,c:...}} nested to the given depth
+func nestedStructMapType(depth int) dgo.Type {
+	var t dgo.Type = StructMapType(false, []dgo.StructMapEntry{
+		StructMapEntry(`a`, DefaultIntegerType, true),
+		StructMapEntry(`b`, DefaultStringType, false)})
+	for i := 1; i < depth; i++ {
+		t = StructMapType(false, []dgo.StructMapEntry{
+			StructMapEntry(`a`, DefaultIntegerType, true),
+			StructMapEntry(`b`, DefaultStringType, false),
+			StructMapEntry(`c`, t, true)})
+	}
+	return t
+}
+
+// nestedStructMap returns a value that is an instance of the nestedStructMapType of the same depth
+func nestedStructMap(depth int) dgo.Value {
+	var v dgo.Value = Map([]interface{}{`a`, 1, `b`, `x`})
+	for i := 1; i < depth; i++ {
+		v = Map([]interface{}{`a`, 1, `b`, `x`, `c`, v})
+	}
+	return v
+}
+
+func BenchmarkAssignable_sizedArray_to_exactArray(b *testing.B) {
+	for _, d := range benchDepths {
+		t := nestedArrayType(d)
+		et := nestedArray(d).Type()
+		b.Run(fmt.Sprintf(`depth%d`, d), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if !Assignable(nil, t, et) {
+					b.Fatal(`not assignable`)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAssignable_AnyOf_deep(b *testing.B) {
+	for _, d := range benchDepths {
+		for _, w := range []int{2, 5, 10} {
+			t := nestedAnyOfType(d, w)
+			b.Run(fmt.Sprintf(`depth%d_breadth%d`, d, w), func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					if !Assignable(nil, t, DefaultBooleanType) {
+						b.Fatal(`not assignable`)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkInstance_tupleType(b *testing.B) {
+	for _, d := range benchDepths {
+		t := nestedTupleType(d)
+		v := nestedTuple(d)
+		b.Run(fmt.Sprintf(`depth%d`, d), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if !Instance(nil, t, v) {
+					b.Fatal(`not an instance`)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInstance_structMap(b *testing.B) {
+	for _, d := range benchDepths {
+		t := nestedStructMapType(d)
+		v := nestedStructMap(d)
+		b.Run(fmt.Sprintf(`depth%d`, d), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if !Instance(nil, t, v) {
+					b.Fatal(`not an instance`)
+				}
+			}
+		})
+	}
+}
+
+var integerSink dgo.Integer
+
+func BenchmarkInteger(b *testing.B) {
+	for _, v := range []int64{42, 1 << 20} {
+		b.Run(fmt.Sprintf(`value=%d`, v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				integerSink = Integer(v)
+			}
+		})
+	}
+}
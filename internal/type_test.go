@@ -3,7 +3,12 @@ package internal_test
 import (
 	"reflect"
 	"regexp"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
 
 	"github.com/lyraproj/dgo/vf"
 
@@ -38,3 +43,137 @@ func TestGeneric(t *testing.T) {
 	require.NotEqual(t, typ.Generic(typ.String), tf.String(10))
 	require.Same(t, typ.String, typ.Generic(vf.String(`hello`).Type()))
 }
+
+func TestToType(t *testing.T) {
+	tp, ok := typ.ToType(typ.String)
+	require.True(t, ok)
+	require.Same(t, typ.String, tp)
+
+	tp, ok = typ.ToType(vf.String(`hello`))
+	require.False(t, ok)
+	require.Nil(t, tp)
+}
+
+func TestMustToType(t *testing.T) {
+	require.Same(t, typ.Integer, typ.MustToType(typ.Integer))
+	require.Panic(t, func() { typ.MustToType(vf.Integer(3)) }, `the value 3 cannot be assigned to a variable of type type`)
+}
+
+func TestTypePredicates(t *testing.T) {
+	require.True(t, typ.IsExact(vf.String(`a`).Type()))
+	require.False(t, typ.IsExact(typ.String))
+
+	require.True(t, typ.IsArray(typ.Array))
+	require.True(t, typ.IsArray(tf.ParseType(`{int,string}`)))
+	require.True(t, typ.IsArray(vf.Values(1).Type()))
+	require.False(t, typ.IsArray(typ.Map))
+
+	require.True(t, typ.IsMap(typ.Map))
+	require.True(t, typ.IsMap(tf.ParseType(`{a:int}`)))
+	require.True(t, typ.IsMap(vf.Map(`a`, 1).Type()))
+	require.False(t, typ.IsMap(typ.Array))
+
+	require.True(t, typ.IsString(typ.String))
+	require.True(t, typ.IsString(tf.String(1, 3)))
+	require.True(t, typ.IsString(tf.CiString(`a`)))
+	require.True(t, typ.IsString(tf.Pattern(regexp.MustCompile(`a`))))
+	require.False(t, typ.IsString(typ.Array))
+	require.False(t, typ.IsString(tf.ParseType(`"a"|"b"`)))
+
+	require.True(t, typ.IsInteger(tf.Integer(1, 3, true)))
+	require.True(t, typ.IsInteger(vf.Integer(1).Type()))
+	require.False(t, typ.IsInteger(typ.Float))
+
+	require.True(t, typ.IsFloat(typ.Float))
+	require.True(t, typ.IsFloat(vf.Float(1).Type()))
+	require.False(t, typ.IsFloat(typ.Integer))
+
+	require.True(t, typ.IsBoolean(typ.Boolean))
+	require.True(t, typ.IsBoolean(typ.True))
+	require.False(t, typ.IsBoolean(typ.Any))
+
+	require.True(t, typ.IsNil(typ.Nil))
+	require.True(t, typ.IsNil(vf.Nil.Type()))
+	require.False(t, typ.IsNil(typ.Any))
+}
+
+func TestSetTypeCheckTracer(t *testing.T) {
+	var traced []interface{}
+	old := tf.SetTypeCheckTracer(func(t dgo.Type, v dgo.Value, elapsed time.Duration) {
+		traced = append(traced, t, v)
+	})
+	defer tf.SetTypeCheckTracer(old)
+
+	at := tf.Array(tf.Map(typ.String, typ.Integer))
+	v := vf.Values(vf.Map(`a`, 1), vf.Map(`b`, 2))
+	require.True(t, at.Instance(v))
+	require.Equal(t, vf.Values(at, v), vf.Values(traced...))
+
+	traced = nil
+	ot := tf.Array(tf.Map(typ.String, tf.Integer(0, 10, true)))
+	require.True(t, at.Assignable(ot))
+	require.Equal(t, vf.Values(at, ot), vf.Values(traced[len(traced)-2:]...))
+
+	traced = nil
+	require.True(t, typ.Integer.Instance(3))
+	require.Equal(t, 0, len(traced))
+
+	tf.SetTypeCheckTracer(nil)
+	traced = nil
+	require.True(t, at.Instance(v))
+	require.Equal(t, 0, len(traced))
+}
+
+func TestSetTypeCheckTracer_concurrent(t *testing.T) {
+	old := tf.SetTypeCheckTracer(nil)
+	defer tf.SetTypeCheckTracer(old)
+
+	var count int64
+	tracer := func(t dgo.Type, v dgo.Value, elapsed time.Duration) { atomic.AddInt64(&count, 1) }
+	at := tf.Array(typ.Integer)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				tf.SetTypeCheckTracer(tracer)
+				tf.SetTypeCheckTracer(nil)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				at.Instance(vf.Values(n))
+			}
+		}()
+	}
+	wg.Wait()
+	require.True(t, tf.SetTypeCheckTracer(nil) == nil)
+}
+
+func TestType_predicateMethods(t *testing.T) {
+	require.True(t, vf.String(`a`).Type().IsExact())
+	require.False(t, typ.String.IsExact())
+
+	require.True(t, tf.ParseType(`{int,string}`).IsArray())
+	require.False(t, typ.Map.IsArray())
+
+	require.True(t, tf.ParseType(`{a:int}`).IsMap())
+	require.False(t, typ.Array.IsMap())
+
+	require.True(t, tf.CiString(`a`).IsString())
+	require.False(t, tf.ParseType(`"a"|"b"`).IsString())
+
+	require.True(t, vf.Integer(1).Type().IsInteger())
+	require.False(t, typ.Float.IsInteger())
+
+	require.True(t, vf.Float(1).Type().IsFloat())
+	require.False(t, typ.Integer.IsFloat())
+
+	require.True(t, typ.True.IsBoolean())
+	require.False(t, typ.Any.IsBoolean())
+
+	require.True(t, typ.Nil.IsNil())
+	require.False(t, typ.Any.IsNil())
+}
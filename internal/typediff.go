@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// TypeDiff returns a human readable description of the parts of type b that prevent it from being assignable to
+// type a, e.g. "array min: expected >=3, got 0" or "field 'name': expected string, got int". Each difference is
+// described on a separate line. An empty string is returned when b is assignable to a.
+func TypeDiff(a, b dgo.Type) string {
+	if a.Assignable(b) {
+		return ``
+	}
+	var diffs []string
+	typeDiff(``, a, b, func(d string) { diffs = append(diffs, d) })
+	return strings.Join(diffs, "\n")
+}
+
+// typeDiff reports the differences between a and b. The given prefix describes the location of the types in the
+// type trees that are compared.
+func typeDiff(prefix string, a, b dgo.Type, report func(string)) {
+	n := 0
+	counter := func(d string) {
+		n++
+		report(d)
+	}
+	switch at := a.(type) {
+	case dgo.StructMapType:
+		if bt, ok := b.(dgo.StructMapType); ok {
+			structDiff(prefix, at, bt, counter)
+		}
+	case dgo.MapType:
+		if bt, ok := b.(dgo.MapType); ok {
+			mapDiff(prefix, at, bt, counter)
+		}
+	case dgo.ArrayType:
+		if bt, ok := b.(dgo.ArrayType); ok {
+			arrayDiff(prefix, at, bt, counter)
+		}
+	case dgo.StringType:
+		if bt, ok := b.(dgo.StringType); ok {
+			sizeDiff(prefix+`string `, at, bt, counter)
+		}
+	case dgo.IntegerType:
+		if bt, ok := b.(dgo.IntegerType); ok {
+			if bt.Min() < at.Min() {
+				counter(fmt.Sprintf(`%sinteger min: expected >=%d, got %d`, prefix, at.Min(), bt.Min()))
+			}
+			if bt.Max() > at.Max() {
+				counter(fmt.Sprintf(`%sinteger max: expected <=%d, got %d`, prefix, at.Max(), bt.Max()))
+			}
+		}
+	}
+	if n == 0 {
+		// No specific difference could be found so the types are reported as a whole
+		report(fmt.Sprintf(`%sexpected %s, got %s`, prefix, TypeString(a), TypeString(b)))
+	}
+}
+
+func sizeDiff(prefix string, a, b dgo.SizedType, report func(string)) {
+	if b.Min() < a.Min() {
+		report(fmt.Sprintf(`%smin: expected >=%d, got %d`, prefix, a.Min(), b.Min()))
+	}
+	if b.Max() > a.Max() {
+		report(fmt.Sprintf(`%smax: expected <=%d, got %s`, prefix, a.Max(), sizeString(b.Max())))
+	}
+}
+
+func sizeString(sz int) string {
+	if sz == math.MaxInt64 {
+		return `unbounded`
+	}
+	return fmt.Sprintf(`%d`, sz)
+}
+
+func arrayDiff(prefix string, a, b dgo.ArrayType, report func(string)) {
+	at, aTuple := a.(dgo.TupleType)
+	bt, bTuple := b.(dgo.TupleType)
+	if aTuple && bTuple && !(at.Variadic() || bt.Variadic()) {
+		if at.Len() != bt.Len() {
+			report(fmt.Sprintf(`%stuple length: expected %d, got %d`, prefix, at.Len(), bt.Len()))
+			return
+		}
+		for i, n := 0, at.Len(); i < n; i++ {
+			elementDiff(fmt.Sprintf(`%selement %d: `, prefix, i), at.Element(i), bt.Element(i), report)
+		}
+		return
+	}
+
+	sizeDiff(prefix+`array `, a, b, report)
+	if aTuple {
+		// Comparing the elements of variadic tuples requires the assignability rules of the tuple type
+		return
+	}
+	if bTuple && !bt.Variadic() {
+		for i, n := 0, bt.Len(); i < n; i++ {
+			elementDiff(fmt.Sprintf(`%selement %d: `, prefix, i), a.ElementType(), bt.Element(i), report)
+		}
+		return
+	}
+	elementDiff(prefix+`array element: `, a.ElementType(), b.ElementType(), report)
+}
+
+func mapDiff(prefix string, a, b dgo.MapType, report func(string)) {
+	sizeDiff(prefix+`map `, a, b, report)
+	elementDiff(prefix+`map key: `, a.KeyType(), b.KeyType(), report)
+	elementDiff(prefix+`map value: `, a.ValueType(), b.ValueType(), report)
+}
+
+func structDiff(prefix string, a, b dgo.StructMapType, report func(string)) {
+	a.Each(func(ae dgo.StructMapEntry) {
+		ak, _ := entryKey(ae)
+		p := fmt.Sprintf(`%sfield '%s': `, prefix, ak)
+		be := b.Get(ae.Key())
+		switch {
+		case be == nil:
+			if ae.Required() {
+				report(p + `missing`)
+			} else if b.Additional() {
+				report(fmt.Sprintf(`%sexpected %s, got any`, p, TypeString(ae.Value().(dgo.Type))))
+			}
+		case ae.Required() && !be.Required():
+			report(p + `expected required, got optional`)
+		default:
+			elementDiff(p, ae.Value().(dgo.Type), be.Value().(dgo.Type), report)
+		}
+	})
+	if a.Additional() {
+		return
+	}
+	b.Each(func(be dgo.StructMapEntry) {
+		if a.Get(be.Key()) == nil {
+			bk, _ := entryKey(be)
+			report(fmt.Sprintf(`%sfield '%s': unexpected`, prefix, bk))
+		}
+	})
+	if b.Additional() {
+		report(prefix + `additional fields: not allowed`)
+	}
+}
+
+// elementDiff reports the differences between a and b unless b is assignable to a
+func elementDiff(prefix string, a, b dgo.Type, report func(string)) {
+	if !a.Assignable(b) {
+		typeDiff(prefix, a, b, report)
+	}
+}
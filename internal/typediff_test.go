@@ -0,0 +1,58 @@
+package internal_test
+
+import (
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestTypeDiff_assignable(t *testing.T) {
+	require.Equal(t, ``, typ.Diff(typ.Any, typ.String))
+	require.Equal(t, ``, typ.Diff(tf.ParseType(`[]int`), tf.ParseType(`[3]0..3`)))
+}
+
+func TestTypeDiff_simple(t *testing.T) {
+	require.Equal(t, `expected string, got int`, typ.Diff(typ.String, typ.Integer))
+	require.Equal(t, `integer min: expected >=3, got 0`, typ.Diff(tf.ParseType(`3..10`), tf.ParseType(`0..5`)))
+	require.Equal(t, `string max: expected <=3, got unbounded`, typ.Diff(tf.ParseType(`string[0,3]`), typ.String))
+}
+
+func TestTypeDiff_array(t *testing.T) {
+	require.Equal(t, `array min: expected >=3, got 0`, typ.Diff(tf.ParseType(`[3]int`), tf.ParseType(`[]int`)))
+	require.Equal(t, "array max: expected <=3, got 5\narray element: expected int, got string",
+		typ.Diff(tf.ParseType(`[0,3]int`), tf.ParseType(`[0,5]string`)))
+	require.Equal(t, `element 1: expected int, got string`,
+		typ.Diff(tf.ParseType(`[]int`), tf.ParseType(`{int,string}`)))
+	require.Equal(t, `element 1: expected int, got "a"`,
+		typ.Diff(tf.ParseType(`[]int`), vf.Values(1, `a`).Type()))
+}
+
+func TestTypeDiff_tuple(t *testing.T) {
+	require.Equal(t, `tuple length: expected 2, got 1`, typ.Diff(tf.ParseType(`{int,string}`), tf.ParseType(`{int}`)))
+	require.Equal(t, `element 0: expected int, got string`,
+		typ.Diff(tf.ParseType(`{int,string}`), tf.ParseType(`{string,string}`)))
+}
+
+func TestTypeDiff_map(t *testing.T) {
+	require.Equal(t, "map key: expected string, got int\nmap value: expected int, got string",
+		typ.Diff(tf.ParseType(`map[string]int`), tf.ParseType(`map[int]string`)))
+}
+
+func TestTypeDiff_struct(t *testing.T) {
+	a := tf.ParseType(`{name:string,age:int,email?:string}`)
+	require.Equal(t, `field 'name': expected string, got int`,
+		typ.Diff(a, tf.ParseType(`{name:int,age:int}`)))
+	require.Equal(t, `field 'age': missing`,
+		typ.Diff(a, tf.ParseType(`{name:string}`)))
+	require.Equal(t, `field 'age': expected required, got optional`,
+		typ.Diff(a, tf.ParseType(`{name:string,age?:int}`)))
+	require.Equal(t, `field 'extra': unexpected`,
+		typ.Diff(a, tf.ParseType(`{name:string,age:int,extra:int}`)))
+	require.Equal(t, "field 'email': expected string, got any\nadditional fields: not allowed",
+		typ.Diff(a, tf.ParseType(`{name:string,age:int,...}`)))
+	require.Equal(t, `field 'address': field 'zip': integer max: expected <=99999, got 999999`,
+		typ.Diff(tf.ParseType(`{address:{zip:0..99999}}`), tf.ParseType(`{address:{zip:0..999999}}`)))
+}
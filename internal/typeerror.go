@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/lyraproj/dgo/dgo"
 )
@@ -18,10 +19,20 @@ type (
 		actual   dgo.Type
 	}
 
+	validationError struct {
+		typeError
+		reasons []string
+	}
+
 	sizeError struct {
 		sizedType     dgo.Type
 		attemptedSize int
 	}
+
+	frozenError struct {
+		function string
+		kind     string
+	}
 )
 
 func (v *mapKeyError) Equals(other interface{}) bool {
@@ -39,6 +50,10 @@ func (v *mapKeyError) HashCode() int {
 	return v.mapType.HashCode()*31 + v.key.HashCode()
 }
 
+func (v *mapKeyError) Unwrap() error {
+	return dgo.ErrAssignment
+}
+
 func (v *mapKeyError) String() string {
 	return v.Error()
 }
@@ -47,6 +62,10 @@ func (v *mapKeyError) Type() dgo.Type {
 	return DefaultErrorType
 }
 
+func (v *typeError) Actual() dgo.Type {
+	return v.actual
+}
+
 func (v *typeError) Equals(other interface{}) bool {
 	if ov, ok := other.(*typeError); ok {
 		return v.expected.Equals(ov.expected) && v.actual.Equals(ov.actual)
@@ -67,6 +86,10 @@ func (v *typeError) Error() string {
 	return fmt.Sprintf("%s cannot be assigned to a variable of type %s", what, TypeString(v.expected))
 }
 
+func (v *typeError) Expected() dgo.Type {
+	return v.expected
+}
+
 func (v *typeError) HashCode() int {
 	return v.expected.HashCode()*31 + v.actual.HashCode()
 }
@@ -79,6 +102,33 @@ func (v *typeError) Type() dgo.Type {
 	return DefaultErrorType
 }
 
+func (v *typeError) Unwrap() error {
+	return dgo.ErrAssignment
+}
+
+func (v *validationError) Equals(other interface{}) bool {
+	if ov, ok := other.(*validationError); ok {
+		return v.typeError.Equals(&ov.typeError) && strings.Join(v.reasons, "\n") == strings.Join(ov.reasons, "\n")
+	}
+	return false
+}
+
+func (v *validationError) Error() string {
+	return v.typeError.Error() + `: ` + strings.Join(v.reasons, `; `)
+}
+
+func (v *validationError) Reasons() []string {
+	return v.reasons
+}
+
+func (v *validationError) String() string {
+	return v.Error()
+}
+
+func (v *sizeError) AttemptedSize() int {
+	return v.attemptedSize
+}
+
 func (v *sizeError) Equals(other interface{}) bool {
 	if ov, ok := other.(*sizeError); ok {
 		return v.sizedType.Equals(ov.sizedType) && v.attemptedSize == ov.attemptedSize
@@ -95,6 +145,10 @@ func (v *sizeError) HashCode() int {
 	return v.sizedType.HashCode()*7 + v.attemptedSize
 }
 
+func (v *sizeError) SizedType() dgo.Type {
+	return v.sizedType
+}
+
 func (v *sizeError) String() string {
 	return v.Error()
 }
@@ -103,7 +157,20 @@ func (v *sizeError) Type() dgo.Type {
 	return DefaultErrorType
 }
 
-// IllegalAssignment returns the error that represents an assignment type constraint mismatch
+func (v *sizeError) Unwrap() error {
+	return dgo.ErrSize
+}
+
+func (v *frozenError) Error() string {
+	return fmt.Sprintf(`%s called on a frozen %s`, v.function, v.kind)
+}
+
+func (v *frozenError) Unwrap() error {
+	return dgo.ErrFrozen
+}
+
+// IllegalAssignment returns the error that represents an assignment type constraint mismatch. The returned value
+// is a dgo.AssignmentError.
 func IllegalAssignment(t dgo.Type, v dgo.Value) dgo.Value {
 	return &typeError{t, v.Type()}
 }
@@ -113,7 +180,8 @@ func IllegalMapKey(t dgo.StructMapType, v dgo.Value) dgo.Value {
 	return &mapKeyError{t, v.Type()}
 }
 
-// IllegalSize returns the error that represents an size constraint mismatch
+// IllegalSize returns the error that represents an size constraint mismatch. The returned value is a
+// dgo.SizeError.
 func IllegalSize(t dgo.Type, sz int) dgo.Value {
 	return &sizeError{t, sz}
 }
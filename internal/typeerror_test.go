@@ -1,6 +1,8 @@
 package internal_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/lyraproj/dgo/dgo"
@@ -8,6 +10,7 @@ import (
 	require "github.com/lyraproj/dgo/dgo_test"
 	"github.com/lyraproj/dgo/tf"
 	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/util"
 	"github.com/lyraproj/dgo/vf"
 )
 
@@ -50,3 +53,44 @@ func TestIllegalMapKey(t *testing.T) {
 	require.Equal(t, v.HashCode(), v.HashCode())
 	require.Equal(t, `key "b" cannot added to type {"a":string}`, v.String())
 }
+
+func TestIllegalAssignment_errors(t *testing.T) {
+	err := tf.IllegalAssignment(typ.String, vf.Integer(3)).(error)
+	require.True(t, errors.Is(err, dgo.ErrAssignment))
+	require.False(t, errors.Is(err, dgo.ErrSize))
+
+	var ae dgo.AssignmentError
+	require.True(t, errors.As(fmt.Errorf(`wrapped: %w`, err), &ae))
+	require.Equal(t, typ.String, ae.Expected())
+	require.Equal(t, vf.Integer(3).Type(), ae.Actual())
+
+	err = tf.IllegalMapKey(tf.ParseType(`{a:string}`).(dgo.StructMapType), vf.String(`b`)).(error)
+	require.True(t, errors.Is(err, dgo.ErrAssignment))
+
+	err = util.Catch(func() { vf.MutableValues().Annotate(typ.String).Add(1) })
+	require.True(t, errors.Is(err, dgo.ErrAssignment))
+}
+
+func TestIllegalSize_errors(t *testing.T) {
+	err := tf.IllegalSize(tf.String(1, 10), 12).(error)
+	require.True(t, errors.Is(err, dgo.ErrSize))
+	require.False(t, errors.Is(err, dgo.ErrAssignment))
+
+	var se dgo.SizeError
+	require.True(t, errors.As(fmt.Errorf(`wrapped: %w`, err), &se))
+	require.Equal(t, tf.String(1, 10), se.SizedType())
+	require.Equal(t, 12, se.AttemptedSize())
+}
+
+func TestFrozen_errors(t *testing.T) {
+	err := util.Catch(func() { vf.Values(1).Add(2) })
+	require.True(t, errors.Is(err, dgo.ErrFrozen))
+	require.Equal(t, `Add called on a frozen Array`, err.Error())
+
+	err = util.Catch(func() { vf.Map(`a`, 1).Put(`b`, 2) })
+	require.True(t, errors.Is(err, dgo.ErrFrozen))
+	require.Equal(t, `Put called on a frozen Map`, err.Error())
+
+	err = util.Catch(func() { vf.MutableValues(1).Add(2) })
+	require.Nil(t, err)
+}
@@ -0,0 +1,312 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type (
+	// uriVal is a url.URL that implements the dgo.URI interface. The URL is never mutated.
+	uriVal url.URL
+
+	defaultURIType int
+
+	// uriType matches URIs with a specific scheme and/or host. An empty scheme or host is unconstrained.
+	uriType struct {
+		scheme string
+		host   string
+	}
+
+	exactURIType struct {
+		exactType
+		value *uriVal
+	}
+)
+
+// DefaultURIType is the unconstrained URI type
+const DefaultURIType = defaultURIType(0)
+
+var reflectURIType = reflect.TypeOf(&url.URL{})
+
+// URI returns a dgo.URI that represents a copy of the given URL
+func URI(u *url.URL) dgo.URI {
+	return (*uriVal)(copyURL(u))
+}
+
+func copyURL(u *url.URL) *url.URL {
+	c := *u
+	if u.User != nil {
+		ui := *u.User
+		c.User = &ui
+	}
+	return &c
+}
+
+// URIFromString returns the given string as a dgo.URI. The function panics if the string cannot be parsed.
+func URIFromString(s string) dgo.URI {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return (*uriVal)(u)
+}
+
+// URIType returns a dgo.URIType that matches URIs with the given scheme and host. An empty scheme or host means
+// that the URI type isn't constrained by it. Both comparisons are case insensitive.
+func URIType(scheme, host string) dgo.URIType {
+	if scheme == `` && host == `` {
+		return DefaultURIType
+	}
+	if strings.ContainsAny(scheme, `:/`) {
+		panic(fmt.Errorf(`illegal URI scheme '%s'`, scheme))
+	}
+	return &uriType{scheme: strings.ToLower(scheme), host: strings.ToLower(host)}
+}
+
+// URITypeFromArgs returns a dgo.URIType created from the given arguments. No arguments gives the unconstrained
+// type. One argument is either a scheme or, if it contains a colon or a slash, a URI for which the exact type is
+// returned. Two arguments are the scheme and the host, either of which may be nil.
+func URITypeFromArgs(args []interface{}) dgo.URIType {
+	switch len(args) {
+	case 0:
+		return DefaultURIType
+	case 1:
+		if a0, ok := Value(args[0]).(dgo.String); ok {
+			s := a0.GoString()
+			if strings.ContainsAny(s, `:/`) {
+				return URIFromString(s).Type().(dgo.URIType)
+			}
+			return URIType(s, ``)
+		}
+		panic(illegalArgument(`URIType`, `String`, args, 0))
+	case 2:
+		return URIType(uriTypeArg(args, 0), uriTypeArg(args, 1))
+	}
+	panic(illegalArgumentCount(`URIType`, 0, 2, len(args)))
+}
+
+func uriTypeArg(args []interface{}, argno int) string {
+	switch a := Value(args[argno]).(type) {
+	case dgo.String:
+		return a.GoString()
+	case nilValue:
+		return ``
+	}
+	panic(illegalArgument(`URIType`, `String or nil`, args, argno))
+}
+
+// toURL returns the given value as a *url.URL and true or nil and false if the value isn't a URL
+func toURL(v interface{}) (*url.URL, bool) {
+	switch v := v.(type) {
+	case *uriVal:
+		return (*url.URL)(v), true
+	case *url.URL:
+		return v, v != nil
+	case url.URL:
+		return &v, true
+	}
+	return nil, false
+}
+
+func (t defaultURIType) Assignable(other dgo.Type) bool {
+	switch other.(type) {
+	case defaultURIType, *uriType, *exactURIType:
+		return true
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t defaultURIType) Describe() string {
+	return Describe(t)
+}
+
+func (t defaultURIType) Equals(other interface{}) bool {
+	return t == other
+}
+
+func (t defaultURIType) HashCode() int {
+	return int(dgo.TiURI)
+}
+
+func (t defaultURIType) Host() string {
+	return ``
+}
+
+func (t defaultURIType) Instance(value interface{}) bool {
+	_, ok := toURL(value)
+	return ok
+}
+
+func (t defaultURIType) New(arg dgo.Value) dgo.Value {
+	return newURI(t, arg)
+}
+
+func (t defaultURIType) ReflectType() reflect.Type {
+	return reflectURIType
+}
+
+func (t defaultURIType) Scheme() string {
+	return ``
+}
+
+func (t defaultURIType) String() string {
+	return TypeString(t)
+}
+
+func (t defaultURIType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t defaultURIType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiURI
+}
+
+func (t *uriType) Assignable(other dgo.Type) bool {
+	switch ot := other.(type) {
+	case *exactURIType:
+		return t.Instance(ot.value)
+	case *uriType:
+		return (t.scheme == `` || t.scheme == ot.scheme) && (t.host == `` || t.host == ot.host)
+	}
+	return CheckAssignableTo(nil, other, t)
+}
+
+func (t *uriType) Describe() string {
+	return Describe(t)
+}
+
+func (t *uriType) Equals(other interface{}) bool {
+	if ot, ok := other.(*uriType); ok {
+		return *t == *ot
+	}
+	return false
+}
+
+func (t *uriType) HashCode() int {
+	return (int(dgo.TiURIRestricted)*31+util.StringHash(t.scheme))*31 + util.StringHash(t.host)
+}
+
+func (t *uriType) Host() string {
+	return t.host
+}
+
+func (t *uriType) Instance(value interface{}) bool {
+	if u, ok := toURL(value); ok {
+		return (t.scheme == `` || strings.EqualFold(t.scheme, u.Scheme)) &&
+			(t.host == `` || strings.EqualFold(t.host, u.Hostname()))
+	}
+	return false
+}
+
+func (t *uriType) New(arg dgo.Value) dgo.Value {
+	return newURI(t, arg)
+}
+
+func (t *uriType) ReflectType() reflect.Type {
+	return reflectURIType
+}
+
+func (t *uriType) Scheme() string {
+	return t.scheme
+}
+
+func (t *uriType) String() string {
+	return TypeString(t)
+}
+
+func (t *uriType) Type() dgo.Type {
+	return &metaType{t}
+}
+
+func (t *uriType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiURIRestricted
+}
+
+func (t *exactURIType) ExactValue() dgo.Value {
+	return t.value
+}
+
+func (t *exactURIType) Generic() dgo.Type {
+	return DefaultURIType
+}
+
+func (t *exactURIType) Host() string {
+	return strings.ToLower(t.value.GoURL().Hostname())
+}
+
+func (t *exactURIType) New(arg dgo.Value) dgo.Value {
+	return newURI(t, arg)
+}
+
+func (t *exactURIType) ReflectType() reflect.Type {
+	return reflectURIType
+}
+
+func (t *exactURIType) Scheme() string {
+	return strings.ToLower(t.value.Scheme)
+}
+
+func (t *exactURIType) TypeIdentifier() dgo.TypeIdentifier {
+	return dgo.TiURIExact
+}
+
+func newURI(t dgo.Type, arg dgo.Value) dgo.URI {
+	if args, ok := arg.(dgo.Arguments); ok {
+		args.AssertSize(`uri`, 1, 1)
+		arg = args.Get(0)
+	}
+	var uv dgo.URI
+	switch arg := arg.(type) {
+	case dgo.URI:
+		uv = arg
+	case dgo.String:
+		uv = URIFromString(arg.GoString())
+	default:
+		panic(illegalArgument(`uri`, `uri|string`, []interface{}{arg}, 0))
+	}
+	if !t.Instance(uv) {
+		panic(IllegalAssignment(t, uv))
+	}
+	return uv
+}
+
+func (v *uriVal) Equals(other interface{}) bool {
+	if u, ok := toURL(other); ok {
+		return v.String() == u.String()
+	}
+	return false
+}
+
+func (v *uriVal) GoURL() *url.URL {
+	return copyURL((*url.URL)(v))
+}
+
+func (v *uriVal) HashCode() int {
+	return util.StringHash(v.String())
+}
+
+func (v *uriVal) ReflectTo(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(v.String())
+	case reflect.Struct:
+		value.Set(reflect.ValueOf(v.GoURL()).Elem())
+	default:
+		value.Set(reflect.ValueOf(v.GoURL()))
+	}
+}
+
+func (v *uriVal) String() string {
+	return (*url.URL)(v).String()
+}
+
+func (v *uriVal) Type() dgo.Type {
+	et := &exactURIType{value: v}
+	et.ExactType = et
+	return et
+}
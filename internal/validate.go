@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// Validate returns nil if the given value is an instance of the given type. Otherwise it returns a
+// dgo.ValidationError that describes each part of the value that violates the type. For AllOf, AnyOf, and OneOf
+// types, the description tells which operands the value failed to satisfy.
+func Validate(t dgo.Type, value interface{}) error {
+	if t.Instance(value) {
+		return nil
+	}
+	v := Value(value)
+	var reasons []string
+	validationDiff(``, t, v, func(r string) { reasons = append(reasons, r) })
+	return &validationError{typeError: typeError{expected: t, actual: v.Type()}, reasons: reasons}
+}
+
+// validationDiff reports the reasons why v is not an instance of t. The given prefix describes the location of t in
+// the type tree.
+func validationDiff(prefix string, t dgo.Type, v dgo.Value, report func(string)) {
+	switch t.TypeIdentifier() {
+	case dgo.TiAllOf, dgo.TiAllOfValue:
+		t.(dgo.TernaryType).Operands().EachWithIndex(func(op dgo.Value, i int) {
+			ot := operandType(t, op)
+			if !ot.Instance(v) {
+				validationDiff(fmt.Sprintf(`%sallOf operand %d: `, prefix, i), ot, v, report)
+			}
+		})
+	case dgo.TiAnyOf:
+		t.(dgo.TernaryType).Operands().EachWithIndex(func(op dgo.Value, i int) {
+			validationDiff(fmt.Sprintf(`%sanyOf operand %d: `, prefix, i), op.(dgo.Type), v, report)
+		})
+	case dgo.TiOneOf:
+		var matches []int
+		ops := t.(dgo.TernaryType).Operands()
+		ops.EachWithIndex(func(op dgo.Value, i int) {
+			if op.(dgo.Type).Instance(v) {
+				matches = append(matches, i)
+			}
+		})
+		if len(matches) > 1 {
+			report(fmt.Sprintf(`%soneOf: matches operands %v, expected exactly one`, prefix, matches))
+			return
+		}
+		ops.EachWithIndex(func(op dgo.Value, i int) {
+			validationDiff(fmt.Sprintf(`%soneOf operand %d: `, prefix, i), op.(dgo.Type), v, report)
+		})
+	case dgo.TiConditionalStruct:
+		ct := t.(*conditionalStructType)
+		if m, ok := v.(dgo.Map); ok {
+			fieldLabel := func(k dgo.Value) string { return fmt.Sprintf(`field '%s'`, k) }
+			if err := ct.discriminatorError(fieldLabel, m); err != nil {
+				report(prefix + err.Error())
+			} else {
+				typeDiff(prefix, ct.selectCase(m), v.Type(), report)
+			}
+			return
+		}
+		typeDiff(prefix, t, v.Type(), report)
+	default:
+		typeDiff(prefix, t, v.Type(), report)
+	}
+}
+
+// mapKeyLabel returns the label used for a map key in validation errors, e.g. "key 'type'"
+func mapKeyLabel(key dgo.Value) string {
+	return fmt.Sprintf(`key '%s'`, key)
+}
+
+// operandType returns the type of the given operand of the given ternary type. The operands of an AllOfValue type
+// are values rather than types.
+func operandType(t dgo.Type, op dgo.Value) dgo.Type {
+	if t.TypeIdentifier() == dgo.TiAllOfValue {
+		return op.Type()
+	}
+	return op.(dgo.Type)
+}
+
+// pathError is a dgo.PathError
+type pathError struct {
+	path string
+	err  error
+}
+
+func (e *pathError) Error() string {
+	if e.path == `` {
+		return e.err.Error()
+	}
+	return e.path + `: ` + e.err.Error()
+}
+
+func (e *pathError) Path() string {
+	return e.path
+}
+
+func (e *pathError) Unwrap() error {
+	return e.err
+}
+
+var pointerEscaper = strings.NewReplacer(`~`, `~0`, `/`, `~1`)
+
+// pointerPath returns the given path extended with the given map key or array index in JSON Pointer notation
+func pointerPath(path string, key dgo.Value) string {
+	var s string
+	if ks, ok := key.(dgo.String); ok {
+		s = ks.GoString()
+	} else {
+		s = key.String()
+	}
+	return path + `/` + pointerEscaper.Replace(s)
+}
+
+// ValidateAll returns a dgo.PathError for each violation that prevents the given value from being an instance of
+// the given type. Arrays, maps, and struct maps are traversed so that all violations within nested values are found.
+// Violations of other types are reported as a whole. A nil slice is returned when the value is an instance of the
+// type.
+func ValidateAll(t dgo.Type, value interface{}) []error {
+	var errs []error
+	validateAll(nil, ``, t, Value(value), func(err error) { errs = append(errs, err) })
+	return errs
+}
+
+func validateAll(seen []dgo.Value, path string, t dgo.Type, v dgo.Value, report func(error)) {
+	if t.Instance(v) {
+		return
+	}
+	fail := func(err interface{}) { report(&pathError{path: path, err: err.(error)}) }
+	if _, ok := t.(dgo.ExactType); !ok {
+		for _, s := range seen {
+			if s == v {
+				fail(IllegalAssignment(t, v))
+				return
+			}
+		}
+		switch tt := t.(type) {
+		case *conditionalStructType:
+			if m, ok := v.(dgo.Map); ok {
+				if err := tt.discriminatorError(mapKeyLabel, m); err != nil {
+					report(&pathError{path: pointerPath(path, tt.key), err: err})
+				} else {
+					validateAllStruct(append(seen, v), path, tt.selectCase(m), m, report)
+				}
+				return
+			}
+		case dgo.StructMapType:
+			if m, ok := v.(dgo.Map); ok {
+				validateAllStruct(append(seen, v), path, tt, m, report)
+				return
+			}
+		case dgo.MapType:
+			if m, ok := v.(dgo.Map); ok {
+				if sz := m.Len(); sz < tt.Min() || sz > tt.Max() {
+					fail(IllegalSize(t, sz))
+				}
+				seen = append(seen, v)
+				kt, vt := tt.KeyType(), tt.ValueType()
+				m.EachEntry(func(e dgo.MapEntry) {
+					p := pointerPath(path, e.Key())
+					if !kt.Instance(e.Key()) {
+						report(&pathError{path: p, err: IllegalAssignment(kt, e.Key()).(error)})
+					}
+					validateAll(seen, p, vt, e.Value(), report)
+				})
+				return
+			}
+		case dgo.ArrayType:
+			if a, ok := v.(dgo.Array); ok {
+				validateAllArray(append(seen, v), path, tt, a, report)
+				return
+			}
+		}
+	}
+	fail(IllegalAssignment(t, v))
+}
+
+func validateAllArray(seen []dgo.Value, path string, t dgo.ArrayType, a dgo.Array, report func(error)) {
+	if sz := a.Len(); sz < t.Min() || sz > t.Max() {
+		report(&pathError{path: path, err: IllegalSize(t, sz).(error)})
+	}
+	elementType := func(int) dgo.Type { return t.ElementType() }
+	if tt, ok := t.(dgo.TupleType); ok {
+		elementType = func(i int) dgo.Type {
+			n := tt.Len()
+			switch {
+			case tt.Variadic() && i >= n-1:
+				return tt.Element(n - 1)
+			case i >= n:
+				return nil
+			}
+			return tt.Element(i)
+		}
+	}
+	a.EachWithIndex(func(e dgo.Value, i int) {
+		if et := elementType(i); et != nil {
+			validateAll(seen, path+`/`+strconv.Itoa(i), et, e, report)
+		}
+	})
+}
+
+func validateAllStruct(seen []dgo.Value, path string, t dgo.StructMapType, m dgo.Map, report func(error)) {
+	t.Each(func(e dgo.StructMapEntry) {
+		k, exact := entryKey(e)
+		if !exact {
+			return
+		}
+		p := pointerPath(path, k)
+		if v := m.Get(k); v != nil {
+			validateAll(seen, p, e.Value().(dgo.Type), v, report)
+		} else if e.Required() {
+			report(&pathError{path: p, err: fmt.Errorf(`missing required key '%s'`, k)})
+		}
+	})
+	m.EachEntry(func(me dgo.MapEntry) {
+		k := me.Key()
+		eachMatchingPattern(t, k, func(e dgo.StructMapEntry) {
+			validateAll(seen, pointerPath(path, k), e.Value().(dgo.Type), me.Value(), report)
+		})
+		if !t.Additional() && t.Get(k) == nil {
+			report(&pathError{path: pointerPath(path, k), err: fmt.Errorf(`unknown key '%s'`, k)})
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestValidate(t *testing.T) {
+	require.Nil(t, typ.Validate(typ.Integer, 3))
+
+	err := typ.Validate(tf.Integer(0, 10, true), 12)
+	require.NotNil(t, err)
+	require.Equal(t,
+		`the value 12 cannot be assigned to a variable of type 0..10: integer max: expected <=10, got 12`, err.Error())
+	require.True(t, errors.Is(err, dgo.ErrAssignment))
+	ve := err.(dgo.ValidationError)
+	require.Equal(t, tf.Integer(0, 10, true), ve.Expected())
+	require.Equal(t, vf.Integer(12).Type(), ve.Actual())
+	require.Equal(t, []string{`integer max: expected <=10, got 12`}, ve.Reasons())
+	require.Equal(t, ve, typ.Validate(tf.Integer(0, 10, true), 12))
+	require.NotEqual(t, ve, typ.Validate(tf.Integer(0, 10, true), 13))
+	require.NotEqual(t, ve, vf.String(`x`))
+	require.Equal(t, err.Error(), ve.String())
+
+	st := tf.ParseType(`{name:string,age:0..150}`)
+	ve = typ.Validate(st, vf.Map(`age`, 200)).(dgo.ValidationError)
+	require.Equal(t, []string{`field 'name': missing`, `field 'age': integer max: expected <=150, got 200`}, ve.Reasons())
+}
+
+func TestValidate_ternary(t *testing.T) {
+	ve := typ.Validate(tf.ParseType(`0..10&1..20`), 0).(dgo.ValidationError)
+	require.Equal(t, []string{`allOf operand 1: integer min: expected >=1, got 0`}, ve.Reasons())
+
+	ve = typ.Validate(tf.ParseType(`string|0..10`), 12).(dgo.ValidationError)
+	require.Equal(t, []string{
+		`anyOf operand 0: expected string, got 12`,
+		`anyOf operand 1: integer max: expected <=10, got 12`}, ve.Reasons())
+
+	ve = typ.Validate(tf.ParseType(`0..10^5..15`), 7).(dgo.ValidationError)
+	require.Equal(t, []string{`oneOf: matches operands [0 1], expected exactly one`}, ve.Reasons())
+
+	ve = typ.Validate(tf.ParseType(`0..10^5..15`), 20).(dgo.ValidationError)
+	require.Equal(t, 2, len(ve.Reasons()))
+
+	ve = typ.Validate(vf.Values(1, 2).Type().(dgo.ArrayType).ElementType(), 1).(dgo.ValidationError)
+	require.Equal(t, []string{`allOf operand 1: integer min: expected >=2, got 1`}, ve.Reasons())
+}
+
+func errorStrings(errs []error) []string {
+	ss := make([]string, len(errs))
+	for i, err := range errs {
+		ss[i] = err.Error()
+	}
+	return ss
+}
+
+func TestValidateAll(t *testing.T) {
+	require.Equal(t, 0, len(typ.ValidateAll(typ.Integer, 3)))
+
+	errs := typ.ValidateAll(typ.Integer, `x`)
+	require.Equal(t, []string{`the string "x" cannot be assigned to a variable of type int`}, errorStrings(errs))
+	require.Equal(t, ``, errs[0].(dgo.PathError).Path())
+
+	st := tf.ParseType(`{name:string, servers:[1,2]{host:string,port?:0..65535}, tags?:map[string]int}`)
+	errs = typ.ValidateAll(st, vf.Map(
+		`servers`, vf.Values(
+			vf.Map(`host`, `a`, `port`, 80),
+			vf.Map(`port`, 70000, `extra`, true),
+			`x`),
+		`tags`, vf.Map(`a/b`, 1, `c~d`, `two`),
+		`other`, 1))
+	require.Equal(t, []string{
+		`/name: missing required key 'name'`,
+		`/servers: size constraint violation on type [1,2]{"host":string,"port"?:0..65535} when attempting resize to 3`,
+		`/servers/1/host: missing required key 'host'`,
+		`/servers/1/port: the value 70000 cannot be assigned to a variable of type 0..65535`,
+		`/servers/1/extra: unknown key 'extra'`,
+		`/servers/2: the string "x" cannot be assigned to a variable of type {"host":string,"port"?:0..65535}`,
+		`/tags/c~0d: the string "two" cannot be assigned to a variable of type int`,
+		`/other: unknown key 'other'`,
+	}, errorStrings(errs))
+
+	pe := errs[3].(dgo.PathError)
+	require.Equal(t, `/servers/1/port`, pe.Path())
+	require.True(t, errors.Is(pe, dgo.ErrAssignment))
+}
+
+func TestValidateAll_pattern(t *testing.T) {
+	st := tf.ParseType(`{name:string,/^env_/:string}`)
+	errs := typ.ValidateAll(st, vf.Map(`name`, `a`, `env_a`, `x`, `env_b`, 2, `other`, true))
+	require.Equal(t, []string{
+		`/env_b: the value 2 cannot be assigned to a variable of type string`,
+		`/other: unknown key 'other'`,
+	}, errorStrings(errs))
+}
+
+func TestValidateAll_mapAndTuple(t *testing.T) {
+	errs := typ.ValidateAll(tf.ParseType(`map[/^a/,1,1]int`), vf.Map(`a`, 1, `b`, `x`))
+	require.Equal(t, []string{
+		`size constraint violation on type map[/^a/,1,1]int when attempting resize to 2`,
+		`/b: the string "b" cannot be assigned to a variable of type /^a/`,
+		`/b: the string "x" cannot be assigned to a variable of type int`,
+	}, errorStrings(errs))
+
+	errs = typ.ValidateAll(tf.ParseType(`{string,...int}`), vf.Values(1, 2, `x`))
+	require.Equal(t, []string{
+		`/0: the value 1 cannot be assigned to a variable of type string`,
+		`/2: the string "x" cannot be assigned to a variable of type int`,
+	}, errorStrings(errs))
+
+	errs = typ.ValidateAll(tf.ParseType(`{string,int}`), vf.Values(1, 2, 3))
+	require.Equal(t, []string{
+		`size constraint violation on type {string,int} when attempting resize to 3`,
+		`/0: the value 1 cannot be assigned to a variable of type string`,
+	}, errorStrings(errs))
+
+	errs = typ.ValidateAll(tf.ParseType(`{a:int}`), vf.Values(1))
+	require.Equal(t, 1, len(errs))
+}
+
+func TestValidateAll_recursive(t *testing.T) {
+	a := vf.MutableValues(1)
+	a.Add(a)
+	errs := typ.ValidateAll(tf.Array(typ.Array), a)
+	require.Equal(t, 1, len(errs))
+	require.Equal(t, `/0`, errs[0].(dgo.PathError).Path())
+}
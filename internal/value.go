@@ -3,6 +3,9 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
 	"regexp"
 	"time"
@@ -64,6 +67,20 @@ func value(v interface{}) dgo.Value {
 		dv = Regexp(v)
 	case time.Time:
 		dv = (*timeVal)(&v)
+	case time.Duration:
+		dv = durationVal(v)
+	case *big.Int:
+		dv = BigInt(v)
+	case *big.Rat:
+		if d, ok := decimalFromRat(v); ok {
+			dv = d
+		}
+	case net.IP:
+		dv = IP(v)
+	case *net.IPNet:
+		dv = IPNet(v)
+	case *url.URL:
+		dv = URI(v)
 	case error:
 		dv = &errw{v}
 	case json.Number:
@@ -72,7 +89,7 @@ func value(v interface{}) dgo.Value {
 		dv = ValueFromReflected(v)
 	default:
 		if i, ok := ToInt(v); ok {
-			dv = intVal(i)
+			dv = Integer(i)
 		} else {
 			var f float64
 			if f, ok = ToFloat(v); ok {
@@ -87,6 +104,9 @@ func valueFromJSONNumber(v json.Number) dgo.Value {
 	if i, err := v.Int64(); err == nil {
 		return Integer(i)
 	}
+	if b, ok := new(big.Int).SetString(string(v), 10); ok {
+		return &bigIntVal{b}
+	}
 	f, err := v.Float64()
 	if err != nil {
 		panic(err)
@@ -104,6 +124,12 @@ func ValueFromReflected(vr reflect.Value) dgo.Value {
 	isPtr := false
 	switch vr.Kind() {
 	case reflect.Slice:
+		if vr.Type() == reflectIPType {
+			if vr.Len() == 0 {
+				return Nil
+			}
+			return IP(vr.Bytes())
+		}
 		return ArrayFromReflected(vr, true)
 	case reflect.Map:
 		return FromReflectedMap(vr, true)
@@ -163,8 +189,92 @@ func ReflectTo(src dgo.Value, dest reflect.Value) {
 	dest.Set(reflect.ValueOf(src))
 }
 
+// IsNil returns true if the given value is nil or the Nil value
+func IsNil(v dgo.Value) bool {
+	return v == nil || v == Nil
+}
+
+// IsZero returns true if the given value is nil, the Nil value, or the zero value of its type, i.e. the integer 0,
+// the float 0.0, false, an empty string, an empty binary, an empty array, or an empty map.
+func IsZero(v dgo.Value) bool {
+	switch v := v.(type) {
+	case nil, nilValue:
+		return true
+	case dgo.Integer:
+		return v.GoInt() == 0
+	case *bigIntVal:
+		return v.v.Sign() == 0
+	case *decimalVal:
+		return v.unscaled.Sign() == 0
+	case dgo.Float:
+		return v.GoFloat() == 0
+	case dgo.Boolean:
+		return !v.GoBool()
+	case dgo.String:
+		return v.GoString() == ``
+	case *binary:
+		return len(v.bytes) == 0
+	case dgo.Array:
+		return v.Len() == 0
+	case dgo.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
 // Add well known types like regexp, time, etc. here
 var wellKnownTypes = map[reflect.Type]dgo.Type{
 	reflect.TypeOf(&regexp.Regexp{}): DefaultRegexpType,
 	reflect.TypeOf(time.Time{}):      DefaultTimeType,
+	reflect.TypeOf(time.Duration(0)): DefaultDurationType,
+	reflect.TypeOf(&big.Int{}):       DefaultBigIntType,
+	reflect.TypeOf(&big.Rat{}):       DefaultDecimalType,
+	reflect.TypeOf(&url.URL{}):       DefaultURIType,
+	reflect.TypeOf(net.IP{}):         DefaultIPType,
+	reflect.TypeOf(&net.IPNet{}):     DefaultCIDRType,
+}
+
+// asArray returns the given value as an Array and true or nil and false if the value isn't an Array
+func asArray(v dgo.Value) (dgo.Array, bool) {
+	a, ok := v.(dgo.Array)
+	return a, ok
+}
+
+// asGoBool returns the Go bool of the given value and true or false and false if the value isn't a Boolean
+func asGoBool(v dgo.Value) (bool, bool) {
+	if b, ok := v.(dgo.Boolean); ok {
+		return b.GoBool(), true
+	}
+	return false, false
+}
+
+// asGoFloat returns the Go float64 of the given value and true or zero and false if the value isn't a Float
+func asGoFloat(v dgo.Value) (float64, bool) {
+	if f, ok := v.(dgo.Float); ok {
+		return f.GoFloat(), true
+	}
+	return 0, false
+}
+
+// asGoInt returns the Go int64 of the given value and true or zero and false if the value isn't an Integer
+func asGoInt(v dgo.Value) (int64, bool) {
+	if i, ok := v.(dgo.Integer); ok {
+		return i.GoInt(), true
+	}
+	return 0, false
+}
+
+// asMap returns the given value as a Map and true or nil and false if the value isn't a Map
+func asMap(v dgo.Value) (dgo.Map, bool) {
+	m, ok := v.(dgo.Map)
+	return m, ok
+}
+
+// asGoString returns the Go string of the given value and true or an empty string and false if the value
+// isn't a String
+func asGoString(v dgo.Value) (string, bool) {
+	if s, ok := v.(dgo.String); ok {
+		return s.GoString(), true
+	}
+	return ``, false
 }
@@ -198,3 +198,70 @@ func TestNew(t *testing.T) {
 	require.Panic(t, func() { vf.New(typ.Any, vf.Arguments(vf.Nil, vf.Nil)) }, `unable to create`)
 	require.Panic(t, func() { vf.New(tf.Not(typ.Nil), vf.Nil) }, `unable to create`)
 }
+
+func TestIsNil(t *testing.T) {
+	require.True(t, vf.IsNil(nil))
+	require.True(t, vf.IsNil(vf.Nil))
+	require.True(t, vf.IsNil(vf.Value(nil)))
+	require.False(t, vf.IsNil(vf.Integer(0)))
+	require.False(t, vf.IsNil(vf.String(``)))
+}
+
+func TestIsZero(t *testing.T) {
+	require.True(t, vf.IsZero(nil))
+	require.True(t, vf.IsZero(vf.Nil))
+	require.True(t, vf.IsZero(vf.Integer(0)))
+	require.True(t, vf.IsZero(vf.Float(0)))
+	require.True(t, vf.IsZero(vf.False))
+	require.True(t, vf.IsZero(vf.String(``)))
+	require.True(t, vf.IsZero(vf.Binary([]byte{}, true)))
+	require.True(t, vf.IsZero(vf.Values()))
+	require.True(t, vf.IsZero(vf.Map()))
+
+	require.False(t, vf.IsZero(vf.Integer(1)))
+	require.False(t, vf.IsZero(vf.Float(0.1)))
+	require.False(t, vf.IsZero(vf.True))
+	require.False(t, vf.IsZero(vf.String(` `)))
+	require.False(t, vf.IsZero(vf.Binary([]byte{0}, true)))
+	require.False(t, vf.IsZero(vf.Values(nil)))
+	require.False(t, vf.IsZero(vf.Map(`a`, nil)))
+	require.False(t, vf.IsZero(typ.Any))
+}
+
+func TestToConversions(t *testing.T) {
+	i, ok := vf.ToInteger(vf.Integer(3))
+	require.True(t, ok)
+	require.Equal(t, int64(3), i.GoInt())
+	_, ok = vf.ToInteger(vf.Float(3))
+	require.False(t, ok)
+
+	f, ok := vf.ToFloat(vf.Float(3.5))
+	require.True(t, ok)
+	require.Equal(t, 3.5, f.GoFloat())
+	_, ok = vf.ToFloat(vf.Integer(3))
+	require.False(t, ok)
+
+	s, ok := vf.ToString(vf.String(`a`))
+	require.True(t, ok)
+	require.Equal(t, `a`, s.GoString())
+	_, ok = vf.ToString(vf.Nil)
+	require.False(t, ok)
+
+	b, ok := vf.ToBoolean(vf.True)
+	require.True(t, ok)
+	require.True(t, b.GoBool())
+	_, ok = vf.ToBoolean(nil)
+	require.False(t, ok)
+
+	a, ok := vf.ToArray(vf.Values(1))
+	require.True(t, ok)
+	require.Equal(t, 1, a.Len())
+	_, ok = vf.ToArray(vf.Map())
+	require.False(t, ok)
+
+	m, ok := vf.ToMap(vf.Map(`a`, 1))
+	require.True(t, ok)
+	require.Equal(t, 1, m.Len())
+	_, ok = vf.ToMap(vf.Values())
+	require.False(t, ok)
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/util"
+)
+
+type valueDiff struct {
+	added   dgo.Map
+	removed dgo.Map
+	changed dgo.Map
+	seen    []dgo.Value
+}
+
+// Diff returns a frozen Map that describes how value b differs from value a. Nested Maps and Arrays are compared
+// recursively. The differences are grouped under the keys "added", "removed", and "changed", where each group is a
+// Map keyed by the JSON Pointer of the difference. An added or removed entry maps to its value and a changed entry
+// maps to a Map with the keys "from" and "to". Array elements are compared by index, so elements beyond the length
+// of the shorter Array are added or removed. A Map key that is not a string is written as its string representation
+// prefixed with "~~", a sequence that never occurs in an escaped string key, so the int 1 and the string "1" give
+// different pointers. Groups without differences are omitted, so an empty Map is returned when the values are equal.
+// Values that refer to themselves are compared the same way as by Equals.
+func Diff(a, b interface{}) dgo.Map {
+	d := &valueDiff{added: MapWithCapacity(0), removed: MapWithCapacity(0), changed: MapWithCapacity(0)}
+	d.diff(``, Value(a), Value(b))
+	r := MapWithCapacity(3)
+	for _, g := range []struct {
+		key   string
+		group dgo.Map
+	}{{`added`, d.added}, {`removed`, d.removed}, {`changed`, d.changed}} {
+		if g.group.Len() > 0 {
+			r.Put(g.key, g.group)
+		}
+	}
+	r.Freeze()
+	return r
+}
+
+func (d *valueDiff) diff(path string, a, b dgo.Value) {
+	switch av := a.(type) {
+	case dgo.Map:
+		if bv, ok := b.(dgo.Map); ok {
+			if d.enter(a) {
+				d.mapDiff(path, av, bv)
+				d.seen = d.seen[:len(d.seen)-1]
+			}
+			return
+		}
+	case dgo.Array:
+		if bv, ok := b.(dgo.Array); ok {
+			if d.enter(a) {
+				d.arrayDiff(path, av, bv)
+				d.seen = d.seen[:len(d.seen)-1]
+			}
+			return
+		}
+	}
+	if !a.Equals(b) {
+		d.changed.Put(path, Map([]interface{}{`from`, a, `to`, b}))
+	}
+}
+
+// enter returns false if the given container is already being compared, in which case it is assumed to be equal just
+// like Equals does. Otherwise the container is added to the seen containers and true is returned.
+func (d *valueDiff) enter(a dgo.Value) bool {
+	if util.RecursionHit(d.seen, a) {
+		return false
+	}
+	d.seen = append(d.seen, a)
+	return true
+}
+
+// keyPath returns the pointer to the entry with the given key in the map at the given path
+func keyPath(path string, key dgo.Value) string {
+	if _, ok := key.(dgo.String); ok {
+		return pointerPath(path, key)
+	}
+	return path + `/~~` + pointerEscaper.Replace(key.String())
+}
+
+func (d *valueDiff) mapDiff(path string, a, b dgo.Map) {
+	a.EachEntry(func(e dgo.MapEntry) {
+		k := e.Key()
+		p := keyPath(path, k)
+		if bv := b.Get(k); bv != nil {
+			d.diff(p, e.Value(), bv)
+		} else {
+			d.removed.Put(p, frozenCopy(e.Value()))
+		}
+	})
+	b.EachEntry(func(e dgo.MapEntry) {
+		if !a.ContainsKey(e.Key()) {
+			d.added.Put(keyPath(path, e.Key()), frozenCopy(e.Value()))
+		}
+	})
+}
+
+func (d *valueDiff) arrayDiff(path string, a, b dgo.Array) {
+	al, bl := a.Len(), b.Len()
+	for i := 0; i < al || i < bl; i++ {
+		p := pointerPath(path, intVal(i))
+		switch {
+		case i >= bl:
+			d.removed.Put(p, frozenCopy(a.Get(i)))
+		case i >= al:
+			d.added.Put(p, frozenCopy(b.Get(i)))
+		default:
+			d.diff(p, a.Get(i), b.Get(i))
+		}
+	}
+}
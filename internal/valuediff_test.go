@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func TestDiff(t *testing.T) {
+	a := vf.Map(
+		`name`, `web`,
+		`server`, vf.Map(`host`, `localhost`, `port`, 80),
+		`tags`, vf.Strings(`a`, `b`, `c`),
+		`extra`, true)
+	b := vf.Map(
+		`name`, `web`,
+		`server`, vf.Map(`host`, `localhost`, `port`, 8080, `tls/on`, true),
+		`tags`, vf.Strings(`a`, `x`),
+		`owner`, vf.Map(`team`, `ops`))
+
+	require.Equal(t, vf.Map(
+		`added`, vf.Map(`/server/tls~1on`, true, `/owner`, vf.Map(`team`, `ops`)),
+		`removed`, vf.Map(`/tags/2`, `c`, `/extra`, true),
+		`changed`, vf.Map(
+			`/server/port`, vf.Map(`from`, 80, `to`, 8080),
+			`/tags/1`, vf.Map(`from`, `b`, `to`, `x`))),
+		vf.Diff(a, b))
+
+	require.Equal(t, vf.Map(`added`, vf.Map(`/2`, 3)), vf.Diff(vf.Values(1, 2), vf.Values(1, 2, 3)))
+	require.Equal(t, vf.Map(`changed`, vf.Map(``, vf.Map(`from`, 1, `to`, `1`))), vf.Diff(1, `1`))
+	require.Equal(t, vf.Map(`changed`, vf.Map(`/a`, vf.Map(`from`, vf.Values(1), `to`, vf.Map(`x`, 1)))),
+		vf.Diff(vf.Map(`a`, vf.Values(1)), vf.Map(`a`, vf.Map(`x`, 1))))
+}
+
+func TestDiff_equal(t *testing.T) {
+	d := vf.Diff(vf.Map(`a`, vf.Values(1, 2)), vf.Map(`a`, vf.Values(1, 2)))
+	require.Equal(t, 0, d.Len())
+	require.True(t, d.Frozen())
+}
+
+func TestDiff_mutable(t *testing.T) {
+	m := vf.MutableMap(`a`, vf.MutableValues(1))
+	d := vf.Diff(vf.Map(), m)
+	require.True(t, d.Frozen())
+	require.False(t, m.Frozen())
+	require.False(t, m.Get(`a`).(dgo.Array).Frozen())
+}
+
+func TestDiff_selfReference(t *testing.T) {
+	a := vf.MutableMap(`x`, 1)
+	a.Put(`self`, a)
+	b := vf.MutableMap(`x`, 2)
+	b.Put(`self`, b)
+	require.Equal(t, vf.Map(`changed`, vf.Map(`/x`, vf.Map(`from`, 1, `to`, 2))), vf.Diff(a, b))
+
+	c := vf.MutableValues(1)
+	c.Add(c)
+	require.Equal(t, 0, vf.Diff(c, c).Len())
+}
+
+func TestDiff_nonStringKeys(t *testing.T) {
+	d := vf.Diff(vf.Map(1, `a`, `1`, `b`), vf.Map(1, `x`, `1`, `y`))
+	require.Equal(t, vf.Map(`changed`, vf.Map(
+		`/~~1`, vf.Map(`from`, `a`, `to`, `x`),
+		`/1`, vf.Map(`from`, `b`, `to`, `y`))), d)
+
+	require.Equal(t, vf.Map(`added`, vf.Map(`/~~{1,"a~1b"}`, 2)),
+		vf.Diff(vf.Map(), vf.Map(vf.Values(1, `a/b`), 2)))
+}
@@ -0,0 +1,393 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/parser"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+// FromJSONSchema returns a dgo.Type that corresponds to the given JSON Schema document.
+//
+// The keywords type, enum, const, properties, required, additionalProperties, patternProperties, propertyNames,
+// minProperties, maxProperties, items, prefixItems, minItems, maxItems, minLength, maxLength, pattern, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, anyOf, allOf, oneOf, not, and $ref are converted. A $ref must be a
+// JSON Pointer into the given document, e.g. "#/$defs/address". Annotations such as title, description, and format
+// are ignored. When a schema has no type, the type is inferred from the keywords that are present.
+//
+// The type "number" is converted to float|int where both types are constrained by the range keywords. Some
+// constructs are approximated: the elements given by prefixItems are required, an additionalProperties schema on an
+// object with properties allows any additional entries, and an exclusiveMinimum of a float is converted to the
+// closest greater float.
+//
+// A $ref that is used within the schema that it refers to results in a recursive type. The recursion is expressed
+// using an alias that is resolved once the whole document has been converted.
+//
+// The function panics if the document contains a keyword value of the wrong type, an unknown type name, a $ref
+// that cannot be resolved, or a $ref that is nothing but a reference to itself.
+func FromJSONSchema(schema dgo.Map) dgo.Type {
+	im := &importer{root: schema, refs: make(map[string]dgo.Type)}
+	t := im.resolve(`#`)
+	if len(im.recursive) > 0 {
+		// Resolve the aliases of the recursive references in place
+		tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+			for _, ref := range im.recursive {
+				aa.Add(im.refs[ref], vf.String(ref))
+			}
+		})
+	}
+	return t
+}
+
+type importer struct {
+	root      dgo.Map
+	refs      map[string]dgo.Type
+	recursive []string
+}
+
+func (im *importer) convert(schema dgo.Value) dgo.Type {
+	switch s := schema.(type) {
+	case dgo.Boolean:
+		if s.GoBool() {
+			return typ.Any
+		}
+		return tf.Not(typ.Any)
+	case dgo.Map:
+		return im.convertMap(s)
+	}
+	panic(fmt.Errorf(`expected a JSON Schema, got %s`, schema))
+}
+
+func (im *importer) convertAll(schemas dgo.Value) []interface{} {
+	a := schemas.(dgo.Array)
+	ts := make([]interface{}, a.Len())
+	a.EachWithIndex(func(s dgo.Value, i int) { ts[i] = im.convert(s) })
+	return ts
+}
+
+func (im *importer) convertMap(s dgo.Map) dgo.Type {
+	var parts []interface{}
+	if ref := s.Get(`$ref`); ref != nil {
+		parts = append(parts, im.resolve(ref.(dgo.String).GoString()))
+	}
+
+	var enum dgo.Array
+	if v := s.Get(`enum`); v != nil {
+		enum = v.(dgo.Array)
+	} else if v = s.Get(`const`); v != nil {
+		enum = vf.Values(v)
+	}
+
+	if base := im.typeOf(s); base != nil {
+		if enum == nil || !enum.All(func(v dgo.Value) bool { return base.Instance(v) }) {
+			parts = append(parts, base)
+		}
+	}
+	if enum != nil {
+		ts := make([]interface{}, enum.Len())
+		enum.EachWithIndex(func(v dgo.Value, i int) { ts[i] = v.Type() })
+		parts = append(parts, tf.AnyOf(ts...))
+	}
+	if v := s.Get(`anyOf`); v != nil {
+		parts = append(parts, tf.AnyOf(im.convertAll(v)...))
+	}
+	if v := s.Get(`oneOf`); v != nil {
+		parts = append(parts, tf.OneOf(im.convertAll(v)...))
+	}
+	if v := s.Get(`allOf`); v != nil {
+		parts = append(parts, im.convertAll(v)...)
+	}
+	if v := s.Get(`not`); v != nil {
+		parts = append(parts, tf.Not(im.convert(v)))
+	}
+
+	switch len(parts) {
+	case 0:
+		return typ.Any
+	case 1:
+		return parts[0].(dgo.Type)
+	}
+	return tf.AllOf(parts...)
+}
+
+// resolve returns the type of the schema that the given reference points to
+func (im *importer) resolve(ref string) dgo.Type {
+	if t, ok := im.refs[ref]; ok {
+		if t == nil {
+			// The schema is being converted so the reference is recursive
+			t = parser.NewAlias(vf.String(ref))
+			im.refs[ref] = t
+			im.recursive = append(im.recursive, ref)
+		}
+		return t
+	}
+	if !strings.HasPrefix(ref, `#`) {
+		panic(fmt.Errorf(`unable to resolve $ref "%s": only references within the document are supported`, ref))
+	}
+	var v dgo.Value = im.root
+	if ptr := ref[1:]; ptr != `` {
+		for _, token := range strings.Split(strings.TrimPrefix(ptr, `/`), `/`) {
+			token = strings.NewReplacer(`~1`, `/`, `~0`, `~`).Replace(token)
+			switch c := v.(type) {
+			case dgo.Map:
+				v = c.Get(token)
+			case dgo.Array:
+				if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < c.Len() {
+					v = c.Get(i)
+				} else {
+					v = nil
+				}
+			default:
+				v = nil
+			}
+			if v == nil {
+				panic(fmt.Errorf(`unable to resolve $ref "%s"`, ref))
+			}
+		}
+	}
+	im.refs[ref] = nil
+	t := im.convert(v)
+	if _, ok := t.(dgo.Alias); ok {
+		panic(fmt.Errorf(`the $ref "%s" resolves to nothing but a recursive reference`, ref))
+	}
+	im.refs[ref] = t
+	return t
+}
+
+// typeOf returns the type that is described by the "type" keyword of the given schema together with the keywords
+// that constrain it, or nil when the schema has no such keywords.
+func (im *importer) typeOf(s dgo.Map) dgo.Type {
+	switch tn := s.Get(`type`).(type) {
+	case nil:
+		kind := inferKind(s)
+		if kind == `` {
+			return nil
+		}
+		return im.kindType(kind, s)
+	case dgo.String:
+		return im.kindType(tn.GoString(), s)
+	case dgo.Array:
+		ts := make([]interface{}, tn.Len())
+		tn.EachWithIndex(func(n dgo.Value, i int) { ts[i] = im.kindType(n.(dgo.String).GoString(), s) })
+		return tf.AnyOf(ts...)
+	}
+	panic(errors.New(`the "type" keyword must be a string or an array of strings`))
+}
+
+// inferKind returns the name of the type implied by the keywords of a schema that has no "type" keyword
+func inferKind(s dgo.Map) string {
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if s.ContainsKey(k) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has(`properties`, `required`, `additionalProperties`, `patternProperties`, `propertyNames`,
+		`minProperties`, `maxProperties`):
+		return `object`
+	case has(`items`, `prefixItems`, `minItems`, `maxItems`):
+		return `array`
+	case has(`pattern`, `minLength`, `maxLength`):
+		return `string`
+	case has(`minimum`, `maximum`, `exclusiveMinimum`, `exclusiveMaximum`):
+		return `number`
+	}
+	return ``
+}
+
+func (im *importer) kindType(kind string, s dgo.Map) dgo.Type {
+	switch kind {
+	case `null`:
+		return typ.Nil
+	case `boolean`:
+		return typ.Boolean
+	case `integer`:
+		return integerType(s)
+	case `number`:
+		// A JSON number may be an integer or a float
+		return tf.AnyOf(floatType(s), integerType(s))
+	case `string`:
+		return stringType(s)
+	case `array`:
+		return im.arrayType(s)
+	case `object`:
+		return im.objectType(s)
+	}
+	panic(fmt.Errorf(`unknown JSON Schema type "%s"`, kind))
+}
+
+func number(s dgo.Map, key string) (float64, bool) {
+	if v := s.Get(key); v != nil {
+		return v.(dgo.Number).ToFloat(), true
+	}
+	return 0, false
+}
+
+func size(s dgo.Map, minKey, maxKey string) (int, int) {
+	min, max := 0, math.MaxInt64
+	if v, ok := number(s, minKey); ok {
+		min = int(v)
+	}
+	if v, ok := number(s, maxKey); ok {
+		max = int(v)
+	}
+	return min, max
+}
+
+// integerType returns the integer range that is described by the range keywords of the given schema. When both an
+// inclusive and an exclusive bound are present at the same end, the tighter one is used.
+func integerType(s dgo.Map) dgo.Type {
+	min, max := int64(math.MinInt64), int64(math.MaxInt64)
+	inclusive := true
+	bounded := false
+	if v, ok := number(s, `minimum`); ok {
+		min, bounded = int64(math.Ceil(v)), true
+	}
+	if v, ok := number(s, `exclusiveMinimum`); ok {
+		if m := int64(math.Floor(v)) + 1; !bounded || m > min {
+			min = m
+		}
+		bounded = true
+	}
+	maxBounded := false
+	if v, ok := number(s, `maximum`); ok {
+		max, bounded, maxBounded = int64(math.Floor(v)), true, true
+	}
+	if v, ok := number(s, `exclusiveMaximum`); ok {
+		// An exclusive maximum m excludes all values greater than m-1
+		if m := int64(math.Ceil(v)); !maxBounded || m <= max {
+			max, inclusive = m, false
+		}
+		bounded = true
+	}
+	if !bounded {
+		return typ.Integer
+	}
+	return tf.Integer(min, max, inclusive)
+}
+
+// floatType returns the float range that is described by the range keywords of the given schema. When both an
+// inclusive and an exclusive bound are present at the same end, the tighter one is used.
+func floatType(s dgo.Map) dgo.Type {
+	min, max := -math.MaxFloat64, math.MaxFloat64
+	inclusive := true
+	bounded := false
+	if v, ok := number(s, `minimum`); ok {
+		min, bounded = v, true
+	}
+	if v, ok := number(s, `exclusiveMinimum`); ok {
+		if m := math.Nextafter(v, math.Inf(1)); !bounded || m > min {
+			min = m
+		}
+		bounded = true
+	}
+	maxBounded := false
+	if v, ok := number(s, `maximum`); ok {
+		max, bounded, maxBounded = v, true, true
+	}
+	if v, ok := number(s, `exclusiveMaximum`); ok {
+		if !maxBounded || v <= max {
+			max, inclusive = v, false
+		}
+		bounded = true
+	}
+	if !bounded {
+		return typ.Float
+	}
+	return tf.Float(min, max, inclusive)
+}
+
+func stringType(s dgo.Map) dgo.Type {
+	min, max := size(s, `minLength`, `maxLength`)
+	st := tf.String(min, max)
+	if p := s.Get(`pattern`); p != nil {
+		pt := tf.Pattern(regexp.MustCompile(p.(dgo.String).GoString()))
+		if st.Unbounded() {
+			return pt
+		}
+		return tf.AllOf(pt, st)
+	}
+	return st
+}
+
+func (im *importer) arrayType(s dgo.Map) dgo.Type {
+	items := s.Get(`items`)
+	if prefix := s.Get(`prefixItems`); prefix != nil {
+		ts := im.convertAll(prefix)
+		if b, ok := items.(dgo.Boolean); ok && !b.GoBool() {
+			return tf.Tuple(ts...)
+		}
+		et := typ.Any
+		if items != nil {
+			et = im.convert(items)
+		}
+		return tf.VariadicTuple(append(ts, et)...)
+	}
+	et := typ.Any
+	if items != nil {
+		et = im.convert(items)
+	}
+	min, max := size(s, `minItems`, `maxItems`)
+	return tf.Array(et, min, max)
+}
+
+func (im *importer) objectType(s dgo.Map) dgo.Type {
+	ap := s.Get(`additionalProperties`)
+	closed := ap != nil && ap.Equals(false)
+
+	props, _ := s.Get(`properties`).(dgo.Map)
+	required, _ := s.Get(`required`).(dgo.Array)
+	pp, _ := s.Get(`patternProperties`).(dgo.Map)
+	if props != nil || required != nil {
+		var entries []dgo.StructMapEntry
+		isRequired := func(k dgo.Value) bool { return required != nil && required.IndexOf(k) >= 0 }
+		if props != nil {
+			props.EachEntry(func(e dgo.MapEntry) {
+				entries = append(entries, tf.StructMapEntry(e.Key(), im.convert(e.Value()), isRequired(e.Key())))
+			})
+		}
+		if required != nil {
+			required.Each(func(k dgo.Value) {
+				if props == nil || !props.ContainsKey(k) {
+					entries = append(entries, tf.StructMapEntry(k, typ.Any, true))
+				}
+			})
+		}
+		if pp != nil {
+			pp.EachEntry(func(e dgo.MapEntry) {
+				pt := tf.Pattern(regexp.MustCompile(e.Key().(dgo.String).GoString()))
+				entries = append(entries, tf.StructMapEntry(pt, im.convert(e.Value()), false))
+			})
+		}
+		return tf.StructMap(!closed, entries...)
+	}
+
+	min, max := size(s, `minProperties`, `maxProperties`)
+	if pp != nil && pp.Len() == 1 && closed {
+		var mt dgo.Type
+		pp.EachEntry(func(e dgo.MapEntry) {
+			mt = tf.Map(tf.Pattern(regexp.MustCompile(e.Key().(dgo.String).GoString())), im.convert(e.Value()), min, max)
+		})
+		return mt
+	}
+	var kt dgo.Type = typ.String
+	if pn := s.Get(`propertyNames`); pn != nil {
+		kt = im.convert(pn)
+	}
+	vt := typ.Any
+	if ap != nil {
+		vt = im.convert(ap)
+	}
+	return tf.Map(kt, vt, min, max)
+}
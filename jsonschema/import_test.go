@@ -0,0 +1,187 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/jsonschema"
+	"github.com/lyraproj/dgo/streamer"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func fromJSON(t *testing.T, schema string) dgo.Type {
+	t.Helper()
+	return jsonschema.FromJSONSchema(streamer.UnmarshalJSON([]byte(schema), nil).(dgo.Map))
+}
+
+func TestFromJSONSchema_primitives(t *testing.T) {
+	require.Same(t, typ.Any, fromJSON(t, `{}`))
+	require.Same(t, typ.Any, fromJSON(t, `{"title":"anything","description":"no constraints"}`))
+	require.Same(t, typ.Nil, fromJSON(t, `{"type":"null"}`))
+	require.Same(t, typ.Boolean, fromJSON(t, `{"type":"boolean"}`))
+	require.Same(t, typ.Integer, fromJSON(t, `{"type":"integer"}`))
+	require.Equal(t, tf.AnyOf(typ.Float, typ.Integer), fromJSON(t, `{"type":"number"}`))
+	require.Same(t, typ.String, fromJSON(t, `{"type":"string","format":"email"}`))
+	require.Equal(t, tf.AnyOf(typ.String, typ.Nil), fromJSON(t, `{"type":["string","null"]}`))
+}
+
+func TestFromJSONSchema_ranges(t *testing.T) {
+	require.Equal(t, tf.Integer(1, 10, true), fromJSON(t, `{"type":"integer","minimum":1,"maximum":10}`))
+	require.Equal(t, tf.Integer(2, 10, false), fromJSON(t, `{"type":"integer","exclusiveMinimum":1,"exclusiveMaximum":10}`))
+	require.Equal(t, tf.ParseType(`0.5..1.5|1..1`), fromJSON(t, `{"type":"number","minimum":0.5,"maximum":1.5}`))
+	require.Equal(t, tf.ParseType(`0.0...1.0|0...1`), fromJSON(t, `{"minimum":0,"exclusiveMaximum":1}`))
+
+	// The tighter of an inclusive and an exclusive bound is used
+	require.Equal(t, tf.Integer(1, 5, true), fromJSON(t, `{"type":"integer","maximum":5,"exclusiveMaximum":10,"minimum":1}`))
+	require.Equal(t, tf.Integer(1, 5, false), fromJSON(t, `{"type":"integer","maximum":10,"exclusiveMaximum":5,"minimum":1}`))
+	require.Equal(t, tf.Integer(4, 10, true), fromJSON(t, `{"type":"integer","minimum":4,"exclusiveMinimum":2,"maximum":10}`))
+	require.Equal(t, tf.Integer(3, 10, true), fromJSON(t, `{"type":"integer","minimum":2,"exclusiveMinimum":2,"maximum":10}`))
+	ft := fromJSON(t, `{"type":"number","maximum":5,"exclusiveMaximum":10}`)
+	require.Instance(t, ft, 5.0)
+	require.NotInstance(t, ft, 6.0)
+	ft = fromJSON(t, `{"type":"number","maximum":5,"exclusiveMaximum":5,"minimum":1,"exclusiveMinimum":0}`)
+	require.NotInstance(t, ft, 5.0)
+	require.Instance(t, ft, 4.5)
+	require.NotInstance(t, ft, 0.5)
+
+	nt := fromJSON(t, `{"type":"number","exclusiveMinimum":0}`)
+	require.NotInstance(t, nt, 0.0)
+	require.Instance(t, nt, 0.001)
+	require.Instance(t, nt, 3)
+
+	require.Equal(t, tf.String(1, 10), fromJSON(t, `{"type":"string","minLength":1,"maxLength":10}`))
+	require.Equal(t, tf.ParseType(`/^[a-z]+$/`), fromJSON(t, `{"type":"string","pattern":"^[a-z]+$"}`))
+	require.Equal(t, tf.ParseType(`/^[a-z]+$/&string[2]`), fromJSON(t, `{"pattern":"^[a-z]+$","minLength":2}`))
+}
+
+func TestFromJSONSchema_enum(t *testing.T) {
+	require.Equal(t, tf.ParseType(`"a"|"b"`), fromJSON(t, `{"type":"string","enum":["a","b"]}`))
+	require.Equal(t, tf.ParseType(`"a"|1|nil`), fromJSON(t, `{"enum":["a",1,null]}`))
+	require.Equal(t, tf.ParseType(`"a"`), fromJSON(t, `{"const":"a"}`))
+	require.Equal(t, tf.ParseType(`string[3]&("a"|"abc")`), fromJSON(t, `{"type":"string","minLength":3,"enum":["a","abc"]}`))
+}
+
+func TestFromJSONSchema_combinations(t *testing.T) {
+	require.Equal(t, tf.ParseType(`string|int`), fromJSON(t, `{"anyOf":[{"type":"string"},{"type":"integer"}]}`))
+	require.Equal(t, tf.ParseType(`string^int`), fromJSON(t, `{"oneOf":[{"type":"string"},{"type":"integer"}]}`))
+	require.Equal(t, tf.ParseType(`0..&..10`),
+		fromJSON(t, `{"allOf":[{"type":"integer","minimum":0},{"type":"integer","maximum":10}]}`))
+	require.Equal(t, tf.ParseType(`!nil`), fromJSON(t, `{"not":{"type":"null"}}`))
+	require.Equal(t, tf.ParseType(`string&!"x"`), fromJSON(t, `{"type":"string","not":{"const":"x"}}`))
+	require.Equal(t, tf.ParseType(`[]any`), fromJSON(t, `{"type":"array","items":true}`))
+	require.Equal(t, tf.Array(tf.Not(typ.Any)), fromJSON(t, `{"type":"array","items":false}`))
+}
+
+func TestFromJSONSchema_arrays(t *testing.T) {
+	require.Equal(t, tf.ParseType(`[1,5]string`), fromJSON(t, `{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":5}`))
+	require.Equal(t, tf.ParseType(`[]any`), fromJSON(t, `{"type":"array"}`))
+	require.Equal(t, tf.ParseType(`{string,int}`),
+		fromJSON(t, `{"type":"array","prefixItems":[{"type":"string"},{"type":"integer"}],"items":false}`))
+	require.Equal(t, tf.ParseType(`{string,...int}`),
+		fromJSON(t, `{"type":"array","prefixItems":[{"type":"string"}],"items":{"type":"integer"}}`))
+	require.Equal(t, tf.ParseType(`{string,...any}`), fromJSON(t, `{"prefixItems":[{"type":"string"}]}`))
+}
+
+func TestFromJSONSchema_objects(t *testing.T) {
+	require.Equal(t, tf.ParseType(`map[string]any`), fromJSON(t, `{"type":"object"}`))
+	require.Equal(t, tf.ParseType(`map[string,1]int`),
+		fromJSON(t, `{"type":"object","additionalProperties":{"type":"integer"},"minProperties":1}`))
+	require.Equal(t, tf.ParseType(`map[/^X-/]string`),
+		fromJSON(t, `{"type":"object","patternProperties":{"^X-":{"type":"string"}},"additionalProperties":false}`))
+	require.Equal(t, tf.ParseType(`map[string[0,3]]int`),
+		fromJSON(t, `{"propertyNames":{"maxLength":3},"additionalProperties":{"type":"integer"}}`))
+	require.Equal(t, tf.ParseType(`{name:string,age?:0..}`), fromJSON(t,
+		`{"type":"object","properties":{"name":{"type":"string"},"age":{"type":"integer","minimum":0}},`+
+			`"required":["name"],"additionalProperties":false}`))
+	require.Equal(t, tf.ParseType(`{name:string,id:any,...}`),
+		fromJSON(t, `{"properties":{"name":{"type":"string"}},"required":["name","id"]}`))
+	require.Equal(t, tf.ParseType(`{name:string,/^env_/:string}`), fromJSON(t,
+		`{"type":"object","properties":{"name":{"type":"string"}},"patternProperties":{"^env_":{"type":"string"}},`+
+			`"required":["name"],"additionalProperties":false}`))
+}
+
+func TestFromJSONSchema_ref(t *testing.T) {
+	st := fromJSON(t, `{
+  "$defs": {
+    "port": {"type":"integer","minimum":1,"maximum":65535},
+    "a/b": {"type":"string"}
+  },
+  "type": "object",
+  "properties": {
+    "port": {"$ref":"#/$defs/port"},
+    "ports": {"type":"array","items":{"$ref":"#/$defs/port"}},
+    "name": {"$ref":"#/$defs/a~1b"},
+    "first": {"$ref":"#/properties/ports/items"}
+  },
+  "additionalProperties": false
+}`)
+	require.Equal(t, tf.ParseType(`{port?:1..65535,ports?:[]1..65535,name?:string,first?:1..65535}`), st)
+
+	require.Equal(t, tf.ParseType(`0..&..10`),
+		fromJSON(t, `{"$defs":{"n":{"type":"integer","minimum":0}},"$ref":"#/$defs/n","type":"integer","maximum":10}`))
+
+	require.Panic(t, func() { fromJSON(t, `{"$ref":"#/$defs/missing"}`) }, `unable to resolve \$ref "#/\$defs/missing"`)
+	require.Panic(t, func() { fromJSON(t, `{"$ref":"other.json#/a"}`) }, `only references within the document`)
+	require.Panic(t, func() { fromJSON(t, `{"$defs":{"n":{"$ref":"#/$defs/n"}},"$ref":"#/$defs/n"}`) },
+		`the \$ref "#/\$defs/n" resolves to nothing but a recursive reference`)
+	require.Panic(t, func() { fromJSON(t, `{"anyOf":[{"$ref":"#"}]}`) }, `nothing but a recursive reference`)
+}
+
+func TestFromJSONSchema_recursiveRef(t *testing.T) {
+	nt := fromJSON(t, `{"$defs":{"n":{"type":"array","items":{"$ref":"#/$defs/n"}}},"$ref":"#/$defs/n"}`)
+	require.Instance(t, nt, vf.Values())
+	require.Instance(t, nt, vf.Values(vf.Values(), vf.Values(vf.Values())))
+	require.NotInstance(t, nt, vf.Values(1))
+
+	tree := fromJSON(t, `{
+  "type": "object",
+  "properties": {
+    "value": {"type":"integer"},
+    "children": {"type":"array","items":{"$ref":"#"}}
+  },
+  "required": ["value"],
+  "additionalProperties": false
+}`)
+	require.Instance(t, tree, vf.Map(`value`, 1, `children`, vf.Values(vf.Map(`value`, 2), vf.Map(`value`, 3))))
+	require.NotInstance(t, tree, vf.Map(`value`, 1, `children`, vf.Values(vf.Map(`value`, `x`))))
+	require.NotInstance(t, tree, vf.Map(`value`, 1, `children`, vf.Values(vf.Map())))
+
+	// Mutually recursive definitions
+	mt := fromJSON(t, `{
+  "$defs": {
+    "a": {"type":"object","properties":{"b":{"$ref":"#/$defs/b"}},"additionalProperties":false},
+    "b": {"type":"array","items":{"$ref":"#/$defs/a"}}
+  },
+  "$ref": "#/$defs/a"
+}`)
+	require.Instance(t, mt, vf.Map(`b`, vf.Values(vf.Map(), vf.Map(`b`, vf.Values()))))
+	require.NotInstance(t, mt, vf.Map(`b`, vf.Values(1)))
+}
+
+func TestFromJSONSchema_illegal(t *testing.T) {
+	require.Panic(t, func() { fromJSON(t, `{"type":"date"}`) }, `unknown JSON Schema type "date"`)
+	require.Panic(t, func() { fromJSON(t, `{"type":1}`) }, `must be a string or an array of strings`)
+	require.Panic(t, func() { fromJSON(t, `{"not":1}`) }, `expected a JSON Schema, got 1`)
+}
+
+func TestFromJSONSchema_roundTrip(t *testing.T) {
+	for _, te := range []string{
+		`1..10`, `1...10`, `string[1,10]`, `/^[a-z]+$/`, `"a"|"b"`, `string|int`, `!nil`,
+		`[1,5]string`, `{string,int}`, `{string,...int}`, `map[/^X-/]string`,
+		`{name:string,age?:0..}`, `{name:string,...}`,
+	} {
+		tp := tf.ParseType(te)
+		require.Equal(t, tp, jsonschema.FromJSONSchema(jsonschema.ToJSONSchema(tp)))
+	}
+}
+
+func TestFromJSONSchema_validation(t *testing.T) {
+	st := fromJSON(t, `{"type":"object","properties":{"host":{"type":"string"},"port":{"type":"integer"}},
+"required":["host"],"additionalProperties":false}`)
+	require.Instance(t, st, vf.Map(`host`, `example.com`, `port`, 443))
+	errs := typ.ValidateAll(st, vf.Map(`port`, `https`))
+	require.Equal(t, 2, len(errs))
+}
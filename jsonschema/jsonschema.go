@@ -0,0 +1,303 @@
+// Package jsonschema contains functions to convert dgo types into JSON Schema documents so that contracts that are
+// defined using dgo can be shared with consumers that aren't written in Go.
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/util"
+	"github.com/lyraproj/dgo/vf"
+)
+
+// Draft is the URI of the JSON Schema dialect that ToJSONSchema produces
+const Draft = `https://json-schema.org/draft/2020-12/schema`
+
+// ToJSONSchema returns a frozen Map that is a draft 2020-12 JSON Schema document for the given type. The document
+// has a "$schema" entry with the Draft URI.
+//
+// Arrays, tuples, maps, struct maps, conditional structs, integer and float ranges, sized strings, patterns, exact
+// values, enums (an anyOf where all operands are exact), and the allOf, anyOf, oneOf, and not combinations are
+// converted along with the time, uri, ipv4, and ipv6 string formats. Patterns are converted verbatim, so they must
+// not use constructs that are specific to Go regular expressions, such as \A and \z, when the schema is consumed by
+// an ECMA 262 validator. Defaults of struct entries, and annotations of struct entries and annotated types, are
+// exported when JSON Schema has a corresponding keyword.
+//
+// The function panics if the type, or a type that it contains, cannot be represented in JSON Schema or if the type
+// is recursive.
+func ToJSONSchema(t dgo.Type) dgo.Map {
+	s := vf.MapWithCapacity(4)
+	s.Put(`$schema`, Draft)
+	(&converter{}).convert(t, s)
+	s.Freeze()
+	return s
+}
+
+type converter struct {
+	seen []dgo.Value
+}
+
+// schema returns a new schema for the given type
+func (c *converter) schema(t dgo.Type) dgo.Map {
+	s := vf.MapWithCapacity(4)
+	c.convert(t, s)
+	return s
+}
+
+// schemas returns an array with one schema for each of the given types
+func (c *converter) schemas(ts dgo.Array) dgo.Array {
+	a := vf.ArrayWithCapacity(ts.Len())
+	ts.Each(func(t dgo.Value) { a.Add(c.schema(t.(dgo.Type))) })
+	return a
+}
+
+// convert adds the keywords of the schema for the given type to the given map
+func (c *converter) convert(t dgo.Type, s dgo.Map) {
+	if util.RecursionHit(c.seen, t) {
+		panic(fmt.Errorf(`the recursive type %s cannot be represented as a JSON Schema`, t))
+	}
+	c.seen = append(c.seen, t)
+	defer func() { c.seen = c.seen[:len(c.seen)-1] }()
+
+	if a, ok := t.(dgo.Annotated); ok {
+		annotate(a.Annotations(), s)
+	}
+
+	switch t.TypeIdentifier() {
+	case dgo.TiAny:
+	case dgo.TiNil:
+		s.Put(`type`, `null`)
+	case dgo.TiBoolean:
+		s.Put(`type`, `boolean`)
+	case dgo.TiInteger, dgo.TiIntegerRange:
+		s.Put(`type`, `integer`)
+		integerRange(t.(dgo.IntegerType), s)
+	case dgo.TiBigInt:
+		s.Put(`type`, `integer`)
+	case dgo.TiFloat, dgo.TiFloatRange:
+		s.Put(`type`, `number`)
+		floatRange(t.(dgo.FloatType), s)
+	case dgo.TiString, dgo.TiStringSized, dgo.TiDgoString:
+		s.Put(`type`, `string`)
+		sizeRange(t.(dgo.SizedType), `minLength`, `maxLength`, s)
+	case dgo.TiStringPattern:
+		s.Put(`type`, `string`)
+		s.Put(`pattern`, t.(dgo.ExactType).ExactValue().(dgo.Regexp).GoRegexp().String())
+	case dgo.TiBase64String:
+		s.Put(`type`, `string`)
+		s.Put(`contentEncoding`, `base64`)
+	case dgo.TiTime:
+		stringFormat(`date-time`, s)
+	case dgo.TiURI:
+		stringFormat(`uri`, s)
+	case dgo.TiIPv4, dgo.TiIPv6:
+		if t.(dgo.IPType).Network() != nil {
+			unsupported(t)
+		}
+		stringFormat(t.TypeIdentifier().String(), s)
+	case dgo.TiArray:
+		c.array(t.(dgo.ArrayType), s)
+	case dgo.TiTuple:
+		c.tuple(t.(dgo.TupleType), s)
+	case dgo.TiMap:
+		c.object(t.(dgo.MapType), s)
+	case dgo.TiStruct:
+		c.structObject(t.(dgo.StructMapType), s)
+	case dgo.TiConditionalStruct:
+		s.Put(`oneOf`, c.schemas(t.(dgo.ConditionalStructType).Cases()))
+	case dgo.TiAnyOf:
+		ops := t.(dgo.TernaryType).Operands()
+		if allExact(ops) {
+			enum := vf.ArrayWithCapacity(ops.Len())
+			ops.Each(func(op dgo.Value) {
+				if et, ok := op.(dgo.ExactType); ok {
+					enum.Add(et.ExactValue())
+				} else {
+					enum.Add(nil)
+				}
+			})
+			s.Put(`enum`, enum)
+		} else {
+			s.Put(`anyOf`, c.schemas(ops))
+		}
+	case dgo.TiOneOf:
+		s.Put(`oneOf`, c.schemas(t.(dgo.TernaryType).Operands()))
+	case dgo.TiAllOf:
+		s.Put(`allOf`, c.schemas(t.(dgo.TernaryType).Operands()))
+	case dgo.TiNot:
+		s.Put(`not`, c.schema(t.(dgo.UnaryType).Operand()))
+	case dgo.TiArrayExact, dgo.TiBooleanExact, dgo.TiFloatExact, dgo.TiIntegerExact, dgo.TiMapExact,
+		dgo.TiStringExact, dgo.TiBigIntExact:
+		s.Put(`const`, t.(dgo.ExactType).ExactValue())
+	default:
+		unsupported(t)
+	}
+}
+
+// annotationKeywords are the JSON Schema keywords that an annotation can be exported as
+var annotationKeywords = []string{
+	`title`, dgo.AnnotationDescription, dgo.AnnotationDeprecated, dgo.AnnotationExamples, `readOnly`, `writeOnly`,
+	`$comment`}
+
+// annotate adds the annotations that have a corresponding JSON Schema keyword to the given schema. A deprecation
+// notice is exported as true since the deprecated keyword is a boolean.
+func annotate(annotations dgo.Map, s dgo.Map) {
+	for _, k := range annotationKeywords {
+		v := annotations.Get(k)
+		if v == nil {
+			continue
+		}
+		if _, ok := v.(dgo.String); ok && k == dgo.AnnotationDeprecated {
+			v = vf.True
+		}
+		s.Put(k, v)
+	}
+}
+
+func unsupported(t dgo.Type) {
+	panic(fmt.Errorf(`the type %s cannot be represented as a JSON Schema`, t))
+}
+
+func allExact(ts dgo.Array) bool {
+	return ts.All(func(t dgo.Value) bool {
+		switch t.(dgo.Type).TypeIdentifier() {
+		case dgo.TiArrayExact, dgo.TiBooleanExact, dgo.TiFloatExact, dgo.TiIntegerExact, dgo.TiMapExact,
+			dgo.TiStringExact, dgo.TiBigIntExact, dgo.TiNil:
+			return true
+		}
+		return false
+	})
+}
+
+func stringFormat(format string, s dgo.Map) {
+	s.Put(`type`, `string`)
+	s.Put(`format`, format)
+}
+
+func integerRange(t dgo.IntegerType, s dgo.Map) {
+	if min := t.Min(); min != math.MinInt64 {
+		s.Put(`minimum`, min)
+	}
+	if max := t.Max(); max != math.MaxInt64 {
+		if t.Inclusive() {
+			s.Put(`maximum`, max)
+		} else {
+			s.Put(`exclusiveMaximum`, max)
+		}
+	}
+}
+
+func floatRange(t dgo.FloatType, s dgo.Map) {
+	if min := t.Min(); min != -math.MaxFloat64 {
+		s.Put(`minimum`, min)
+	}
+	if max := t.Max(); max != math.MaxFloat64 {
+		if t.Inclusive() {
+			s.Put(`maximum`, max)
+		} else {
+			s.Put(`exclusiveMaximum`, max)
+		}
+	}
+}
+
+func sizeRange(t dgo.SizedType, minKey, maxKey string, s dgo.Map) {
+	if min := t.Min(); min > 0 {
+		s.Put(minKey, min)
+	}
+	if max := t.Max(); max != math.MaxInt64 {
+		s.Put(maxKey, max)
+	}
+}
+
+func (c *converter) array(t dgo.ArrayType, s dgo.Map) {
+	s.Put(`type`, `array`)
+	if et := t.ElementType(); et != typ.Any {
+		s.Put(`items`, c.schema(et))
+	}
+	sizeRange(t, `minItems`, `maxItems`, s)
+}
+
+func (c *converter) tuple(t dgo.TupleType, s dgo.Map) {
+	s.Put(`type`, `array`)
+	n := t.Len()
+	if t.Variadic() {
+		n--
+	}
+	if n > 0 {
+		prefix := vf.ArrayWithCapacity(n)
+		for i := 0; i < n; i++ {
+			prefix.Add(c.schema(t.Element(i)))
+		}
+		s.Put(`prefixItems`, prefix)
+	}
+	if t.Variadic() {
+		s.Put(`items`, c.schema(t.Element(n)))
+	} else {
+		s.Put(`items`, false)
+	}
+	sizeRange(t, `minItems`, `maxItems`, s)
+}
+
+func (c *converter) object(t dgo.MapType, s dgo.Map) {
+	s.Put(`type`, `object`)
+	kt, vt := t.KeyType(), t.ValueType()
+	switch kt.TypeIdentifier() {
+	case dgo.TiAny, dgo.TiString:
+		if vt != typ.Any {
+			s.Put(`additionalProperties`, c.schema(vt))
+		}
+	case dgo.TiStringPattern:
+		s.Put(`patternProperties`, vf.Map(kt.(dgo.ExactType).ExactValue().(dgo.Regexp).GoRegexp().String(), c.schema(vt)))
+		s.Put(`additionalProperties`, false)
+	default:
+		if !typ.String.Assignable(kt) {
+			unsupported(t)
+		}
+		s.Put(`propertyNames`, c.schema(kt))
+		if vt != typ.Any {
+			s.Put(`additionalProperties`, c.schema(vt))
+		}
+	}
+	sizeRange(t, `minProperties`, `maxProperties`, s)
+}
+
+func (c *converter) structObject(t dgo.StructMapType, s dgo.Map) {
+	s.Put(`type`, `object`)
+	props := vf.MapWithCapacity(t.Len())
+	patternProps := vf.MapWithCapacity(0)
+	required := vf.ArrayWithCapacity(t.Len())
+	t.Each(func(e dgo.StructMapEntry) {
+		ps := c.schema(e.Value().(dgo.Type))
+		annotate(e.Annotations(), ps)
+		kv := e.Key().(dgo.ExactType).ExactValue()
+		if rx, ok := kv.(dgo.Regexp); ok && e.Key().(dgo.Type).TypeIdentifier() == dgo.TiStringPattern {
+			patternProps.Put(rx.GoRegexp().String(), ps)
+			return
+		}
+		k, ok := kv.(dgo.String)
+		if !ok {
+			unsupported(t)
+		}
+		if d := e.Default(); d != nil {
+			ps.Put(`default`, d)
+		}
+		props.Put(k, ps)
+		if e.Required() {
+			required.Add(k)
+		}
+	})
+	if props.Len() > 0 {
+		s.Put(`properties`, props)
+	}
+	if patternProps.Len() > 0 {
+		s.Put(`patternProperties`, patternProps)
+	}
+	if required.Len() > 0 {
+		s.Put(`required`, required)
+	}
+	if !t.Additional() {
+		s.Put(`additionalProperties`, false)
+	}
+}
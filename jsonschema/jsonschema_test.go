@@ -0,0 +1,120 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/jsonschema"
+	"github.com/lyraproj/dgo/streamer"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func schemaJSON(t *testing.T, typeExpr string) string {
+	t.Helper()
+	s := jsonschema.ToJSONSchema(tf.ParseType(typeExpr))
+	require.Equal(t, jsonschema.Draft, s.Get(`$schema`))
+	require.True(t, s.Frozen())
+	return string(streamer.MarshalJSON(s.Without(`$schema`), nil))
+}
+
+func TestToJSONSchema_primitives(t *testing.T) {
+	require.Equal(t, `{}`, schemaJSON(t, `any`))
+	require.Equal(t, `{"type":"null"}`, schemaJSON(t, `nil`))
+	require.Equal(t, `{"type":"boolean"}`, schemaJSON(t, `bool`))
+	require.Equal(t, `{"type":"integer"}`, schemaJSON(t, `int`))
+	require.Equal(t, `{"type":"integer"}`, schemaJSON(t, `bigint`))
+	require.Equal(t, `{"type":"number"}`, schemaJSON(t, `float`))
+	require.Equal(t, `{"type":"string"}`, schemaJSON(t, `string`))
+	require.Equal(t, `{"type":"string","contentEncoding":"base64"}`, schemaJSON(t, `base64`))
+	require.Equal(t, `{"type":"string","format":"date-time"}`, schemaJSON(t, `time`))
+	require.Equal(t, `{"type":"string","format":"uri"}`, schemaJSON(t, `uri`))
+	require.Equal(t, `{"type":"string","format":"ipv4"}`, schemaJSON(t, `ipv4`))
+	require.Equal(t, `{"type":"string","format":"ipv6"}`, schemaJSON(t, `ipv6`))
+}
+
+func TestToJSONSchema_ranges(t *testing.T) {
+	require.Equal(t, `{"type":"integer","minimum":1,"maximum":10}`, schemaJSON(t, `1..10`))
+	require.Equal(t, `{"type":"integer","minimum":1,"exclusiveMaximum":10}`, schemaJSON(t, `1...10`))
+	require.Equal(t, `{"type":"integer","minimum":0}`, schemaJSON(t, `0..`))
+	require.Equal(t, `{"type":"integer","maximum":0}`, schemaJSON(t, `..0`))
+	require.Equal(t, `{"type":"number","minimum":0.5,"maximum":1.5}`, schemaJSON(t, `0.5..1.5`))
+	require.Equal(t, `{"type":"number","exclusiveMaximum":1.5}`, schemaJSON(t, `...1.5`))
+	require.Equal(t, `{"type":"string","minLength":1,"maxLength":10}`, schemaJSON(t, `string[1,10]`))
+	require.Equal(t, `{"type":"string","minLength":3}`, schemaJSON(t, `string[3]`))
+	require.Equal(t, `{"type":"string","pattern":"^[a-z]+$"}`, schemaJSON(t, `/^[a-z]+$/`))
+}
+
+func TestToJSONSchema_exact(t *testing.T) {
+	require.Equal(t, `{"const":"a"}`, schemaJSON(t, `"a"`))
+	require.Equal(t, `{"const":42}`, schemaJSON(t, `42`))
+	require.Equal(t, `{"const":true}`, schemaJSON(t, `true`))
+	require.Equal(t, `{"const":[1,2]}`, schemaJSON(t, `{1,2}`))
+	require.Equal(t, `{"const":{"a":1}}`, schemaJSON(t, `{a:1}`))
+	require.Equal(t, `{"enum":["a","b",null]}`, schemaJSON(t, `"a"|"b"|nil`))
+}
+
+func TestToJSONSchema_combinations(t *testing.T) {
+	require.Equal(t, `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, schemaJSON(t, `string|int`))
+	require.Equal(t, `{"oneOf":[{"type":"string"},{"type":"integer"}]}`, schemaJSON(t, `string^int`))
+	require.Equal(t, `{"allOf":[{"type":"integer","minimum":0},{"type":"integer","maximum":10}]}`,
+		schemaJSON(t, `0..&..10`))
+	require.Equal(t, `{"not":{"type":"null"}}`, schemaJSON(t, `!nil`))
+}
+
+func TestToJSONSchema_arrays(t *testing.T) {
+	require.Equal(t, `{"type":"array"}`, schemaJSON(t, `[]any`))
+	require.Equal(t, `{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":5}`, schemaJSON(t, `[1,5]string`))
+	require.Equal(t, `{"type":"array","prefixItems":[{"type":"string"},{"type":"integer"}],"items":false,"minItems":2,"maxItems":2}`,
+		schemaJSON(t, `{string,int}`))
+	require.Equal(t, `{"type":"array","prefixItems":[{"type":"string"}],"items":{"type":"integer"},"minItems":1}`,
+		schemaJSON(t, `{string,...int}`))
+}
+
+func TestToJSONSchema_maps(t *testing.T) {
+	require.Equal(t, `{"type":"object"}`, schemaJSON(t, `map[string]any`))
+	require.Equal(t, `{"type":"object","additionalProperties":{"type":"integer"},"minProperties":1}`,
+		schemaJSON(t, `map[string,1]int`))
+	require.Equal(t, `{"type":"object","patternProperties":{"^X-":{"type":"string"}},"additionalProperties":false}`,
+		schemaJSON(t, `map[/^X-/]string`))
+	require.Equal(t, `{"type":"object","propertyNames":{"type":"string","maxLength":3},"additionalProperties":{"type":"integer"}}`,
+		schemaJSON(t, `map[string[0,3]]int`))
+	require.Equal(t,
+		`{"type":"object","properties":{"name":{"type":"string"},"age":{"type":"integer","minimum":0}},`+
+			`"required":["name"],"additionalProperties":false}`,
+		schemaJSON(t, `{name:string,age?:0..}`))
+	require.Equal(t, `{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`,
+		schemaJSON(t, `{name:string,...}`))
+	require.Equal(t,
+		`{"type":"object","properties":{"name":{"type":"string"}},"patternProperties":{"^env_":{"type":"string"}},`+
+			`"required":["name"],"additionalProperties":false}`,
+		schemaJSON(t, `{name:string,/^env_/:string}`))
+	require.Equal(t,
+		`{"type":"object","properties":{"port":{"type":"integer","default":80}},"additionalProperties":false}`,
+		schemaJSON(t, `{port:80 int}`))
+	require.Equal(t,
+		`{"oneOf":[{"type":"object","properties":{"type":{"const":"aws"},"region":{"type":"string"}},`+
+			`"required":["type","region"],"additionalProperties":false},{"const":{"type":"gcp"}}]}`,
+		schemaJSON(t, `conditional["type",{type:"aws",region:string},{type:"gcp"}]`))
+}
+
+func TestToJSONSchema_annotations(t *testing.T) {
+	tp := tf.StructMap(false,
+		tf.AnnotatedStructMapEntry(tf.StructMapEntry(`port`, typ.Integer, true),
+			vf.Map(`description`, `the port`, `examples`, vf.Values(80, 443), `deprecated`, `use url`, `internal`, 1)))
+	require.Equal(t,
+		`{"type":"object","properties":{"port":{"type":"integer","description":"the port","deprecated":true,`+
+			`"examples":[80,443]}},"required":["port"],"additionalProperties":false}`,
+		string(streamer.MarshalJSON(jsonschema.ToJSONSchema(tp).Without(`$schema`), nil)))
+}
+
+func TestToJSONSchema_unsupported(t *testing.T) {
+	require.Panic(t, func() { jsonschema.ToJSONSchema(typ.Binary) }, `cannot be represented as a JSON Schema`)
+	require.Panic(t, func() { jsonschema.ToJSONSchema(tf.ParseType(`map[int]string`)) }, `cannot be represented`)
+	require.Panic(t, func() { jsonschema.ToJSONSchema(tf.ParseType(`{1:string}`)) }, `cannot be represented`)
+	require.Panic(t, func() { jsonschema.ToJSONSchema(tf.ParseType(`ipv4["10.0.0.0/8"]`)) }, `cannot be represented`)
+	require.Panic(t, func() {
+		jsonschema.ToJSONSchema(tf.ParseType(`x=map[string](string|x)`))
+	}, `recursive type`)
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+type (
+	// ImportReader returns the content of the file appointed by the given path. The path of a file
+	// that is imported using a relative path is resolved relative to the directory of the importing
+	// file prior to the call.
+	ImportReader func(path string) string
+
+	importEntry struct {
+		ns   string
+		path string
+	}
+
+	// importer keeps track of the imports declared by a parsed file and all files that it imports.
+	importer struct {
+		reader  ImportReader
+		entries []*importEntry
+		used    map[string]bool
+		loaded  map[string]bool
+	}
+)
+
+func newImporter(reader ImportReader) *importer {
+	return &importer{reader: reader, used: make(map[string]bool), loaded: make(map[string]bool)}
+}
+
+func readImportFile(path string) string {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return string(bs)
+}
+
+func importPath(fileName, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(fileName), path)
+}
+
+// add binds the given namespace to the file appointed by path. The file will not be read
+// until the namespace is referenced.
+func (im *importer) add(ns, path string) {
+	for _, e := range im.entries {
+		if e.ns == ns {
+			if e.path != path {
+				panic(fmt.Errorf(`namespace '%s' is already bound to "%s"`, ns, e.path))
+			}
+			return
+		}
+	}
+	im.entries = append(im.entries, &importEntry{ns: ns, path: path})
+}
+
+// use marks the given namespace as referenced
+func (im *importer) use(ns string) {
+	im.used[ns] = true
+}
+
+// next returns the first entry that is referenced but not yet loaded or nil if no such entry exists
+func (im *importer) next() *importEntry {
+	for _, e := range im.entries {
+		if im.used[e.ns] && !im.loaded[e.ns] {
+			return e
+		}
+	}
+	return nil
+}
+
+// resolve loads all referenced imports. Files are loaded one at a time and aliases that they declare
+// are added to the given AliasAdder using their namespace qualified names. Loading a file might add new
+// entries and new references so the process continues until no more unloaded references remain.
+func (im *importer) resolve(aa dgo.AliasAdder) {
+	for e := im.next(); e != nil; e = im.next() {
+		im.loaded[e.ns] = true
+		p := &parser{Base: NewParserBase(aa, nextToken, im.reader(e.path)), fileName: e.path, ns: e.ns, imports: im}
+		parseImport(p)
+	}
+}
+
+// parseImport parses an imported file. The result of the file is discarded. Only the declared aliases
+// are of interest and they are not resolved until the importing top level file is resolved.
+func parseImport(p *parser) {
+	defer reportError(p, p.fileName)
+	p.Parse(p.NextToken())
+	p.PopLast()
+}
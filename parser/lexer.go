@@ -7,6 +7,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lyraproj/dgo/internal"
 
@@ -22,6 +23,8 @@ const (
 	identifier
 	dotdot
 	dotdotdot
+	colonColon
+	durationLiteral
 )
 
 const (
@@ -75,7 +78,7 @@ func tokenString(t *Token) (s string) {
 		return "EOT"
 	}
 	switch tt {
-	case identifier, integer, float, dotdot, dotdotdot:
+	case identifier, integer, float, durationLiteral, dotdot, dotdotdot, colonColon:
 		s = t.Value
 	case regexpLiteral:
 		sb := &strings.Builder{}
@@ -122,6 +125,13 @@ func nextToken(sr *util.StringReader) (t *Token) {
 			} else {
 				t = &Token{Type: int(r)}
 			}
+		case ':':
+			if sr.Peek() == ':' {
+				sr.Next()
+				t = &Token{`::`, colonColon}
+			} else {
+				t = &Token{Type: int(r)}
+			}
 		case '-', '+':
 			n := sr.Next()
 			if !IsDigit(n) {
@@ -131,7 +141,7 @@ func nextToken(sr *util.StringReader) (t *Token) {
 			if r == '-' {
 				util.WriteRune(buf, r)
 			}
-			tkn := ConsumeNumber(sr, n, buf, integer)
+			tkn := consumeNumberOrDuration(sr, n, buf)
 			return &Token{buf.String(), tkn}
 		default:
 			t = buildToken(r, sr)
@@ -145,7 +155,7 @@ func buildToken(r rune, sr *util.StringReader) *Token {
 	switch {
 	case IsDigit(r):
 		buf := bytes.NewBufferString(``)
-		tkn := ConsumeNumber(sr, r, buf, integer)
+		tkn := consumeNumberOrDuration(sr, r, buf)
 		return &Token{buf.String(), tkn}
 	case IsIdentifierStart(r):
 		buf := bytes.NewBufferString(``)
@@ -293,6 +303,27 @@ func ConsumeNumber(sr *util.StringReader, start rune, buf io.Writer, t int) int
 	return t
 }
 
+// consumeNumberOrDuration consumes the current number. If the number is immediately followed by a unit such as "s"
+// or "ms", then the rest of a Go duration literal is consumed and validated, and the durationLiteral token type is
+// returned.
+func consumeNumberOrDuration(sr *util.StringReader, start rune, buf *bytes.Buffer) int {
+	tkn := ConsumeNumber(sr, start, buf, integer)
+	if !isDurationUnit(sr.Peek()) {
+		return tkn
+	}
+	for r := sr.Peek(); isDurationUnit(r) || IsDigit(r) || r == '.'; r = sr.Peek() {
+		util.WriteRune(buf, sr.Next())
+	}
+	if _, err := time.ParseDuration(buf.String()); err != nil {
+		panic(err)
+	}
+	return durationLiteral
+}
+
+func isDurationUnit(r rune) bool {
+	return IsLetter(r) || r == 'µ' || r == 'μ'
+}
+
 // ConsumeRegexp consumes the current regexp up to the ending '/' character, taking escaped
 // escapes and ends into account.
 func ConsumeRegexp(sr *util.StringReader) string {
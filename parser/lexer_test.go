@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/lyraproj/dgo/util"
@@ -19,6 +20,23 @@ func Test_nextToken_unicodeError(t *testing.T) {
 	nextToken(sr)
 }
 
+func Test_nextToken_duration(t *testing.T) {
+	for _, src := range []string{`1s`, `-1.5h`, `1h30m`, `300ms`, `2µs`} {
+		tk := nextToken(util.NewStringReader(src))
+		if !(tk.Type == durationLiteral && tk.Value == src) {
+			t.Errorf(`expected duration literal %s, got %s`, src, tokenString(tk))
+		}
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !(ok && strings.Contains(err.Error(), `unknown unit`)) {
+			t.Error(`expected panic did no occur`)
+		}
+	}()
+	nextToken(util.NewStringReader(`1q`))
+}
+
 func Example_nextToken() {
 	const src = `constants: {
     first: 0,
@@ -120,3 +138,22 @@ func Example_nextToken() {
 	//'>'
 	//'}'
 }
+
+func Example_nextToken_namespace() {
+	sr := util.NewStringReader(`u::UserType, x: y`)
+	for {
+		tf := nextToken(sr)
+		if tf.Type == end {
+			break
+		}
+		fmt.Println(tokenString(tf))
+	}
+	// Output:
+	//u
+	//::
+	//UserType
+	//','
+	//x
+	//':'
+	//y
+}
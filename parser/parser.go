@@ -26,6 +26,7 @@ const (
 	exStringLiteral
 	exTypeExpression
 	exAliasRef
+	exFrom
 	exEnd
 )
 
@@ -37,6 +38,12 @@ type optionalValue struct {
 	dgo.Value
 }
 
+// defaultedValue is the type of a struct entry that declares a default value, e.g. port: 80 int
+type defaultedValue struct {
+	dgo.Value
+	dflt dgo.Value
+}
+
 // LexFunction returns the next Token from the given StringReader
 type LexFunction func(reader *util.StringReader) *Token
 
@@ -72,6 +79,8 @@ func expect(state int) (s string) {
 		s = `a type expression`
 	case exAliasRef:
 		s = `an identifier`
+	case exFrom:
+		s = `'from'`
 	case exEnd:
 		s = `end of expression`
 	}
@@ -120,6 +129,9 @@ type (
 
 	parser struct {
 		Base
+		fileName string
+		ns       string
+		imports  *importer
 	}
 )
 
@@ -137,37 +149,21 @@ func Parse(content string) (result dgo.Value) {
 }
 
 // ParseFile parses the given content into a dgo.Type. Aliases are added to the given AliasAdder. The filename
-// is used in error messages.
+// is used in error messages and as the base when resolving the paths of imported files. Imported files are read
+// from the file system.
 func ParseFile(am dgo.AliasAdder, fileName, content string) dgo.Value {
-	p := &parser{NewParserBase(am, nextToken, content)}
+	return ParseFileWithImports(am, fileName, content, readImportFile)
+}
+
+// ParseFileWithImports is like ParseFile but uses the given ImportReader to obtain the content of imported files.
+func ParseFileWithImports(am dgo.AliasAdder, fileName, content string, reader ImportReader) dgo.Value {
+	p := &parser{Base: NewParserBase(am, nextToken, content), fileName: fileName, imports: newImporter(reader)}
 	return DoParse(p, fileName)
 }
 
 // DoParse performs the actual parsing and returns the result
 func DoParse(p Parser, fileName string) dgo.Value {
-	defer func() {
-		if r := recover(); r != nil {
-			es := r
-			if err, ok := r.(error); ok {
-				es = err.Error()
-			}
-			tl := 1
-			lt := p.LastToken()
-			if lt != nil && lt.Value != `` {
-				tl = len(lt.Value)
-			}
-			fn := ``
-			if fileName != `` {
-				fn = fmt.Sprintf(`file: %s, `, fileName)
-			}
-			ln := ``
-			sr := p.StringReader()
-			if fileName != `` || sr.Line() > 1 {
-				ln = fmt.Sprintf(`line: %d, `, sr.Line())
-			}
-			panic(fmt.Errorf("%s: (%s%scolumn: %d)", es, fn, ln, sr.Column()-tl))
-		}
-	}()
+	defer reportError(p, fileName)
 	p.Parse(p.NextToken())
 	v := p.PopLast()
 	if aa := p.AliasAdder(); aa != nil {
@@ -176,6 +172,32 @@ func DoParse(p Parser, fileName string) dgo.Value {
 	return v
 }
 
+// reportError must be deferred. It recovers from a panic and panics again with an error that contains
+// the position of the last token.
+func reportError(p Parser, fileName string) {
+	if r := recover(); r != nil {
+		es := r
+		if err, ok := r.(error); ok {
+			es = err.Error()
+		}
+		tl := 1
+		lt := p.LastToken()
+		if lt != nil && lt.Value != `` {
+			tl = len(lt.Value)
+		}
+		fn := ``
+		if fileName != `` {
+			fn = fmt.Sprintf(`file: %s, `, fileName)
+		}
+		ln := ``
+		sr := p.StringReader()
+		if fileName != `` || sr.Line() > 1 {
+			ln = fmt.Sprintf(`line: %d, `, sr.Line())
+		}
+		panic(fmt.Errorf("%s: (%s%scolumn: %d)", es, fn, ln, sr.Column()-tl))
+	}
+}
+
 // AliasAdder returns the AliasAdder used by this parser
 func (p *Base) AliasAdder() dgo.AliasAdder {
 	return p.sc
@@ -253,11 +275,67 @@ func (p *Base) StringReader() *util.StringReader {
 
 // Parse performs the actual parsing, starting at the given token
 func (p *parser) Parse(t *Token) {
+	for t.Type == identifier && t.Value == `import` && p.PeekToken().Type == identifier {
+		p.importStatement()
+		t = p.NextToken()
+	}
 	p.anyOf(t)
 	tk := p.NextToken()
 	if tk.Type != end {
 		panic(badSyntax(tk, exEnd))
 	}
+	if p.ns == `` {
+		// Imports are resolved once the top level file has been parsed. Resolving them in a loop rather
+		// than recursively ensures that circular imports are harmless.
+		p.imports.resolve(p.sc)
+	}
+}
+
+// importStatement parses the statement `import <name> from "<path>" [as <namespace>]`
+func (p *parser) importStatement() {
+	t := p.NextToken()
+	ns := t.Value
+	t = p.NextToken()
+	if !(t.Type == identifier && t.Value == `from`) {
+		panic(badSyntax(t, exFrom))
+	}
+	t = p.NextToken()
+	if t.Type != stringLiteral {
+		panic(badSyntax(t, exStringLiteral))
+	}
+	path := t.Value
+	if n := p.PeekToken(); n.Type == identifier && n.Value == `as` {
+		p.NextToken()
+		t = p.NextToken()
+		if t.Type != identifier {
+			panic(badSyntax(t, exAliasRef))
+		}
+		ns = t.Value
+	}
+	p.imports.add(ns, importPath(p.fileName, path))
+}
+
+// qualify prefixes the given name with the namespace of the file being parsed, if any.
+func (p *parser) qualify(name string) string {
+	if p.ns == `` {
+		return name
+	}
+	return p.ns + `::` + name
+}
+
+// qualifiedName returns the name of the given identifier token. The name will include a namespace
+// if the token is followed by '::' and another identifier.
+func (p *parser) qualifiedName(t *Token) (string, bool) {
+	if p.PeekToken().Type != colonColon {
+		return t.Value, false
+	}
+	p.NextToken()
+	n := p.NextToken()
+	if n.Type != identifier {
+		panic(badSyntax(n, exAliasRef))
+	}
+	p.imports.use(t.Value)
+	return t.Value + `::` + n.Value, true
 }
 
 func (p *parser) list(endChar int) {
@@ -354,15 +432,36 @@ func makeStructType(as []dgo.Value, ellipsis bool) dgo.MapType {
 		if optional {
 			v = ov.Value
 		}
+		dv, defaulted := v.(*defaultedValue)
+		if defaulted {
+			v = dv.Value
+		}
 		vt, isType := v.(dgo.Type)
 		if !isType {
 			vt = v.Type()
 		}
-		entries[i] = internal.StructMapEntry(kt, vt, !optional)
+		if defaulted {
+			entries[i] = internal.StructMapEntryWithDefaultUnresolved(kt, vt, literalValue(k, dv.dflt))
+		} else {
+			entries[i] = internal.StructMapEntry(kt, vt, !optional)
+		}
 	}
 	return internal.StructMapTypeUnresolved(ellipsis, entries)
 }
 
+// literalValue returns the given default value of the given key. A default that has been parsed into an exact type,
+// such as {a:1} or [1,2], is replaced by its value.
+func literalValue(k, v dgo.Value) dgo.Value {
+	if t, ok := v.(dgo.Type); ok {
+		et, ok := t.(dgo.ExactType)
+		if !ok {
+			panic(fmt.Errorf(`the default value of key '%s' is not a literal value`, k))
+		}
+		v = et.ExactValue()
+	}
+	return v
+}
+
 func (p *parser) params() {
 	szp := p.Len()
 	for {
@@ -414,6 +513,11 @@ func (p *parser) arrayElement(t *Token, expectEntry int) int {
 		}
 		p.anyOf(p.NextToken())
 		val := p.PopLast()
+		if nt = p.PeekToken(); nt.Type != ',' && nt.Type != '}' && nt.Type != end {
+			// A type that follows the value means that the value is the default
+			p.anyOf(p.NextToken())
+			val = &defaultedValue{Value: p.PopLast(), dflt: val}
+		}
 		if optional {
 			val = &optionalValue{val}
 		}
@@ -544,6 +648,32 @@ func (p *parser) meta() dgo.Value {
 	return internal.MetaType(tp)
 }
 
+func (p *parser) not() dgo.Value {
+	if p.PeekToken().Type != '[' {
+		return internal.DefaultNotType
+	}
+	p.NextToken()
+
+	p.anyOf(p.NextToken())
+	tp := p.PopLastType()
+	t := p.NextToken()
+	if t.Type != ']' {
+		panic(badSyntax(t, exRightBracket))
+	}
+	return internal.NotType(tp)
+}
+
+func (p *parser) conditional() dgo.Value {
+	t := p.NextToken()
+	if t.Type != '[' {
+		panic(badSyntax(t, exLeftBracket))
+	}
+	// get discriminator key and struct arguments
+	p.params()
+	cc := p.PopLast().(dgo.Array)
+	return internal.ConditionalStructTypeFromArgs(cc.InterfaceSlice())
+}
+
 func (p *parser) string() dgo.Value {
 	if p.PeekToken().Type == '[' {
 		// get size arguments
@@ -555,6 +685,94 @@ func (p *parser) string() dgo.Value {
 	return internal.DefaultStringType
 }
 
+func (p *parser) time() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get range arguments
+		p.NextToken()
+		p.params()
+		rc := p.PopLast().(dgo.Array)
+		return internal.TimeTypeFromArgs(rc.InterfaceSlice())
+	}
+	return internal.DefaultTimeType
+}
+
+func (p *parser) duration() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get range arguments
+		p.NextToken()
+		p.params()
+		rc := p.PopLast().(dgo.Array)
+		return internal.DurationTypeFromArgs(rc.InterfaceSlice())
+	}
+	return internal.DefaultDurationType
+}
+
+func (p *parser) decimal() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get precision and scale arguments
+		p.NextToken()
+		p.params()
+		pc := p.PopLast().(dgo.Array)
+		return internal.DecimalTypeFromArgs(pc.InterfaceSlice())
+	}
+	return internal.DefaultDecimalType
+}
+
+func (p *parser) uri() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get scheme and host arguments
+		p.NextToken()
+		p.params()
+		uc := p.PopLast().(dgo.Array)
+		return internal.URITypeFromArgs(uc.InterfaceSlice())
+	}
+	return internal.DefaultURIType
+}
+
+func (p *parser) semVer() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get version or range argument
+		p.NextToken()
+		p.params()
+		sc := p.PopLast().(dgo.Array)
+		return internal.SemVerTypeFromArgs(sc.InterfaceSlice())
+	}
+	return internal.DefaultSemVerType
+}
+
+func (p *parser) semVerRange() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get range argument
+		p.NextToken()
+		p.params()
+		rc := p.PopLast().(dgo.Array)
+		return internal.SemVerRangeTypeFromArgs(rc.InterfaceSlice())
+	}
+	return internal.DefaultSemVerRangeType
+}
+
+func (p *parser) ip(version int) dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get network or address argument
+		p.NextToken()
+		p.params()
+		ic := p.PopLast().(dgo.Array)
+		return internal.IPTypeFromArgs(version, ic.InterfaceSlice())
+	}
+	return internal.IPType(version, nil)
+}
+
+func (p *parser) cidr() dgo.Value {
+	if p.PeekToken().Type == '[' {
+		// get network argument
+		p.NextToken()
+		p.params()
+		nc := p.PopLast().(dgo.Array)
+		return internal.CIDRTypeFromArgs(nc.InterfaceSlice())
+	}
+	return internal.DefaultCIDRType
+}
+
 func (p *parser) sensitive() dgo.Value {
 	tt := p.PeekToken().Type
 	if tt == '[' {
@@ -598,8 +816,11 @@ var identifierToTypeMap = map[string]dgo.Value{
 	`any`:    internal.DefaultAnyType,
 	`bool`:   internal.DefaultBooleanType,
 	`int`:    internal.DefaultIntegerType,
+	`bigint`: internal.DefaultBigIntType,
 	`float`:  internal.DefaultFloatType,
 	`dgo`:    internal.DefaultDgoStringType,
+	`base64`: internal.DefaultBase64StringType,
+	`hex`:    internal.DefaultHexStringType,
 	`binary`: internal.DefaultBinaryType,
 	`true`:   internal.True,
 	`false`:  internal.False,
@@ -616,10 +837,34 @@ func (p *parser) identifier(t *Token, returnUnknown bool) dgo.Value {
 		tp = p.mapExpression()
 	case `type`:
 		tp = p.meta()
+	case `not`:
+		tp = p.not()
+	case `conditional`:
+		tp = p.conditional()
 	case `string`:
 		tp = p.string()
 	case `sensitive`:
 		tp = p.sensitive()
+	case `time`:
+		tp = p.time()
+	case `duration`:
+		tp = p.duration()
+	case `decimal`:
+		tp = p.decimal()
+	case `uri`:
+		tp = p.uri()
+	case `semver`:
+		tp = p.semVer()
+	case `semverrange`:
+		tp = p.semVerRange()
+	case `ipaddr`:
+		tp = p.ip(0)
+	case `ipv4`:
+		tp = p.ip(4)
+	case `ipv6`:
+		tp = p.ip(6)
+	case `cidr`:
+		tp = p.cidr()
 	case `func`:
 		tp = p.funcExpression()
 	default:
@@ -666,9 +911,9 @@ func (p *parser) float(t *Token) dgo.Value {
 
 func (p *parser) integer(t *Token) dgo.Value {
 	var tp dgo.Value
-	i := tokenInt(t)
 	n := p.PeekToken()
 	if n.Type == dotdot || n.Type == dotdotdot {
+		i := tokenInt(t)
 		inclusive := n.Type == dotdot
 		p.NextToken()
 		x := p.PeekToken()
@@ -683,7 +928,7 @@ func (p *parser) integer(t *Token) dgo.Value {
 			tp = internal.IntegerType(i, math.MaxInt64, inclusive) // Unbounded at upper end
 		}
 	} else {
-		tp = internal.Integer(i)
+		tp = tokenInteger(t)
 	}
 	return tp
 }
@@ -717,10 +962,23 @@ func (p *parser) aliasReference(t *Token) dgo.Value {
 	if t.Type != identifier {
 		panic(badSyntax(t, exAliasRef))
 	}
-	if tp := internal.NamedType(t.Value); tp != nil {
-		return tp
+	n, qualified := p.qualifiedName(t)
+	if !qualified {
+		if tp := internal.NamedType(n); tp != nil {
+			return tp
+		}
+		if p.ns != `` {
+			// Names declared in the imported file takes precedence over global names
+			if tp := p.sc.GetType(internal.String(p.qualify(n))); tp != nil {
+				return tp
+			}
+			if tp := p.sc.GetType(internal.String(n)); tp != nil {
+				return tp
+			}
+			n = p.qualify(n)
+		}
 	}
-	vn := internal.String(t.Value)
+	vn := internal.String(n)
 	if tp := p.sc.GetType(vn); tp != nil {
 		return tp
 	}
@@ -731,8 +989,8 @@ func (p *parser) aliasDeclaration(t *Token) dgo.Value {
 	// Should result in an unknown identifier or name is reserved
 	tp := p.identifier(t, true)
 	if un, ok := tp.(*unknownIdentifier); ok {
-		s := un.Value.(dgo.String)
-		if internal.NamedType(s.GoString()) == nil && p.sc.GetType(s) == nil {
+		s := internal.String(p.qualify(un.Value.(dgo.String).GoString()))
+		if internal.NamedType(t.Value) == nil && p.sc.GetType(s) == nil {
 			p.NextToken() // skip '='
 			p.sc.Add(NewAlias(s), s)
 			p.anyOf(p.NextToken())
@@ -770,12 +1028,17 @@ func (p *parser) typeExpression(t *Token) {
 		tp = p.integer(t)
 	case float:
 		tp = p.float(t)
+	case durationLiteral:
+		tp = internal.DurationFromString(t.Value)
 	case dotdot, dotdotdot: // Unbounded at lower end
 		tp = p.dotRange(t)
 	case identifier:
-		if p.PeekToken().Type == '=' {
+		switch p.PeekToken().Type {
+		case '=':
 			tp = p.aliasDeclaration(t)
-		} else {
+		case colonColon:
+			tp = p.aliasReference(t)
+		default:
 			tp = p.identifier(t, false)
 		}
 	case stringLiteral:
@@ -789,10 +1052,22 @@ func (p *parser) typeExpression(t *Token) {
 }
 
 func tokenInt(t *Token) int64 {
-	i, _ := strconv.ParseInt(t.Value, 0, 64)
+	i, err := strconv.ParseInt(t.Value, 0, 64)
+	if err != nil {
+		panic(fmt.Errorf(`integer %s is out of range for an int range`, t.Value))
+	}
 	return i
 }
 
+// tokenInteger returns the integer of the given token as a dgo.Integer, or as a dgo.BigInt when it doesn't fit
+// into an int64.
+func tokenInteger(t *Token) dgo.Value {
+	if i, err := strconv.ParseInt(t.Value, 0, 64); err == nil {
+		return internal.Integer(i)
+	}
+	return internal.BigIntFromString(t.Value)
+}
+
 func tokenFloat(t *Token) float64 {
 	f, _ := strconv.ParseFloat(t.Value, 64)
 	return f
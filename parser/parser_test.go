@@ -1,15 +1,18 @@
 package parser_test
 
 import (
+	"fmt"
 	"math"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/lyraproj/dgo/stringer"
 
 	"github.com/lyraproj/dgo/internal"
 
 	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/parser"
 
 	require "github.com/lyraproj/dgo/dgo_test"
 	"github.com/lyraproj/dgo/tf"
@@ -58,6 +61,176 @@ func TestParse_exact(t *testing.T) {
 	require.Equal(t, `{...}`, st.String())
 }
 
+func TestParse_literals(t *testing.T) {
+	require.Equal(t, vf.Value(42).Type(), tf.ParseType(`42`))
+	require.Equal(t, 42, tf.Parse(`42`))
+	require.Equal(t, vf.Value(-3.5).Type(), tf.ParseType(`-3.5`))
+	require.Equal(t, vf.Value(`hello`).Type(), tf.ParseType(`"hello"`))
+	require.Equal(t, `hello`, tf.Parse(`"hello"`))
+	require.Equal(t, vf.True.Type(), tf.ParseType(`true`))
+	require.Equal(t, vf.True, tf.Parse(`true`))
+
+	at := tf.ParseType(`{1,2,3}`)
+	require.Equal(t, vf.Values(1, 2, 3).Type(), at)
+	require.Instance(t, at, vf.Values(1, 2, 3))
+	require.NotInstance(t, at, vf.Values(1, 2))
+	require.Equal(t, vf.Values(1, 2, 3), tf.Parse(`{1,2,3}`))
+	require.Equal(t, vf.Map(`a`, 1).Type(), tf.ParseType(`{a:1}`))
+}
+
+func TestParse_time(t *testing.T) {
+	t1, _ := time.Parse(time.RFC3339, `2019-01-01T00:00:00Z`)
+	t2, _ := time.Parse(time.RFC3339, `2020-01-01T00:00:00Z`)
+	require.Same(t, typ.Time, tf.ParseType(`time`))
+	require.Same(t, typ.Time, tf.ParseType(`time[]`))
+	require.Equal(t, vf.Time(t1).Type(), tf.ParseType(`time["2019-01-01T00:00:00Z"]`))
+	require.Equal(t, tf.Time(t1, t2), tf.ParseType(`time["2019-01-01T00:00:00Z","2020-01-01T00:00:00Z"]`))
+	require.Equal(t, tf.Time(time.Time{}, t2), tf.ParseType(`time[nil,"2020-01-01T00:00:00Z"]`))
+	require.Equal(t, tf.Time(t1, time.Time{}), tf.ParseType(`time["2019-01-01T00:00:00Z",nil]`))
+	require.Same(t, typ.Time, tf.ParseType(`time[nil,nil]`))
+
+	tt := tf.Time(t1, time.Time{})
+	require.Equal(t, tt, tf.ParseType(tt.String()))
+
+	require.Panic(t, func() { tf.ParseType(`time[nil]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`time[1,2]`) }, `illegal argument 1`)
+	require.Panic(t, func() { tf.ParseType(`time["a","b","c"]`) }, `illegal number of arguments`)
+}
+
+func TestParse_duration(t *testing.T) {
+	require.Same(t, typ.Duration, tf.ParseType(`duration`))
+	require.Same(t, typ.Duration, tf.ParseType(`duration[nil,nil]`))
+	require.Equal(t, vf.Duration(time.Second).Type(), tf.ParseType(`1s`))
+	require.Equal(t, vf.Duration(90*time.Minute), tf.Parse(`1h30m`))
+	require.Equal(t, vf.Duration(-1500*time.Millisecond), tf.Parse(`-1.5s`))
+	require.Equal(t, vf.Duration(time.Second).Type(), tf.ParseType(`duration[1s]`))
+	require.Equal(t, tf.Duration(time.Second, time.Hour), tf.ParseType(`duration[1s,1h]`))
+	require.Equal(t, tf.Duration(time.Second, time.Hour), tf.ParseType(`duration["1s","1h"]`))
+	require.Equal(t, tf.Duration(time.Second, math.MaxInt64), tf.ParseType(`duration[1s,nil]`))
+	require.Equal(t, tf.Duration(math.MinInt64, time.Hour), tf.ParseType(`duration[nil,1h]`))
+	require.Equal(t, tf.Duration(0, 2*time.Hour), tf.ParseType(`duration[0s,2h]`))
+
+	dt := tf.Duration(time.Second, 90*time.Minute)
+	require.Equal(t, `duration[1s,1h30m0s]`, dt.String())
+	require.Equal(t, dt, tf.ParseType(dt.String()))
+	require.Equal(t, tf.AnyOf(typ.String, dt), tf.ParseType(`string|duration[1s,1h30m]`))
+
+	require.Panic(t, func() { tf.ParseType(`duration[nil]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`duration[1,2]`) }, `illegal argument 1`)
+	require.Panic(t, func() { tf.ParseType(`duration["1x",nil]`) }, `unknown unit`)
+	require.Panic(t, func() { tf.ParseType(`duration[1s,2s,3s]`) }, `illegal number of arguments`)
+}
+
+func TestParse_uri(t *testing.T) {
+	require.Same(t, typ.URI, tf.ParseType(`uri`))
+	require.Same(t, typ.URI, tf.ParseType(`uri[nil,nil]`))
+	require.Equal(t, tf.URI(`https`, ``), tf.ParseType(`uri["https"]`))
+	require.Equal(t, tf.URI(`https`, `example.com`), tf.ParseType(`uri["https","example.com"]`))
+	require.Equal(t, tf.URI(``, `example.com`), tf.ParseType(`uri[nil,"example.com"]`))
+	require.Equal(t, vf.URIFromString(`https://example.com/a`).Type(), tf.ParseType(`uri["https://example.com/a"]`))
+	require.Equal(t, vf.URIFromString(`/a/b`).Type(), tf.ParseType(`uri["/a/b"]`))
+
+	ut := tf.URI(`https`, `example.com`)
+	require.Equal(t, ut, tf.ParseType(ut.String()))
+	et := vf.URIFromString(`mailto:someone@example.com`).Type()
+	require.Equal(t, et, tf.ParseType(et.String()))
+	require.Equal(t, tf.AnyOf(typ.String, ut), tf.ParseType(`string|uri["https","example.com"]`))
+
+	require.Panic(t, func() { tf.ParseType(`uri[nil]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`uri["https",1]`) }, `illegal argument 2`)
+	require.Panic(t, func() { tf.ParseType(`uri["a","b","c"]`) }, `illegal number of arguments`)
+}
+
+func TestParse_semVer(t *testing.T) {
+	require.Same(t, typ.SemVer, tf.ParseType(`semver`))
+	require.Same(t, typ.SemVer, tf.ParseType(`semver["*"]`))
+	require.Equal(t, tf.SemVer(`^1.2.0`), tf.ParseType(`semver["^1.2.0"]`))
+	require.Equal(t, tf.SemVer(`>=1.2.0 <2.0.0 || 3.x`), tf.ParseType(`semver[">=1.2.0 <2.0.0 || 3.x"]`))
+	require.Equal(t, vf.SemVer(`1.2.3`).Type(), tf.ParseType(`semver["1.2.3"]`))
+	require.Same(t, typ.SemVerRange, tf.ParseType(`semverrange`))
+	require.Equal(t, vf.SemVerRange(`~1.2`).Type(), tf.ParseType(`semverrange["~1.2"]`))
+
+	for _, st := range []dgo.Type{tf.SemVer(`^1.2.0`), tf.SemVer(`1.2.3`), vf.SemVer(`1.2.3-rc.1`).Type(),
+		vf.SemVerRange(`1.x || 3.x`).Type()} {
+		require.Equal(t, st, tf.ParseType(st.String()))
+	}
+	require.Equal(t, tf.AnyOf(typ.String, tf.SemVer(`~1.2`)), tf.ParseType(`string|semver["~1.2"]`))
+
+	require.Panic(t, func() { tf.ParseType(`semver[1]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`semver["a","b"]`) }, `illegal number of arguments`)
+	require.Panic(t, func() { tf.ParseType(`semver["latest"]`) }, `not a valid semantic version range`)
+	require.Panic(t, func() { tf.ParseType(`semverrange[1]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`semverrange["a","b"]`) }, `illegal number of arguments`)
+}
+
+func TestParse_ip(t *testing.T) {
+	require.Same(t, typ.IP, tf.ParseType(`ipaddr`))
+	require.Same(t, typ.IPv4, tf.ParseType(`ipv4`))
+	require.Same(t, typ.IPv6, tf.ParseType(`ipv6`))
+	require.Same(t, typ.CIDR, tf.ParseType(`cidr`))
+	require.Equal(t, tf.IP(0, `10.0.0.0/8`), tf.ParseType(`ipaddr["10.0.0.0/8"]`))
+	require.Equal(t, tf.IP(4, `10.0.0.0/8`), tf.ParseType(`ipv4["10.0.0.0/8"]`))
+	require.Equal(t, vf.IPFromString(`10.0.0.1`).Type(), tf.ParseType(`ipaddr["10.0.0.1"]`))
+	require.Equal(t, vf.IPFromString(`::1`).Type(), tf.ParseType(`ipv6["::1"]`))
+	require.Equal(t, tf.CIDR(`10.0.0.0/8`), tf.ParseType(`cidr["10.0.0.0/8"]`))
+	require.Equal(t, vf.IPNetFromString(`10.0.0.0/8`).Type(), tf.ParseType(`cidr["=10.0.0.0/8"]`))
+
+	for _, it := range []dgo.Type{tf.IP(0, `10.0.0.0/8`), tf.IP(6, `fd00::/8`), vf.IPFromString(`10.0.0.1`).Type(),
+		tf.CIDR(`10.0.0.0/8`), vf.IPNetFromString(`10.0.0.0/8`).Type()} {
+		require.Equal(t, it, tf.ParseType(it.String()))
+	}
+
+	require.Panic(t, func() { tf.ParseType(`ipv6["10.0.0.1"]`) }, `10.0.0.1 is not an IPv6 address`)
+	require.Panic(t, func() { tf.ParseType(`ipv6["10.0.0.0/8"]`) }, `not an IPv6 network`)
+	require.Panic(t, func() { tf.ParseType(`ipaddr[1]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`ipaddr["a","b"]`) }, `illegal number of arguments`)
+	require.Panic(t, func() { tf.ParseType(`cidr[1]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`cidr["a","b"]`) }, `illegal number of arguments`)
+}
+
+func TestParse_conditional(t *testing.T) {
+	ct := tf.ParseType(`conditional["type", {type:"aws",region:string}, {type:"gcp",project:string}]`)
+	require.Equal(t, tf.ConditionalStruct(`type`,
+		tf.ParseType(`{type:"aws",region:string}`).(dgo.StructMapType),
+		tf.ParseType(`{type:"gcp",project:string}`).(dgo.StructMapType)), ct)
+	require.Equal(t, ct, tf.ParseType(`conditional["type",{"type":"aws","region":string},{"type":"gcp","project":string}]`))
+	require.Equal(t, ct, tf.ParseType(ct.String()))
+	require.Panic(t, func() { tf.ParseType(`conditional["type"]`) }, `illegal number of arguments`)
+}
+
+func TestParse_bigInt(t *testing.T) {
+	require.Same(t, typ.BigInt, tf.ParseType(`bigint`))
+	bi := vf.BigIntFromString(`123456789012345678901234567890`)
+	require.Equal(t, bi, tf.Parse(`123456789012345678901234567890`))
+	require.Equal(t, bi.Type(), tf.ParseType(bi.Type().String()))
+	require.Equal(t, vf.BigIntFromString(`-9223372036854775809`), tf.Parse(`-9223372036854775809`))
+	require.Equal(t, vf.BigIntFromString(`0x1ffffffffffffffff`), tf.Parse(`0x1ffffffffffffffff`))
+	require.Equal(t, vf.Integer(math.MinInt64), tf.Parse(`-9223372036854775808`))
+	require.Equal(t, vf.Values(1, bi), tf.Parse(`{1,123456789012345678901234567890}`))
+	require.Panic(t, func() { tf.ParseType(`0..123456789012345678901234567890`) }, `out of range for an int range`)
+	require.Panic(t, func() { tf.ParseType(`123456789012345678901234567890..`) }, `out of range for an int range`)
+}
+
+func TestParse_decimal(t *testing.T) {
+	require.Same(t, typ.Decimal, tf.ParseType(`decimal`))
+	require.Same(t, typ.Decimal, tf.ParseType(`decimal[]`))
+	require.Equal(t, tf.Decimal(10, 2), tf.ParseType(`decimal[10,2]`))
+	require.Equal(t, tf.Decimal(10, 0), tf.ParseType(`decimal[10]`))
+	require.Equal(t, vf.DecimalFromString(`12.50`).Type(), tf.ParseType(`decimal["12.50"]`))
+
+	dt := vf.DecimalFromString(`-0.05`).Type()
+	require.Equal(t, dt, tf.ParseType(dt.String()))
+	require.Equal(t, `decimal[10,2]`, tf.ParseType(`decimal[10,2]`).String())
+	require.Equal(t, tf.AnyOf(tf.Decimal(4, 2), typ.Nil), tf.ParseType(`decimal[4,2]|nil`))
+
+	require.Panic(t, func() { tf.ParseType(`decimal[2.5]`) }, `illegal argument`)
+	require.Panic(t, func() { tf.ParseType(`decimal[10,"2"]`) }, `illegal argument 2`)
+	require.Panic(t, func() { tf.ParseType(`decimal["2",2]`) }, `illegal argument 1`)
+	require.Panic(t, func() { tf.ParseType(`decimal[1,2,3]`) }, `illegal number of arguments`)
+	require.Panic(t, func() { tf.ParseType(`decimal[2,3]`) }, `illegal decimal precision`)
+	require.Panic(t, func() { tf.ParseType(`decimal["x"]`) }, `unable to parse 'x' as a decimal`)
+}
+
 func TestParse_func(t *testing.T) {
 	tt := tf.ParseType(`func(string,...any) (string, bool)`)
 	require.Equal(t, tf.Function(
@@ -70,6 +243,19 @@ func TestParse_tuple(t *testing.T) {
 	require.Equal(t, tf.Function(typ.EmptyTuple, typ.EmptyTuple), tf.ParseType(`func()`))
 }
 
+func TestParse_structDefault(t *testing.T) {
+	require.Equal(t,
+		tf.StructMap(false, tf.StructMapEntry(`host`, typ.String, true), tf.StructMapEntryWithDefault(`port`, typ.Integer, 80)),
+		tf.ParseType(`{host:string,port:80 int}`))
+	require.Equal(t,
+		tf.StructMap(false, tf.StructMapEntryWithDefault(`name`, typ.String, `x`)),
+		tf.ParseType(`{name?:"x" string}`))
+	require.Equal(t,
+		tf.StructMap(false, tf.StructMapEntryWithDefault(`opts`, tf.Map(typ.String, typ.Integer), vf.Map(`a`, 1))),
+		tf.ParseType(`{opts:{a:1} map[string]int}`))
+	require.Panic(t, func() { tf.ParseType(`{port:string int}`) }, `default value of key 'port' is not a literal value`)
+}
+
 func TestParse_ciEnum(t *testing.T) {
 	st := tf.ParseType(`~"foo"|~"fee"`)
 	require.Equal(t, tf.CiEnum(`foo`, `fee`), st)
@@ -171,6 +357,10 @@ func TestParse_range(t *testing.T) {
 
 func TestParse_unary(t *testing.T) {
 	require.Equal(t, tf.Not(typ.String), tf.ParseType(`!string`))
+	require.Equal(t, tf.Not(typ.String), tf.ParseType(`not[string]`))
+	require.Equal(t, tf.Not(tf.AnyOf(typ.String, typ.Integer)), tf.ParseType(`not[string|int]`))
+	require.Same(t, typ.Not, tf.ParseType(`not`))
+	require.Panic(t, func() { tf.ParseType(`not[string,int]`) }, `expected ']', got ','`)
 	require.Equal(t, typ.String.Type(), tf.ParseType(`type[string]`))
 	require.Equal(t, typ.Any.Type(), tf.ParseType(`type`))
 	require.Panic(t, func() { tf.ParseType(`type[string`) }, `expected ']', got EOT`)
@@ -188,6 +378,19 @@ func TestParse_ternary(t *testing.T) {
 	require.Equal(t, tf.Enum(`a`, `b`, `c`), tf.ParseType(`"a"|"b"|"c"`))
 }
 
+func TestParse_ternaryPrecedence(t *testing.T) {
+	require.Equal(t, tf.AnyOf(tf.AllOf(typ.String, typ.Integer), tf.AllOf(typ.Float, typ.Boolean)),
+		tf.ParseType(`string&int|float&bool`))
+	require.Equal(t, tf.OneOf(tf.AllOf(typ.String, typ.Integer), typ.Float), tf.ParseType(`string&int^float`))
+	require.Equal(t, tf.AllOf(typ.String, tf.Not(typ.Integer)), tf.ParseType(`string&!int`))
+	require.Equal(t, tf.Tuple(tf.AnyOf(typ.String, typ.Integer), tf.AllOf(typ.Float, typ.Boolean)),
+		tf.ParseType(`{string|int,float&bool}`))
+	require.Equal(t, tf.Map(tf.AnyOf(typ.String, typ.Integer), typ.Boolean), tf.ParseType(`map[string|int]bool`))
+	require.Equal(t, tf.Array(tf.AnyOf(typ.String, typ.Integer)), tf.ParseType(`[](string|int)`))
+	require.Equal(t, tf.AnyOf(tf.Array(typ.String), typ.Integer), tf.ParseType(`[]string|int`))
+	require.Equal(t, tf.OneOf(tf.Integer(0, 10, true), tf.Integer(5, 15, true)), tf.ParseType(`0..10^5..15`))
+}
+
 func TestParse_string(t *testing.T) {
 	require.Equal(t, vf.String("\r").Type(), tf.ParseType(`"\r"`))
 	require.Equal(t, vf.String("\n").Type(), tf.ParseType(`"\n"`))
@@ -271,3 +474,67 @@ func TestParseFile_errors(t *testing.T) {
 func TestParse_value(t *testing.T) {
 	require.Equal(t, vf.Map(), tf.Parse(`{}`))
 }
+
+func TestParseFileWithImports(t *testing.T) {
+	files := map[string]string{
+		`schemas/users.dgo`: `{UserType={name:string,address:Address},Address={street:string}}`,
+	}
+	reader := func(path string) string {
+		if c, ok := files[path]; ok {
+			return c
+		}
+		panic(fmt.Errorf(`no such file %q`, path))
+	}
+	var tp dgo.Type
+	am := tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		tp = parser.ParseFileWithImports(aa, `main.dgo`, `
+import users from "schemas/users.dgo" as u
+import unused from "unused.dgo"
+map[string]u::UserType`, reader).(dgo.Type)
+	})
+	require.Equal(t, `map[string]u::UserType`, stringer.TypeStringWithAliasMap(tp, am))
+	require.Equal(t, `{"street":string}`, stringer.TypeString(am.GetType(vf.String(`u::Address`))))
+	require.Instance(t, tp, vf.Map(`bob`, vf.Map(`name`, `Bob`, `address`, vf.Map(`street`, `Main`))))
+	require.NotInstance(t, tp, vf.Map(`bob`, vf.Map(`name`, `Bob`, `address`, vf.Map(`street`, 3))))
+}
+
+func TestParseFileWithImports_circular(t *testing.T) {
+	files := map[string]string{
+		`a.dgo`: `import b from "b.dgo"
+{Node={value:int,link?:b::Link}}`,
+		`b.dgo`: `import a from "a.dgo"
+{Link={node:a::Node}}`,
+	}
+	reader := func(path string) string { return files[path] }
+	var tp dgo.Type
+	am := tf.BuiltInAliases().Collect(func(aa dgo.AliasAdder) {
+		tp = parser.ParseFileWithImports(aa, `a.dgo`, files[`a.dgo`], reader).(dgo.Type)
+	})
+	require.NotNil(t, am.GetType(vf.String(`Node`)))
+	require.NotNil(t, am.GetType(vf.String(`a::Node`)))
+	require.NotNil(t, am.GetType(vf.String(`b::Link`)))
+	require.Instance(t, am.GetType(vf.String(`Node`)), vf.Map(`value`, 1, `link`, vf.Map(`node`, vf.Map(`value`, 2))))
+	require.NotNil(t, tp)
+}
+
+func TestParseFileWithImports_errors(t *testing.T) {
+	reader := func(path string) string { return `{X=int}` }
+	require.Panic(t, func() {
+		parser.ParseFileWithImports(nil, `main.dgo`, `import x "a.dgo" int`, reader)
+	}, `expected 'from', got "a.dgo"`)
+	require.Panic(t, func() {
+		parser.ParseFileWithImports(nil, `main.dgo`, `import x from a int`, reader)
+	}, `expected a literal string, got a`)
+	require.Panic(t, func() {
+		parser.ParseFileWithImports(nil, `main.dgo`, `import x from "a.dgo" as "y" int`, reader)
+	}, `expected an identifier, got "y"`)
+	require.Panic(t, func() {
+		parser.ParseFileWithImports(nil, `main.dgo`, `import x from "a.dgo"
+import x from "b.dgo" int`, reader)
+	}, `namespace 'x' is already bound to "a.dgo"`)
+	require.Panic(t, func() {
+		parser.ParseFileWithImports(nil, `main.dgo`, `x::3`, reader)
+	}, `expected an identifier, got 3`)
+	internal.ResetDefaultAliases()
+	require.Panic(t, func() { tf.ParseType(`x::Y`) }, `reference to unresolved type 'x::Y'`)
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 
 	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/util"
 	"github.com/lyraproj/dgo/vf"
 )
 
@@ -29,10 +33,108 @@ func MarshalJSON(v interface{}, dialect Dialect) []byte {
 	return b.Bytes()
 }
 
+// JSONStreamMap writes a JSON object to the given writer using the entries received from the given channel. The
+// opening brace is written immediately and each entry is validated against the schema and written as soon as it is
+// received, so the complete map is never held in memory. The closing brace is written when the channel is closed.
+//
+// Keys must be strings and each key can only be sent once. The keys are retained so that duplicates can be detected.
+// The first entry that doesn't conform to the schema, or a failure to write, terminates the stream and is returned as
+// an error. The channel is not drained in that case. The size constraint and the required entries of the schema are
+// checked when the channel is closed.
+func JSONStreamMap(w io.Writer, schema dgo.MapType, entries <-chan dgo.MapEntry) error {
+	return util.Catch(func() {
+		s := New(nil, nil)
+		assertOk(w.Write([]byte{'{'}))
+		seen := make(map[string]bool)
+		n := 0
+		for e := range entries {
+			k := validateStreamEntry(schema, e)
+			if seen[k.GoString()] {
+				panic(fmt.Errorf(`duplicate key '%s'`, k))
+			}
+			seen[k.GoString()] = true
+			if n > 0 {
+				assertOk(w.Write([]byte{','}))
+			}
+			kb, err := json.Marshal(k.GoString())
+			assertOk(0, err)
+			assertOk(w.Write(kb))
+			assertOk(w.Write([]byte{':'}))
+			s.Stream(e.Value(), JSON(w))
+			n++
+		}
+		if n < schema.Min() || n > schema.Max() {
+			panic(tf.IllegalSize(schema, n))
+		}
+		assertRequired(schema, seen)
+		assertOk(w.Write([]byte{'}'}))
+	})
+}
+
+// validateStreamEntry panics unless the given entry is valid in a map of the given type. The key of the entry is
+// returned.
+func validateStreamEntry(schema dgo.MapType, e dgo.MapEntry) dgo.String {
+	k, ok := e.Key().(dgo.String)
+	if !ok {
+		panic(fmt.Errorf(`JSON object keys must be strings, got %s`, e.Key().Type()))
+	}
+	var vt dgo.Type
+	if st, ok := schema.(dgo.StructMapType); ok {
+		if se := st.Get(k); se != nil {
+			vt = se.Value().(dgo.Type)
+		} else if st.Additional() {
+			return k
+		} else {
+			panic(fmt.Errorf(`key '%s' is not allowed by %s`, k, schema))
+		}
+	} else {
+		if !schema.KeyType().Instance(k) {
+			panic(tf.IllegalAssignment(schema.KeyType(), k))
+		}
+		vt = schema.ValueType()
+	}
+	if !vt.Instance(e.Value()) {
+		panic(tf.IllegalAssignment(vt, e.Value()))
+	}
+	return k
+}
+
+// assertRequired panics unless the given keys contain the key of each required entry of the given schema
+func assertRequired(schema dgo.MapType, seen map[string]bool) {
+	st, ok := schema.(dgo.StructMapType)
+	if !ok {
+		return
+	}
+	st.Each(func(e dgo.StructMapEntry) {
+		if et, ok := e.Key().(dgo.ExactType); ok && e.Required() {
+			if k, ok := et.ExactValue().(dgo.String); ok && !seen[k.GoString()] {
+				panic(fmt.Errorf(`missing required key '%s'`, k))
+			}
+		}
+	})
+}
+
+// JSONDecodeOptions controls how JSON is decoded into dgo.Values
+type JSONDecodeOptions struct {
+	// PreferIntegerForWholeFloats makes a number such as 1.0 or 1e3, which has no fraction, decode into a
+	// dgo.Integer when it fits in an int64. When false, only numbers written as integers decode into a dgo.Integer.
+	PreferIntegerForWholeFloats bool
+}
+
+// DefaultJSONDecodeOptions returns the options that are used by UnmarshalJSON
+func DefaultJSONDecodeOptions() *JSONDecodeOptions {
+	return &JSONDecodeOptions{PreferIntegerForWholeFloats: true}
+}
+
 // UnmarshalJSON decodes the JSON representation of the given bytes into a dgo.Value. The order of entries
 // in an object is retained in its corresponding dgo.Map and rich data constructs such as Sensitive and Timestamp are
-// converted.
+// converted. Numbers without a fraction decode into a dgo.Integer, see DefaultJSONDecodeOptions.
 func UnmarshalJSON(b []byte, dialect Dialect) dgo.Value {
+	return UnmarshalJSONWithOptions(b, dialect, nil)
+}
+
+// UnmarshalJSONWithOptions is like UnmarshalJSON but uses the given options. Options are defaulted when nil.
+func UnmarshalJSONWithOptions(b []byte, dialect Dialect, options *JSONDecodeOptions) dgo.Value {
 	// Using an explicit decoder enables setting the UseNumber() attribute which in turn
 	// will allow the vf.Value() method to perform the actual decoding of that number and
 	// turn it into an int64 or a float64 depending on the if the string representation can
@@ -44,9 +146,13 @@ func UnmarshalJSON(b []byte, dialect Dialect) dgo.Value {
 	if dialect != nil {
 		opts.Dialect = dialect
 	}
+	if options == nil {
+		options = DefaultJSONDecodeOptions()
+	}
 	vc := DataDecoder(nil, opts.Dialect)
 
-	j := &jsonDecoder{consumer: vc, refKey: opts.Dialect.RefKey().GoString(), decoder: je}
+	j := &jsonDecoder{consumer: vc, refKey: opts.Dialect.RefKey().GoString(), decoder: je,
+		wholeFloatsAsInts: options.PreferIntegerForWholeFloats}
 	j.decode()
 	return vc.Value()
 }
@@ -54,10 +160,11 @@ func UnmarshalJSON(b []byte, dialect Dialect) dgo.Value {
 // jsonDecoder decodes a json stream into a dgo.Value. It retains the order of maps and
 // resolves references.
 type jsonDecoder struct {
-	consumer Consumer
-	refKey   string
-	decoder  *json.Decoder
-	pbToken  json.Token
+	consumer          Consumer
+	refKey            string
+	decoder           *json.Decoder
+	pbToken           json.Token
+	wholeFloatsAsInts bool
 }
 
 func (j *jsonDecoder) decode() {
@@ -74,12 +181,7 @@ func (j *jsonDecoder) decodeElem(end json.Delim) bool {
 	case string:
 		j.consumer.Add(vf.String(t))
 	case json.Number:
-		if i, err := t.Int64(); err == nil {
-			j.consumer.Add(vf.Integer(i))
-		} else {
-			f, _ := t.Float64()
-			j.consumer.Add(vf.Float(f))
-		}
+		j.consumer.Add(j.number(t))
 	case bool:
 		j.consumer.Add(vf.Boolean(t))
 	default:
@@ -88,6 +190,25 @@ func (j *jsonDecoder) decodeElem(end json.Delim) bool {
 	return true
 }
 
+// number returns the dgo.Integer or dgo.Float that corresponds to the given number
+func (j *jsonDecoder) number(n json.Number) dgo.Value {
+	if i, err := n.Int64(); err == nil {
+		return vf.Integer(i)
+	}
+	if b, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return vf.BigInt(b)
+	}
+	f, _ := n.Float64()
+	if j.wholeFloatsAsInts && f == math.Trunc(f) && f >= math.MinInt64 && f <= math.MaxInt64 {
+		// The float64 is only an approximation. The literal is parsed as a rational so that whole numbers beyond
+		// the precision of a float64 stay exact.
+		if r, ok := new(big.Rat).SetString(n.String()); ok && r.IsInt() && r.Num().IsInt64() {
+			return vf.Integer(r.Num().Int64())
+		}
+	}
+	return vf.Float(f)
+}
+
 func (j *jsonDecoder) decodeCollection(delim json.Delim) {
 	if delim == json.Delim('{') {
 		k := j.nextToken()
@@ -227,6 +348,8 @@ func (j *jsonEncoder) write(e dgo.Value) {
 		v, err = json.Marshal(e.GoFloat())
 	case dgo.Integer:
 		v, err = json.Marshal(e.GoInt())
+	case dgo.BigInt, dgo.Decimal:
+		v, err = json.Marshal(json.Number(e.String()))
 	case dgo.Boolean:
 		v, err = json.Marshal(e.GoBool())
 	default:
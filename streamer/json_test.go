@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/lyraproj/dgo/dgo"
 	require "github.com/lyraproj/dgo/dgo_test"
 	"github.com/lyraproj/dgo/streamer"
+	"github.com/lyraproj/dgo/tf"
+	"github.com/lyraproj/dgo/typ"
 	"github.com/lyraproj/dgo/vf"
 )
 
@@ -68,6 +73,123 @@ func TestJSON_ComplexKeys(t *testing.T) {
 		b.String())
 }
 
+func entryChannel(kvs ...interface{}) <-chan dgo.MapEntry {
+	ch := make(chan dgo.MapEntry, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		ch <- vf.MapEntry(kvs[i], kvs[i+1])
+	}
+	close(ch)
+	return ch
+}
+
+func ExampleJSONStreamMap() {
+	ch := make(chan dgo.MapEntry)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- vf.MapEntry(fmt.Sprintf(`k%d`, i), i)
+		}
+		close(ch)
+	}()
+	if err := streamer.JSONStreamMap(os.Stdout, tf.Map(typ.String, typ.Integer), ch); err != nil {
+		fmt.Println(err)
+	}
+	// Output: {"k0":0,"k1":1,"k2":2}
+}
+
+func TestJSONStreamMap(t *testing.T) {
+	b := bytes.Buffer{}
+	require.Nil(t, streamer.JSONStreamMap(&b, typ.Map, entryChannel(`a`, vf.Values(1, 2), `b`, vf.Map(`c`, nil))))
+	require.Equal(t, `{"a":[1,2],"b":{"c":null}}`, b.String())
+
+	b.Reset()
+	require.Nil(t, streamer.JSONStreamMap(&b, typ.Map, entryChannel()))
+	require.Equal(t, `{}`, b.String())
+}
+
+func TestJSONStreamMap_struct(t *testing.T) {
+	st := tf.StructMap(false,
+		tf.StructMapEntry(`a`, typ.Integer, true),
+		tf.StructMapEntry(`b`, typ.String, false))
+	b := bytes.Buffer{}
+	require.Nil(t, streamer.JSONStreamMap(&b, st, entryChannel(`a`, 1, `b`, `x`)))
+	require.Equal(t, `{"a":1,"b":"x"}`, b.String())
+
+	err := streamer.JSONStreamMap(&b, st, entryChannel(`a`, 1, `c`, `x`))
+	require.Match(t, `key 'c' is not allowed`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, st, entryChannel(`a`, `x`))
+	require.Match(t, `the string "x" cannot be assigned to a variable of type int`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, tf.StructMap(true, tf.StructMapEntry(`a`, typ.Integer, true)),
+		entryChannel(`a`, 1, `c`, `x`))
+	require.Nil(t, err)
+
+	ab := tf.StructMap(false,
+		tf.StructMapEntry(`a`, typ.Integer, true),
+		tf.StructMapEntry(`b`, typ.Integer, true))
+	err = streamer.JSONStreamMap(&b, ab, entryChannel(`a`, 1, `a`, 2))
+	require.Match(t, `duplicate key 'a'`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, st, entryChannel(`b`, `x`))
+	require.Match(t, `missing required key 'a'`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, tf.StructMap(true, tf.StructMapEntry(`a`, typ.Integer, true)),
+		entryChannel(`a`, 1, `c`, `x`, `c`, `y`))
+	require.Match(t, `duplicate key 'c'`, err.Error())
+}
+
+func TestJSONStreamMap_invalid(t *testing.T) {
+	b := bytes.Buffer{}
+	err := streamer.JSONStreamMap(&b, typ.Map, entryChannel(1, 2))
+	require.Match(t, `JSON object keys must be strings, got 1`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, tf.Map(typ.String, typ.String), entryChannel(`a`, 1))
+	require.Match(t, `cannot be assigned to a variable of type string`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, tf.Map(tf.Pattern(regexp.MustCompile(`^a`)), typ.Any), entryChannel(`b`, 1))
+	require.Match(t, `the string "b" cannot be assigned`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, tf.Map(typ.String, typ.Any, 2, 3), entryChannel(`a`, 1))
+	require.Match(t, `size constraint violation on type map\[string,2,3\]any when attempting resize to 1`, err.Error())
+
+	err = streamer.JSONStreamMap(&b, typ.Map, entryChannel(`a`, 1, `a`, 2))
+	require.Match(t, `duplicate key 'a'`, err.Error())
+
+	err = streamer.JSONStreamMap(badWriter(0), typ.Map, entryChannel(`a`, 1))
+	require.Match(t, `bang`, err.Error())
+}
+
+func TestUnmarshalJSON_wholeFloats(t *testing.T) {
+	js := []byte(`[1, 1.0, 1e3, 1.5, -2.0, 1e19]`)
+	v := streamer.UnmarshalJSON(js, nil)
+	require.Equal(t, vf.Values(1, 1, 1000, 1.5, -2, 1e19), v)
+	require.Instance(t, typ.Integer, v.(dgo.Array).Get(1))
+	require.Instance(t, typ.Float, v.(dgo.Array).Get(5))
+
+	v = streamer.UnmarshalJSONWithOptions(js, nil, &streamer.JSONDecodeOptions{})
+	require.Equal(t, vf.Values(1, 1.0, 1000.0, 1.5, -2.0, 1e19), v)
+	require.Instance(t, typ.Float, v.(dgo.Array).Get(1))
+	require.Instance(t, typ.Integer, v.(dgo.Array).Get(0))
+
+	v = streamer.UnmarshalJSON([]byte(`[9007199254740993.0, 9.007199254740993e15, -9223372036854775808.0]`), nil)
+	require.Equal(t, vf.Values(9007199254740993, 9007199254740993, math.MinInt64), v)
+}
+
+func TestJSON_bigInt(t *testing.T) {
+	js := []byte(`[123456789012345678901234567890,-9223372036854775809,9223372036854775807]`)
+	v := streamer.UnmarshalJSON(js, nil)
+	require.Equal(t, vf.Values(
+		vf.BigIntFromString(`123456789012345678901234567890`),
+		vf.BigIntFromString(`-9223372036854775809`),
+		int64(math.MaxInt64)), v)
+	require.Equal(t, string(js), string(streamer.MarshalJSON(v, nil)))
+}
+
+func TestJSON_decimal(t *testing.T) {
+	v := vf.Values(vf.DecimalFromString(`12.50`), vf.DecimalFromString(`-0.000000000000000000001`))
+	require.Equal(t, `[12.50,-0.000000000000000000001]`, string(streamer.MarshalJSON(v, nil)))
+}
+
 func TestUnmarshalJSON_ref(t *testing.T) {
 	v := streamer.UnmarshalJSON(
 		[]byte(`[{"x":"xxxxxxxxxxxxxxxxxxxxx","y":{"__ref":3}}]`),
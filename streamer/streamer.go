@@ -29,6 +29,11 @@ type (
 		DedupLevel DedupLevel
 		Dialect    Dialect
 		RichData   bool
+
+		// RedactSensitive causes Sensitive values to be streamed as their redacted string instead of as a
+		// rich data construct that reveals the wrapped value. The redacted string is also used when RichData is
+		// false. A redacted value cannot be restored by a decoder.
+		RedactSensitive bool
 	}
 
 	// Streamer is a re-entrant fully configured serializer that streams the given
@@ -92,7 +97,7 @@ func (sc *context) emitData(value dgo.Value) {
 	}
 
 	switch value := value.(type) {
-	case dgo.Integer, dgo.Float, dgo.Boolean:
+	case dgo.Integer, dgo.BigInt, dgo.Decimal, dgo.Float, dgo.Boolean:
 		// Never dedup
 		sc.addData(value)
 	case dgo.String:
@@ -294,6 +299,10 @@ func (sc *context) emitMap(value dgo.Map) {
 }
 
 func (sc *context) emitSensitive(value dgo.Sensitive) {
+	if sc.config.RedactSensitive {
+		sc.addData(vf.String(value.String()))
+		return
+	}
 	sc.process(value, func() {
 		if !sc.config.RichData {
 			panic(sc.unknownSerialization(value))
@@ -118,6 +118,20 @@ func TestEncode_sensitive_not_rich(t *testing.T) {
 	}, `unable to serialize`)
 }
 
+func TestEncode_sensitive_redacted(t *testing.T) {
+	o := streamer.DefaultOptions()
+	o.RedactSensitive = true
+	s := vf.Sensitive(`secret`)
+	b := &bytes.Buffer{}
+	streamer.New(nil, o).Stream(vf.Map(`a`, s, `b`, vf.Values(s)), streamer.JSON(b))
+	require.Equal(t, `{"a":"sensitive [value redacted]","b":["sensitive [value redacted]"]}`, b.String())
+
+	o.RichData = false
+	b.Reset()
+	streamer.New(nil, o).Stream(s, streamer.JSON(b))
+	require.Equal(t, `"sensitive [value redacted]"`, b.String())
+}
+
 func TestEncode_not_string_key_not_rich(t *testing.T) {
 	o := streamer.DefaultOptions()
 	o.RichData = false
@@ -221,3 +235,42 @@ func TestEncode_namedUsingValue(t *testing.T) {
 	s.Stream(vf.Map(`__type`, `testNamed`), c)
 	require.Same(t, tp, c.Value())
 }
+
+type eventRecorder struct {
+	events []string
+}
+
+func (r *eventRecorder) CanDoBinary() bool { return false }
+
+func (r *eventRecorder) CanDoTime() bool { return false }
+
+func (r *eventRecorder) CanDoComplexKeys() bool { return false }
+
+func (r *eventRecorder) StringDedupThreshold() int { return 0 }
+
+func (r *eventRecorder) AddArray(len int, doer dgo.Doer) {
+	r.events = append(r.events, `[`)
+	doer()
+	r.events = append(r.events, `]`)
+}
+
+func (r *eventRecorder) AddMap(len int, doer dgo.Doer) {
+	r.events = append(r.events, `{`)
+	doer()
+	r.events = append(r.events, `}`)
+}
+
+func (r *eventRecorder) Add(element dgo.Value) {
+	r.events = append(r.events, element.String())
+}
+
+func (r *eventRecorder) AddRef(ref int) {
+	r.events = append(r.events, `ref `+vf.Integer(int64(ref)).String())
+}
+
+func TestStream_consumerEvents(t *testing.T) {
+	v := vf.Strings(`x`, `y`)
+	r := &eventRecorder{}
+	streamer.New(nil, nil).Stream(vf.Map(`a`, v, `b`, v), r)
+	require.Equal(t, []string{`{`, `a`, `[`, `x`, `y`, `]`, `b`, `ref 2`, `}`}, r.events)
+}
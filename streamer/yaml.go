@@ -0,0 +1,259 @@
+package streamer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/vf"
+)
+
+// MarshalYAML returns the block style YAML encoding for the given dgo.Value. Binaries and timestamps are written
+// using the !!binary and !!timestamp tags and floats always contain a decimal point or an exponent so that all
+// primitive types survive a round trip through UnmarshalYAML.
+func MarshalYAML(v interface{}, dialect Dialect) []byte {
+	b := bytes.Buffer{}
+	opts := DefaultOptions()
+	if dialect != nil {
+		opts.Dialect = dialect
+	}
+	New(nil, opts).Stream(vf.Value(v), YAML(&b))
+	return b.Bytes()
+}
+
+// UnmarshalYAML decodes the YAML representation of the given bytes into a dgo.Value. The order of entries in a
+// mapping is retained in its corresponding dgo.Map and rich data constructs such as Sensitive are converted.
+//
+// The decoder handles the subset of YAML that is commonly used for data: block and flow collections, plain and
+// quoted scalars, literal and folded block scalars, comments, and the standard tags. Anchors, aliases, complex
+// keys, and multiple documents are not supported.
+func UnmarshalYAML(b []byte, dialect Dialect) dgo.Value {
+	opts := DefaultOptions()
+	if dialect != nil {
+		opts.Dialect = dialect
+	}
+	vc := DataDecoder(nil, opts.Dialect)
+	y := &yamlDecoder{consumer: vc, refKey: opts.Dialect.RefKey().GoString()}
+	y.decode(newYamlParser(string(b)).parse())
+	return vc.Value()
+}
+
+// YAML creates a new Consumer that encodes everything into block style YAML
+func YAML(out io.Writer) Consumer {
+	return &yamlEncoder{out: out, dialect: DgoDialect()}
+}
+
+// yamlFrame is a block collection that is being written by the yamlEncoder
+type yamlFrame struct {
+	isMap  bool
+	indent int
+	count  int
+
+	// inline is true when the first entry of the collection continues the line of its parent sequence entry
+	inline bool
+}
+
+type yamlEncoder struct {
+	out     io.Writer
+	dialect Dialect
+	stack   []*yamlFrame
+}
+
+func (y *yamlEncoder) AddArray(size int, doer dgo.Doer) {
+	y.addCollection(false, size, doer)
+}
+
+func (y *yamlEncoder) AddMap(size int, doer dgo.Doer) {
+	y.addCollection(true, size, doer)
+}
+
+func (y *yamlEncoder) Add(element dgo.Value) {
+	y.scalar(yamlFormat(element))
+}
+
+func (y *yamlEncoder) AddRef(ref int) {
+	y.AddMap(1, func() {
+		y.Add(y.dialect.RefKey())
+		y.Add(vf.Integer(int64(ref)))
+	})
+}
+
+func (y *yamlEncoder) CanDoBinary() bool {
+	return true
+}
+
+func (y *yamlEncoder) CanDoComplexKeys() bool {
+	return false
+}
+
+func (y *yamlEncoder) CanDoTime() bool {
+	return true
+}
+
+func (y *yamlEncoder) StringDedupThreshold() int {
+	return 20
+}
+
+func (y *yamlEncoder) addCollection(isMap bool, size int, doer dgo.Doer) {
+	if size == 0 {
+		if isMap {
+			y.scalar(`{}`)
+		} else {
+			y.scalar(`[]`)
+		}
+		doer()
+		return
+	}
+	indent, inline := y.start(true)
+	y.stack = append(y.stack, &yamlFrame{isMap: isMap, indent: indent, inline: inline})
+	doer()
+	y.stack = y.stack[:len(y.stack)-1]
+}
+
+func (y *yamlEncoder) scalar(s string) {
+	if _, key := y.start(false); key {
+		y.write(s, `:`)
+	} else {
+		y.write(s, "\n")
+	}
+}
+
+// start writes what precedes the next node in the current collection. When the node is a collection, its indent and
+// inline status is returned. When it is a scalar, the returned boolean is true if the scalar is a mapping key.
+func (y *yamlEncoder) start(collection bool) (int, bool) {
+	n := len(y.stack)
+	if n == 0 {
+		return 0, false
+	}
+	f := y.stack[n-1]
+	f.count++
+	if f.isMap {
+		if f.count%2 == 1 {
+			if collection {
+				panic(fmt.Errorf(`YAML mapping keys must be scalars`))
+			}
+			y.indent(f)
+			return 0, true
+		}
+		if collection {
+			y.write("\n")
+			return f.indent + 2, false
+		}
+		y.write(` `)
+		return 0, false
+	}
+	y.indent(f)
+	y.write(`- `)
+	return f.indent + 2, collection
+}
+
+func (y *yamlEncoder) indent(f *yamlFrame) {
+	if f.inline {
+		f.inline = false
+	} else {
+		y.write(strings.Repeat(` `, f.indent))
+	}
+}
+
+func (y *yamlEncoder) write(ss ...string) {
+	for _, s := range ss {
+		assertOk(io.WriteString(y.out, s))
+	}
+}
+
+func yamlFormat(v dgo.Value) string {
+	switch v := v.(type) {
+	case dgo.String:
+		return yamlString(v.GoString())
+	case dgo.Integer:
+		return strconv.FormatInt(v.GoInt(), 10)
+	case dgo.BigInt, dgo.Decimal:
+		return v.String()
+	case dgo.Float:
+		return yamlFloat(v.GoFloat())
+	case dgo.Boolean:
+		return strconv.FormatBool(v.GoBool())
+	case dgo.Binary:
+		return `!!binary ` + v.String()
+	case dgo.Time:
+		return `!!timestamp ` + v.GoTime().Format(time.RFC3339Nano)
+	default:
+		return `null`
+	}
+}
+
+func yamlFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return `.inf`
+	case math.IsInf(f, -1):
+		return `-.inf`
+	case math.IsNaN(f):
+		return `.nan`
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, `.e`) {
+		s += `.0`
+	}
+	return s
+}
+
+var yamlPlainRx = regexp.MustCompile(`\A[A-Za-z0-9_/.]([A-Za-z0-9_/. -]*[A-Za-z0-9_/.-])?\z`)
+
+// yaml11Bools are strings that YAML 1.1 parsers resolve to booleans. They are quoted for compatibility.
+var yaml11Bools = map[string]bool{`y`: true, `yes`: true, `n`: true, `no`: true, `on`: true, `off`: true}
+
+// yamlString returns the given string as a plain scalar when it can be read back as the same string and otherwise
+// as a double quoted scalar.
+func yamlString(s string) string {
+	if yamlPlainRx.MatchString(s) && !yaml11Bools[strings.ToLower(s)] {
+		if _, ok := yamlResolvePlain(s).(dgo.String); ok {
+			return s
+		}
+	}
+	b := bytes.Buffer{}
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	assertOk(0, e.Encode(s))
+	return strings.TrimRight(b.String(), "\n")
+}
+
+var yamlIntRx = regexp.MustCompile(`\A[-+]?[0-9]+\z`)
+var yamlFloatRx = regexp.MustCompile(`\A[-+]?(?:\.[0-9]+|[0-9]+(?:\.[0-9]*)?)(?:[eE][-+]?[0-9]+)?\z`)
+
+// yamlResolvePlain resolves an untagged plain scalar using the YAML 1.2 core schema
+func yamlResolvePlain(s string) dgo.Value {
+	switch s {
+	case ``, `~`, `null`, `Null`, `NULL`:
+		return vf.Nil
+	case `true`, `True`, `TRUE`:
+		return vf.True
+	case `false`, `False`, `FALSE`:
+		return vf.False
+	case `.inf`, `.Inf`, `.INF`, `+.inf`, `+.Inf`, `+.INF`:
+		return vf.Float(math.Inf(1))
+	case `-.inf`, `-.Inf`, `-.INF`:
+		return vf.Float(math.Inf(-1))
+	case `.nan`, `.NaN`, `.NAN`:
+		return vf.Float(math.NaN())
+	}
+	if yamlIntRx.MatchString(s) {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return vf.Integer(i)
+		}
+		return vf.BigIntFromString(strings.TrimPrefix(s, `+`))
+	}
+	if yamlFloatRx.MatchString(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return vf.Float(f)
+		}
+	}
+	return vf.String(s)
+}
@@ -0,0 +1,205 @@
+package streamer_test
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lyraproj/dgo/dgo"
+	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/streamer"
+	"github.com/lyraproj/dgo/typ"
+	"github.com/lyraproj/dgo/vf"
+)
+
+func ExampleYAML() {
+	s := streamer.New(nil, nil)
+	s.Stream(vf.Map(`a`, 1, `b`, []int{1, 2}, `c`, vf.Map(`d`, 2.0)), streamer.YAML(os.Stdout))
+	// Output:
+	// a: 1
+	// b:
+	//   - 1
+	//   - 2
+	// c:
+	//   d: 2.0
+}
+
+func TestMarshalYAML(t *testing.T) {
+	v := vf.Map(
+		`a`, vf.Values(vf.Map(`x`, 1, `y`, vf.Values(1, 2)), vf.Values(vf.Values(), 3), vf.Map()),
+		`b`, `needs: quotes`,
+		`c`, `plain string`,
+		`true`, `1.5`,
+		`d`, nil)
+	require.Equal(t, `a:
+  - x: 1
+    "y":
+      - 1
+      - 2
+  - - []
+    - 3
+  - {}
+b: "needs: quotes"
+c: plain string
+"true": "1.5"
+d: null
+`, string(streamer.MarshalYAML(v, nil)))
+
+	require.Equal(t, "42\n", string(streamer.MarshalYAML(42, nil)))
+	require.Equal(t, "[]\n", string(streamer.MarshalYAML(vf.Values(), nil)))
+}
+
+func TestMarshalYAML_scalars(t *testing.T) {
+	ts, _ := time.Parse(time.RFC3339, `2019-10-06T07:15:00-07:00`)
+	v := vf.Values(1.0, 1e21, math.Inf(1), math.Inf(-1), math.NaN(), vf.BinaryFromString(`AQID`), ts, `yes`, "a\nb",
+		``, `-a`, true)
+	require.Equal(t, `- 1.0
+- 1e+21
+- .inf
+- -.inf
+- .nan
+- !!binary AQID
+- !!timestamp 2019-10-06T07:15:00-07:00
+- "yes"
+- "a\nb"
+- ""
+- "-a"
+- true
+`, string(streamer.MarshalYAML(v, nil)))
+}
+
+func TestYAML_roundTrip(t *testing.T) {
+	ts, _ := time.Parse(time.RFC3339Nano, `2019-10-06T07:15:00.123-07:00`)
+	v := vf.Map(
+		`int`, 42,
+		`float`, 42.0,
+		`neg`, -3.5,
+		`bool`, false,
+		`nil`, nil,
+		`bin`, vf.BinaryFromString(`AQID`),
+		`time`, ts,
+		`str`, `it's a "string" # not a comment`,
+		`num`, `12`,
+		`nested`, vf.Values(vf.Values(1, vf.Map(`a`, vf.Values())), vf.Map(`b`, vf.Map(`c`, 1))),
+		`sensitive`, vf.Sensitive(`secret`),
+		`intKeys`, vf.Map(1, `one`, 2, `two`))
+	b := streamer.MarshalYAML(v, nil)
+	require.Equal(t, v, streamer.UnmarshalYAML(b, nil))
+
+	s := vf.Strings(`a string that is long enough to dedup`)
+	a := vf.Values(s, s)
+	b = streamer.MarshalYAML(a, nil)
+	require.Equal(t, a, streamer.UnmarshalYAML(b, nil))
+}
+
+func TestYAML_bigInt(t *testing.T) {
+	v := vf.Values(vf.BigIntFromString(`123456789012345678901234567890`), vf.BigIntFromString(`-9223372036854775809`))
+	b := streamer.MarshalYAML(v, nil)
+	require.Equal(t, "- 123456789012345678901234567890\n- -9223372036854775809\n", string(b))
+	require.Equal(t, v, streamer.UnmarshalYAML(b, nil))
+	require.Equal(t, vf.BigIntFromString(`0x1ffffffffffffffff`),
+		streamer.UnmarshalYAML([]byte(`!!int 0x1ffffffffffffffff`), nil))
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	v := streamer.UnmarshalYAML([]byte(`%YAML 1.2
+---
+# a comment
+name: test   # trailing comment
+'quoted key': 'it''s'
+list:
+- a
+- b: 1
+  c: 2
+-
+  - x
+flow: {a: [1, 2.5, "three"], "b": null, c, d: }
+multi: [
+  1, # one
+  2
+]
+empty:
+tagged: !!str 123
+float: !!float 1
+int: !!int 0x1f
+lit: |
+  line 1
+
+  line 2
+fold: >-
+  a
+  b
+
+  c
+keep: |+
+  x
+
+esc: "\t\u00e9\U0001F600\x41\"\/\\\ud83d\ude00"
+...
+ignored: true
+`), nil)
+	require.Equal(t, vf.Map(
+		`name`, `test`,
+		`quoted key`, `it's`,
+		`list`, vf.Values(`a`, vf.Map(`b`, 1, `c`, 2), vf.Values(`x`)),
+		`flow`, vf.Map(`a`, vf.Values(1, 2.5, `three`), `b`, nil, `c`, nil, `d`, nil),
+		`multi`, vf.Values(1, 2),
+		`empty`, nil,
+		`tagged`, `123`,
+		`float`, 1.0,
+		`int`, 31,
+		`lit`, "line 1\n\nline 2\n",
+		`fold`, "a b\nc",
+		`keep`, "x\n\n",
+		`esc`, "\té😀A\"/\\😀"), v)
+	require.Instance(t, typ.Float, v.(dgo.Map).Get(`float`))
+
+	require.Nil(t, streamer.UnmarshalYAML([]byte("# only a comment\n"), nil))
+	require.Equal(t, `x`, streamer.UnmarshalYAML([]byte(`x`), nil))
+	require.Equal(t, vf.Values(vf.Values(1, 2), 3), streamer.UnmarshalYAML([]byte("- - 1\n  - 2\n- 3"), nil))
+	require.Equal(t, vf.Map(`a`, vf.Values(1)), streamer.UnmarshalYAML([]byte("a:\n  - 1\n"), nil))
+	require.Equal(t, vf.Map(`a`, `b`), streamer.UnmarshalYAML([]byte(`{"a":"b"}`), nil))
+	require.Equal(t, vf.Map(`a`, vf.Map(`b`, 1)), streamer.UnmarshalYAML([]byte("a: !!map\n  b: 1\n"), nil))
+}
+
+func TestUnmarshalYAML_errors(t *testing.T) {
+	tests := map[string]string{
+		"a: 1\n  b: 2":          `line 2: unexpected content`,
+		"a: [1, 2":              `unexpected end of flow collection`,
+		"a: [1, 2}":             `expected ',' or '\]', got '}'`,
+		"a: &x 1":               `anchors and aliases are not supported`,
+		"a: *x":                 `anchors and aliases are not supported`,
+		"a: \"x":                `unterminated quoted scalar`,
+		"a: \"x\ny\"":           `multi-line quoted scalars are not supported`,
+		"a: \"\\q\"":            `invalid escape sequence '\\q'`,
+		"a: \"x\" y":            `unexpected "y"`,
+		"a: !!int x":            `line 1: "x" is not a valid !!int`,
+		"a: !!foo x":            `unsupported tag !!foo`,
+		"a: !!str\n  - 1":       `the tag !!str cannot be used on a collection`,
+		"a: |3\n   x":           `unsupported block scalar header`,
+		"--- \na: 1\n---\nb: 2": `multiple documents are not supported`,
+		"{[1]: 2}":              `complex mapping keys are not supported`,
+	}
+	for src, msg := range tests {
+		require.Panic(t, func() { streamer.UnmarshalYAML([]byte(src), nil) }, msg)
+	}
+}
+
+func TestUnmarshalYAML_maxDepth(t *testing.T) {
+	n := 20000000
+	src := strings.Repeat(`[`, n) + strings.Repeat(`]`, n)
+	require.Panic(t, func() { streamer.UnmarshalYAML([]byte(src), nil) }, `collections are nested deeper than 10000 levels`)
+
+	src = strings.Repeat(`- `, n) + `1`
+	require.Panic(t, func() { streamer.UnmarshalYAML([]byte(src), nil) }, `collections are nested deeper than 10000 levels`)
+
+	n = 9999
+	src = strings.Repeat(`[`, n) + strings.Repeat(`]`, n)
+	require.Equal(t, 1, streamer.UnmarshalYAML([]byte(src), nil).(dgo.Array).Len())
+}
+
+func TestYAML_badWrite(t *testing.T) {
+	require.Panic(t, func() { streamer.New(nil, nil).Stream(vf.Integer(3), streamer.YAML(badWriter(0))) }, `bang`)
+}
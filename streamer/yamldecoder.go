@@ -0,0 +1,684 @@
+package streamer
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lyraproj/dgo/dgo"
+	"github.com/lyraproj/dgo/vf"
+)
+
+// yamlMaxDepth is the maximum nesting depth of collections that the yamlParser accepts
+const yamlMaxDepth = 10000
+
+const (
+	yamlScalar = iota
+	yamlSeq
+	yamlMap
+)
+
+// yamlNode is a node in the tree produced by the yamlParser. The items of a mapping alternate between key and value.
+type yamlNode struct {
+	kind   int
+	tag    string
+	value  string
+	quoted bool
+	items  []*yamlNode
+	line   int
+}
+
+// yamlLine is a line of YAML source that contains something other than whitespace and comments
+type yamlLine struct {
+	start  int
+	end    int
+	indent int
+	number int
+}
+
+// yamlParser parses YAML source into a tree of yamlNodes. Block structure is parsed line by line while flow
+// collections and quoted scalars are parsed character by character.
+type yamlParser struct {
+	src   string
+	lines []yamlLine
+	i     int
+	depth int
+}
+
+var yamlTimeLayouts = []string{
+	time.RFC3339Nano,
+	`2006-01-02 15:04:05.999999999Z07:00`,
+	`2006-01-02 15:04:05.999999999 -07:00`,
+	`2006-01-02T15:04:05.999999999`,
+	`2006-01-02 15:04:05.999999999`,
+	`2006-01-02`}
+
+func newYamlParser(src string) *yamlParser {
+	p := &yamlParser{src: src}
+	ln := 0
+	inDoc := false
+	for start := 0; start < len(src); {
+		end := strings.IndexByte(src[start:], '\n')
+		next := len(src)
+		if end < 0 {
+			end = len(src)
+		} else {
+			end += start
+			next = end + 1
+		}
+		ln++
+		l := yamlLine{start: start, end: start + len(strings.TrimRight(src[start:end], "\r")), number: ln}
+		start = next
+
+		c := src[l.start:l.end]
+		t := strings.TrimLeft(c, ` `)
+		if t == `` || t[0] == '#' || strings.HasPrefix(c, `%`) {
+			continue
+		}
+		if c == `---` || strings.HasPrefix(c, `--- #`) {
+			if inDoc {
+				panic(fmt.Errorf(`yaml: line %d: multiple documents are not supported`, ln))
+			}
+			inDoc = true
+			continue
+		}
+		if c == `...` {
+			break
+		}
+		inDoc = true
+		l.indent = len(c) - len(t)
+		p.lines = append(p.lines, l)
+	}
+	return p
+}
+
+// len returns the number of characters on the line
+func (l yamlLine) len() int {
+	return l.end - l.start
+}
+
+func (p *yamlParser) fail(line int, format string, args ...interface{}) {
+	panic(fmt.Errorf(`yaml: line %d: %s`, line, fmt.Sprintf(format, args...)))
+}
+
+// enter increments the nesting depth and fails if it exceeds yamlMaxDepth
+func (p *yamlParser) enter(line int) {
+	if p.depth >= yamlMaxDepth {
+		p.fail(line, `collections are nested deeper than %d levels`, yamlMaxDepth)
+	}
+	p.depth++
+}
+
+func (p *yamlParser) parse() *yamlNode {
+	if len(p.lines) == 0 {
+		return &yamlNode{kind: yamlScalar}
+	}
+	n := p.parseNode()
+	if p.i < len(p.lines) {
+		p.fail(p.lines[p.i].number, `unexpected content`)
+	}
+	return n
+}
+
+func (p *yamlParser) content(l *yamlLine) string {
+	return p.src[l.start+l.indent : l.end]
+}
+
+// parseNode parses the node that starts at the indent of the current line
+func (p *yamlParser) parseNode() *yamlNode {
+	l := &p.lines[p.i]
+	if isYamlSeqItem(p.content(l)) {
+		return p.parseSeq(l.indent)
+	}
+	if _, _, ok := p.mapKey(l); ok {
+		return p.parseMap(l.indent)
+	}
+	return p.parseInline(l, l.indent, l.indent)
+}
+
+func isYamlSeqItem(c string) bool {
+	return c == `-` || strings.HasPrefix(c, `- `)
+}
+
+func (p *yamlParser) parseSeq(ind int) *yamlNode {
+	n := &yamlNode{kind: yamlSeq, line: p.lines[p.i].number}
+	p.enter(n.line)
+	for p.i < len(p.lines) {
+		l := &p.lines[p.i]
+		if l.indent != ind || !isYamlSeqItem(p.content(l)) {
+			break
+		}
+		n.items = append(n.items, p.parseValue(l, ind+1, ind, true))
+	}
+	p.depth--
+	return n
+}
+
+func (p *yamlParser) parseMap(ind int) *yamlNode {
+	n := &yamlNode{kind: yamlMap, line: p.lines[p.i].number}
+	p.enter(n.line)
+	for p.i < len(p.lines) {
+		l := &p.lines[p.i]
+		if l.indent != ind {
+			break
+		}
+		k, col, ok := p.mapKey(l)
+		if !ok {
+			break
+		}
+		n.items = append(n.items, k, p.parseValue(l, col, ind, false))
+	}
+	p.depth--
+	return n
+}
+
+// parseValue parses the value that follows an indicator that ends at the given column of the given line. The value
+// is either found on the same line or in the following lines that are indented more than ind. A sequence entry may
+// contain a compact nested sequence or mapping on the same line.
+func (p *yamlParser) parseValue(l *yamlLine, col, ind int, seqEntry bool) *yamlNode {
+	rest := strings.TrimLeft(p.src[l.start+col:l.end], ` `)
+	if rest == `` || rest[0] == '#' {
+		p.i++
+		if p.i < len(p.lines) {
+			nl := &p.lines[p.i]
+			if nl.indent > ind || !seqEntry && nl.indent == ind && isYamlSeqItem(p.content(nl)) {
+				return p.parseNode()
+			}
+		}
+		return &yamlNode{kind: yamlScalar, line: l.number}
+	}
+	col = l.len() - len(rest)
+	if seqEntry {
+		l.indent = col
+		return p.parseNode()
+	}
+	return p.parseInline(l, col, ind)
+}
+
+// mapKey returns the key of the mapping entry that starts the given line and the column that follows the colon
+// after the key. The returned boolean is false if the line doesn't start with a mapping key.
+func (p *yamlParser) mapKey(l *yamlLine) (*yamlNode, int, bool) {
+	c := p.content(l)
+	switch c[0] {
+	case '"', '\'':
+		s, end := p.parseQuoted(l.number, l.start+l.indent)
+		for end < l.end && p.src[end] == ' ' {
+			end++
+		}
+		if end < l.end && p.src[end] == ':' && (end+1 == l.end || p.src[end+1] == ' ') {
+			return &yamlNode{kind: yamlScalar, value: s, quoted: true, line: l.number}, end + 1 - l.start, true
+		}
+		return nil, 0, false
+	case '[', '{', '#', '!', '&', '*', '|', '>', '?':
+		return nil, 0, false
+	case '-':
+		if isYamlSeqItem(c) {
+			return nil, 0, false
+		}
+	}
+	for i := 0; i < len(c); i++ {
+		switch c[i] {
+		case '#':
+			if c[i-1] == ' ' {
+				return nil, 0, false
+			}
+		case ':':
+			if i+1 == len(c) || c[i+1] == ' ' {
+				k := strings.TrimRight(c[:i], ` `)
+				return &yamlNode{kind: yamlScalar, value: k, line: l.number}, l.indent + i + 1, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// parseInline parses the scalar or flow collection that starts at the given column of the given line. Everything
+// that follows on the line must be whitespace or a comment. The current line is advanced past the value.
+func (p *yamlParser) parseInline(l *yamlLine, col, ind int) *yamlNode {
+	pos := l.start + col
+	tag := ``
+	if p.src[pos] == '!' {
+		end := pos
+		for end < l.end && p.src[end] != ' ' {
+			end++
+		}
+		tag = p.src[pos:end]
+		rest := strings.TrimLeft(p.src[end:l.end], ` `)
+		if rest == `` || rest[0] == '#' {
+			n := p.parseValue(l, l.len(), ind, false)
+			n.tag = tag
+			return n
+		}
+		pos = l.end - len(rest)
+	}
+
+	var n *yamlNode
+	switch p.src[pos] {
+	case '[', '{', '"', '\'':
+		n, pos = p.parseFlow(l.number, pos)
+	case '|', '>':
+		n = p.parseBlockScalar(l, pos, ind)
+		n.tag = tag
+		return n
+	case '&', '*':
+		p.fail(l.number, `anchors and aliases are not supported`)
+	default:
+		end := l.end
+		if ci := strings.Index(p.src[pos:l.end], ` #`); ci >= 0 {
+			end = pos + ci
+		}
+		n = &yamlNode{kind: yamlScalar, value: strings.TrimRight(p.src[pos:end], ` `), line: l.number}
+		pos = end
+	}
+	n.tag = tag
+	p.finishAt(pos)
+	return n
+}
+
+// finishAt asserts that the line that contains the given position contains nothing but whitespace and comments after
+// that position and makes the line that follows the current line.
+func (p *yamlParser) finishAt(pos int) {
+	for p.i < len(p.lines) && p.lines[p.i].end < pos {
+		p.i++
+	}
+	l := p.lines[p.i]
+	rest := strings.TrimLeft(p.src[pos:l.end], ` `)
+	if rest != `` && rest[0] != '#' {
+		p.fail(l.number, `unexpected %q`, rest)
+	}
+	p.i++
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar whose header starts at the given position
+func (p *yamlParser) parseBlockScalar(l *yamlLine, pos, ind int) *yamlNode {
+	header := strings.TrimSpace(p.src[pos:l.end])
+	if ci := strings.Index(header, ` #`); ci >= 0 {
+		header = strings.TrimSpace(header[:ci])
+	}
+	chomp := byte(0)
+	if len(header) == 2 && (header[1] == '-' || header[1] == '+') {
+		chomp = header[1]
+	} else if len(header) != 1 {
+		p.fail(l.number, `unsupported block scalar header %q`, header)
+	}
+
+	// Block scalars may contain blank lines and lines that look like comments so the raw source is used here
+	var lines []string
+	blockIndent := -1
+	start := l.end
+	end := start
+	for start < len(p.src) {
+		start++ // skip newline
+		le := strings.IndexByte(p.src[start:], '\n')
+		if le < 0 {
+			le = len(p.src)
+		} else {
+			le += start
+		}
+		c := strings.TrimRight(p.src[start:le], "\r")
+		t := strings.TrimLeft(c, ` `)
+		if t == `` {
+			lines = append(lines, ``)
+			start = le
+			continue
+		}
+		li := len(c) - len(t)
+		if blockIndent < 0 {
+			if li <= ind {
+				break
+			}
+			blockIndent = li
+		}
+		if li < blockIndent {
+			break
+		}
+		lines = append(lines, c[blockIndent:])
+		start = le
+		end = le
+	}
+
+	// Trailing blank lines are only kept when chomping is "keep"
+	content := len(lines)
+	for content > 0 && lines[content-1] == `` {
+		content--
+	}
+	var s string
+	if header[0] == '|' {
+		s = strings.Join(lines[:content], "\n")
+	} else {
+		sb := strings.Builder{}
+		for i, line := range lines[:content] {
+			switch {
+			case line == ``:
+				sb.WriteByte('\n')
+			case i == 0 || lines[i-1] == ``:
+			default:
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(line)
+		}
+		s = sb.String()
+	}
+	switch chomp {
+	case '-':
+	case '+':
+		s += strings.Repeat("\n", len(lines)-content+1)
+	default:
+		if content > 0 {
+			s += "\n"
+		}
+	}
+	for p.i < len(p.lines) && p.lines[p.i].start <= end {
+		p.i++
+	}
+	return &yamlNode{kind: yamlScalar, value: s, quoted: true, line: l.number}
+}
+
+// lineAt returns the line number of the given position
+func (p *yamlParser) lineAt(pos int) int {
+	return strings.Count(p.src[:pos], "\n") + 1
+}
+
+// skipSpace skips whitespace, line breaks, and comments in a flow collection
+func (p *yamlParser) skipSpace(pos int) int {
+	for pos < len(p.src) {
+		switch p.src[pos] {
+		case ' ', '\t', '\r', '\n':
+			pos++
+		case '#':
+			for pos < len(p.src) && p.src[pos] != '\n' {
+				pos++
+			}
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+func (p *yamlParser) expectMore(pos int) {
+	if pos >= len(p.src) {
+		p.fail(p.lineAt(pos), `unexpected end of flow collection`)
+	}
+}
+
+// parseFlow parses the flow node that starts at the given position and returns it together with the position that
+// follows it.
+func (p *yamlParser) parseFlow(line, pos int) (*yamlNode, int) {
+	pos = p.skipSpace(pos)
+	p.expectMore(pos)
+	line = p.lineAt(pos)
+	tag := ``
+	if p.src[pos] == '!' {
+		end := pos
+		for end < len(p.src) && !strings.ContainsRune(" \t\r\n,[]{}", rune(p.src[end])) {
+			end++
+		}
+		tag = p.src[pos:end]
+		pos = p.skipSpace(end)
+		p.expectMore(pos)
+	}
+	var n *yamlNode
+	switch p.src[pos] {
+	case '[':
+		n = &yamlNode{kind: yamlSeq, line: line}
+		p.enter(line)
+		pos = p.parseFlowItems(n, pos+1, ']')
+		p.depth--
+	case '{':
+		n = &yamlNode{kind: yamlMap, line: line}
+		p.enter(line)
+		pos = p.parseFlowItems(n, pos+1, '}')
+		p.depth--
+	case '"', '\'':
+		var s string
+		s, pos = p.parseQuoted(line, pos)
+		n = &yamlNode{kind: yamlScalar, value: s, quoted: true, line: line}
+	case '&', '*':
+		p.fail(line, `anchors and aliases are not supported`)
+	default:
+		end := pos
+	scan:
+		for end < len(p.src) {
+			switch p.src[end] {
+			case ',', '[', ']', '{', '}', '\n', '\r':
+				break scan
+			case ':':
+				if end+1 == len(p.src) || strings.ContainsRune(" \t\r\n,]}", rune(p.src[end+1])) {
+					break scan
+				}
+			case '#':
+				if p.src[end-1] == ' ' {
+					break scan
+				}
+			}
+			end++
+		}
+		n = &yamlNode{kind: yamlScalar, value: strings.TrimRight(p.src[pos:end], " \t"), line: line}
+		pos = end
+	}
+	n.tag = tag
+	return n, pos
+}
+
+// parseFlowItems parses the entries of a flow sequence or flow mapping up to and including the given end character
+func (p *yamlParser) parseFlowItems(n *yamlNode, pos int, end byte) int {
+	isMap := end == '}'
+	for {
+		pos = p.skipSpace(pos)
+		p.expectMore(pos)
+		if p.src[pos] == end {
+			return pos + 1
+		}
+		var v *yamlNode
+		v, pos = p.parseFlow(n.line, pos)
+		n.items = append(n.items, v)
+		pos = p.skipSpace(pos)
+		p.expectMore(pos)
+		if isMap {
+			if v.kind != yamlScalar {
+				p.fail(v.line, `complex mapping keys are not supported`)
+			}
+			if p.src[pos] == ':' {
+				pos = p.skipSpace(pos + 1)
+				p.expectMore(pos)
+				if p.src[pos] == ',' || p.src[pos] == end {
+					v = &yamlNode{kind: yamlScalar, line: v.line}
+				} else {
+					v, pos = p.parseFlow(n.line, pos)
+				}
+			} else {
+				v = &yamlNode{kind: yamlScalar, line: v.line}
+			}
+			n.items = append(n.items, v)
+			pos = p.skipSpace(pos)
+			p.expectMore(pos)
+		}
+		switch p.src[pos] {
+		case ',':
+			pos++
+		case end:
+		default:
+			p.fail(p.lineAt(pos), `expected ',' or '%c', got %q`, end, p.src[pos])
+		}
+	}
+}
+
+// parseQuoted parses the single or double quoted scalar that starts at the given position and returns its value and
+// the position that follows the closing quote.
+func (p *yamlParser) parseQuoted(line, pos int) (string, int) {
+	q := p.src[pos]
+	sb := strings.Builder{}
+	for i := pos + 1; i < len(p.src); {
+		c := p.src[i]
+		switch {
+		case c == '\n':
+			p.fail(line, `multi-line quoted scalars are not supported`)
+		case c == q:
+			if q == '\'' && i+1 < len(p.src) && p.src[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i += 2
+				continue
+			}
+			return sb.String(), i + 1
+		case c == '\\' && q == '"':
+			i = p.unescape(line, &sb, i+1)
+			continue
+		default:
+			sb.WriteByte(c)
+		}
+		i++
+	}
+	p.fail(line, `unterminated quoted scalar`)
+	return ``, 0
+}
+
+// unescape writes the character denoted by the escape sequence that starts at the given position and returns the
+// position that follows the sequence
+func (p *yamlParser) unescape(line int, sb *strings.Builder, i int) int {
+	if i >= len(p.src) {
+		p.fail(line, `unterminated quoted scalar`)
+	}
+	c := p.src[i]
+	hexLen := 0
+	switch c {
+	case '0':
+		sb.WriteByte(0)
+	case 'a':
+		sb.WriteByte('\a')
+	case 'b':
+		sb.WriteByte('\b')
+	case 't', '\t':
+		sb.WriteByte('\t')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'v':
+		sb.WriteByte('\v')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'r':
+		sb.WriteByte('\r')
+	case 'e':
+		sb.WriteByte(0x1b)
+	case ' ', '"', '/', '\\':
+		sb.WriteByte(c)
+	case 'x':
+		hexLen = 2
+	case 'u':
+		hexLen = 4
+	case 'U':
+		hexLen = 8
+	default:
+		p.fail(line, `invalid escape sequence '\%c'`, c)
+	}
+	i++
+	if hexLen > 0 {
+		if i+hexLen > len(p.src) {
+			p.fail(line, `invalid escape sequence`)
+		}
+		r, err := strconv.ParseUint(p.src[i:i+hexLen], 16, 32)
+		if err != nil {
+			p.fail(line, `invalid escape sequence '\%c%s'`, c, p.src[i:i+hexLen])
+		}
+		i += hexLen
+		if hexLen == 4 && r >= 0xd800 && r < 0xdc00 && strings.HasPrefix(p.src[i:], `\u`) && i+6 <= len(p.src) {
+			// JSON style surrogate pair
+			if lo, err := strconv.ParseUint(p.src[i+2:i+6], 16, 32); err == nil && lo >= 0xdc00 && lo < 0xe000 {
+				r = (r-0xd800)<<10 + (lo - 0xdc00) + 0x10000
+				i += 6
+			}
+		}
+		var buf [utf8.UTFMax]byte
+		sb.Write(buf[:utf8.EncodeRune(buf[:], rune(r))])
+	}
+	return i
+}
+
+// yamlDecoder converts a tree of yamlNodes into events for a Consumer
+type yamlDecoder struct {
+	consumer Consumer
+	refKey   string
+}
+
+func (y *yamlDecoder) decode(n *yamlNode) {
+	switch n.kind {
+	case yamlSeq:
+		y.checkTag(n, `!!seq`)
+		y.consumer.AddArray(len(n.items), func() {
+			for _, e := range n.items {
+				y.decode(e)
+			}
+		})
+	case yamlMap:
+		y.checkTag(n, `!!map`)
+		if len(n.items) == 2 {
+			k, v := n.items[0], n.items[1]
+			if k.kind == yamlScalar && k.value == y.refKey && v.kind == yamlScalar && v.tag == `` && !v.quoted {
+				if ref, err := strconv.Atoi(v.value); err == nil {
+					y.consumer.AddRef(ref)
+					return
+				}
+			}
+		}
+		y.consumer.AddMap(len(n.items)/2, func() {
+			for _, e := range n.items {
+				y.decode(e)
+			}
+		})
+	default:
+		y.consumer.Add(y.scalar(n))
+	}
+}
+
+func (y *yamlDecoder) checkTag(n *yamlNode, tag string) {
+	if n.tag != `` && n.tag != tag {
+		panic(fmt.Errorf(`yaml: line %d: the tag %s cannot be used on a collection`, n.line, n.tag))
+	}
+}
+
+func (y *yamlDecoder) scalar(n *yamlNode) dgo.Value {
+	s := n.value
+	switch n.tag {
+	case ``:
+		if n.quoted {
+			return vf.String(s)
+		}
+		return yamlResolvePlain(s)
+	case `!`, `!!str`:
+		return vf.String(s)
+	case `!!null`:
+		return vf.Nil
+	case `!!bool`:
+		if v, ok := yamlResolvePlain(s).(dgo.Boolean); ok {
+			return v
+		}
+	case `!!int`:
+		if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return vf.Integer(i)
+		}
+		if b, ok := new(big.Int).SetString(s, 0); ok {
+			return vf.BigInt(b)
+		}
+	case `!!float`:
+		switch v := yamlResolvePlain(s).(type) {
+		case dgo.Float:
+			return v
+		case dgo.Integer:
+			return vf.Float(float64(v.GoInt()))
+		}
+	case `!!binary`:
+		return vf.BinaryFromEncoded(strings.Join(strings.Fields(s), ``), `%b`)
+	case `!!timestamp`:
+		for _, layout := range yamlTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return vf.Time(t)
+			}
+		}
+	default:
+		panic(fmt.Errorf(`yaml: line %d: unsupported tag %s`, n.line, n.tag))
+	}
+	panic(fmt.Errorf(`yaml: line %d: %q is not a valid %s`, n.line, s, n.tag))
+}
@@ -0,0 +1,62 @@
+package stringer
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/lyraproj/dgo/dgo"
+)
+
+// typeCacheLimit is the maximum number of strings retained by the type string cache. The cache is cleared
+// when the limit is reached.
+const typeCacheLimit = 1024
+
+// typeCache retains the strings produced for types using a specific alias map. Types are keyed by identity. A
+// type that represents a value that isn't frozen, e.g. the exact type of a mutable array, or that contains an
+// unresolved alias may change and is therefore never cached. All other types are immutable.
+type typeCache struct {
+	lock    sync.Mutex
+	aliases dgo.AliasMap
+	entries map[dgo.Type]string
+}
+
+var defaultTypeCache = &typeCache{}
+
+// cacheable returns true for types that are represented by pointers. Other types are either cheap to
+// produce strings for or cannot be used as map keys.
+func cacheable(t dgo.Type) bool {
+	return reflect.ValueOf(t).Kind() == reflect.Ptr
+}
+
+// get returns the string for the given type using the given alias map
+func (c *typeCache) get(t dgo.Type, am dgo.AliasMap) string {
+	if !cacheable(t) {
+		return TypeStringWithAliasMap(t, am)
+	}
+	c.lock.Lock()
+	if c.aliases == am {
+		if s, ok := c.entries[t]; ok {
+			c.lock.Unlock()
+			return s
+		}
+	}
+	c.lock.Unlock()
+
+	// The lock is not held while producing the string since producing it might recurse into this cache
+	s := strings.Builder{}
+	sb := newTypeBuilder(&s, am)
+	sb.buildTypeString(t, 0)
+	if sb.mutable {
+		return s.String()
+	}
+
+	c.lock.Lock()
+	if c.aliases != am || len(c.entries) >= typeCacheLimit {
+		c.aliases = am
+		c.entries = make(map[dgo.Type]string)
+	}
+	c.entries[t] = s.String()
+	c.lock.Unlock()
+	return s.String()
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lyraproj/dgo/internal"
 
@@ -27,11 +28,13 @@ type typeBuilder struct {
 	complexTypes map[dgo.TypeIdentifier]typeToString
 	aliasMap     dgo.AliasMap
 	seen         []dgo.Value
+	mutable      bool
 }
 
-// TypeString produces a string with the go-like syntax for the given type.
+// TypeString produces a string with the go-like syntax for the given type. The produced strings are
+// cached so that repeated calls for the same unchanged type don't need to produce them again.
 func TypeString(typ dgo.Type) string {
-	return TypeStringWithAliasMap(typ, internal.DefaultAliases())
+	return defaultTypeCache.get(typ, internal.DefaultAliases())
 }
 
 // TypeStringWithAliasMap produces a string with the go-like syntax for the given type.
@@ -111,6 +114,14 @@ func (sb *typeBuilder) _map(typ dgo.Type, _ int) {
 	sb.buildTypeString(at.ValueType(), typePrio)
 }
 
+func (sb *typeBuilder) multiMap(typ dgo.Type, _ int) {
+	mt := typ.(dgo.MultiMapType)
+	util.WriteString(sb, `multimap[`)
+	sb.buildTypeString(mt.KeyType(), commaPrio)
+	util.WriteByte(sb, ']')
+	sb.buildTypeString(mt.ElementType(), typePrio)
+}
+
 func (sb *typeBuilder) mapExact(typ dgo.Type, _ int) {
 	util.WriteByte(sb, '{')
 	sb.joinValueTypes(typ.(dgo.ExactType).ExactValue().(dgo.Map), `,`, commaPrio)
@@ -130,6 +141,16 @@ func (sb *typeBuilder) _struct(typ dgo.Type, _ int) {
 	util.WriteByte(sb, '}')
 }
 
+func (sb *typeBuilder) conditionalStruct(typ dgo.Type, _ int) {
+	ct := typ.(dgo.ConditionalStructType)
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	sb.buildTypeString(ct.Discriminator().Type(), commaPrio)
+	util.WriteByte(sb, ',')
+	sb.joinTypes(ct.Cases(), `,`, commaPrio)
+	util.WriteByte(sb, ']')
+}
+
 func (sb *typeBuilder) mapEntryExact(typ dgo.Type, _ int) {
 	me := typ.(dgo.ExactType).ExactValue().(dgo.MapEntry)
 	sb.buildTypeString(me.Key().Type(), commaPrio)
@@ -161,6 +182,126 @@ func (sb *typeBuilder) timeExact(typ dgo.Type, _ int) {
 	util.WriteByte(sb, ']')
 }
 
+func (sb *typeBuilder) timeRange(typ dgo.Type, _ int) {
+	st := typ.(dgo.TimeType)
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	sb.writeTimeBound(st.Min())
+	util.WriteByte(sb, ',')
+	sb.writeTimeBound(st.Max())
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) writeTimeBound(t time.Time) {
+	if t.IsZero() {
+		util.WriteString(sb, `nil`)
+	} else {
+		util.WriteString(sb, strconv.Quote(t.Format(time.RFC3339Nano)))
+	}
+}
+
+func (sb *typeBuilder) durationRange(typ dgo.Type, _ int) {
+	dt := typ.(dgo.DurationType)
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	if min := dt.Min(); min == math.MinInt64 {
+		util.WriteString(sb, `nil`)
+	} else {
+		util.WriteString(sb, min.String())
+	}
+	util.WriteByte(sb, ',')
+	if max := dt.Max(); max == math.MaxInt64 {
+		util.WriteString(sb, `nil`)
+	} else {
+		util.WriteString(sb, max.String())
+	}
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) decimalExact(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(typ.(dgo.ExactType).ExactValue().String()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) decimalSized(typ dgo.Type, _ int) {
+	dt := typ.(dgo.DecimalType)
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Itoa(dt.Precision()))
+	util.WriteByte(sb, ',')
+	util.WriteString(sb, strconv.Itoa(dt.Scale()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) uriExact(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(typ.(dgo.ExactType).ExactValue().String()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) uriRestricted(typ dgo.Type, _ int) {
+	ut := typ.(dgo.URIType)
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	if scheme := ut.Scheme(); scheme == `` {
+		util.WriteString(sb, `nil`)
+	} else {
+		util.WriteString(sb, strconv.Quote(scheme))
+	}
+	if host := ut.Host(); host != `` {
+		util.WriteByte(sb, ',')
+		util.WriteString(sb, strconv.Quote(host))
+	}
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) semVerExact(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(typ.(dgo.ExactType).ExactValue().String()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) semVerConstraint(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(typ.(dgo.SemVerType).Range().String()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) ipNetwork(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	var n dgo.IPNet
+	switch nt := typ.(type) {
+	case dgo.IPType:
+		n = nt.Network()
+	case dgo.CIDRType:
+		n = nt.Network()
+	}
+	if n != nil {
+		util.WriteByte(sb, '[')
+		util.WriteString(sb, strconv.Quote(n.String()))
+		util.WriteByte(sb, ']')
+	}
+}
+
+func (sb *typeBuilder) ipExact(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(typ.(dgo.ExactType).ExactValue().String()))
+	util.WriteByte(sb, ']')
+}
+
+func (sb *typeBuilder) cidrExact(typ dgo.Type, _ int) {
+	util.WriteString(sb, typ.TypeIdentifier().String())
+	util.WriteByte(sb, '[')
+	util.WriteString(sb, strconv.Quote(`=`+typ.(dgo.ExactType).ExactValue().String()))
+	util.WriteByte(sb, ']')
+}
+
 func (sb *typeBuilder) sensitive(typ dgo.Type, prio int) {
 	util.WriteString(sb, `sensitive`)
 	if op := typ.(dgo.UnaryType).Operand(); internal.DefaultAnyType != op {
@@ -257,38 +398,57 @@ func (sb *typeBuilder) named(typ dgo.Type, _ int) {
 func newTypeBuilder(w io.Writer, am dgo.AliasMap) *typeBuilder {
 	sb := &typeBuilder{Writer: w, aliasMap: am}
 	sb.complexTypes = map[dgo.TypeIdentifier]typeToString{
-		dgo.TiAnyOf:         sb.anyOf,
-		dgo.TiOneOf:         sb.oneOf,
-		dgo.TiAllOf:         sb.allOf,
-		dgo.TiAllOfValue:    sb.allOfValue,
-		dgo.TiArray:         sb.array,
-		dgo.TiArrayExact:    sb.arrayExact,
-		dgo.TiBinary:        sb.binary,
-		dgo.TiBinaryExact:   sb.binaryExact,
-		dgo.TiBooleanExact:  sb.exactValue,
-		dgo.TiTuple:         sb.tuple,
-		dgo.TiMap:           sb._map,
-		dgo.TiMapExact:      sb.mapExact,
-		dgo.TiMapEntryExact: sb.mapEntryExact,
-		dgo.TiStruct:        sb._struct,
-		dgo.TiFloatExact:    sb.exactValue,
-		dgo.TiFloatRange:    sb.floatRange,
-		dgo.TiIntegerExact:  sb.exactValue,
-		dgo.TiIntegerRange:  sb.integerRange,
-		dgo.TiRegexpExact:   sb.regexpExact,
-		dgo.TiTimeExact:     sb.timeExact,
-		dgo.TiSensitive:     sb.sensitive,
-		dgo.TiStringExact:   sb.stringExact,
-		dgo.TiStringPattern: sb.stringPattern,
-		dgo.TiStringSized:   sb.stringSized,
-		dgo.TiCiString:      sb.ciString,
-		dgo.TiNative:        sb.native,
-		dgo.TiNot:           sb.not,
-		dgo.TiMeta:          sb.meta,
-		dgo.TiFunction:      sb.function,
-		dgo.TiErrorExact:    sb.errorExact,
-		dgo.TiNamed:         sb.named,
-		dgo.TiNamedExact:    sb.exactValue,
+		dgo.TiAnyOf:             sb.anyOf,
+		dgo.TiOneOf:             sb.oneOf,
+		dgo.TiAllOf:             sb.allOf,
+		dgo.TiAllOfValue:        sb.allOfValue,
+		dgo.TiArray:             sb.array,
+		dgo.TiArrayExact:        sb.arrayExact,
+		dgo.TiBinary:            sb.binary,
+		dgo.TiBinaryExact:       sb.binaryExact,
+		dgo.TiBooleanExact:      sb.exactValue,
+		dgo.TiTuple:             sb.tuple,
+		dgo.TiMap:               sb._map,
+		dgo.TiMapExact:          sb.mapExact,
+		dgo.TiMapEntryExact:     sb.mapEntryExact,
+		dgo.TiMultiMap:          sb.multiMap,
+		dgo.TiStruct:            sb._struct,
+		dgo.TiConditionalStruct: sb.conditionalStruct,
+		dgo.TiFloatExact:        sb.exactValue,
+		dgo.TiFloatRange:        sb.floatRange,
+		dgo.TiIntegerExact:      sb.exactValue,
+		dgo.TiIntegerRange:      sb.integerRange,
+		dgo.TiRegexpExact:       sb.regexpExact,
+		dgo.TiTimeExact:         sb.timeExact,
+		dgo.TiTimeRange:         sb.timeRange,
+		dgo.TiDurationExact:     sb.exactValue,
+		dgo.TiBigIntExact:       sb.exactValue,
+		dgo.TiDecimalExact:      sb.decimalExact,
+		dgo.TiDecimalSized:      sb.decimalSized,
+		dgo.TiDurationRange:     sb.durationRange,
+		dgo.TiURIExact:          sb.uriExact,
+		dgo.TiURIRestricted:     sb.uriRestricted,
+		dgo.TiSemVerExact:       sb.semVerExact,
+		dgo.TiSemVerConstraint:  sb.semVerConstraint,
+		dgo.TiSemVerRangeExact:  sb.semVerExact,
+		dgo.TiIPAddr:            sb.ipNetwork,
+		dgo.TiIPv4:              sb.ipNetwork,
+		dgo.TiIPv6:              sb.ipNetwork,
+		dgo.TiIPAddrExact:       sb.ipExact,
+		dgo.TiCIDR:              sb.ipNetwork,
+		dgo.TiCIDRExact:         sb.cidrExact,
+		dgo.TiSensitive:         sb.sensitive,
+		dgo.TiStringExact:       sb.stringExact,
+		dgo.TiStringPattern:     sb.stringPattern,
+		dgo.TiStringSized:       sb.stringSized,
+		dgo.TiCiString:          sb.ciString,
+		dgo.TiNative:            sb.native,
+		dgo.TiNot:               sb.not,
+		dgo.TiMeta:              sb.meta,
+		dgo.TiFunction:          sb.function,
+		dgo.TiErrorExact:        sb.errorExact,
+		dgo.TiNamed:             sb.named,
+		dgo.TiNamedExact:        sb.exactValue,
 	}
 	return sb
 }
@@ -321,11 +481,16 @@ func (sb *typeBuilder) joinStructMapEntries(v dgo.StructMapType) {
 		} else {
 			util.WriteByte(sb, ',')
 		}
-		sb.buildTypeString(e.Key().(dgo.Type), commaPrio)
-		if !e.Required() {
+		kt := e.Key().(dgo.Type)
+		sb.buildTypeString(kt, commaPrio)
+		if !(e.Required() || kt.TypeIdentifier() == dgo.TiStringPattern) {
 			util.WriteByte(sb, '?')
 		}
 		util.WriteByte(sb, ':')
+		if d := e.Default(); d != nil {
+			sb.buildTypeString(d.Type(), commaPrio)
+			util.WriteByte(sb, ' ')
+		}
 		sb.buildTypeString(e.Value().(dgo.Type), commaPrio)
 	})
 }
@@ -398,6 +563,7 @@ func (sb *typeBuilder) writeTernary(typ dgo.Type, tc func(dgo.Value) dgo.Type, p
 }
 
 func (sb *typeBuilder) buildTypeString(typ dgo.Type, prio int) {
+	sb.noteMutable(typ)
 	if tn := sb.aliasMap.GetName(typ); tn != nil {
 		util.WriteString(sb, tn.GoString())
 		return
@@ -420,6 +586,26 @@ func (sb *typeBuilder) buildTypeString(typ dgo.Type, prio int) {
 	}
 }
 
+// noteMutable records when the given type represents a value that isn't frozen or is an unresolved alias, i.e.
+// when the string produced for it might change
+func (sb *typeBuilder) noteMutable(typ dgo.Type) {
+	var v dgo.Value
+	switch ti := typ.TypeIdentifier(); {
+	case ti == dgo.TiAlias:
+		sb.mutable = true
+		return
+	case ti == dgo.TiAllOfValue:
+		v = typ.(dgo.TernaryType).Operands()
+	default:
+		if et, ok := typ.(dgo.ExactType); ok {
+			v = et.ExactValue()
+		}
+	}
+	if f, ok := v.(dgo.Freezable); ok && !f.Frozen() {
+		sb.mutable = true
+	}
+}
+
 func typeAsType(v dgo.Value) dgo.Type {
 	return v.(dgo.Type)
 }
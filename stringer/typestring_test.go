@@ -6,6 +6,7 @@ import (
 
 	"github.com/lyraproj/dgo/dgo"
 	require "github.com/lyraproj/dgo/dgo_test"
+	"github.com/lyraproj/dgo/internal"
 	"github.com/lyraproj/dgo/tf"
 	"github.com/lyraproj/dgo/typ"
 	"github.com/lyraproj/dgo/vf"
@@ -94,3 +95,39 @@ func TestTypeIdentifier_String(t *testing.T) {
 
 	require.Panic(t, func() { _ = dgo.TypeIdentifier(0x1000).String() }, `unhandled TypeIdentifier 4096`)
 }
+
+func TestTypeString_cachedMutableExact(t *testing.T) {
+	a := vf.MutableValues(1, 2)
+	at := tf.AnyOf(a.Type(), typ.String)
+	require.Equal(t, `{1,2}|string`, at.String())
+	require.Equal(t, `{1,2}|string`, at.String())
+	a.Add(3)
+	require.Equal(t, `{1,2,3}|string`, at.String())
+}
+
+func TestTypeString_cachedMutableExactSameHash(t *testing.T) {
+	a := vf.MutableValues(0, 31)
+	at := tf.AnyOf(a.Type(), typ.String)
+	require.Equal(t, `{0,31}|string`, at.String())
+	a.Set(0, 1)
+	a.Set(1, 0)
+	require.Equal(t, `{1,0}|string`, at.String())
+}
+
+func TestTypeString_cachedFrozenExact(t *testing.T) {
+	a := vf.MutableValues(1, 2)
+	at := tf.AnyOf(a.Type(), typ.String)
+	a.Freeze()
+	require.Equal(t, `{1,2}|string`, at.String())
+	require.Equal(t, `{1,2}|string`, at.String())
+}
+
+func TestTypeString_cachedAliases(t *testing.T) {
+	st := tf.Array(tf.String(3, 8))
+	require.Equal(t, `[]string[3,8]`, st.String())
+	tf.AddDefaultAliases(func(aa dgo.AliasAdder) {
+		aa.Add(tf.String(3, 8), vf.String(`short`))
+	})
+	defer internal.ResetDefaultAliases()
+	require.Equal(t, `[]short`, st.String())
+}
@@ -1,6 +1,8 @@
 package tf
 
 import (
+	"reflect"
+
 	"github.com/lyraproj/dgo/dgo"
 	"github.com/lyraproj/dgo/internal"
 )
@@ -10,18 +12,59 @@ func Map(args ...interface{}) dgo.MapType {
 	return internal.MapType(args)
 }
 
+// MultiMap returns a type that represents a MultiMap where all keys are instances of the given keyType and all values
+// that are associated with a key are instances of the given elementType.
+func MultiMap(keyType, elementType dgo.Type) dgo.MultiMapType {
+	return internal.MultiMapType(keyType, elementType)
+}
+
+// PatternMap returns a type that represents a Map where all keys are strings that match the given regular
+// expression and all values are instances of the given value type. It is equivalent to the type expression
+// map[/keyPattern/]valueType
+func PatternMap(keyPattern string, valueType dgo.Type) dgo.MapType {
+	return internal.PatternMapType(keyPattern, valueType)
+}
+
 // StructMapEntry returns a new StructMapEntry initiated with the given parameters
 func StructMapEntry(key interface{}, value interface{}, required bool) dgo.StructMapEntry {
 	return internal.StructMapEntry(key, value, required)
 }
 
+// StructMapEntryWithDefault returns a new optional StructMapEntry initiated with the given parameters. The given
+// default value is used when the entry is missing in a Map. The function panics if the key is a pattern or if the
+// default value is not an instance of the value type.
+func StructMapEntryWithDefault(key interface{}, value interface{}, defaultValue interface{}) dgo.StructMapEntry {
+	return internal.StructMapEntryWithDefault(key, value, defaultValue)
+}
+
+// AnnotatedStructMapEntry returns a copy of the given StructMapEntry that has the given annotations
+func AnnotatedStructMapEntry(entry dgo.StructMapEntry, annotations dgo.Map) dgo.StructMapEntry {
+	return internal.AnnotatedStructMapEntry(entry, annotations)
+}
+
 // StructMap returns a new StructMapType type built from the given MapEntryTypes. If
 // additional is true, the struct will allow additional unconstrained entries
 func StructMap(additional bool, entries ...dgo.StructMapEntry) dgo.StructMapType {
 	return internal.StructMapType(additional, entries)
 }
 
-// StructMapFromMap returns a new type built from a map[string](dgo|type|{type:dgo|type,required?:bool,...})
+// StructMapFromMap returns a new type built from a
+// map[string](dgo|type|{type:dgo|type,required?:bool,default?:any,annotations?:map[string]any,...}). An entry that
+// has a default value is never required.
 func StructMapFromMap(additional bool, entries dgo.Map) dgo.StructMapType {
 	return internal.StructMapTypeFromMap(additional, entries)
 }
+
+// StructMapFromReflected returns a new type with one required entry for each field of the given Go struct type. The
+// value type of an entry is parsed from the dgo struct tag of the field, such as one produced by
+// codegen.StructFieldTag, or derived from the Go type of the field when it has no such tag.
+func StructMapFromReflected(rt reflect.Type) dgo.StructMapType {
+	return internal.StructMapTypeFromReflected(rt)
+}
+
+// ConditionalStruct returns a new ConditionalStructType where the value of the given discriminator key selects one
+// of the given struct types. Each struct type must have a required entry for the key with an exact value type, and
+// those values must be unique.
+func ConditionalStruct(key interface{}, cases ...dgo.StructMapType) dgo.ConditionalStructType {
+	return internal.ConditionalStructType(key, cases)
+}
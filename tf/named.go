@@ -57,6 +57,12 @@ func NamedFromReflected(rt reflect.Type) dgo.NamedType {
 	return internal.NamedTypeFromReflected(rt)
 }
 
+// AnnotateNamed replaces the annotations of the given named type. The annotations are shared by all parameterized
+// and exact variants of the type.
+func AnnotateNamed(named dgo.NamedType, annotations dgo.Map) {
+	internal.AnnotateNamedType(named, annotations)
+}
+
 // RemoveNamed removes a named type from the global type registry. It is primarily intended for
 // testing purposes.
 func RemoveNamed(name string) {
@@ -3,6 +3,7 @@ package tf
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/lyraproj/dgo/dgo"
 	"github.com/lyraproj/dgo/internal"
@@ -56,3 +57,50 @@ func IntEnum(ints ...int) dgo.Type {
 func Float(min, max float64, inclusive bool) dgo.FloatType {
 	return internal.FloatType(min, max, inclusive)
 }
+
+// Time returns a dgo.TimeType that is limited to the inclusive range given by min and max. A zero min or max
+// means that the range is unbounded in that direction.
+func Time(min, max time.Time) dgo.TimeType {
+	return internal.TimeType(min, max)
+}
+
+// Duration returns a dgo.DurationType that is limited to the inclusive range given by min and max
+func Duration(min, max time.Duration) dgo.DurationType {
+	return internal.DurationType(min, max)
+}
+
+// Decimal returns a dgo.DecimalType that matches decimals with at most precision digits in total, of which at most
+// scale digits are after the decimal point.
+func Decimal(precision, scale int) dgo.DecimalType {
+	return internal.DecimalType(precision, scale)
+}
+
+// URI returns a dgo.URIType that matches URIs with the given scheme and host. An empty scheme or host means that
+// the type isn't constrained by it.
+func URI(scheme, host string) dgo.URIType {
+	return internal.URIType(scheme, host)
+}
+
+// SemVer returns a dgo.SemVerType that matches the semantic versions that are included in the given range
+// expression, e.g. "^1.2.0". The function will panic if the expression cannot be parsed.
+func SemVer(rangeExpr string) dgo.SemVerType {
+	return internal.SemVerType(rangeExpr)
+}
+
+// IP returns a dgo.IPType that matches addresses of the given version, 4, 6, or zero for both, that are contained in
+// the given network in CIDR notation. An empty network means that the type isn't constrained by a network.
+func IP(version int, network string) dgo.IPType {
+	if network == `` {
+		return internal.IPType(version, nil)
+	}
+	return internal.IPType(version, internal.IPNetFromString(network).GoIPNet())
+}
+
+// CIDR returns a dgo.CIDRType that matches networks that are subnets of, or equal to, the given network in CIDR
+// notation. An empty network gives the type of all networks.
+func CIDR(network string) dgo.CIDRType {
+	if network == `` {
+		return internal.DefaultCIDRType
+	}
+	return internal.CIDRType(internal.IPNetFromString(network).GoIPNet())
+}
@@ -41,6 +41,22 @@ func AddDefaultAliases(adderFunc func(aliasAdder dgo.AliasAdder)) {
 	internal.AddDefaultAliases(adderFunc)
 }
 
+// SetResolveDepthLimit sets the limit for the nesting of containers that is permitted during alias
+// resolution and returns the previous limit. The default limit is 100.
+func SetResolveDepthLimit(limit int) int {
+	return internal.SetResolveDepthLimit(limit)
+}
+
+// SetTypeCheckTracer sets a function that is called after each call to the Instance or Assignable method of a type
+// that contains other types, with the type, the value or type that was checked against it, and the time that the
+// check took. Those are the array, tuple, map, struct map, multi map, sensitive, function, allOf, anyOf, and oneOf
+// types, and the exact types of arrays and maps. Checks of scalar types, such as typ.Integer.Instance(3), are not
+// traced. Checks of nested values may be traced before the check that made them. A nil tracer turns tracing off. The
+// previous tracer is returned. The tracer can be set while type checks are made concurrently.
+func SetTypeCheckTracer(tracer dgo.TypeCheckTracer) dgo.TypeCheckTracer {
+	return internal.SetTypeCheckTracer(tracer)
+}
+
 // AddAliases will call the given adder function, and if entries were added, lock the appointed Locker, create
 // a copy of the appointed AliasMap, add the entries to that copy, swap the appointed AliasMap for the copy,
 // and finally release the lock.
@@ -68,6 +68,36 @@ var Regexp dgo.RegexpType = internal.DefaultRegexpType
 // Time is a type that represents all timestamps
 var Time dgo.Type = internal.DefaultTimeType
 
+// Duration is a type that represents all durations
+var Duration dgo.Type = internal.DefaultDurationType
+
+// BigInt is a type that represents all integers regardless of size
+var BigInt dgo.Type = internal.DefaultBigIntType
+
+// Decimal is a type that represents all decimals
+var Decimal dgo.Type = internal.DefaultDecimalType
+
+// URI is a type that represents all URIs
+var URI dgo.URIType = internal.DefaultURIType
+
+// SemVer is a type that represents all semantic versions and all strings that are valid semantic versions
+var SemVer dgo.SemVerType = internal.DefaultSemVerType
+
+// SemVerRange is a type that represents all semantic version ranges and all strings that are valid range expressions
+var SemVerRange dgo.Type = internal.DefaultSemVerRangeType
+
+// IP is a type that represents all IP addresses and all strings that are valid IP addresses
+var IP dgo.IPType = internal.DefaultIPType
+
+// IPv4 is a type that represents all IPv4 addresses and all strings that are valid IPv4 addresses
+var IPv4 dgo.IPType = internal.DefaultIPv4Type
+
+// IPv6 is a type that represents all IPv6 addresses and all strings that are valid IPv6 addresses
+var IPv6 dgo.IPType = internal.DefaultIPv6Type
+
+// CIDR is a type that represents all IP networks and all strings that are valid networks in CIDR notation
+var CIDR dgo.CIDRType = internal.DefaultCIDRType
+
 // Binary is a type that represents all Binary values
 var Binary dgo.BinaryType = internal.DefaultBinaryType
 
@@ -77,6 +107,12 @@ var String dgo.StringType = internal.DefaultStringType
 // DgoString is a type that represents all strings with Dgo syntax
 var DgoString dgo.StringType = internal.DefaultDgoStringType
 
+// Base64String is a type that represents all strings that are valid standard or URL-safe base64 encodings
+var Base64String dgo.StringType = internal.DefaultBase64StringType
+
+// HexString is a type that represents all strings of even length that contain only hexadecimal digits
+var HexString dgo.StringType = internal.DefaultHexStringType
+
 // Error is a type that represents all implementation of error
 var Error dgo.ErrorType = internal.DefaultErrorType
 
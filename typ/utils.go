@@ -20,6 +20,17 @@ func AsType(value dgo.Value) dgo.Type {
 	return value.Type()
 }
 
+// ToType returns the given value and true if the value is a dgo.Type. Otherwise it returns nil and false.
+// Unlike AsType, it never returns the exact type of a value that isn't a type.
+func ToType(value dgo.Value) (dgo.Type, bool) {
+	return internal.ToType(value)
+}
+
+// MustToType returns the given value if it is a dgo.Type. Otherwise it panics.
+func MustToType(value dgo.Value) dgo.Type {
+	return internal.MustToType(value)
+}
+
 // Generic returns the generic form of the given type. All non exact types are considered generic
 // and will be returned directly. Exact types will loose information about what instance they represent
 // and also range and size information. Nested types will return a generic version of the contained
@@ -27,3 +38,78 @@ func AsType(value dgo.Value) dgo.Type {
 func Generic(t dgo.Type) dgo.Type {
 	return internal.Generic(t)
 }
+
+// Diff returns a human readable description of the parts of type b that prevent it from being assignable to type a.
+// Each difference is described on a separate line. An empty string is returned when b is assignable to a.
+func Diff(a, b dgo.Type) string {
+	return internal.TypeDiff(a, b)
+}
+
+// Lint examines the given type and all types nested within it and returns warnings for constructs that are valid
+// but suspicious, such as an AllOf type that can never be satisfied or an AnyOf type with redundant operands.
+func Lint(t dgo.Type) []dgo.LintWarning {
+	return internal.Lint(t)
+}
+
+// Describe returns a natural language description of the given type that is suitable for messages intended for
+// end users, e.g. "an integer between 0 and 100" or "an array of 1 to 10 strings".
+func Describe(t dgo.Type) string {
+	return internal.Describe(t)
+}
+
+// IsExact returns true if the given type is a dgo.ExactType, i.e. a type that represents exactly one value.
+func IsExact(t dgo.Type) bool {
+	return t.IsExact()
+}
+
+// IsArray returns true if the given type is a dgo.ArrayType. Tuple types and exact array types are array types.
+func IsArray(t dgo.Type) bool {
+	return t.IsArray()
+}
+
+// IsMap returns true if the given type is a dgo.MapType. Struct types and exact map types are map types.
+func IsMap(t dgo.Type) bool {
+	return t.IsMap()
+}
+
+// IsString returns true if the given type is a type whose instances are strings, e.g. an exact, sized, pattern, or
+// case insensitive string type.
+func IsString(t dgo.Type) bool {
+	return t.IsString()
+}
+
+// IsInteger returns true if the given type is a dgo.IntegerType.
+func IsInteger(t dgo.Type) bool {
+	return t.IsInteger()
+}
+
+// IsFloat returns true if the given type is a dgo.FloatType.
+func IsFloat(t dgo.Type) bool {
+	return t.IsFloat()
+}
+
+// IsBoolean returns true if the given type is a dgo.BooleanType.
+func IsBoolean(t dgo.Type) bool {
+	return t.IsBoolean()
+}
+
+// IsNil returns true if the given type is the type of the Nil value.
+func IsNil(t dgo.Type) bool {
+	return t.IsNil()
+}
+
+// ValidateAll returns a dgo.PathError for each violation that prevents the given value from being an instance of the
+// given type. Nested arrays and maps are traversed so that all violations are reported at once, each with a JSON
+// Pointer to its location. The returned slice is empty when the value is an instance of the type.
+func ValidateAll(t dgo.Type, value interface{}) []error {
+	return internal.ValidateAll(t, value)
+}
+
+// Validate returns nil if the given value is an instance of the given type. Otherwise it returns a
+// dgo.ValidationError that describes each part of the value that violates the type, e.g. "field 'name': missing".
+//
+// Validate is not a method on dgo.Type because dgo.StructMapType already declares a Validate method with a different
+// signature, and a type cannot have two methods with the same name.
+func Validate(t dgo.Type, value interface{}) error {
+	return internal.Validate(t, value)
+}
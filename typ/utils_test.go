@@ -23,3 +23,17 @@ func ExampleAsType() {
 	// Output:
 	// "hello"
 }
+
+func ExampleIsString() {
+	fmt.Println(IsString(String), IsString(vf.String(`hello`).Type()), IsString(Array))
+
+	// Output:
+	// true true false
+}
+
+func ExampleIsExact() {
+	fmt.Println(IsExact(vf.Integer(1).Type()), IsExact(Integer))
+
+	// Output:
+	// true false
+}
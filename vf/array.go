@@ -16,6 +16,13 @@ func ArrayWithCapacity(capacity int) dgo.Array {
 	return internal.ArrayWithCapacity(capacity)
 }
 
+// CircularArray creates a new mutable array with a fixed capacity. When the capacity is reached, adding
+// a new element will overwrite the oldest element. All elements must be instances of the given type. A nil
+// type means that elements can be of any type.
+func CircularArray(capacity int, typ dgo.Type) dgo.Array {
+	return internal.CircularArray(capacity, typ)
+}
+
 // WrapSlice wraps the given slice in an array. Unset entries in the slice will be replaced by Nil.
 func WrapSlice(slice []dgo.Value) dgo.Array {
 	return internal.WrapSlice(slice)
@@ -32,6 +39,12 @@ func MutableValues(values ...interface{}) dgo.Array {
 	return internal.MutableValues(values)
 }
 
+// IterableToArray returns a new dgo.Array that contains the values of the given dgo.Iterable. Custom Iterable
+// implementations can use this function to collect their values. The Array is frozen if the Iterable is frozen.
+func IterableToArray(iterable dgo.Iterable) dgo.Array {
+	return internal.IterableToArray(iterable)
+}
+
 // Strings returns a frozen dgo.Array that represents the given strings
 func Strings(values ...string) dgo.Array {
 	return internal.Strings(values)
@@ -51,3 +64,9 @@ func Arguments(values ...interface{}) dgo.Arguments {
 func ArgumentsFromArray(values dgo.Array) dgo.Arguments {
 	return internal.ArgumentsFromArray(values)
 }
+
+// SyncArray creates a dgo.SyncArray that is safe for concurrent use and initially contains a copy of the elements of
+// the given array, which may be nil.
+func SyncArray(a dgo.Array) dgo.SyncArray {
+	return internal.SyncArray(a)
+}
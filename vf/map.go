@@ -25,6 +25,66 @@ func MutableMap(m ...interface{}) dgo.Map {
 	return internal.MutableMap(m)
 }
 
+// MultiMap creates an empty and mutable dgo.MultiMap that can associate several values with each key.
+func MultiMap() dgo.MultiMap {
+	return internal.MultiMap()
+}
+
+// FlattenToMap returns a frozen Map that associates the path of each leaf in the given value with that leaf. The path
+// is formed by joining map keys and array indexes with the given separator.
+func FlattenToMap(v interface{}, sep string) dgo.Map {
+	return internal.FlattenToMap(internal.Value(v), sep)
+}
+
+// UnflattenFromMap is the inverse of FlattenToMap. It splits each key of the given map using the given separator and
+// builds a frozen nested structure of Maps and Arrays.
+func UnflattenFromMap(m dgo.Map, sep string) dgo.Value {
+	return internal.UnflattenFromMap(m, sep)
+}
+
+// LRUMap creates an empty and mutable dgo.LRUMap that holds at most capacity entries and evicts the least recently
+// used entry when a new key is added at capacity. The keyType and valueType constrain the entries and may be nil.
+func LRUMap(capacity int, keyType, valueType dgo.Type) dgo.LRUMap {
+	return internal.LRUMap(capacity, keyType, valueType)
+}
+
+// TimestampedMap creates an empty and mutable dgo.TimestampedMap that records the time of each Put. It is intended
+// as a debugging aid.
+func TimestampedMap() dgo.TimestampedMap {
+	return internal.TimestampedMap()
+}
+
+// ZipToMap creates a Map that associates each key produced by the given keys Iterable with the value at the same
+// position in the given values Iterable. The returned Map is frozen when both Iterables are frozen. An error is
+// returned if the number of keys and values differ.
+func ZipToMap(keys, values dgo.Iterable) (dgo.Map, error) {
+	return internal.ZipToMap(keys, values)
+}
+
+// MustZipToMap is like ZipToMap but panics instead of returning an error.
+func MustZipToMap(keys, values dgo.Iterable) dgo.Map {
+	return internal.MustZipToMap(keys, values)
+}
+
+// TypedZipToMap is like ZipToMap but also returns an error if a key is not an instance of the given keyType or if a
+// value is not an instance of the given valueType.
+func TypedZipToMap(keys, values dgo.Array, keyType, valueType dgo.Type) (dgo.Map, error) {
+	return internal.TypedZipToMap(keys, values, keyType, valueType)
+}
+
+// StringKeyMap returns a new empty and mutable dgo.Map that passes all string keys through the given normalize
+// function before they are stored or looked up. Keys are compared case insensitively, using strings.ToLower, when
+// normalize is nil.
+func StringKeyMap(normalize func(string) string) dgo.Map {
+	return internal.StringKeyMap(normalize)
+}
+
+// MapFromGoSlice creates a frozen Map from a Go slice of alternating keys and values, e.g. []string{"k1", "v1", "k2",
+// "v2"}. It panics if the argument is not a slice or if the slice has an odd number of elements.
+func MapFromGoSlice(slice interface{}) dgo.Map {
+	return internal.MapFromGoSlice(slice)
+}
+
 // MapWithCapacity creates an empty dgo.Map suitable to hold a given number of entries.
 func MapWithCapacity(capacity int) dgo.Map {
 	return internal.MapWithCapacity(capacity)
@@ -41,3 +101,16 @@ func FromReflectedMap(rm reflect.Value, frozen bool) dgo.Map {
 func MapEntry(k, v interface{}) dgo.MapEntry {
 	return internal.NewMapEntry(k, v)
 }
+
+// SortedMap creates an empty and mutable dgo.SortedMap that orders its keys using the given compare function, or by
+// the natural order of the keys when the function is nil. The function must return a negative number when a is less
+// than b, zero when they are equal, and a positive number otherwise.
+func SortedMap(compare func(a, b dgo.Value) int) dgo.SortedMap {
+	return internal.SortedMap(compare)
+}
+
+// SyncMap creates a dgo.SyncMap that is safe for concurrent use and initially contains a copy of the entries of the
+// given map, which may be nil.
+func SyncMap(m dgo.Map) dgo.SyncMap {
+	return internal.SyncMap(m)
+}
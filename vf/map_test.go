@@ -25,3 +25,28 @@ func ExampleMutableMap() {
 	fmt.Println(m)
 	// Output: {"a":32}
 }
+
+func ExampleZipToMap() {
+	m, err := vf.ZipToMap(vf.Strings(`a`, `b`), vf.Values(1, 2))
+	fmt.Println(m, err)
+	_, err = vf.ZipToMap(vf.Strings(`a`, `b`), vf.Values(1))
+	fmt.Println(err)
+	// Output:
+	// {"a":1,"b":2} <nil>
+	// the number of keys (2) does not match the number of values (1)
+}
+
+func ExampleStringKeyMap() {
+	m := vf.StringKeyMap(nil)
+	m.Put(`Content-Type`, `text/html`)
+	fmt.Println(m.Get(`CONTENT-TYPE`))
+	fmt.Println(m)
+	// Output:
+	// text/html
+	// {"content-type":"text/html"}
+}
+
+func ExampleMapFromGoSlice() {
+	fmt.Println(vf.MapFromGoSlice([]string{`k1`, `v1`, `k2`, `v2`}))
+	// Output: {"k1":"v1","k2":"v2"}
+}
@@ -2,6 +2,9 @@
 package vf
 
 import (
+	"math/big"
+	"net"
+	"net/url"
 	"regexp"
 	"time"
 
@@ -53,6 +56,111 @@ func TimeFromString(s string) dgo.Time {
 	return internal.TimeFromString(s)
 }
 
+// Duration returns the given duration as a dgo.Duration
+func Duration(d time.Duration) dgo.Duration {
+	return internal.Duration(d)
+}
+
+// DurationFromString returns the given duration string as a dgo.Duration. The string must be in the format
+// accepted by time.ParseDuration. The function will panic if the given string cannot be parsed.
+func DurationFromString(s string) dgo.Duration {
+	return internal.DurationFromString(s)
+}
+
+// BigInt returns a copy of the given *big.Int as a dgo.BigInt
+func BigInt(v *big.Int) dgo.BigInt {
+	return internal.BigInt(v)
+}
+
+// BigIntFromString returns the given string as a dgo.BigInt. The string may have a base prefix such as "0x". The
+// function will panic if the given string cannot be parsed.
+func BigIntFromString(s string) dgo.BigInt {
+	return internal.BigIntFromString(s)
+}
+
+// IntegerFromBig returns the given *big.Int as a dgo.Integer if it fits into an int64, and as a dgo.BigInt otherwise.
+func IntegerFromBig(v *big.Int) dgo.Value {
+	return internal.IntegerFromBig(v)
+}
+
+// Decimal returns a dgo.Decimal with the value unscaled * 10^-scale. The given unscaled value is copied. The function
+// will panic if the absolute value of the scale is greater than 10000.
+func Decimal(unscaled *big.Int, scale int) dgo.Decimal {
+	return internal.Decimal(unscaled, scale)
+}
+
+// DecimalFromString returns the given string as a dgo.Decimal. The string must be a decimal number with an optional
+// sign, fraction, and exponent, e.g. "-12.50" or "1.5e3". The function will panic if the string cannot be parsed or if
+// the absolute value of its exponent or resulting scale is greater than 10000.
+func DecimalFromString(s string) dgo.Decimal {
+	return internal.DecimalFromString(s)
+}
+
+// URI returns a dgo.URI that represents a copy of the given URL
+func URI(u *url.URL) dgo.URI {
+	return internal.URI(u)
+}
+
+// URIFromString returns the given string as a dgo.URI. The function will panic if the string cannot be parsed.
+func URIFromString(s string) dgo.URI {
+	return internal.URIFromString(s)
+}
+
+// SemVer returns the given string as a dgo.SemVer. The function will panic if the string is not a valid semantic
+// version.
+func SemVer(s string) dgo.SemVer {
+	return internal.SemVer(s)
+}
+
+// SemVerRange returns the given range expression as a dgo.SemVerRange. The expression uses the same syntax as npm,
+// e.g. "^1.2.0", "~1.2", "1.x", "1.2 - 2", or ">=1.2.0 <2.0.0 || 3.x". The function will panic if the expression
+// cannot be parsed.
+func SemVerRange(s string) dgo.SemVerRange {
+	return internal.SemVerRange(s)
+}
+
+// IP returns a dgo.IP that represents a copy of the given address
+func IP(ip net.IP) dgo.IP {
+	return internal.IP(ip)
+}
+
+// IPFromString returns the given string as a dgo.IP. The function will panic if the string is not a valid IPv4 or
+// IPv6 address.
+func IPFromString(s string) dgo.IP {
+	return internal.IPFromString(s)
+}
+
+// IPNet returns a dgo.IPNet that represents a copy of the given network
+func IPNet(n *net.IPNet) dgo.IPNet {
+	return internal.IPNet(n)
+}
+
+// IPNetFromString returns the given network in CIDR notation, e.g. "10.0.0.0/8", as a dgo.IPNet. The function will
+// panic if the string is not a valid network or if the address has host bits set.
+func IPNetFromString(s string) dgo.IPNet {
+	return internal.IPNetFromString(s)
+}
+
+// AddIntegers returns the sum of the given integers. The arguments may be Go integers, *big.Int, dgo.Integer, or
+// dgo.BigInt values. The result is a dgo.Integer when it fits into an int64 and a dgo.BigInt otherwise.
+func AddIntegers(a, b interface{}) dgo.Value {
+	return internal.AddIntegers(a, b)
+}
+
+// SubtractIntegers returns the difference between the given integers. The arguments may be Go integers, *big.Int,
+// dgo.Integer, or dgo.BigInt values. The result is a dgo.Integer when it fits into an int64 and a dgo.BigInt
+// otherwise.
+func SubtractIntegers(a, b interface{}) dgo.Value {
+	return internal.SubtractIntegers(a, b)
+}
+
+// MultiplyIntegers returns the product of the given integers. The arguments may be Go integers, *big.Int,
+// dgo.Integer, or dgo.BigInt values. The result is a dgo.Integer when it fits into an int64 and a dgo.BigInt
+// otherwise.
+func MultiplyIntegers(a, b interface{}) dgo.Value {
+	return internal.MultiplyIntegers(a, b)
+}
+
 // Regexp returns the given regexp as a dgo.Regexp
 func Regexp(rx *regexp.Regexp) dgo.Regexp {
 	return internal.Regexp(rx)
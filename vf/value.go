@@ -37,3 +37,92 @@ func ReflectTo(src dgo.Value, dest reflect.Value) {
 func FromValue(src dgo.Value, dest interface{}) {
 	internal.FromValue(src, dest)
 }
+
+// AssertFrozen traverses the value graph of the given value and returns an error that lists all mutable nodes that
+// were found, or nil if the whole graph is immutable. It is intended for debugging and testing.
+func AssertFrozen(v dgo.Value) error {
+	return internal.AssertFrozen(v)
+}
+
+// IsNil returns true if the given value is nil or the Nil value
+func IsNil(v dgo.Value) bool {
+	return internal.IsNil(v)
+}
+
+// IsZero returns true if the given value is nil, the Nil value, or the zero value of its type, i.e. the integer 0,
+// the float 0.0, false, an empty string, an empty binary, an empty array, or an empty map.
+func IsZero(v dgo.Value) bool {
+	return internal.IsZero(v)
+}
+
+// Diff returns a frozen Map that describes how value b differs from value a. The differences are grouped under the
+// keys "added", "removed", and "changed", where each group is a Map keyed by the JSON Pointer of the difference. An
+// added or removed entry maps to its value and a changed entry maps to a Map with the keys "from" and "to". Nested
+// Maps and Arrays are compared recursively and Array elements are compared by index. An empty Map is returned when
+// the values are equal.
+func Diff(a, b interface{}) dgo.Map {
+	return internal.Diff(a, b)
+}
+
+// JSONPath evaluates the given JSONPath expression (a subset of RFC 9535) against the given value and
+// returns an Array with all matching values in document order. A filter can test values against a dgo type
+// using instance(operand, 'type'). An error is returned if the expression cannot be parsed.
+func JSONPath(v dgo.Value, expr string) (dgo.Array, error) {
+	return internal.JSONPath(v, expr)
+}
+
+// NewArena returns a new dgo.Arena that allocates values that are reused after the arena is reset
+func NewArena() dgo.Arena {
+	return internal.NewArena()
+}
+
+// ToArray returns the given value as an Array and true, or nil and false if the value isn't an Array
+func ToArray(v dgo.Value) (dgo.Array, bool) {
+	a, ok := v.(dgo.Array)
+	return a, ok
+}
+
+// ToBoolean returns the given value as a Boolean and true, or nil and false if the value isn't a Boolean
+func ToBoolean(v dgo.Value) (dgo.Boolean, bool) {
+	b, ok := v.(dgo.Boolean)
+	return b, ok
+}
+
+// ToFloat returns the given value as a Float and true, or nil and false if the value isn't a Float
+func ToFloat(v dgo.Value) (dgo.Float, bool) {
+	f, ok := v.(dgo.Float)
+	return f, ok
+}
+
+// ToInteger returns the given value as an Integer and true, or nil and false if the value isn't an Integer
+func ToInteger(v dgo.Value) (dgo.Integer, bool) {
+	i, ok := v.(dgo.Integer)
+	return i, ok
+}
+
+// ToMap returns the given value as a Map and true, or nil and false if the value isn't a Map
+func ToMap(v dgo.Value) (dgo.Map, bool) {
+	m, ok := v.(dgo.Map)
+	return m, ok
+}
+
+// ToString returns the given value as a String and true, or nil and false if the value isn't a String
+func ToString(v dgo.Value) (dgo.String, bool) {
+	s, ok := v.(dgo.String)
+	return s, ok
+}
+
+// ToMsgpack returns the MessagePack encoding of the given value. Nil, Boolean, Integer, BigInt (within the range of
+// an int64 or an uint64), Float, String, Binary, Time, Array, and Map values are supported. A Binary is encoded using
+// the bin family and a Time is encoded using the timestamp extension type. The function panics if the value contains
+// a value that cannot be encoded.
+func ToMsgpack(v dgo.Value) []byte {
+	return internal.ToMsgpack(v)
+}
+
+// FromMsgpack decodes the given MessagePack encoded data into a frozen value. Integers that don't fit into an int64
+// are decoded as BigInt and the timestamp extension type is decoded as a Time. The function panics if the data is
+// malformed, if it contains more than one value, or if it contains an extension type other than the timestamp.
+func FromMsgpack(data []byte) dgo.Value {
+	return internal.FromMsgpack(data)
+}
@@ -67,3 +67,15 @@ func ExampleFromValue_slice_ints() {
 	// [10 20 30]
 	// [10 20 30]
 }
+
+func ExampleToInteger() {
+	m := vf.Map(`port`, 8080, `host`, `localhost`)
+	if port, ok := vf.ToInteger(m.Get(`port`)); ok {
+		fmt.Println(port.GoInt() + 1)
+	}
+	_, ok := vf.ToInteger(m.Get(`host`))
+	fmt.Println(ok)
+	// Output:
+	// 8081
+	// false
+}